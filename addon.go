@@ -152,17 +152,10 @@ func (c *Client) UpdateAddonWithContext(ctx context.Context, id string, a Addon)
 }
 
 func getAddonFromResponse(c *Client, resp *http.Response) (*Addon, error) {
-	var result map[string]Addon
-	if err := c.decodeJSON(resp, &result); err != nil {
+	var a Addon
+	if err := decodeEnvelope(c, resp, nil, "addon", &a); err != nil {
 		return nil, err
 	}
 
-	const rootNode = "addon"
-
-	a, ok := result[rootNode]
-	if !ok {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
-	}
-
 	return &a, nil
 }
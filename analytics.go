@@ -3,6 +3,8 @@ package pagerduty
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"time"
 )
 
 const analyticsBaseURL = "/analytics/metrics/incidents"
@@ -34,6 +36,24 @@ type AnalyticsFilter struct {
 	TeamIDs        []string `json:"team_ids,omitempty"`
 	PriorityIDs    []string `json:"priority_ids,omitempty"`
 	PriorityNames  []string `json:"priority_names,omitempty"`
+
+	EscalationPolicyIDs []string `json:"escalation_policy_ids,omitempty"`
+}
+
+// Values of the AggregateUnit field of AnalyticsRequest.
+const (
+	AnalyticsAggregateUnitDay   = "day"
+	AnalyticsAggregateUnitWeek  = "week"
+	AnalyticsAggregateUnitMonth = "month"
+)
+
+// NewAnalyticsFilter returns a filter of the incidents created between start
+// and end.
+func NewAnalyticsFilter(start, end time.Time) *AnalyticsFilter {
+	return &AnalyticsFilter{
+		CreatedAtStart: start.Format(time.RFC3339),
+		CreatedAtEnd:   end.Format(time.RFC3339),
+	}
 }
 
 // AnalyticsData represents the structure of the analytics we have available.
@@ -42,6 +62,8 @@ type AnalyticsData struct {
 	ServiceName                    string  `json:"service_name,omitempty"`
 	TeamID                         string  `json:"team_id,omitempty"`
 	TeamName                       string  `json:"team_name,omitempty"`
+	EscalationPolicyID             string  `json:"escalation_policy_id,omitempty"`
+	EscalationPolicyName           string  `json:"escalation_policy_name,omitempty"`
 	MeanSecondsToResolve           int     `json:"mean_seconds_to_resolve,omitempty"`
 	MeanSecondsToFirstAck          int     `json:"mean_seconds_to_first_ack,omitempty"`
 	MeanSecondsToEngage            int     `json:"mean_seconds_to_engage,omitempty"`
@@ -61,6 +83,81 @@ type AnalyticsData struct {
 	RangeStart                     string  `json:"range_start,omitempty"`
 }
 
+// analyticsRangeStartLayout is the layout of the RangeStart field of
+// AnalyticsData, which has no time zone.
+const analyticsRangeStartLayout = "2006-01-02T15:04:05.999999"
+
+// RangeStartTime returns the start of the aggregation range of the data, in
+// loc, which should be the location of the TimeZone of the request.
+func (d AnalyticsData) RangeStartTime(loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation(analyticsRangeStartLayout, d.RangeStart, loc)
+}
+
+// Range returns the aggregation range of the data, for the aggregate unit of
+// the request, in loc, which should be the location of the TimeZone of the
+// request.
+func (d AnalyticsData) Range(aggregateUnit string, loc *time.Location) (TimeRange, error) {
+	start, err := d.RangeStartTime(loc)
+	if err != nil {
+		return TimeRange{}, err
+	}
+
+	var end time.Time
+
+	switch aggregateUnit {
+	case AnalyticsAggregateUnitDay:
+		end = start.AddDate(0, 0, 1)
+	case AnalyticsAggregateUnitWeek:
+		end = start.AddDate(0, 0, 7)
+	case AnalyticsAggregateUnitMonth:
+		end = start.AddDate(0, 1, 0)
+	default:
+		return TimeRange{}, fmt.Errorf("unknown aggregate unit %q", aggregateUnit)
+	}
+
+	return TimeRange{Start: start, End: end}, nil
+}
+
+// MeanTimeToResolve returns the mean time from the creation of incidents to
+// their resolution.
+func (d AnalyticsData) MeanTimeToResolve() time.Duration {
+	return time.Duration(d.MeanSecondsToResolve) * time.Second
+}
+
+// MeanTimeToFirstAck returns the mean time from the creation of incidents to
+// their first acknowledgement.
+func (d AnalyticsData) MeanTimeToFirstAck() time.Duration {
+	return time.Duration(d.MeanSecondsToFirstAck) * time.Second
+}
+
+// MeanTimeToEngage returns the mean time from the creation of incidents to
+// the first responder engaging.
+func (d AnalyticsData) MeanTimeToEngage() time.Duration {
+	return time.Duration(d.MeanSecondsToEngage) * time.Second
+}
+
+// MeanTimeToMobilize returns the mean time from the creation of incidents to
+// the first added responder accepting.
+func (d AnalyticsData) MeanTimeToMobilize() time.Duration {
+	return time.Duration(d.MeanSecondsToMobilize) * time.Second
+}
+
+// MeanEngagedTime returns the mean time responders were engaged in incidents.
+func (d AnalyticsData) MeanEngagedTime() time.Duration {
+	return time.Duration(d.MeanEngagedSeconds) * time.Second
+}
+
+// TotalEngagedTime returns the total time responders were engaged in
+// incidents.
+func (d AnalyticsData) TotalEngagedTime() time.Duration {
+	return time.Duration(d.TotalEngagedSeconds) * time.Second
+}
+
+// TotalSnoozedTime returns the total time incidents were snoozed.
+func (d AnalyticsData) TotalSnoozedTime() time.Duration {
+	return time.Duration(d.TotalSnoozedSeconds) * time.Second
+}
+
 // GetAggregatedIncidentData gets the aggregated incident analytics for the requested data.
 func (c *Client) GetAggregatedIncidentData(ctx context.Context, analytics AnalyticsRequest) (AnalyticsResponse, error) {
 	return c.getAggregatedData(ctx, analytics, "all")
@@ -76,6 +173,12 @@ func (c *Client) GetAggregatedTeamData(ctx context.Context, analytics AnalyticsR
 	return c.getAggregatedData(ctx, analytics, "teams")
 }
 
+// GetAggregatedEscalationPolicyData gets the aggregated escalation policy
+// analytics for the requested data.
+func (c *Client) GetAggregatedEscalationPolicyData(ctx context.Context, analytics AnalyticsRequest) (AnalyticsResponse, error) {
+	return c.getAggregatedData(ctx, analytics, "escalation_policies")
+}
+
 func (c *Client) getAggregatedData(ctx context.Context, analytics AnalyticsRequest, endpoint string) (AnalyticsResponse, error) {
 	h := map[string]string{
 		"X-EARLY-ACCESS": "analytics-v2",
@@ -94,3 +197,353 @@ func (c *Client) getAggregatedData(ctx context.Context, analytics AnalyticsReque
 
 	return analyticsResponse, nil
 }
+
+// Values of the Order field of analytics raw data requests.
+const (
+	AnalyticsOrderAsc  = "asc"
+	AnalyticsOrderDesc = "desc"
+)
+
+// AnalyticsRawIncidentsRequest is the request to list the raw analytics data
+// of incidents.
+type AnalyticsRawIncidentsRequest struct {
+	Filters *AnalyticsFilter `json:"filters,omitempty"`
+
+	// StartingAfter and EndingBefore are the cursors of the page, as returned
+	// in the Last and First fields of the previous response.
+	StartingAfter string `json:"starting_after,omitempty"`
+	EndingBefore  string `json:"ending_before,omitempty"`
+
+	// Order is the direction of the ordering, either AnalyticsOrderAsc or
+	// AnalyticsOrderDesc.
+	Order string `json:"order,omitempty"`
+
+	// OrderBy is the field the incidents are ordered by, which defaults to
+	// created_at.
+	OrderBy string `json:"order_by,omitempty"`
+
+	// Limit is the number of incidents per page, up to 1000.
+	Limit uint `json:"limit,omitempty"`
+
+	TimeZone string `json:"time_zone,omitempty"`
+}
+
+// AnalyticsRawIncidentsResponse is a page of the raw analytics data of
+// incidents.
+type AnalyticsRawIncidentsResponse struct {
+	Data     []AnalyticsRawIncident `json:"data"`
+	Filters  *AnalyticsFilter       `json:"filters,omitempty"`
+	First    string                 `json:"first,omitempty"`
+	Last     string                 `json:"last,omitempty"`
+	Limit    uint                   `json:"limit,omitempty"`
+	More     bool                   `json:"more,omitempty"`
+	Order    string                 `json:"order,omitempty"`
+	OrderBy  string                 `json:"order_by,omitempty"`
+	TimeZone string                 `json:"time_zone,omitempty"`
+}
+
+// AnalyticsRawIncident is the raw analytics data of a single incident.
+type AnalyticsRawIncident struct {
+	ID                        string `json:"id"`
+	IncidentNumber            uint   `json:"incident_number,omitempty"`
+	Description               string `json:"description,omitempty"`
+	CreatedAt                 string `json:"created_at,omitempty"`
+	ResolvedAt                string `json:"resolved_at,omitempty"`
+	Urgency                   string `json:"urgency,omitempty"`
+	Major                     bool   `json:"major,omitempty"`
+	PriorityID                string `json:"priority_id,omitempty"`
+	PriorityName              string `json:"priority_name,omitempty"`
+	ServiceID                 string `json:"service_id,omitempty"`
+	ServiceName               string `json:"service_name,omitempty"`
+	TeamID                    string `json:"team_id,omitempty"`
+	TeamName                  string `json:"team_name,omitempty"`
+	EscalationPolicyID        string `json:"escalation_policy_id,omitempty"`
+	EscalationPolicyName      string `json:"escalation_policy_name,omitempty"`
+	AssignmentCount           int    `json:"assignment_count,omitempty"`
+	EscalationCount           int    `json:"escalation_count,omitempty"`
+	EngagedSeconds            int    `json:"engaged_seconds,omitempty"`
+	EngagedUserCount          int    `json:"engaged_user_count,omitempty"`
+	BusinessHourInterruptions int    `json:"business_hour_interruptions,omitempty"`
+	SleepHourInterruptions    int    `json:"sleep_hour_interruptions,omitempty"`
+	OffHourInterruptions      int    `json:"off_hour_interruptions,omitempty"`
+	SnoozedSeconds            int    `json:"snoozed_seconds,omitempty"`
+	SecondsToFirstAck         int    `json:"seconds_to_first_ack,omitempty"`
+	SecondsToEngage           int    `json:"seconds_to_engage,omitempty"`
+	SecondsToMobilize         int    `json:"seconds_to_mobilize,omitempty"`
+	SecondsToResolve          int    `json:"seconds_to_resolve,omitempty"`
+	UserDefinedEffortSeconds  int    `json:"user_defined_effort_seconds,omitempty"`
+}
+
+// TimeToFirstAck returns the time from the creation of the incident to its
+// first acknowledgement.
+func (i AnalyticsRawIncident) TimeToFirstAck() time.Duration {
+	return time.Duration(i.SecondsToFirstAck) * time.Second
+}
+
+// TimeToResolve returns the time from the creation of the incident to its
+// resolution.
+func (i AnalyticsRawIncident) TimeToResolve() time.Duration {
+	return time.Duration(i.SecondsToResolve) * time.Second
+}
+
+// EngagedTime returns the time responders were engaged in the incident.
+func (i AnalyticsRawIncident) EngagedTime() time.Duration {
+	return time.Duration(i.EngagedSeconds) * time.Second
+}
+
+// GetRawIncidentData gets a page of the raw analytics data of the incidents
+// matching the request.
+func (c *Client) GetRawIncidentData(ctx context.Context, analytics AnalyticsRawIncidentsRequest) (AnalyticsRawIncidentsResponse, error) {
+	h := map[string]string{
+		"X-EARLY-ACCESS": "analytics-v2",
+	}
+
+	resp, err := c.post(ctx, "/analytics/raw/incidents", analytics, h)
+	if err != nil {
+		return AnalyticsRawIncidentsResponse{}, err
+	}
+
+	var result AnalyticsRawIncidentsResponse
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return AnalyticsRawIncidentsResponse{}, err
+	}
+
+	return result, nil
+}
+
+// ListRawIncidentDataPaginated gets the raw analytics data of all the
+// incidents matching the request, handling the cursor pagination of the
+// results. The StartingAfter and EndingBefore fields of the request are
+// ignored.
+func (c *Client) ListRawIncidentDataPaginated(ctx context.Context, analytics AnalyticsRawIncidentsRequest) ([]AnalyticsRawIncident, error) {
+	var incidents []AnalyticsRawIncident
+
+	analytics.StartingAfter = ""
+	analytics.EndingBefore = ""
+
+	for {
+		page, err := c.GetRawIncidentData(ctx, analytics)
+		if err != nil {
+			return nil, err
+		}
+
+		incidents = append(incidents, page.Data...)
+
+		if !page.More || len(page.Last) == 0 {
+			return incidents, nil
+		}
+
+		analytics.StartingAfter = page.Last
+	}
+}
+
+// GetRawIncident gets the raw analytics data of a single incident.
+func (c *Client) GetRawIncident(ctx context.Context, id string) (*AnalyticsRawIncident, error) {
+	h := map[string]string{
+		"X-EARLY-ACCESS": "analytics-v2",
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, "/analytics/raw/incidents/"+id, nil, h)
+	if err != nil {
+		return nil, err
+	}
+
+	var result AnalyticsRawIncident
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// AnalyticsResponderFilter is the set of filters of responder analytics
+// requests.
+type AnalyticsResponderFilter struct {
+	DateRangeStart string   `json:"date_range_start,omitempty"`
+	DateRangeEnd   string   `json:"date_range_end,omitempty"`
+	Urgency        string   `json:"urgency,omitempty"`
+	ResponderIDs   []string `json:"responder_ids,omitempty"`
+	TeamIDs        []string `json:"team_ids,omitempty"`
+	PriorityIDs    []string `json:"priority_ids,omitempty"`
+	PriorityNames  []string `json:"priority_names,omitempty"`
+}
+
+// NewAnalyticsResponderFilter returns a filter of the responder activity
+// between start and end.
+func NewAnalyticsResponderFilter(start, end time.Time) *AnalyticsResponderFilter {
+	return &AnalyticsResponderFilter{
+		DateRangeStart: start.Format(time.RFC3339),
+		DateRangeEnd:   end.Format(time.RFC3339),
+	}
+}
+
+// AnalyticsResponderRequest is the request to get aggregated responder
+// analytics.
+type AnalyticsResponderRequest struct {
+	Filters  *AnalyticsResponderFilter `json:"filters,omitempty"`
+	TimeZone string                    `json:"time_zone,omitempty"`
+}
+
+// AnalyticsResponderResponse is the aggregated responder analytics returned
+// by the API.
+type AnalyticsResponderResponse struct {
+	Data     []AnalyticsResponderData  `json:"data,omitempty"`
+	Filters  *AnalyticsResponderFilter `json:"filters,omitempty"`
+	TimeZone string                    `json:"time_zone,omitempty"`
+}
+
+// AnalyticsResponderData is the on-call load of a responder, or of the
+// responders of a team.
+type AnalyticsResponderData struct {
+	ResponderID                    string `json:"responder_id,omitempty"`
+	ResponderName                  string `json:"responder_name,omitempty"`
+	TeamID                         string `json:"team_id,omitempty"`
+	TeamName                       string `json:"team_name,omitempty"`
+	MeanTimeToAcknowledgeSeconds   int    `json:"mean_time_to_acknowledge_seconds,omitempty"`
+	TotalIncidentCount             int    `json:"total_incident_count,omitempty"`
+	TotalIncidentsAcknowledged     int    `json:"total_incidents_acknowledged,omitempty"`
+	TotalIncidentsManualEscalated  int    `json:"total_incidents_manual_escalated,omitempty"`
+	TotalIncidentsTimeoutEscalated int    `json:"total_incidents_timeout_escalated,omitempty"`
+	TotalIncidentsReassigned       int    `json:"total_incidents_reassigned,omitempty"`
+	TotalNotifications             int    `json:"total_notifications,omitempty"`
+	TotalInterruptions             int    `json:"total_interruptions,omitempty"`
+	TotalBusinessHourInterruptions int    `json:"total_business_hour_interruptions,omitempty"`
+	TotalSleepHourInterruptions    int    `json:"total_sleep_hour_interruptions,omitempty"`
+	TotalOffHourInterruptions      int    `json:"total_off_hour_interruptions,omitempty"`
+	TotalEngagedSeconds            int    `json:"total_engaged_seconds,omitempty"`
+}
+
+// MeanTimeToAcknowledge returns the mean time the responder took to
+// acknowledge incidents.
+func (d AnalyticsResponderData) MeanTimeToAcknowledge() time.Duration {
+	return time.Duration(d.MeanTimeToAcknowledgeSeconds) * time.Second
+}
+
+// TotalEngagedTime returns the total time the responder was engaged in
+// incidents.
+func (d AnalyticsResponderData) TotalEngagedTime() time.Duration {
+	return time.Duration(d.TotalEngagedSeconds) * time.Second
+}
+
+// GetAggregatedResponderData gets the on-call load of each responder.
+func (c *Client) GetAggregatedResponderData(ctx context.Context, analytics AnalyticsResponderRequest) (AnalyticsResponderResponse, error) {
+	return c.getAggregatedResponderData(ctx, analytics, "/analytics/metrics/responders/all")
+}
+
+// GetAggregatedResponderTeamData gets the on-call load of the responders of
+// each team.
+func (c *Client) GetAggregatedResponderTeamData(ctx context.Context, analytics AnalyticsResponderRequest) (AnalyticsResponderResponse, error) {
+	return c.getAggregatedResponderData(ctx, analytics, "/analytics/metrics/responders/teams")
+}
+
+func (c *Client) getAggregatedResponderData(ctx context.Context, analytics AnalyticsResponderRequest, path string) (AnalyticsResponderResponse, error) {
+	h := map[string]string{
+		"X-EARLY-ACCESS": "analytics-v2",
+	}
+
+	resp, err := c.post(ctx, path, analytics, h)
+	if err != nil {
+		return AnalyticsResponderResponse{}, err
+	}
+
+	var result AnalyticsResponderResponse
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return AnalyticsResponderResponse{}, err
+	}
+
+	return result, nil
+}
+
+// AnalyticsRawResponderIncidentsRequest is the request to list the raw
+// analytics data of the incidents of a responder.
+type AnalyticsRawResponderIncidentsRequest struct {
+	Filters *AnalyticsResponderFilter `json:"filters,omitempty"`
+
+	// StartingAfter and EndingBefore are the cursors of the page, as returned
+	// in the Last and First fields of the previous response.
+	StartingAfter string `json:"starting_after,omitempty"`
+	EndingBefore  string `json:"ending_before,omitempty"`
+
+	Order    string `json:"order,omitempty"`
+	OrderBy  string `json:"order_by,omitempty"`
+	Limit    uint   `json:"limit,omitempty"`
+	TimeZone string `json:"time_zone,omitempty"`
+}
+
+// AnalyticsRawResponderIncidentsResponse is a page of the raw analytics data
+// of the incidents of a responder.
+type AnalyticsRawResponderIncidentsResponse struct {
+	ResponderID   string                          `json:"responder_id,omitempty"`
+	ResponderName string                          `json:"responder_name,omitempty"`
+	Data          []AnalyticsRawResponderIncident `json:"data"`
+	Filters       *AnalyticsResponderFilter       `json:"filters,omitempty"`
+	First         string                          `json:"first,omitempty"`
+	Last          string                          `json:"last,omitempty"`
+	Limit         uint                            `json:"limit,omitempty"`
+	More          bool                            `json:"more,omitempty"`
+	Order         string                          `json:"order,omitempty"`
+	OrderBy       string                          `json:"order_by,omitempty"`
+	TimeZone      string                          `json:"time_zone,omitempty"`
+}
+
+// AnalyticsRawResponderIncident is the raw analytics data of an incident a
+// responder took part in.
+type AnalyticsRawResponderIncident struct {
+	AnalyticsRawIncident
+
+	// TimeToAcknowledgeSeconds is how long the responder took to acknowledge
+	// the incident.
+	TimeToAcknowledgeSeconds int `json:"time_to_acknowledge_seconds,omitempty"`
+
+	// WasBusinessHour, WasSleepHour and WasOffHour are when the responder was
+	// interrupted by the incident.
+	WasBusinessHour bool `json:"was_business_hour,omitempty"`
+	WasSleepHour    bool `json:"was_sleep_hour,omitempty"`
+	WasOffHour      bool `json:"was_off_hour,omitempty"`
+}
+
+// GetRawResponderIncidentData gets a page of the raw analytics data of the
+// incidents of a responder.
+func (c *Client) GetRawResponderIncidentData(ctx context.Context, responderID string, analytics AnalyticsRawResponderIncidentsRequest) (AnalyticsRawResponderIncidentsResponse, error) {
+	h := map[string]string{
+		"X-EARLY-ACCESS": "analytics-v2",
+	}
+
+	resp, err := c.post(ctx, "/analytics/raw/responders/"+responderID+"/incidents", analytics, h)
+	if err != nil {
+		return AnalyticsRawResponderIncidentsResponse{}, err
+	}
+
+	var result AnalyticsRawResponderIncidentsResponse
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return AnalyticsRawResponderIncidentsResponse{}, err
+	}
+
+	return result, nil
+}
+
+// ListRawResponderIncidentDataPaginated gets the raw analytics data of all
+// the incidents of a responder, handling the cursor pagination of the
+// results. The StartingAfter and EndingBefore fields of the request are
+// ignored.
+func (c *Client) ListRawResponderIncidentDataPaginated(ctx context.Context, responderID string, analytics AnalyticsRawResponderIncidentsRequest) ([]AnalyticsRawResponderIncident, error) {
+	var incidents []AnalyticsRawResponderIncident
+
+	analytics.StartingAfter = ""
+	analytics.EndingBefore = ""
+
+	for {
+		page, err := c.GetRawResponderIncidentData(ctx, responderID, analytics)
+		if err != nil {
+			return nil, err
+		}
+
+		incidents = append(incidents, page.Data...)
+
+		if !page.More || len(page.Last) == 0 {
+			return incidents, nil
+		}
+
+		analytics.StartingAfter = page.Last
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestAnalytics_GetAggregatedIncidentData(t *testing.T) {
@@ -139,3 +140,199 @@ func TestAnalytics_GetAggregatedTeamData(t *testing.T) {
 	}
 	testEqual(t, want, res)
 }
+
+func TestAnalyticsData_Times(t *testing.T) {
+	d := AnalyticsData{
+		MeanSecondsToResolve:  34550,
+		MeanSecondsToFirstAck: 70,
+		TotalEngagedSeconds:   2514,
+		RangeStart:            "2021-01-06T00:00:00.000000",
+	}
+
+	testEqual(t, 9*time.Hour+35*time.Minute+50*time.Second, d.MeanTimeToResolve())
+	testEqual(t, 70*time.Second, d.MeanTimeToFirstAck())
+	testEqual(t, 2514*time.Second, d.TotalEngagedTime())
+
+	start, err := d.RangeStartTime(time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, time.Date(2021, 1, 6, 0, 0, 0, 0, time.UTC), start)
+
+	f := NewAnalyticsFilter(start, start.AddDate(0, 0, 7))
+	testEqual(t, &AnalyticsFilter{CreatedAtStart: "2021-01-06T00:00:00Z", CreatedAtEnd: "2021-01-13T00:00:00Z"}, f)
+}
+
+func TestAnalytics_ListRawIncidentDataPaginated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/analytics/raw/incidents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testEqual(t, "analytics-v2", r.Header.Get("X-EARLY-ACCESS"))
+
+		var req AnalyticsRawIncidentsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		testEqual(t, AnalyticsOrderAsc, req.Order)
+		testEqual(t, []string{"PSVC1"}, req.Filters.ServiceIDs)
+
+		switch req.StartingAfter {
+		case "":
+			_, _ = w.Write([]byte(`{"data": [{"id": "PI1", "seconds_to_first_ack": 60}], "first": "c0", "last": "c1", "more": true}`))
+		case "c1":
+			_, _ = w.Write([]byte(`{"data": [{"id": "PI2", "seconds_to_resolve": 3600}], "first": "c2", "last": "c2", "more": false}`))
+		default:
+			t.Errorf("unexpected cursor %q", req.StartingAfter)
+		}
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListRawIncidentDataPaginated(context.Background(), AnalyticsRawIncidentsRequest{
+		Filters: &AnalyticsFilter{ServiceIDs: []string{"PSVC1"}},
+		Order:   AnalyticsOrderAsc,
+		Limit:   1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 2, len(res))
+	testEqual(t, time.Minute, res[0].TimeToFirstAck())
+	testEqual(t, time.Hour, res[1].TimeToResolve())
+}
+
+func TestAnalytics_GetRawIncident(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/analytics/raw/incidents/PI1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "analytics-v2", r.Header.Get("X-EARLY-ACCESS"))
+		_, _ = w.Write([]byte(`{"id": "PI1", "engaged_seconds": 120, "escalation_count": 2}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.GetRawIncident(context.Background(), "PI1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, &AnalyticsRawIncident{ID: "PI1", EngagedSeconds: 120, EscalationCount: 2}, res)
+	testEqual(t, 2*time.Minute, res.EngagedTime())
+}
+
+func TestAnalytics_GetAggregatedResponderData(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/analytics/metrics/responders/all", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var req AnalyticsResponderRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		testEqual(t, "2021-01-01T00:00:00Z", req.Filters.DateRangeStart)
+
+		_, _ = w.Write([]byte(`{"data": [{"responder_id": "PU1", "total_sleep_hour_interruptions": 3, "mean_time_to_acknowledge_seconds": 90}]}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	res, err := client.GetAggregatedResponderData(context.Background(), AnalyticsResponderRequest{
+		Filters: NewAnalyticsResponderFilter(start, start.AddDate(0, 1, 0)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 1, len(res.Data))
+	testEqual(t, 3, res.Data[0].TotalSleepHourInterruptions)
+	testEqual(t, 90*time.Second, res.Data[0].MeanTimeToAcknowledge())
+}
+
+func TestAnalytics_ListRawResponderIncidentDataPaginated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/analytics/raw/responders/PU1/incidents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var req AnalyticsRawResponderIncidentsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		if req.StartingAfter == "" {
+			_, _ = w.Write([]byte(`{"data": [{"id": "PI1", "was_sleep_hour": true}], "last": "c1", "more": true}`))
+			return
+		}
+
+		testEqual(t, "c1", req.StartingAfter)
+		_, _ = w.Write([]byte(`{"data": [{"id": "PI2", "time_to_acknowledge_seconds": 30}], "more": false}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListRawResponderIncidentDataPaginated(context.Background(), "PU1", AnalyticsRawResponderIncidentsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []AnalyticsRawResponderIncident{
+		{AnalyticsRawIncident: AnalyticsRawIncident{ID: "PI1"}, WasSleepHour: true},
+		{AnalyticsRawIncident: AnalyticsRawIncident{ID: "PI2"}, TimeToAcknowledgeSeconds: 30},
+	}
+
+	testEqual(t, want, res)
+}
+
+func TestAnalytics_GetAggregatedEscalationPolicyData(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/analytics/metrics/incidents/escalation_policies", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var req AnalyticsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		testEqual(t, []string{"PEP1"}, req.Filters.EscalationPolicyIDs)
+		testEqual(t, AnalyticsAggregateUnitWeek, req.AggregateUnit)
+
+		_, _ = w.Write([]byte(`{"data": [{"escalation_policy_id": "PEP1", "escalation_policy_name": "Web", "total_incident_count": 4, "range_start": "2021-01-04T00:00:00.000000"}], "aggregate_unit": "week"}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.GetAggregatedEscalationPolicyData(context.Background(), AnalyticsRequest{
+		Filters:       &AnalyticsFilter{EscalationPolicyIDs: []string{"PEP1"}},
+		AggregateUnit: AnalyticsAggregateUnitWeek,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "Web", res.Data[0].EscalationPolicyName)
+
+	r, err := res.Data[0].Range(res.AggregateUnit, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, TimeRange{Start: time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC), End: time.Date(2021, 1, 11, 0, 0, 0, 0, time.UTC)}, r)
+
+	_, err = res.Data[0].Range("year", time.UTC)
+	testErrCheck(t, "Range()", `unknown aggregate unit "year"`, err)
+}
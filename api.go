@@ -0,0 +1,120 @@
+package pagerduty
+
+import "context"
+
+// The interfaces below are implemented by *Client, and group its methods by
+// resource, so that code using the client can depend on the smallest
+// interface it needs and be tested with fakes, without an HTTP server. Fakes
+// can embed the interface they implement, to only implement the methods they
+// use.
+//
+// Methods may be added to these interfaces in minor releases, as new
+// endpoints are supported.
+
+// IncidentsAPI is the part of the API managing incidents and their alerts,
+// notes and log entries.
+type IncidentsAPI interface {
+	ListIncidentsWithContext(ctx context.Context, o ListIncidentsOptions) (*ListIncidentsResponse, error)
+	GetIncidentWithContext(ctx context.Context, id string) (*Incident, error)
+	CreateIncidentWithContext(ctx context.Context, from string, o *CreateIncidentOptions) (*Incident, error)
+	ManageIncidentsWithContext(ctx context.Context, from string, incidents []ManageIncidentsOptions) (*ListIncidentsResponse, error)
+	MergeIncidentsWithContext(ctx context.Context, from, id string, sourceIncidents []MergeIncidentsOptions) (*Incident, error)
+	SnoozeIncidentWithContext(ctx context.Context, id string, duration uint) (*Incident, error)
+	ResponderRequestWithContext(ctx context.Context, id string, o ResponderRequestOptions) (*ResponderRequestResponse, error)
+
+	ListIncidentAlertsWithContext(ctx context.Context, id string, o ListIncidentAlertsOptions) (*ListAlertsResponse, error)
+	GetIncidentAlertWithContext(ctx context.Context, incidentID, alertID string) (*IncidentAlertResponse, error)
+	ManageIncidentAlerts(ctx context.Context, incidentID, from string, alerts *IncidentAlertList) (*ListAlertsResponse, error)
+
+	ListIncidentNotesWithContext(ctx context.Context, id string) ([]IncidentNote, error)
+	CreateIncidentNoteWithContext(ctx context.Context, id string, note IncidentNote) (*IncidentNote, error)
+	CreateIncidentStatusUpdate(ctx context.Context, id string, from string, message string) (IncidentStatusUpdate, error)
+
+	ListIncidentLogEntriesWithContext(ctx context.Context, id string, o ListIncidentLogEntriesOptions) (*ListIncidentLogEntriesResponse, error)
+	ListIncidentLogEntriesPaginated(ctx context.Context, id string, o ListIncidentLogEntriesOptions) ([]LogEntry, error)
+}
+
+// ServicesAPI is the part of the API managing services.
+type ServicesAPI interface {
+	ListServicesWithContext(ctx context.Context, o ListServiceOptions) (*ListServiceResponse, error)
+	ListServicesPaginated(ctx context.Context, o ListServiceOptions) ([]Service, error)
+	GetServiceWithContext(ctx context.Context, id string, o *GetServiceOptions) (*Service, error)
+	CreateServiceWithContext(ctx context.Context, s Service) (*Service, error)
+	UpdateServiceWithContext(ctx context.Context, s Service) (*Service, error)
+	DeleteServiceWithContext(ctx context.Context, id string) error
+}
+
+// UsersAPI is the part of the API managing users.
+type UsersAPI interface {
+	ListUsersWithContext(ctx context.Context, o ListUsersOptions) (*ListUsersResponse, error)
+	ListUsersPaginated(ctx context.Context, o ListUsersOptions) ([]User, error)
+	GetUserWithContext(ctx context.Context, id string, o GetUserOptions) (*User, error)
+	GetCurrentUserWithContext(ctx context.Context, o GetCurrentUserOptions) (*User, error)
+	CreateUserWithContext(ctx context.Context, u User) (*User, error)
+	UpdateUserWithContext(ctx context.Context, u User) (*User, error)
+	DeleteUserWithContext(ctx context.Context, id string) error
+}
+
+// SchedulesAPI is the part of the API managing schedules and their overrides.
+type SchedulesAPI interface {
+	ListSchedulesWithContext(ctx context.Context, o ListSchedulesOptions) (*ListSchedulesResponse, error)
+	ListSchedulesPaginated(ctx context.Context, o ListSchedulesOptions) ([]Schedule, error)
+	GetScheduleWithContext(ctx context.Context, id string, o GetScheduleOptions) (*Schedule, error)
+	CreateScheduleWithContext(ctx context.Context, s Schedule) (*Schedule, error)
+	UpdateScheduleWithContext(ctx context.Context, id string, s Schedule) (*Schedule, error)
+	DeleteScheduleWithContext(ctx context.Context, id string) error
+	ListOnCallUsersWithContext(ctx context.Context, id string, o ListOnCallUsersOptions) ([]User, error)
+
+	ListOverridesWithContext(ctx context.Context, id string, o ListOverridesOptions) (*ListOverridesResponse, error)
+	CreateOverrideWithContext(ctx context.Context, id string, o Override) (*Override, error)
+	DeleteOverrideWithContext(ctx context.Context, scheduleID, overrideID string) error
+}
+
+// EscalationPoliciesAPI is the part of the API managing escalation policies.
+type EscalationPoliciesAPI interface {
+	ListEscalationPoliciesWithContext(ctx context.Context, o ListEscalationPoliciesOptions) (*ListEscalationPoliciesResponse, error)
+	ListEscalationPoliciesPaginated(ctx context.Context, o ListEscalationPoliciesOptions) ([]EscalationPolicy, error)
+	GetEscalationPolicyWithContext(ctx context.Context, id string, o *GetEscalationPolicyOptions) (*EscalationPolicy, error)
+	CreateEscalationPolicyWithContext(ctx context.Context, e EscalationPolicy) (*EscalationPolicy, error)
+	UpdateEscalationPolicyWithContext(ctx context.Context, id string, e EscalationPolicy) (*EscalationPolicy, error)
+	DeleteEscalationPolicyWithContext(ctx context.Context, id string) error
+}
+
+// OnCallsAPI is the part of the API listing who is on call.
+type OnCallsAPI interface {
+	ListOnCallsWithContext(ctx context.Context, o ListOnCallOptions) (*ListOnCallsResponse, error)
+	ListOnCallsPaginated(ctx context.Context, o ListOnCallOptions) ([]OnCall, error)
+}
+
+// TeamsAPI is the part of the API managing teams and their members.
+type TeamsAPI interface {
+	ListTeamsWithContext(ctx context.Context, o ListTeamOptions) (*ListTeamResponse, error)
+	ListTeamsPaginated(ctx context.Context, o ListTeamOptions) ([]Team, error)
+	GetTeamWithContext(ctx context.Context, id string) (*Team, error)
+	CreateTeamWithContext(ctx context.Context, t *Team) (*Team, error)
+	UpdateTeamWithContext(ctx context.Context, id string, t *Team) (*Team, error)
+	DeleteTeamWithContext(ctx context.Context, id string) error
+	ListTeamMembersPaginated(ctx context.Context, teamID string) ([]Member, error)
+	AddUserToTeamWithContext(ctx context.Context, o AddUserToTeamOptions) error
+	RemoveUserFromTeamWithContext(ctx context.Context, teamID, userID string) error
+}
+
+// EventsAPI is the part of the API sending events with the Events API V2.
+type EventsAPI interface {
+	ManageEventWithContext(ctx context.Context, e *V2Event) (*V2EventResponse, error)
+	CreateChangeEventWithContext(ctx context.Context, e ChangeEvent) (*ChangeEventResponse, error)
+}
+
+// API is the union of the interfaces implemented by *Client.
+type API interface {
+	IncidentsAPI
+	ServicesAPI
+	UsersAPI
+	SchedulesAPI
+	EscalationPoliciesAPI
+	OnCallsAPI
+	TeamsAPI
+	EventsAPI
+}
+
+var _ API = (*Client)(nil)
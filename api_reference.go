@@ -0,0 +1,62 @@
+package pagerduty
+
+import "strings"
+
+// NewAPIReference returns a reference to the object of the given type and ID.
+// The type can be given with or without its "_reference" suffix, e.g. both
+// "service" and "service_reference" return a reference of type
+// "service_reference".
+func NewAPIReference(objectType, id string) APIReference {
+	return APIReference{
+		ID:   id,
+		Type: strings.TrimSuffix(objectType, "_reference") + "_reference",
+	}
+}
+
+// Ref returns a reference to the object, such as to pass an incident or a
+// service returned by the API to another request. The Type of the object must
+// be set, as it is in objects returned by the API.
+func (o APIObject) Ref() APIReference {
+	return NewAPIReference(o.Type, o.ID)
+}
+
+// Ref returns a reference to the object.
+func (r APIReference) Ref() APIReference {
+	return NewAPIReference(r.Type, r.ID)
+}
+
+// EscalationPolicyRef returns a reference to the escalation policy with the
+// given ID.
+func EscalationPolicyRef(id string) APIReference {
+	return NewAPIReference("escalation_policy", id)
+}
+
+// IncidentRef returns a reference to the incident with the given ID.
+func IncidentRef(id string) APIReference {
+	return NewAPIReference("incident", id)
+}
+
+// PriorityRef returns a reference to the priority with the given ID.
+func PriorityRef(id string) APIReference {
+	return NewAPIReference("priority", id)
+}
+
+// ScheduleRef returns a reference to the schedule with the given ID.
+func ScheduleRef(id string) APIReference {
+	return NewAPIReference("schedule", id)
+}
+
+// ServiceRef returns a reference to the service with the given ID.
+func ServiceRef(id string) APIReference {
+	return NewAPIReference("service", id)
+}
+
+// TeamRef returns a reference to the team with the given ID.
+func TeamRef(id string) APIReference {
+	return NewAPIReference("team", id)
+}
+
+// UserRef returns a reference to the user with the given ID.
+func UserRef(id string) APIReference {
+	return NewAPIReference("user", id)
+}
@@ -0,0 +1,15 @@
+package pagerduty
+
+import "testing"
+
+func TestAPIReference(t *testing.T) {
+	testEqual(t, APIReference{ID: "PS1", Type: "service_reference"}, ServiceRef("PS1"))
+	testEqual(t, APIReference{ID: "PU1", Type: "user_reference"}, UserRef("PU1"))
+	testEqual(t, APIReference{ID: "PS1", Type: "service_reference"}, NewAPIReference("service_reference", "PS1"))
+
+	i := Incident{APIObject: APIObject{ID: "PI1", Type: "incident"}}
+	testEqual(t, IncidentRef("PI1"), i.Ref())
+
+	s := Service{APIObject: APIObject{ID: "PS1", Type: "service_reference"}}
+	testEqual(t, ServiceRef("PS1"), s.Ref())
+}
@@ -0,0 +1,48 @@
+package pagerduty
+
+import (
+	"context"
+	"testing"
+)
+
+// fakeIncidents only implements the methods of IncidentsAPI used by
+// acknowledgeAll.
+type fakeIncidents struct {
+	IncidentsAPI
+
+	managed []ManageIncidentsOptions
+}
+
+func (f *fakeIncidents) ListIncidentsWithContext(ctx context.Context, o ListIncidentsOptions) (*ListIncidentsResponse, error) {
+	return &ListIncidentsResponse{Incidents: []Incident{{APIObject: APIObject{ID: "PI1"}}}}, nil
+}
+
+func (f *fakeIncidents) ManageIncidentsWithContext(ctx context.Context, from string, incidents []ManageIncidentsOptions) (*ListIncidentsResponse, error) {
+	f.managed = append(f.managed, incidents...)
+	return &ListIncidentsResponse{}, nil
+}
+
+func TestIncidentsAPI_Fake(t *testing.T) {
+	acknowledgeAll := func(ctx context.Context, api IncidentsAPI) error {
+		res, err := api.ListIncidentsWithContext(ctx, ListIncidentsOptions{Statuses: []string{IncidentStatusTriggered}})
+		if err != nil {
+			return err
+		}
+
+		var opts []ManageIncidentsOptions
+		for _, i := range res.Incidents {
+			opts = append(opts, ManageIncidentsOptions{ID: i.ID, Status: IncidentStatusAcknowledged})
+		}
+
+		_, err = api.ManageIncidentsWithContext(ctx, "foo@bar.com", opts)
+		return err
+	}
+
+	f := &fakeIncidents{}
+
+	if err := acknowledgeAll(context.Background(), f); err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, []ManageIncidentsOptions{{ID: "PI1", Status: IncidentStatusAcknowledged}}, f.managed)
+}
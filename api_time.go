@@ -0,0 +1,123 @@
+package pagerduty
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// APITime is a timestamp of the API. It's encoded in RFC 3339 format, both in
+// JSON and in query strings, and the zero time is encoded as null in JSON and
+// omitted from query strings.
+type APITime struct {
+	time.Time
+}
+
+var (
+	_ json.Marshaler   = APITime{}
+	_ json.Unmarshaler = (*APITime)(nil)
+)
+
+// NewAPITime returns the APITime of t.
+func NewAPITime(t time.Time) APITime {
+	return APITime{Time: t}
+}
+
+// ParseAPITime parses a timestamp returned by the API, in RFC 3339 format. An
+// empty string is the zero time.
+func ParseAPITime(s string) (APITime, error) {
+	if len(s) == 0 {
+		return APITime{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return APITime{}, fmt.Errorf("failed to parse API time: %w", err)
+	}
+
+	return APITime{Time: t}, nil
+}
+
+// parseRequiredAPITime is like ParseAPITime, but it returns an error if s is
+// empty.
+func parseRequiredAPITime(s string) (time.Time, error) {
+	t, err := ParseAPITime(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if t.IsZero() {
+		return time.Time{}, errors.New("missing time")
+	}
+
+	return t.Time, nil
+}
+
+// parseTimeRange parses the start and end of a time range returned by the
+// API with parseRequiredAPITime.
+func parseTimeRange(start, end string) (time.Time, time.Time, error) {
+	s, err := parseRequiredAPITime(start)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to parse start time: %w", err)
+	}
+
+	e, err := parseRequiredAPITime(end)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to parse end time: %w", err)
+	}
+
+	return s, e, nil
+}
+
+// String returns the time in RFC 3339 format, or an empty string if it's the
+// zero time.
+func (t APITime) String() string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+// MarshalJSON satisfies encoding/json.Marshaler.
+func (t APITime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON satisfies encoding/json.Unmarshaler.
+func (t *APITime) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*t = APITime{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	pt, err := ParseAPITime(s)
+	if err != nil {
+		return err
+	}
+
+	*t = pt
+
+	return nil
+}
+
+// EncodeValues satisfies github.com/google/go-querystring/query.Encoder.
+func (t APITime) EncodeValues(key string, v *url.Values) error {
+	if !t.IsZero() {
+		v.Set(key, t.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+func TestAPITime_JSON(t *testing.T) {
+	var v struct {
+		At    APITime `json:"at"`
+		Until APITime `json:"until"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"at": "2021-01-01T02:00:00+02:00", "until": null}`), &v); err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, true, v.At.Equal(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)))
+	testEqual(t, true, v.Until.IsZero())
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, `{"at":"2021-01-01T02:00:00+02:00","until":null}`, string(data))
+
+	err = json.Unmarshal([]byte(`{"at": "yesterday"}`), &v)
+	testErrCheck(t, "json.Unmarshal()", "failed to parse API time", err)
+}
+
+func TestAPITime_EncodeValues(t *testing.T) {
+	o := struct {
+		Since APITime `url:"since,omitempty"`
+		Until APITime `url:"until,omitempty"`
+	}{
+		Since: NewAPITime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+
+	v, err := query.Values(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "since=2021-01-01T00%3A00%3A00Z", v.Encode())
+}
+
+func TestParseTimeRange(t *testing.T) {
+	start, end, err := parseTimeRange("2021-01-01T00:00:00Z", "2021-01-02T00:00:00+01:00")
+	testErrCheck(t, "parseTimeRange()", "", err)
+	testEqual(t, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), start.UTC())
+	testEqual(t, time.Date(2021, 1, 1, 23, 0, 0, 0, time.UTC), end.UTC())
+
+	_, _, err = parseTimeRange("2021-01-01T00:00:00Z", "")
+	testErrCheck(t, "parseTimeRange()", "failed to parse end time: missing time", err)
+}
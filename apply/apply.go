@@ -0,0 +1,349 @@
+// Package apply provides functionality for declaratively managing the
+// configuration of a PagerDuty account.
+//
+// A Spec lists the teams, users, schedules, escalation policies and services
+// the account should have, in YAML or JSON using the field names of the
+// PagerDuty API, as written by the export package. Objects are matched with
+// the live ones by name, or by email for users, and references to other
+// objects may use a summary holding that name or email instead of an ID, so
+// that a spec can refer to objects it creates:
+//
+//	escalation_policies:
+//	- name: Primary
+//	  escalation_rules:
+//	  - escalation_delay_in_minutes: 30
+//	    targets:
+//	    - type: user_reference
+//	      summary: ada@example.com
+//
+// NewPlan compares the spec with the account and returns the changes needed
+// to match it, which can be reviewed before being made with Plan.Apply.
+// Objects of the account missing from the spec are left alone.
+package apply
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/PagerDuty/go-pagerduty/export"
+)
+
+// Values of the Kind field of Change, in the order they are applied.
+const (
+	KindTeam             = "team"
+	KindUser             = "user"
+	KindSchedule         = "schedule"
+	KindEscalationPolicy = "escalation_policy"
+	KindService          = "service"
+)
+
+var kinds = []string{KindTeam, KindUser, KindSchedule, KindEscalationPolicy, KindService}
+
+// Values of the Action field of Change.
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+)
+
+// Object is an object of a spec, as decoded from its JSON or YAML.
+type Object map[string]interface{}
+
+// Spec is the desired configuration of an account.
+type Spec struct {
+	Teams              []Object `json:"teams,omitempty"`
+	Users              []Object `json:"users,omitempty"`
+	Schedules          []Object `json:"schedules,omitempty"`
+	EscalationPolicies []Object `json:"escalation_policies,omitempty"`
+	Services           []Object `json:"services,omitempty"`
+}
+
+// ParseSpec parses a spec written in YAML or JSON.
+func ParseSpec(data []byte) (*Spec, error) {
+	var s Spec
+	if err := export.UnmarshalYAML(data, &s); err != nil {
+		return nil, err
+	}
+
+	for _, kind := range kinds {
+		seen := make(map[string]bool)
+
+		for i, o := range s.objects(kind) {
+			key := o.key(kind)
+			if key == "" {
+				return nil, fmt.Errorf("%s %d: missing %s", kind, i, keyField(kind))
+			}
+
+			if seen[key] {
+				return nil, fmt.Errorf("%s %q is specified more than once", kind, key)
+			}
+
+			seen[key] = true
+		}
+	}
+
+	return &s, nil
+}
+
+func (s *Spec) objects(kind string) []Object {
+	switch kind {
+	case KindTeam:
+		return s.Teams
+	case KindUser:
+		return s.Users
+	case KindSchedule:
+		return s.Schedules
+	case KindEscalationPolicy:
+		return s.EscalationPolicies
+	default:
+		return s.Services
+	}
+}
+
+// keyField returns the field matching the objects of the spec of a kind with
+// the live ones.
+func keyField(kind string) string {
+	if kind == KindUser {
+		return "email"
+	}
+	return "name"
+}
+
+func (o Object) key(kind string) string {
+	k, _ := o[keyField(kind)].(string)
+	return k
+}
+
+// Change is a change to make to the account.
+type Change struct {
+	Action string
+	Kind   string
+
+	// Name is the name of the object, or its email for users.
+	Name string
+
+	// ID is the ID of the object being updated.
+	ID string
+
+	// Fields are the top-level fields of the object being updated which
+	// differ from the spec.
+	Fields []string
+
+	object Object
+}
+
+// String returns a description of the change, such as
+// `~ service "API" (description, escalation_policy)`.
+func (c Change) String() string {
+	if c.Action == ActionCreate {
+		return fmt.Sprintf("+ %s %q", c.Kind, c.Name)
+	}
+	return fmt.Sprintf("~ %s %q (%s)", c.Kind, c.Name, strings.Join(c.Fields, ", "))
+}
+
+// Plan is the changes to make to an account for it to match a spec.
+type Plan struct {
+	Changes []Change
+
+	// ids are the IDs of the objects by kind and name, which are resolved
+	// from the account and completed with the objects created by Apply.
+	ids map[string]map[string]string
+}
+
+// String returns the description of the changes, one per line.
+func (p *Plan) String() string {
+	if len(p.Changes) == 0 {
+		return "No changes.\n"
+	}
+
+	var b strings.Builder
+	for _, c := range p.Changes {
+		b.WriteString(c.String())
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// NewPlan compares the spec with the account of the client and returns the
+// changes needed for it to match.
+func NewPlan(ctx context.Context, client *pagerduty.Client, spec *Spec) (*Plan, error) {
+	p := &Plan{ids: make(map[string]map[string]string)}
+
+	for _, kind := range kinds {
+		live, err := listLive(ctx, client, kind)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %ss: %w", kind, err)
+		}
+
+		p.ids[kind] = make(map[string]string)
+
+		byKey := make(map[string][]Object)
+		for _, o := range live {
+			key := strings.ToLower(o.key(kind))
+			byKey[key] = append(byKey[key], o)
+
+			id, _ := o["id"].(string)
+			p.ids[kind][key] = id
+		}
+
+		for _, want := range spec.objects(kind) {
+			name := want.key(kind)
+
+			matches := byKey[strings.ToLower(name)]
+			if len(matches) > 1 {
+				err := pagerduty.MatchError{Kind: kind, Name: name, Ambiguous: true}
+				for _, m := range matches {
+					err.Matches = append(err.Matches, m.key(kind))
+				}
+				return nil, err
+			}
+
+			// references to objects which don't exist yet are left
+			// unresolved, and so differ from the account
+			want, _ := p.resolve(want)
+
+			if len(matches) == 0 {
+				p.Changes = append(p.Changes, Change{Action: ActionCreate, Kind: kind, Name: name, object: want})
+				continue
+			}
+
+			got := matches[0]
+			if kind == KindSchedule {
+				// the list of schedules doesn't include their layers
+				if got, err = getSchedule(ctx, client, got["id"].(string)); err != nil {
+					return nil, fmt.Errorf("failed to get schedule %q: %w", name, err)
+				}
+			}
+
+			if fields := diff(want, got); len(fields) > 0 {
+				id, _ := got["id"].(string)
+				p.Changes = append(p.Changes, Change{Action: ActionUpdate, Kind: kind, Name: name, ID: id, Fields: fields, object: want})
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// Apply makes the changes of the plan, in order, stopping at the first
+// error.
+func (p *Plan) Apply(ctx context.Context, client *pagerduty.Client) error {
+	for _, c := range p.Changes {
+		id, err := p.apply(ctx, client, c)
+		if err != nil {
+			return fmt.Errorf("failed to %s %s %q: %w", c.Action, c.Kind, c.Name, err)
+		}
+
+		p.ids[c.Kind][strings.ToLower(c.Name)] = id
+	}
+
+	return nil
+}
+
+// apply makes a change and returns the ID of the object.
+func (p *Plan) apply(ctx context.Context, client *pagerduty.Client, c Change) (string, error) {
+	o, unresolved := p.resolve(c.object)
+	if len(unresolved) > 0 {
+		return "", fmt.Errorf("unknown %s", strings.Join(unresolved, ", "))
+	}
+
+	var err error
+
+	if c.Action == ActionUpdate {
+		o["id"] = c.ID
+	}
+
+	switch c.Kind {
+	case KindTeam:
+		var t pagerduty.Team
+		if err = convert(o, &t); err != nil {
+			return "", err
+		}
+
+		var res *pagerduty.Team
+		if c.Action == ActionCreate {
+			res, err = client.CreateTeamWithContext(ctx, &t)
+		} else {
+			res, err = client.UpdateTeamWithContext(ctx, c.ID, &t)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		return res.ID, nil
+
+	case KindUser:
+		var u pagerduty.User
+		if err = convert(o, &u); err != nil {
+			return "", err
+		}
+
+		var res *pagerduty.User
+		if c.Action == ActionCreate {
+			res, err = client.CreateUserWithContext(ctx, u)
+		} else {
+			res, err = client.UpdateUserWithContext(ctx, u)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		return res.ID, nil
+
+	case KindSchedule:
+		var s pagerduty.Schedule
+		if err = convert(o, &s); err != nil {
+			return "", err
+		}
+
+		var res *pagerduty.Schedule
+		if c.Action == ActionCreate {
+			res, err = client.CreateScheduleWithContext(ctx, s)
+		} else {
+			res, err = client.UpdateScheduleWithContext(ctx, c.ID, s)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		return res.ID, nil
+
+	case KindEscalationPolicy:
+		var ep pagerduty.EscalationPolicy
+		if err = convert(o, &ep); err != nil {
+			return "", err
+		}
+
+		var res *pagerduty.EscalationPolicy
+		if c.Action == ActionCreate {
+			res, err = client.CreateEscalationPolicyWithContext(ctx, ep)
+		} else {
+			res, err = client.UpdateEscalationPolicyWithContext(ctx, c.ID, ep)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		return res.ID, nil
+
+	default:
+		var s pagerduty.Service
+		if err = convert(o, &s); err != nil {
+			return "", err
+		}
+
+		var res *pagerduty.Service
+		if c.Action == ActionCreate {
+			res, err = client.CreateServiceWithContext(ctx, s)
+		} else {
+			res, err = client.UpdateServiceWithContext(ctx, s)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		return res.ID, nil
+	}
+}
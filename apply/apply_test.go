@@ -0,0 +1,214 @@
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+const testSpec = `
+teams:
+- name: SRE
+  description: Site reliability
+users:
+- name: Ada
+  email: ada@example.com
+- name: Bob
+  email: bob@example.com
+escalation_policies:
+- name: Primary
+  teams:
+  - type: team_reference
+    summary: sre
+  escalation_rules:
+  - escalation_delay_in_minutes: 30
+    targets:
+    - type: user_reference
+      summary: ada@example.com
+    - type: user_reference
+      summary: bob@example.com
+services:
+- name: API
+  escalation_policy:
+    type: escalation_policy_reference
+    summary: Primary
+`
+
+func newTestServer(t *testing.T) (*pagerduty.Client, map[string]map[string]interface{}) {
+	t.Helper()
+
+	// requests are the bodies of the requests making changes, by method and
+	// path
+	requests := make(map[string]map[string]interface{})
+
+	record := func(w http.ResponseWriter, r *http.Request, response string) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+		requests[r.Method+" "+r.URL.Path] = body
+		_, _ = w.Write([]byte(response))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/teams", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"teams": [{"id": "PT1", "type": "team", "name": "SRE", "description": "Old"}]}`))
+	})
+	mux.HandleFunc("/teams/PT1", func(w http.ResponseWriter, r *http.Request) {
+		record(w, r, `{"team": {"id": "PT1"}}`)
+	})
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			record(w, r, `{"user": {"id": "PU2"}}`)
+			return
+		}
+		_, _ = w.Write([]byte(`{"users": [{"id": "PU1", "type": "user", "name": "Ada", "email": "ada@example.com", "role": "user"}]}`))
+	})
+	mux.HandleFunc("/schedules", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"schedules": []}`))
+	})
+	mux.HandleFunc("/escalation_policies", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			record(w, r, `{"escalation_policy": {"id": "PEP1"}}`)
+			return
+		}
+		_, _ = w.Write([]byte(`{"escalation_policies": []}`))
+	})
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"services": [{"id": "PSV1", "name": "API", "escalation_policy": {"id": "PEPOLD", "type": "escalation_policy_reference"}}]}`))
+	})
+	mux.HandleFunc("/services/PSV1", func(w http.ResponseWriter, r *http.Request) {
+		record(w, r, `{"service": {"id": "PSV1"}}`)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return pagerduty.NewClient("token", pagerduty.WithAPIEndpoint(server.URL)), requests
+}
+
+func TestPlan(t *testing.T) {
+	client, requests := newTestServer(t)
+
+	spec, err := ParseSpec([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := NewPlan(context.Background(), client, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `~ team "SRE" (description)
++ user "bob@example.com"
++ escalation_policy "Primary"
+~ service "API" (escalation_policy)
+`
+	if got := p.String(); got != want {
+		t.Fatalf("plan:\n%s\nwant:\n%s", got, want)
+	}
+
+	if len(requests) != 0 {
+		t.Fatalf("planning made changes: %v", requests)
+	}
+
+	if err := p.Apply(context.Background(), client); err != nil {
+		t.Fatal(err)
+	}
+
+	team := requests["PUT /teams/PT1"]["team"].(map[string]interface{})
+	if team["description"] != "Site reliability" {
+		t.Errorf("team update = %v", team)
+	}
+
+	user := requests["POST /users"]["user"].(map[string]interface{})
+	if user["email"] != "bob@example.com" {
+		t.Errorf("user create = %v", user)
+	}
+
+	ep := requests["POST /escalation_policies"]["escalation_policy"].(map[string]interface{})
+	targets := ep["escalation_rules"].([]interface{})[0].(map[string]interface{})["targets"].([]interface{})
+	if len(targets) != 2 || targets[0].(map[string]interface{})["id"] != "PU1" || targets[1].(map[string]interface{})["id"] != "PU2" {
+		t.Errorf("escalation policy targets = %v", targets)
+	}
+	if teams := ep["teams"].([]interface{}); len(teams) != 1 || teams[0].(map[string]interface{})["id"] != "PT1" {
+		t.Errorf("escalation policy teams = %v", teams)
+	}
+
+	service := requests["PUT /services/PSV1"]["service"].(map[string]interface{})
+	if got := service["escalation_policy"].(map[string]interface{})["id"]; got != "PEP1" {
+		t.Errorf("service escalation policy = %v, want the created one", got)
+	}
+}
+
+func TestPlan_noChanges(t *testing.T) {
+	client, _ := newTestServer(t)
+
+	spec, err := ParseSpec([]byte(`
+teams:
+- name: SRE
+  description: Old
+users:
+- email: ada@example.com
+  role: user
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := NewPlan(context.Background(), client, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(p.Changes) != 0 || p.String() != "No changes.\n" {
+		t.Errorf("plan = %q, want no changes", p.String())
+	}
+}
+
+func TestPlan_Apply_unknownReference(t *testing.T) {
+	client, _ := newTestServer(t)
+
+	spec, err := ParseSpec([]byte(`
+services:
+- name: Web
+  escalation_policy:
+    type: escalation_policy_reference
+    summary: Nope
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := NewPlan(context.Background(), client, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = p.Apply(context.Background(), client)
+	if err == nil || !strings.Contains(err.Error(), `unknown escalation_policy "Nope"`) {
+		t.Errorf("error = %v, want an unknown reference", err)
+	}
+}
+
+func TestParseSpec(t *testing.T) {
+	tests := map[string]string{
+		"missing name":  "teams:\n- description: No name\n",
+		"missing email": "users:\n- name: Ada\n",
+		"duplicate":     "services:\n- name: API\n- name: API\n",
+	}
+
+	for name, spec := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseSpec([]byte(spec)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// referenceKinds are the kinds of objects referenced by the values of the
+// type field of references.
+var referenceKinds = map[string]string{
+	"team_reference":              KindTeam,
+	"user_reference":              KindUser,
+	"schedule_reference":          KindSchedule,
+	"escalation_policy_reference": KindEscalationPolicy,
+	"service_reference":           KindService,
+}
+
+// resolve returns a copy of the object with the references without an ID
+// resolved from their summary, along with the references which couldn't be.
+func (p *Plan) resolve(o Object) (Object, []string) {
+	var unresolved []string
+
+	var walk func(v interface{}) interface{}
+	walk = func(v interface{}) interface{} {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			m := make(map[string]interface{}, len(v))
+			for k, e := range v {
+				m[k] = walk(e)
+			}
+
+			kind, ok := referenceKinds[stringField(m, "type")]
+			summary := stringField(m, "summary")
+			if !ok || summary == "" || stringField(m, "id") != "" {
+				return m
+			}
+
+			id, ok := p.ids[kind][strings.ToLower(summary)]
+			if !ok {
+				unresolved = append(unresolved, fmt.Sprintf("%s %q", kind, summary))
+				return m
+			}
+
+			m["id"] = id
+			delete(m, "summary")
+
+			return m
+
+		case []interface{}:
+			s := make([]interface{}, len(v))
+			for i, e := range v {
+				s[i] = walk(e)
+			}
+
+			return s
+
+		default:
+			return v
+		}
+	}
+
+	return Object(walk(map[string]interface{}(o)).(map[string]interface{})), unresolved
+}
+
+func stringField(m map[string]interface{}, k string) string {
+	s, _ := m[k].(string)
+	return s
+}
+
+// diff returns the sorted top-level fields of want which differ from got.
+// Fields of objects missing from want, such as those computed by PagerDuty,
+// are ignored.
+func diff(want, got Object) []string {
+	var fields []string
+
+	for k, v := range want {
+		if k == "id" {
+			continue
+		}
+
+		if !subset(v, got[k]) {
+			fields = append(fields, k)
+		}
+	}
+
+	sort.Strings(fields)
+
+	return fields
+}
+
+// subset returns whether the JSON value a is b, ignoring the fields of the
+// objects of b which aren't in a.
+func subset(a, b interface{}) bool {
+	if b == nil {
+		// omitted fields are zero
+		return a == nil || reflect.ValueOf(a).IsZero() || isEmpty(a)
+	}
+
+	switch a := a.(type) {
+	case map[string]interface{}:
+		bm, ok := b.(map[string]interface{})
+		if !ok {
+			return false
+		}
+
+		for k, v := range a {
+			if !subset(v, bm[k]) {
+				return false
+			}
+		}
+
+		return true
+
+	case []interface{}:
+		bs, ok := b.([]interface{})
+		if !ok || len(a) != len(bs) {
+			return false
+		}
+
+		for i := range a {
+			if !subset(a[i], bs[i]) {
+				return false
+			}
+		}
+
+		return true
+
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+func isEmpty(v interface{}) bool {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+// convert converts between JSON values, such as from an Object to a struct
+// of the pagerduty package.
+func convert(from, to interface{}) error {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, to)
+}
+
+// listLive lists the objects of a kind of the account.
+func listLive(ctx context.Context, client *pagerduty.Client, kind string) ([]Object, error) {
+	var (
+		live interface{}
+		err  error
+	)
+
+	switch kind {
+	case KindTeam:
+		live, err = client.ListTeamsPaginated(ctx, pagerduty.ListTeamOptions{})
+	case KindUser:
+		live, err = client.ListUsersPaginated(ctx, pagerduty.ListUsersOptions{})
+	case KindSchedule:
+		live, err = client.ListSchedulesPaginated(ctx, pagerduty.ListSchedulesOptions{})
+	case KindEscalationPolicy:
+		live, err = client.ListEscalationPoliciesPaginated(ctx, pagerduty.ListEscalationPoliciesOptions{})
+	default:
+		live, err = client.ListServicesPaginated(ctx, pagerduty.ListServiceOptions{})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []Object
+	if err := convert(live, &objects); err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+func getSchedule(ctx context.Context, client *pagerduty.Client, id string) (Object, error) {
+	s, err := client.GetScheduleWithContext(ctx, id, pagerduty.GetScheduleOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var o Object
+	if err := convert(s, &o); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -13,18 +14,77 @@ const auditBaseURL = "/audit/records"
 // ListAuditRecordsOptions is the data structure used when calling the
 // ListAuditRecords API endpoint.
 type ListAuditRecordsOptions struct {
-	Actions              []string `url:"actions,omitempty,brackets"`
-	ActorID              string   `url:"actor_id,omitempty"`
-	ActorType            string   `url:"actor_type,omitempty"`
-	Cursor               string   `url:"cursor,omitempty"`
-	Limit                uint     `url:"limit,omitempty"`
-	MethodTruncatedToken string   `url:"method_truncated_token,omitempty"`
-	MethodType           string   `url:"method_type,omitempty"`
-	RootResourcesTypes   []string `url:"root_resources_types,omitempty,brackets"`
-	Since                string   `url:"since,omitempty"`
-	Until                string   `url:"until,omitempty"`
+	// Actions filters the records by action, such as AuditActionUpdate.
+	Actions []string `url:"actions,omitempty,brackets"`
+
+	// ActorID and ActorType filter the records by actor. ActorType is such as
+	// AuditActorTypeUser.
+	ActorID   string `url:"actor_id,omitempty"`
+	ActorType string `url:"actor_type,omitempty"`
+
+	// Cursor is the cursor of the page to list. It's ignored by
+	// ListAuditRecordsPaginated.
+	Cursor string `url:"cursor,omitempty"`
+	Limit  uint   `url:"limit,omitempty"`
+
+	// MethodTruncatedToken and MethodType filter the records by the method
+	// of the actor. MethodType is such as AuditMethodTypeAPIToken.
+	MethodTruncatedToken string `url:"method_truncated_token,omitempty"`
+	MethodType           string `url:"method_type,omitempty"`
+
+	// RootResourcesTypes filters the records by the type of the resource they
+	// are about, such as AuditRootResourceTypeServices.
+	RootResourcesTypes []string `url:"root_resources_types,omitempty,brackets"`
+
+	// Since and Until are the range of the execution times of the records, in
+	// ISO 8601 format, see SetTimeRange. PagerDuty defaults to the last 24
+	// hours, and limits the range to 31 days.
+	Since string `url:"since,omitempty"`
+	Until string `url:"until,omitempty"`
 }
 
+// SetTimeRange sets the Since and Until fields of o. A zero since or until is
+// left unset.
+func (o *ListAuditRecordsOptions) SetTimeRange(since, until time.Time) {
+	o.Since = NewAPITime(since.UTC()).String()
+	o.Until = NewAPITime(until.UTC()).String()
+}
+
+// Values of the Actions field of ListAuditRecordsOptions, and of the Action
+// field of AuditRecord.
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+// Values of the ActorType field of ListAuditRecordsOptions, and of the Type
+// field of the Actors of AuditRecord.
+const (
+	AuditActorTypeUser   = "user_reference"
+	AuditActorTypeAPIKey = "api_key_reference"
+	AuditActorTypeApp    = "app_reference"
+)
+
+// Values of the MethodType field of ListAuditRecordsOptions, and of the Type
+// field of Method.
+const (
+	AuditMethodTypeBrowser          = "browser"
+	AuditMethodTypeOAuth            = "oauth"
+	AuditMethodTypeAPIToken         = "api_token"
+	AuditMethodTypeIdentityProvider = "identity_provider"
+	AuditMethodTypeOther            = "other"
+)
+
+// Values of the RootResourcesTypes field of ListAuditRecordsOptions.
+const (
+	AuditRootResourceTypeUsers              = "users"
+	AuditRootResourceTypeTeams              = "teams"
+	AuditRootResourceTypeSchedules          = "schedules"
+	AuditRootResourceTypeEscalationPolicies = "escalation_policies"
+	AuditRootResourceTypeServices           = "services"
+)
+
 // ListAuditRecordsResponse is the response data received when calling the
 // ListAuditRecords API endpoint.
 type ListAuditRecordsResponse struct {
@@ -55,6 +115,25 @@ type AuditRecord struct {
 	Details          Details          `json:"details,omitempty"`
 }
 
+// ExecutedAt returns the ExecutionTime of the record, parsed with
+// ParseAPITime.
+func (r AuditRecord) ExecutedAt() (time.Time, error) {
+	at, err := ParseAPITime(r.ExecutionTime)
+	return at.Time, err
+}
+
+// ActorOfType returns the first actor of the record with the given type, such
+// as AuditActorTypeUser, and whether there's one.
+func (r AuditRecord) ActorOfType(actorType string) (APIObject, bool) {
+	for _, a := range r.Actors {
+		if a.Type == actorType {
+			return a, true
+		}
+	}
+
+	return APIObject{}, false
+}
+
 // ResponseMetadata contains information about the response.
 type ResponseMetadata struct {
 	Messages []string `json:"messages,omitempty"`
@@ -128,6 +207,8 @@ func (c *Client) ListAuditRecords(ctx context.Context, o ListAuditRecordsOptions
 // the final result. If the include function is nil, all audit records from
 // the API are included by default.
 func (c *Client) ListAuditRecordsPaginated(ctx context.Context, o ListAuditRecordsOptions, include func(AuditRecord) bool) ([]AuditRecord, error) {
+	o.Cursor = ""
+
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
@@ -151,10 +232,7 @@ func (c *Client) ListAuditRecordsPaginated(ctx context.Context, o ListAuditRecor
 			}
 		}
 
-		return cursor{
-			Limit:      result.Limit,
-			NextCursor: *result.NextCursor,
-		}, nil
+		return auditRecordsCursor(result), nil
 	}
 
 	u := fmt.Sprintf("%s?%s", auditBaseURL, v.Encode())
@@ -164,3 +242,75 @@ func (c *Client) ListAuditRecordsPaginated(ctx context.Context, o ListAuditRecor
 
 	return records, nil
 }
+
+// ListResourceAuditRecordsOptions is the data structure used when listing the
+// audit records of a single resource, such as with ListScheduleAuditRecords.
+type ListResourceAuditRecordsOptions struct {
+	Cursor string `url:"cursor,omitempty"`
+	Limit  uint   `url:"limit,omitempty"`
+	Since  string `url:"since,omitempty"`
+	Until  string `url:"until,omitempty"`
+}
+
+// listResourceAuditRecords lists the audit records at path, which is the
+// audit records endpoint of a resource like /schedules/{id}/audit/records.
+func (c *Client) listResourceAuditRecords(ctx context.Context, path string, o ListResourceAuditRecordsOptions) (ListAuditRecordsResponse, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return ListAuditRecordsResponse{}, err
+	}
+
+	resp, err := c.get(ctx, path+"?"+v.Encode())
+	if err != nil {
+		return ListAuditRecordsResponse{}, err
+	}
+
+	var result ListAuditRecordsResponse
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return ListAuditRecordsResponse{}, err
+	}
+
+	return result, nil
+}
+
+// listResourceAuditRecordsPaginated is like listResourceAuditRecords, but it
+// processes paginated responses to return all of the records.
+func (c *Client) listResourceAuditRecordsPaginated(ctx context.Context, path string, o ListResourceAuditRecordsOptions) ([]AuditRecord, error) {
+	o.Cursor = ""
+
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []AuditRecord
+
+	responseHandler := func(response *http.Response) (cursor, error) {
+		var result ListAuditRecordsResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return cursor{}, err
+		}
+
+		records = append(records, result.Records...)
+
+		return auditRecordsCursor(result), nil
+	}
+
+	if err := c.cursorGet(ctx, path+"?"+v.Encode(), responseHandler); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// auditRecordsCursor returns the pagination cursor of a response, whose next
+// cursor is null on the last page.
+func auditRecordsCursor(r ListAuditRecordsResponse) cursor {
+	c := cursor{Limit: r.Limit}
+
+	if r.NextCursor != nil {
+		c.NextCursor = *r.NextCursor
+	}
+
+	return c
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestAudit_List(t *testing.T) {
@@ -93,3 +94,63 @@ func TestAudit_List(t *testing.T) {
 
 	testEqual(t, want, resp)
 }
+
+func TestAudit_ListPaginated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/audit/records", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testEqual(t, "2021-01-01T00:00:00Z", r.URL.Query().Get("since"))
+		testEqual(t, "2021-01-02T00:00:00Z", r.URL.Query().Get("until"))
+		testEqual(t, []string{AuditRootResourceTypeServices}, r.URL.Query()["root_resources_types[]"])
+
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			_, _ = w.Write([]byte(`{"records": [{"id": "R1", "action": "create"}, {"id": "R2", "action": "update"}], "next_cursor": "next", "limit": 2}`))
+		case "next":
+			_, _ = w.Write([]byte(`{"records": [{"id": "R3", "action": "delete"}], "next_cursor": null, "limit": 2}`))
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("cursor"))
+		}
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	opts := ListAuditRecordsOptions{
+		RootResourcesTypes: []string{AuditRootResourceTypeServices},
+	}
+	opts.SetTimeRange(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 1, 2, 1, 0, 0, 0, time.FixedZone("CET", 3600)))
+
+	res, err := client.ListAuditRecordsPaginated(context.Background(), opts, func(r AuditRecord) bool {
+		return r.Action != AuditActionUpdate
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, []AuditRecord{{ID: "R1", Action: "create"}, {ID: "R3", Action: "delete"}}, res)
+}
+
+func TestAuditRecord_Helpers(t *testing.T) {
+	r := AuditRecord{
+		ExecutionTime: "2020-06-04T15:30:16.272Z",
+		Actors: []APIObject{
+			{ID: "PKEY", Type: AuditActorTypeAPIKey},
+			{ID: "PUSER", Type: AuditActorTypeUser},
+		},
+	}
+
+	at, err := r.ExecutedAt()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, time.Date(2020, 6, 4, 15, 30, 16, 272000000, time.UTC), at)
+
+	actor, ok := r.ActorOfType(AuditActorTypeUser)
+	testEqual(t, true, ok)
+	testEqual(t, "PUSER", actor.ID)
+
+	_, ok = r.ActorOfType(AuditActorTypeApp)
+	testEqual(t, false, ok)
+}
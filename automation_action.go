@@ -0,0 +1,120 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// AutomationActionInvocation is an invocation of an Automation action, such
+// as a remediation script run on a runner for an incident.
+type AutomationActionInvocation struct {
+	ID       string                              `json:"id,omitempty"`
+	Type     string                              `json:"type,omitempty"`
+	ActionID string                              `json:"action_id,omitempty"`
+	RunnerID string                              `json:"runner_id,omitempty"`
+	State    string                              `json:"state,omitempty"`
+	Timing   []AutomationActionInvocationTiming  `json:"timing,omitempty"`
+	Metadata *AutomationActionInvocationMetadata `json:"metadata,omitempty"`
+
+	// ActionSnapshot is the action as it was when invoked.
+	ActionSnapshot *AutomationActionSnapshot `json:"action_snapshot,omitempty"`
+}
+
+// AutomationActionInvocationTiming is when an invocation reached a state.
+type AutomationActionInvocationTiming struct {
+	State     string `json:"state"`
+	Timestamp string `json:"timestamp"`
+}
+
+// AutomationActionInvocationMetadata is the context of an invocation.
+type AutomationActionInvocationMetadata struct {
+	IncidentID string        `json:"incident_id,omitempty"`
+	Agent      *APIReference `json:"agent,omitempty"`
+}
+
+// AutomationActionSnapshot is the snapshot of an action taken when invoked.
+type AutomationActionSnapshot struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	ActionType  string `json:"action_type,omitempty"`
+}
+
+// Values of the State field of AutomationActionInvocation.
+const (
+	AutomationActionInvocationStatePrepared  = "prepared"
+	AutomationActionInvocationStateCreated   = "created"
+	AutomationActionInvocationStateSent      = "sent"
+	AutomationActionInvocationStateQueued    = "queued"
+	AutomationActionInvocationStateRunning   = "running"
+	AutomationActionInvocationStateAborted   = "aborted"
+	AutomationActionInvocationStateCompleted = "completed"
+	AutomationActionInvocationStateError     = "error"
+	AutomationActionInvocationStateUnknown   = "unknown"
+)
+
+// Done returns whether the invocation reached a final state, in which case
+// its State is either completed, aborted, error or unknown.
+func (i AutomationActionInvocation) Done() bool {
+	switch i.State {
+	case AutomationActionInvocationStateAborted, AutomationActionInvocationStateCompleted,
+		AutomationActionInvocationStateError, AutomationActionInvocationStateUnknown:
+		return true
+	default:
+		return false
+	}
+}
+
+// CreateAutomationActionInvocationWithContext invokes the Automation action
+// with the given ID for the incident with the given ID.
+func (c *Client) CreateAutomationActionInvocationWithContext(ctx context.Context, actionID, incidentID string) (*AutomationActionInvocation, error) {
+	d := map[string]AutomationActionInvocation{
+		"invocation": {
+			Metadata: &AutomationActionInvocationMetadata{IncidentID: incidentID},
+		},
+	}
+
+	resp, err := c.post(ctx, "/automation_actions/actions/"+actionID+"/invocations", d, nil)
+	return getAutomationActionInvocationFromResponse(c, resp, err)
+}
+
+// GetAutomationActionInvocationWithContext gets an invocation of an
+// Automation action.
+func (c *Client) GetAutomationActionInvocationWithContext(ctx context.Context, id string) (*AutomationActionInvocation, error) {
+	resp, err := c.get(ctx, "/automation_actions/invocations/"+id)
+	return getAutomationActionInvocationFromResponse(c, resp, err)
+}
+
+// WaitForAutomationActionInvocationWithContext polls an invocation of an
+// Automation action every interval until it's done, and returns it. It stops
+// with the error of ctx if ctx is done first.
+func (c *Client) WaitForAutomationActionInvocationWithContext(ctx context.Context, id string, interval time.Duration) (*AutomationActionInvocation, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		i, err := c.GetAutomationActionInvocationWithContext(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if i.Done() {
+			return i, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func getAutomationActionInvocationFromResponse(c *Client, resp *http.Response, err error) (*AutomationActionInvocation, error) {
+	var t AutomationActionInvocation
+	if err := decodeEnvelope(c, resp, err, "invocation", &t); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
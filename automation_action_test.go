@@ -0,0 +1,85 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAutomationActionInvocation_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/automation_actions/actions/A1/invocations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]AutomationActionInvocation
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+		testEqual(t, "PI1", body["invocation"].Metadata.IncidentID)
+
+		_, _ = w.Write([]byte(`{"invocation": {"id": "INV1", "action_id": "A1", "state": "created"}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.CreateAutomationActionInvocationWithContext(context.Background(), "A1", "PI1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, &AutomationActionInvocation{ID: "INV1", ActionID: "A1", State: AutomationActionInvocationStateCreated}, res)
+	testEqual(t, false, res.Done())
+}
+
+func TestAutomationActionInvocation_Wait(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var calls int
+
+	mux.HandleFunc("/automation_actions/invocations/INV1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		calls++
+		if calls < 3 {
+			_, _ = w.Write([]byte(`{"invocation": {"id": "INV1", "state": "running"}}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"invocation": {"id": "INV1", "state": "completed", "timing": [{"state": "completed", "timestamp": "2021-01-01T00:00:00Z"}]}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.WaitForAutomationActionInvocationWithContext(context.Background(), "INV1", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 3, calls)
+	testEqual(t, AutomationActionInvocationStateCompleted, res.State)
+	testEqual(t, true, res.Done())
+}
+
+func TestAutomationActionInvocation_WaitCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/automation_actions/invocations/INV1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"invocation": {"id": "INV1", "state": "queued"}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.WaitForAutomationActionInvocationWithContext(ctx, "INV1", time.Millisecond)
+	if err == nil {
+		t.Fatal("expected an error once the context is done")
+	}
+}
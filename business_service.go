@@ -2,8 +2,9 @@ package pagerduty
 
 import (
 	"context"
-	"fmt"
 	"net/http"
+	"sort"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -19,6 +20,17 @@ type BusinessService struct {
 	HTMLUrl        string               `json:"html_url,omitempty"`
 	Description    string               `json:"description,omitempty"`
 	Team           *BusinessServiceTeam `json:"team,omitempty"`
+
+	// LastIncidentTimestamp is when the last incident impacting the business
+	// service was created. It's read-only.
+	LastIncidentTimestamp string `json:"last_incident_timestamp,omitempty"`
+}
+
+// LastIncidentTime returns when the last incident impacting the business
+// service was created, or the zero time if it has never been impacted.
+func (b BusinessService) LastIncidentTime() (time.Time, error) {
+	t, err := ParseAPITime(b.LastIncidentTimestamp)
+	return t.Time, err
 }
 
 // BusinessServiceTeam represents a team object in a business service
@@ -105,6 +117,7 @@ func (c *Client) ListBusinessServicesPaginated(ctx context.Context, o ListBusine
 			More:   result.More,
 			Offset: result.Offset,
 			Limit:  result.Limit,
+			Total:  result.Total,
 		}, nil
 	}
 
@@ -130,7 +143,7 @@ func (c *Client) CreateBusinessServiceWithContext(ctx context.Context, b *Busine
 	}
 
 	resp, err := c.post(ctx, "/business_services", d, nil)
-	return getBusinessServiceFromResponse(resp, err, c.decodeJSON)
+	return getBusinessServiceFromResponse(c, resp, err)
 }
 
 // GetBusinessService gets details about a business service.
@@ -143,7 +156,7 @@ func (c *Client) GetBusinessService(id string) (*BusinessService, error) {
 // GetBusinessServiceWithContext gets details about a business service.
 func (c *Client) GetBusinessServiceWithContext(ctx context.Context, id string) (*BusinessService, error) {
 	resp, err := c.get(ctx, "/business_services/"+id)
-	return getBusinessServiceFromResponse(resp, err, c.decodeJSON)
+	return getBusinessServiceFromResponse(c, resp, err)
 }
 
 // DeleteBusinessService deletes a business_service.
@@ -176,25 +189,368 @@ func (c *Client) UpdateBusinessServiceWithContext(ctx context.Context, b *Busine
 	}
 
 	resp, err := c.put(ctx, "/business_services/"+id, d, nil)
-	return getBusinessServiceFromResponse(resp, err, c.decodeJSON)
+	return getBusinessServiceFromResponse(c, resp, err)
+}
+
+func getBusinessServiceFromResponse(c *Client, resp *http.Response, err error) (*BusinessService, error) {
+	var t BusinessService
+	if err := decodeEnvelope(c, resp, err, "business_service", &t); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// BusinessServiceSubscriber is a user or team subscribed to the status updates
+// of the incidents impacting a business service.
+type BusinessServiceSubscriber struct {
+	SubscriberID   string `json:"subscriber_id"`
+	SubscriberType string `json:"subscriber_type"`
+}
+
+// Values of the SubscriberType field of BusinessServiceSubscriber.
+const (
+	SubscriberTypeUser = "user"
+	SubscriberTypeTeam = "team"
+)
+
+// ListBusinessServiceSubscribersResponse is the response of
+// ListBusinessServiceSubscribersWithContext.
+type ListBusinessServiceSubscribersResponse struct {
+	APIListObject
+	Subscribers []BusinessServiceSubscriber `json:"subscribers"`
 }
 
-func getBusinessServiceFromResponse(resp *http.Response, err error, decodeFn func(*http.Response, interface{}) error) (*BusinessService, error) {
+// BusinessServiceSubscription is the result of subscribing a subscriber to a
+// business service.
+type BusinessServiceSubscription struct {
+	BusinessServiceSubscriber
+	AccountID string `json:"account_id,omitempty"`
+	Result    string `json:"result,omitempty"`
+}
+
+// BusinessServiceUnsubscribeResponse is the result of unsubscribing
+// subscribers from a business service.
+type BusinessServiceUnsubscribeResponse struct {
+	DeletedCount      uint `json:"deleted_count"`
+	UnauthorizedCount uint `json:"unauthorized_count"`
+	NonExistentCount  uint `json:"non_existent_count"`
+}
+
+// ListBusinessServiceSubscribersWithContext lists the subscribers of a
+// business service.
+func (c *Client) ListBusinessServiceSubscribersWithContext(ctx context.Context, id string) (*ListBusinessServiceSubscribersResponse, error) {
+	resp, err := c.get(ctx, "/business_services/"+id+"/subscribers")
 	if err != nil {
 		return nil, err
 	}
 
-	var target map[string]BusinessService
-	if dErr := decodeFn(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
+	var result ListBusinessServiceSubscribersResponse
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return nil, err
 	}
 
-	const rootNode = "business_service"
+	return &result, nil
+}
+
+// ListBusinessServiceSubscribersPaginated lists the subscribers of a business
+// service, handling pagination of the results.
+func (c *Client) ListBusinessServiceSubscribersPaginated(ctx context.Context, id string) ([]BusinessServiceSubscriber, error) {
+	var subscribers []BusinessServiceSubscriber
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListBusinessServiceSubscribersResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		subscribers = append(subscribers, result.Subscribers...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+			Total:  result.Total,
+		}, nil
+	}
 
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
+	if err := c.pagedGet(ctx, "/business_services/"+id+"/subscribers", responseHandler); err != nil {
+		return nil, err
 	}
 
-	return &t, nil
+	return subscribers, nil
+}
+
+// AddBusinessServiceSubscribersWithContext subscribes users or teams to a
+// business service.
+func (c *Client) AddBusinessServiceSubscribersWithContext(ctx context.Context, id string, subscribers []BusinessServiceSubscriber) ([]BusinessServiceSubscription, error) {
+	d := map[string][]BusinessServiceSubscriber{
+		"subscribers": subscribers,
+	}
+
+	resp, err := c.post(ctx, "/business_services/"+id+"/subscribers", d, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Subscriptions []BusinessServiceSubscription `json:"subscriptions"`
+	}
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Subscriptions, nil
+}
+
+// RemoveBusinessServiceSubscribersWithContext unsubscribes users or teams from
+// a business service.
+func (c *Client) RemoveBusinessServiceSubscribersWithContext(ctx context.Context, id string, subscribers []BusinessServiceSubscriber) (*BusinessServiceUnsubscribeResponse, error) {
+	d := map[string][]BusinessServiceSubscriber{
+		"subscribers": subscribers,
+	}
+
+	resp, err := c.post(ctx, "/business_services/"+id+"/unsubscribe", d, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result BusinessServiceUnsubscribeResponse
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetBusinessServiceAccountSubscriptionWithContext returns whether the
+// account is subscribed to a business service.
+func (c *Client) GetBusinessServiceAccountSubscriptionWithContext(ctx context.Context, id string) (bool, error) {
+	resp, err := c.get(ctx, "/business_services/"+id+"/account_subscription")
+	if err != nil {
+		return false, err
+	}
+
+	var result struct {
+		AccountIsSubscribed bool `json:"account_is_subscribed"`
+	}
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return false, err
+	}
+
+	return result.AccountIsSubscribed, nil
+}
+
+// SubscribeAccountToBusinessServiceWithContext subscribes the account to a
+// business service.
+func (c *Client) SubscribeAccountToBusinessServiceWithContext(ctx context.Context, id string) error {
+	_, err := c.post(ctx, "/business_services/"+id+"/account_subscription", nil, nil)
+	return err
+}
+
+// UnsubscribeAccountFromBusinessServiceWithContext unsubscribes the account
+// from a business service.
+func (c *Client) UnsubscribeAccountFromBusinessServiceWithContext(ctx context.Context, id string) error {
+	_, err := c.delete(ctx, "/business_services/"+id+"/account_subscription")
+	return err
+}
+
+// SyncBusinessServiceSubscribersWithContext makes the subscribers of a
+// business service match the desired ones, such as the stakeholders of a
+// configuration management database. It returns the subscribers it added and
+// removed, sorted by type and ID.
+func (c *Client) SyncBusinessServiceSubscribersWithContext(ctx context.Context, id string, desired []BusinessServiceSubscriber) (added, removed []BusinessServiceSubscriber, err error) {
+	current, err := c.ListBusinessServiceSubscribersPaginated(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	want := make(map[BusinessServiceSubscriber]struct{}, len(desired))
+	for _, s := range desired {
+		want[s] = struct{}{}
+	}
+
+	have := make(map[BusinessServiceSubscriber]struct{}, len(current))
+	for _, s := range current {
+		have[s] = struct{}{}
+
+		if _, ok := want[s]; !ok {
+			removed = append(removed, s)
+		}
+	}
+
+	for s := range want {
+		if _, ok := have[s]; !ok {
+			added = append(added, s)
+		}
+	}
+
+	sortSubscribers(added)
+	sortSubscribers(removed)
+
+	if len(added) > 0 {
+		if _, err := c.AddBusinessServiceSubscribersWithContext(ctx, id, added); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(removed) > 0 {
+		if _, err := c.RemoveBusinessServiceSubscribersWithContext(ctx, id, removed); err != nil {
+			return added, nil, err
+		}
+	}
+
+	return added, removed, nil
+}
+
+func sortSubscribers(s []BusinessServiceSubscriber) {
+	sort.Slice(s, func(i, j int) bool {
+		if s[i].SubscriberType != s[j].SubscriberType {
+			return s[i].SubscriberType < s[j].SubscriberType
+		}
+
+		return s[i].SubscriberID < s[j].SubscriberID
+	})
+}
+
+// businessImpactEarlyAccess is the X-EARLY-ACCESS header value required by the
+// business service impact endpoints.
+const businessImpactEarlyAccess = "business-impact-early-access"
+
+// Values of the Status field of BusinessServiceImpact.
+const (
+	BusinessServiceImpactStatusImpacted    = "impacted"
+	BusinessServiceImpactStatusNotImpacted = "not_impacted"
+)
+
+// BusinessServiceImpactFieldHighestImpactingPriority is the additional field
+// of ListBusinessServiceImpactsOptions returning the highest priority of the
+// incidents impacting each business service.
+const BusinessServiceImpactFieldHighestImpactingPriority = "services.highest_impacting_priority"
+
+// BusinessServicePriority is a reference to a priority, along with its order
+// relative to the other priorities of the account.
+type BusinessServicePriority struct {
+	ID    string `json:"id"`
+	Order int    `json:"order,omitempty"`
+}
+
+// BusinessServiceImpact is whether a business service is currently impacted by
+// incidents.
+type BusinessServiceImpact struct {
+	ID     string `json:"id"`
+	Name   string `json:"name,omitempty"`
+	Type   string `json:"type,omitempty"`
+	Status string `json:"status"`
+
+	AdditionalFields *BusinessServiceImpactFields `json:"additional_fields,omitempty"`
+}
+
+// Impacted returns whether the business service is impacted.
+func (b BusinessServiceImpact) Impacted() bool {
+	return b.Status == BusinessServiceImpactStatusImpacted
+}
+
+// BusinessServiceImpactFields are the additional fields of a
+// BusinessServiceImpact, requested with the AdditionalFields option.
+type BusinessServiceImpactFields struct {
+	HighestImpactingPriority *BusinessServicePriority `json:"highest_impacting_priority,omitempty"`
+}
+
+// ListBusinessServiceImpactsOptions are the options of
+// ListBusinessServiceImpactsWithContext.
+type ListBusinessServiceImpactsOptions struct {
+	// IDs are the IDs of the business services to return, which defaults to
+	// the top-level business services.
+	IDs []string `url:"ids,omitempty,brackets"`
+
+	// AdditionalFields are additional fields to return, such as
+	// BusinessServiceImpactFieldHighestImpactingPriority.
+	AdditionalFields []string `url:"additional_fields[services],omitempty"`
+}
+
+// ListBusinessServiceImpactsWithContext lists business services, sorted by
+// whether they're impacted by incidents.
+func (c *Client) ListBusinessServiceImpactsWithContext(ctx context.Context, o ListBusinessServiceImpactsOptions) ([]BusinessServiceImpact, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	h := map[string]string{
+		"X-EARLY-ACCESS": businessImpactEarlyAccess,
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, "/business_services/impacts?"+v.Encode(), nil, h)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Services []BusinessServiceImpact `json:"services"`
+	}
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Services, nil
+}
+
+// BusinessServicePriorityThresholds are the priority thresholds of the
+// account, which set the minimum priority of incidents impacting business
+// services.
+type BusinessServicePriorityThresholds struct {
+	GlobalThreshold BusinessServicePriority `json:"global_threshold"`
+}
+
+// GetBusinessServicePriorityThresholdsWithContext gets the priority thresholds
+// of the account.
+func (c *Client) GetBusinessServicePriorityThresholdsWithContext(ctx context.Context) (*BusinessServicePriorityThresholds, error) {
+	h := map[string]string{
+		"X-EARLY-ACCESS": businessImpactEarlyAccess,
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, "/business_services/priority_thresholds", nil, h)
+	if err != nil {
+		return nil, err
+	}
+
+	var result BusinessServicePriorityThresholds
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// UpdateBusinessServicePriorityThresholdsWithContext sets the priority
+// thresholds of the account.
+func (c *Client) UpdateBusinessServicePriorityThresholdsWithContext(ctx context.Context, t BusinessServicePriorityThresholds) (*BusinessServicePriorityThresholds, error) {
+	h := map[string]string{
+		"X-EARLY-ACCESS": businessImpactEarlyAccess,
+	}
+
+	resp, err := c.put(ctx, "/business_services/priority_thresholds", t, h)
+	if err != nil {
+		return nil, err
+	}
+
+	var result BusinessServicePriorityThresholds
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DeleteBusinessServicePriorityThresholdsWithContext clears the priority
+// thresholds of the account, so that incidents of any priority impact
+// business services.
+func (c *Client) DeleteBusinessServicePriorityThresholdsWithContext(ctx context.Context) error {
+	h := map[string]string{
+		"X-EARLY-ACCESS": businessImpactEarlyAccess,
+	}
+
+	_, err := c.do(ctx, http.MethodDelete, "/business_services/priority_thresholds", nil, h)
+	return err
 }
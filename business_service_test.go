@@ -1,10 +1,13 @@
 package pagerduty
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"testing"
+	"time"
 )
 
 // List BusinessServices
@@ -151,3 +154,214 @@ func TestBusinessService_Delete(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestBusinessService_GetWithTeam(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/business_services/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"business_service": {"id": "1", "name": "Checkout", "point_of_contact": "#checkout", "team": {"id": "PT1", "type": "team_reference"}, "last_incident_timestamp": "2021-01-01T10:00:00Z"}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.GetBusinessServiceWithContext(context.Background(), "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &BusinessService{
+		ID:                    "1",
+		Name:                  "Checkout",
+		PointOfContact:        "#checkout",
+		Team:                  &BusinessServiceTeam{ID: "PT1", Type: "team_reference"},
+		LastIncidentTimestamp: "2021-01-01T10:00:00Z",
+	}
+
+	testEqual(t, want, res)
+
+	last, err := res.LastIncidentTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC), last)
+
+	last, err = BusinessService{}.LastIncidentTime()
+	testEqual(t, nil, err)
+	testEqual(t, true, last.IsZero())
+}
+
+func TestBusinessService_SyncSubscribers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	decode := func(r *http.Request) []BusinessServiceSubscriber {
+		var body map[string][]BusinessServiceSubscriber
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		return body["subscribers"]
+	}
+
+	mux.HandleFunc("/business_services/1/subscribers", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"subscribers": [{"subscriber_id": "PU1", "subscriber_type": "user"}, {"subscriber_id": "PT1", "subscriber_type": "team"}]}`))
+
+		case http.MethodPost:
+			testEqual(t, []BusinessServiceSubscriber{{SubscriberID: "PU2", SubscriberType: SubscriberTypeUser}}, decode(r))
+			_, _ = w.Write([]byte(`{"subscriptions": [{"subscriber_id": "PU2", "subscriber_type": "user", "result": "success"}]}`))
+
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	mux.HandleFunc("/business_services/1/unsubscribe", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testEqual(t, []BusinessServiceSubscriber{{SubscriberID: "PT1", SubscriberType: SubscriberTypeTeam}}, decode(r))
+		_, _ = w.Write([]byte(`{"deleted_count": 1, "unauthorized_count": 0, "non_existent_count": 0}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	added, removed, err := client.SyncBusinessServiceSubscribersWithContext(context.Background(), "1", []BusinessServiceSubscriber{
+		{SubscriberID: "PU1", SubscriberType: SubscriberTypeUser},
+		{SubscriberID: "PU2", SubscriberType: SubscriberTypeUser},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, []BusinessServiceSubscriber{{SubscriberID: "PU2", SubscriberType: SubscriberTypeUser}}, added)
+	testEqual(t, []BusinessServiceSubscriber{{SubscriberID: "PT1", SubscriberType: SubscriberTypeTeam}}, removed)
+}
+
+func TestBusinessService_AccountSubscription(t *testing.T) {
+	setup()
+	defer teardown()
+
+	subscribed := false
+
+	mux.HandleFunc("/business_services/1/account_subscription", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"account_is_subscribed": ` + strconv.FormatBool(subscribed) + `}`))
+		case http.MethodPost:
+			subscribed = true
+			_, _ = w.Write([]byte(`{"subscriptions": []}`))
+		case http.MethodDelete:
+			subscribed = false
+			_, _ = w.Write([]byte(`{"deleted_count": 1}`))
+		}
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+	ctx := context.Background()
+
+	if err := client.SubscribeAccountToBusinessServiceWithContext(ctx, "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := client.GetBusinessServiceAccountSubscriptionWithContext(ctx, "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, true, got)
+
+	if err := client.UnsubscribeAccountFromBusinessServiceWithContext(ctx, "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = client.GetBusinessServiceAccountSubscriptionWithContext(ctx, "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, false, got)
+}
+
+func TestBusinessService_ListImpacts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/business_services/impacts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testEqual(t, "business-impact-early-access", r.Header.Get("X-EARLY-ACCESS"))
+		testEqual(t, []string{"PB1", "PB2"}, r.URL.Query()["ids[]"])
+		testEqual(t, BusinessServiceImpactFieldHighestImpactingPriority, r.URL.Query().Get("additional_fields[services]"))
+
+		_, _ = w.Write([]byte(`{"services": [
+			{"id": "PB1", "name": "Checkout", "status": "impacted", "additional_fields": {"highest_impacting_priority": {"id": "PP1", "order": 128}}},
+			{"id": "PB2", "name": "Search", "status": "not_impacted"}
+		]}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListBusinessServiceImpactsWithContext(context.Background(), ListBusinessServiceImpactsOptions{
+		IDs:              []string{"PB1", "PB2"},
+		AdditionalFields: []string{BusinessServiceImpactFieldHighestImpactingPriority},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 2, len(res))
+	testEqual(t, true, res[0].Impacted())
+	testEqual(t, &BusinessServicePriority{ID: "PP1", Order: 128}, res[0].AdditionalFields.HighestImpactingPriority)
+	testEqual(t, false, res[1].Impacted())
+}
+
+func TestBusinessService_PriorityThresholds(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/business_services/priority_thresholds", func(w http.ResponseWriter, r *http.Request) {
+		testEqual(t, "business-impact-early-access", r.Header.Get("X-EARLY-ACCESS"))
+
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"global_threshold": {"id": "PP1", "order": 64}}`))
+
+		case http.MethodPut:
+			var body BusinessServicePriorityThresholds
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode request body: %s", err)
+			}
+
+			testEqual(t, "PP2", body.GlobalThreshold.ID)
+			_, _ = w.Write([]byte(`{"global_threshold": {"id": "PP2", "order": 128}}`))
+
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+	ctx := context.Background()
+
+	got, err := client.GetBusinessServicePriorityThresholdsWithContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, BusinessServicePriority{ID: "PP1", Order: 64}, got.GlobalThreshold)
+
+	got, err = client.UpdateBusinessServicePriorityThresholdsWithContext(ctx, BusinessServicePriorityThresholds{
+		GlobalThreshold: BusinessServicePriority{ID: "PP2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 128, got.GlobalThreshold.Order)
+
+	if err := client.DeleteBusinessServicePriorityThresholdsWithContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,181 @@
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores API responses for a client created with WithCache. Keys are
+// request paths, and values are response bodies. Implementations must be safe
+// for concurrent use, and can be backed by a shared store, such as Redis, as
+// long as it's not shared by clients of different accounts.
+type Cache interface {
+	// Get returns the value of the key, and whether it was found and hasn't
+	// expired.
+	Get(key string) ([]byte, bool)
+
+	// Set sets the value of the key, expiring after ttl.
+	Set(key string, value []byte, ttl time.Duration)
+
+	// Delete removes the key.
+	Delete(key string)
+}
+
+// cacheablePathRE matches the paths of the rarely changing objects cached by
+// clients created with WithCache: individual users, services and escalation
+// policies, and the priorities.
+var cacheablePathRE = regexp.MustCompile(`^/((users|services|escalation_policies)/\w+|priorities)(\?.*)?$`)
+
+// cacheablePath returns whether the responses of the path are cached by
+// clients created with WithCache. The current user isn't, as it depends on
+// the token, and updating it through its ID doesn't invalidate it.
+func cacheablePath(path string) bool {
+	if path == "/users/me" || strings.HasPrefix(path, "/users/me?") {
+		return false
+	}
+
+	return cacheablePathRE.MatchString(path)
+}
+
+// WithCache makes the client cache the responses of the lookups of rarely
+// changing objects for ttl: getting a user, a service or an escalation
+// policy, and listing the priorities. This avoids fetching the same objects
+// repeatedly, such as in chatops bots. Updating or deleting an object with
+// the client removes it from the cache, except for lookups with options, such
+// as includes. Changes made by others are only seen after ttl.
+//
+// The current user isn't cached. The responses served from the cache have
+// only a Content-Type header, including the ones passed to a ResponseHook.
+func WithCache(cache Cache, ttl time.Duration) ClientOptions {
+	return func(c *Client) {
+		c.cache = cache
+		c.cacheTTL = ttl
+	}
+}
+
+// doCached sends a request to the REST API, using the cache of the client for
+// lookups of cacheable objects, and invalidating it when they're modified.
+func (c *Client) doCached(ctx context.Context, method, path string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	// lookups without options end with "?", and must be invalidated by
+	// updates, which don't
+	key := strings.TrimSuffix(path, "?")
+
+	if method != http.MethodGet {
+		resp, err := c.doWithEndpoint(ctx, c.apiEndpoint, method, path, true, body, headers)
+		if err == nil {
+			c.cache.Delete(strings.SplitN(path, "?", 2)[0])
+		}
+
+		return resp, err
+	}
+
+	if cached, ok := c.cache.Get(key); ok {
+		req, err := http.NewRequestWithContext(ctx, method, c.apiEndpoint+path, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+		}
+
+		resp := &http.Response{
+			Status:     "200 OK",
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{contentTypeHeader}},
+			Body:       ioutil.NopCloser(bytes.NewReader(cached)),
+			Request:    req,
+		}
+
+		if hook := responseHookFromContext(ctx); hook != nil {
+			hook(resp)
+		}
+
+		return resp, nil
+	}
+
+	resp, err := c.doWithEndpoint(ctx, c.apiEndpoint, method, path, true, nil, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }() // explicitly discard error
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	c.cache.Set(key, b, c.cacheTTL)
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+
+	return resp, nil
+}
+
+type memoryCacheEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// MemoryCache is an in-memory Cache. Expired entries are removed when they're
+// looked up, or when entries are added.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+	now     func() time.Time
+}
+
+// NewMemoryCache returns an empty MemoryCache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		entries: make(map[string]memoryCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// Get implements Cache.
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if !m.now().Before(e.expires) {
+		delete(m.entries, key)
+		return nil, false
+	}
+
+	return e.value, true
+}
+
+// Set implements Cache.
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+
+	for k, e := range m.entries {
+		if !now.Before(e.expires) {
+			delete(m.entries, k)
+		}
+	}
+
+	m.entries[key] = memoryCacheEntry{value: value, expires: now.Add(ttl)}
+}
+
+// Delete implements Cache.
+func (m *MemoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.entries, key)
+}
@@ -0,0 +1,118 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	m := NewMemoryCache()
+	m.now = func() time.Time { return now }
+
+	m.Set("a", []byte("1"), time.Minute)
+
+	v, ok := m.Get("a")
+	testEqual(t, true, ok)
+	testEqual(t, "1", string(v))
+
+	now = now.Add(time.Minute)
+
+	_, ok = m.Get("a")
+	testEqual(t, false, ok)
+
+	m.Set("b", []byte("2"), time.Minute)
+	m.Delete("b")
+
+	_, ok = m.Get("b")
+	testEqual(t, false, ok)
+}
+
+func TestWithCache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var gets int
+
+	mux.HandleFunc("/users/PU1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			gets++
+			_, _ = w.Write([]byte(`{"user": {"id": "PU1", "name": "foo"}}`))
+
+		case http.MethodPut:
+			_, _ = w.Write([]byte(`{"user": {"id": "PU1", "name": "bar"}}`))
+
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+	WithCache(NewMemoryCache(), time.Minute)(client)
+
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		u, err := client.GetUserWithContext(ctx, "PU1", GetUserOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testEqual(t, "foo", u.Name)
+	}
+
+	testEqual(t, 1, gets)
+
+	if _, err := client.UpdateUserWithContext(ctx, User{APIObject: APIObject{ID: "PU1"}, Name: "bar"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetUserWithContext(ctx, "PU1", GetUserOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 2, gets)
+
+	// cache hits are passed to the response hook too
+	var hooked int
+
+	hookCtx := ContextWithResponseHook(ctx, func(resp *http.Response) {
+		hooked++
+		testEqual(t, http.StatusOK, resp.StatusCode)
+	})
+
+	if _, err := client.GetUserWithContext(hookCtx, "PU1", GetUserOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 2, gets)
+	testEqual(t, 1, hooked)
+}
+
+func TestWithCache_currentUser(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var gets int
+
+	mux.HandleFunc("/users/me", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		gets++
+		_, _ = w.Write([]byte(`{"user": {"id": "PU1"}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+	WithCache(NewMemoryCache(), time.Minute)(client)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetCurrentUserWithContext(context.Background(), GetCurrentUserOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testEqual(t, 2, gets)
+}
@@ -6,6 +6,9 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"time"
+
+	"github.com/google/go-querystring/query"
 )
 
 const changeEventPath = "/v2/change/enqueue"
@@ -34,6 +37,24 @@ type ChangeEventLink struct {
 	Text string `json:"text,omitempty"`
 }
 
+// NewChangeEvent returns a ChangeEvent with the given summary and source that
+// happened at the given time. Its RoutingKey is set when it's sent.
+func NewChangeEvent(summary, source string, at time.Time) ChangeEvent {
+	return ChangeEvent{
+		Payload: ChangeEventPayload{
+			Summary:   summary,
+			Source:    source,
+			Timestamp: at.UTC().Format(time.RFC3339),
+		},
+	}
+}
+
+// AddLink adds a link to the change event, such as to the build or the pull
+// request that caused it.
+func (e *ChangeEvent) AddLink(href, text string) {
+	e.Links = append(e.Links, ChangeEventLink{Href: href, Text: text})
+}
+
 // ChangeEventResponse is the json response body for an event
 type ChangeEventResponse struct {
 	Status  string   `json:"status,omitempty"`
@@ -84,3 +105,203 @@ func (c *Client) CreateChangeEventWithContext(ctx context.Context, e ChangeEvent
 
 	return &eventResponse, nil
 }
+
+// ChangeEventRecord is a change event recorded by PagerDuty, as returned by the
+// REST API.
+type ChangeEventRecord struct {
+	ID            string                 `json:"id,omitempty"`
+	Type          string                 `json:"type,omitempty"`
+	Summary       string                 `json:"summary,omitempty"`
+	Source        string                 `json:"source,omitempty"`
+	Timestamp     APITime                `json:"timestamp"`
+	RoutingKey    string                 `json:"routing_key,omitempty"`
+	Services      []APIReference         `json:"services,omitempty"`
+	Integration   *APIReference          `json:"integration,omitempty"`
+	CustomDetails map[string]interface{} `json:"custom_details,omitempty"`
+	Links         []ChangeEventLink      `json:"links,omitempty"`
+}
+
+// RelatedChangeEvent is a change event related to an incident, along with the
+// reason it's considered related.
+type RelatedChangeEvent struct {
+	ChangeEventRecord
+	Reason *RelatedChangeEventReason `json:"reason,omitempty"`
+}
+
+// RelatedChangeEventReason is why a change event is related to an incident.
+type RelatedChangeEventReason struct {
+	Type string `json:"type"`
+}
+
+// Values of the Type field of RelatedChangeEventReason.
+const (
+	RelatedChangeEventReasonTypeRecent    = "recent"
+	RelatedChangeEventReasonTypeSimilar   = "similar"
+	RelatedChangeEventReasonTypeSameTeams = "same_teams"
+)
+
+// ListChangeEventsOptions is the data structure used when calling the
+// ListChangeEvents and ListServiceChangeEvents API endpoints.
+type ListChangeEventsOptions struct {
+	// Limit is the pagination parameter that limits the number of results per
+	// page. PagerDuty defaults this value to 25 if omitted, and sets an upper
+	// bound of 100.
+	Limit uint `url:"limit,omitempty"`
+
+	// Offset is the pagination parameter that specifies the offset at which to
+	// start pagination results. When trying to request the next page of
+	// results, the new Offset value should be currentOffset + Limit.
+	Offset uint `url:"offset,omitempty"`
+
+	// Total is the pagination parameter to request that the API return the
+	// total count of items in the response. If this field is omitted or set to
+	// false, the total number of results will not be sent back from the PagerDuty API.
+	Total bool `url:"total,omitempty"`
+
+	TeamIDs        []string `url:"team_ids,omitempty,brackets"`
+	IntegrationIDs []string `url:"integration_ids,omitempty,brackets"`
+
+	// Since and Until are the range of the timestamps of the change events.
+	Since APITime `url:"since,omitempty"`
+	Until APITime `url:"until,omitempty"`
+}
+
+// ListChangeEventsResponse is the data structure returned from calling the
+// ListChangeEvents API endpoint.
+type ListChangeEventsResponse struct {
+	APIListObject
+	ChangeEvents []ChangeEventRecord `json:"change_events"`
+}
+
+// ListChangeEventsWithContext lists the change events of the account.
+func (c *Client) ListChangeEventsWithContext(ctx context.Context, o ListChangeEventsOptions) (*ListChangeEventsResponse, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, "/change_events?"+v.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListChangeEventsResponse
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListChangeEventsPaginated lists all the change events of the account,
+// handling pagination.
+func (c *Client) ListChangeEventsPaginated(ctx context.Context, o ListChangeEventsOptions) ([]ChangeEventRecord, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.listChangeEventsPaginated(ctx, "/change_events?"+v.Encode())
+}
+
+// ListServiceChangeEventsWithContext lists the change events of a service,
+// handling pagination.
+func (c *Client) ListServiceChangeEventsWithContext(ctx context.Context, serviceID string, o ListChangeEventsOptions) ([]ChangeEventRecord, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.listChangeEventsPaginated(ctx, "/services/"+serviceID+"/change_events?"+v.Encode())
+}
+
+func (c *Client) listChangeEventsPaginated(ctx context.Context, path string) ([]ChangeEventRecord, error) {
+	var events []ChangeEventRecord
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListChangeEventsResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		events = append(events, result.ChangeEvents...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+			Total:  result.Total,
+		}, nil
+	}
+
+	if err := c.pagedGet(ctx, path, responseHandler); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// GetChangeEventWithContext gets a change event.
+func (c *Client) GetChangeEventWithContext(ctx context.Context, id string) (*ChangeEventRecord, error) {
+	resp, err := c.get(ctx, "/change_events/"+id)
+	return getChangeEventRecordFromResponse(c, resp, err)
+}
+
+// UpdateChangeEventWithContext updates a change event. Only its Summary and
+// CustomDetails can be updated, the other fields of e are ignored.
+func (c *Client) UpdateChangeEventWithContext(ctx context.Context, id string, e ChangeEventRecord) (*ChangeEventRecord, error) {
+	d := map[string]interface{}{
+		"change_event": struct {
+			Summary       string                 `json:"summary,omitempty"`
+			CustomDetails map[string]interface{} `json:"custom_details,omitempty"`
+		}{
+			Summary:       e.Summary,
+			CustomDetails: e.CustomDetails,
+		},
+	}
+
+	resp, err := c.put(ctx, "/change_events/"+id, d, nil)
+	return getChangeEventRecordFromResponse(c, resp, err)
+}
+
+// ListIncidentRelatedChangeEventsOptions is the data structure used when
+// calling the ListIncidentRelatedChangeEvents API endpoint.
+type ListIncidentRelatedChangeEventsOptions struct {
+	Limit uint `url:"limit,omitempty"`
+}
+
+// ListIncidentRelatedChangeEventsResponse is the data structure returned from
+// calling the ListIncidentRelatedChangeEvents API endpoint.
+type ListIncidentRelatedChangeEventsResponse struct {
+	ChangeEvents []RelatedChangeEvent `json:"change_events"`
+}
+
+// ListIncidentRelatedChangeEventsWithContext lists the change events related
+// to an incident, such as recent deploys of its service.
+func (c *Client) ListIncidentRelatedChangeEventsWithContext(ctx context.Context, incidentID string, o ListIncidentRelatedChangeEventsOptions) (*ListIncidentRelatedChangeEventsResponse, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, "/incidents/"+incidentID+"/related_change_events?"+v.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListIncidentRelatedChangeEventsResponse
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func getChangeEventRecordFromResponse(c *Client, resp *http.Response, err error) (*ChangeEventRecord, error) {
+	var t ChangeEventRecord
+	if err := decodeEnvelope(c, resp, err, "change_event", &t); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
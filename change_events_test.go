@@ -1,9 +1,13 @@
 package pagerduty
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"testing"
+	"time"
 )
 
 const (
@@ -101,3 +105,164 @@ func TestChangeEvent_CreateWithPayloadVerification(t *testing.T) {
 
 	_, _ = client.CreateChangeEvent(ce)
 }
+
+func TestChangeEvent_ListPaginated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/change_events", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, []string{"PT1"}, r.URL.Query()["team_ids[]"])
+
+		offset := r.URL.Query().Get("offset")
+		if offset == "" {
+			offset = "0"
+		}
+
+		more := offset == "0"
+		id := "P1"
+		if !more {
+			id = "P2"
+		}
+
+		fmt.Fprintf(w, `{"change_events": [{"id": %q, "summary": "deploy"}], "more": %t, "offset": %s, "limit": 1}`, id, more, offset)
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListChangeEventsPaginated(context.Background(), ListChangeEventsOptions{TeamIDs: []string{"PT1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 2, len(res))
+	testEqual(t, "P1", res[0].ID)
+	testEqual(t, "P2", res[1].ID)
+}
+
+func TestChangeEvent_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/change_events/P1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"change_event": {"id": "P1", "type": "change_event", "summary": "deploy", "services": [{"id": "PS1", "type": "service_reference"}]}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.GetChangeEventWithContext(context.Background(), "P1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &ChangeEventRecord{
+		ID:       "P1",
+		Type:     "change_event",
+		Summary:  "deploy",
+		Services: []APIReference{{ID: "PS1", Type: "service_reference"}},
+	}
+
+	testEqual(t, want, res)
+}
+
+func TestChangeEvent_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/change_events/P1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var body map[string]ChangeEventRecord
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		testEqual(t, "rollback", body["change_event"].Summary)
+
+		_, _ = w.Write([]byte(`{"change_event": {"id": "P1", "summary": "rollback"}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.UpdateChangeEventWithContext(context.Background(), "P1", ChangeEventRecord{Summary: "rollback"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "rollback", res.Summary)
+}
+
+func TestChangeEvent_ListIncidentRelated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents/PI1/related_change_events", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"change_events": [{"id": "P1", "summary": "deploy", "reason": {"type": "recent"}}]}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListIncidentRelatedChangeEventsWithContext(context.Background(), "PI1", ListIncidentRelatedChangeEventsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 1, len(res.ChangeEvents))
+	testEqual(t, "P1", res.ChangeEvents[0].ID)
+	testEqual(t, RelatedChangeEventReasonTypeRecent, res.ChangeEvents[0].Reason.Type)
+}
+
+func TestChangeEvent_ListService(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/PS1/change_events", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "2021-01-01T00:00:00Z", r.URL.Query().Get("since"))
+		_, _ = w.Write([]byte(`{"change_events": [{"id": "P1"}], "more": false}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListServiceChangeEventsWithContext(context.Background(), "PS1", ListChangeEventsOptions{Since: NewAPITime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 1, len(res))
+}
+
+func TestEventsAPIV2Client_CreateChangeEvent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/change/enqueue", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var e ChangeEvent
+		if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		testEqual(t, "key", e.RoutingKey)
+		testEqual(t, "2021-01-01T00:00:00Z", e.Payload.Timestamp)
+		testEqual(t, []ChangeEventLink{{Href: "https://ci.example.com/1", Text: "build"}}, e.Links)
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status": "success", "message": "Change event processed"}`))
+	})
+
+	client := NewEventsAPIV2Client(WithEventsAPIV2ClientEndpoint(server.URL))
+
+	e := NewChangeEvent("deploy v1.2", "ci", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	e.AddLink("https://ci.example.com/1", "build")
+
+	res, err := client.CreateChangeEventWithContext(context.Background(), "key", e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "success", res.Status)
+}
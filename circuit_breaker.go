@@ -0,0 +1,176 @@
+package pagerduty
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Values of the state of a circuit breaker, see WithCircuitBreaker.
+const (
+	// CircuitClosed is the state of a circuit breaker letting requests
+	// through.
+	CircuitClosed = "closed"
+
+	// CircuitOpen is the state of a circuit breaker failing requests fast.
+	CircuitOpen = "open"
+
+	// CircuitHalfOpen is the state of a circuit breaker letting a single
+	// request through to probe whether the API recovered.
+	CircuitHalfOpen = "half-open"
+)
+
+// CircuitBreakerSettings are the settings of the circuit breaker of a client,
+// see WithCircuitBreaker.
+type CircuitBreakerSettings struct {
+	// FailureThreshold is the number of consecutive failures opening the
+	// circuit. It defaults to 5.
+	FailureThreshold int
+
+	// OpenTimeout is how long the circuit stays open before a request is let
+	// through to probe whether the API recovered. It defaults to 30 seconds.
+	OpenTimeout time.Duration
+
+	// OnStateChange is called when the state of the circuit changes, such as
+	// from CircuitClosed to CircuitOpen, if set. It must not block.
+	OnStateChange func(from, to string)
+}
+
+// WithCircuitBreaker makes the client stop sending REST API requests after
+// consecutive failures, which are transport errors and HTTP 5xx responses, to
+// degrade gracefully while PagerDuty has an outage. While the circuit is open,
+// requests fail fast with a CircuitOpenError, until a request is let through
+// to probe whether the API recovered, closing the circuit if it succeeds.
+func WithCircuitBreaker(s CircuitBreakerSettings) ClientOptions {
+	return func(c *Client) {
+		c.breaker = newCircuitBreaker(s)
+	}
+}
+
+// CircuitState returns the state of the circuit breaker of the client, such
+// as CircuitOpen, or CircuitClosed if it has none.
+func (c *Client) CircuitState() string {
+	if c.breaker == nil {
+		return CircuitClosed
+	}
+
+	return c.breaker.currentState()
+}
+
+type circuitBreaker struct {
+	threshold     int
+	openTimeout   time.Duration
+	onStateChange func(from, to string)
+
+	// now returns the current time, and is replaced by tests
+	now func() time.Time
+
+	mu       sync.Mutex
+	state    string
+	failures int
+
+	// openedAt is when the circuit was opened, or when the probe started
+	// while half-open
+	openedAt time.Time
+	probing  bool
+}
+
+func newCircuitBreaker(s CircuitBreakerSettings) *circuitBreaker {
+	b := &circuitBreaker{
+		threshold:     s.FailureThreshold,
+		openTimeout:   s.OpenTimeout,
+		onStateChange: s.OnStateChange,
+		now:           time.Now,
+		state:         CircuitClosed,
+	}
+
+	if b.threshold <= 0 {
+		b.threshold = 5
+	}
+
+	if b.openTimeout <= 0 {
+		b.openTimeout = 30 * time.Second
+	}
+
+	return b
+}
+
+func (b *circuitBreaker) currentState() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+// allow returns a CircuitOpenError if a request can't be made. Otherwise, the
+// outcome of the request must be recorded with record.
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+
+	switch b.state {
+	case CircuitOpen:
+		if now.Before(b.openedAt.Add(b.openTimeout)) {
+			return CircuitOpenError{RetryAt: b.openedAt.Add(b.openTimeout)}
+		}
+
+		b.setState(CircuitHalfOpen)
+
+	case CircuitHalfOpen:
+		// a probe which never completes doesn't keep the circuit half-open
+		if b.probing && now.Before(b.openedAt.Add(b.openTimeout)) {
+			return CircuitOpenError{RetryAt: b.openedAt.Add(b.openTimeout)}
+		}
+
+	default:
+		return nil
+	}
+
+	b.probing = true
+	b.openedAt = now
+
+	return nil
+}
+
+// record records the outcome of a request let through by allow.
+func (b *circuitBreaker) record(resp *http.Response, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		// says nothing about the API, but lets another probe through
+		b.probing = false
+
+	case err != nil || (resp != nil && resp.StatusCode >= 500):
+		b.failures++
+
+		if b.state == CircuitHalfOpen || b.failures >= b.threshold {
+			b.probing = false
+			b.openedAt = b.now()
+			b.setState(CircuitOpen)
+		}
+
+	default:
+		b.failures = 0
+		b.probing = false
+		b.setState(CircuitClosed)
+	}
+}
+
+func (b *circuitBreaker) setState(s string) {
+	if b.state == s {
+		return
+	}
+
+	from := b.state
+	b.state = s
+
+	if b.onStateChange != nil {
+		b.onStateChange(from, s)
+	}
+}
@@ -0,0 +1,148 @@
+package pagerduty
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var (
+		calls   int
+		healthy bool
+	)
+
+	mux.HandleFunc("/users/PU1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"user": {"id": "PU1"}}`))
+	})
+
+	var transitions []string
+
+	client := defaultTestClient(server.URL, "foo")
+	WithCircuitBreaker(CircuitBreakerSettings{
+		FailureThreshold: 2,
+		OpenTimeout:      time.Minute,
+		OnStateChange: func(from, to string) {
+			transitions = append(transitions, from+"->"+to)
+		},
+	})(client)
+
+	now := time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC)
+	client.breaker.now = func() time.Time { return now }
+
+	get := func() error {
+		_, err := client.GetUserWithContext(context.Background(), "PU1", GetUserOptions{})
+		return err
+	}
+
+	for i := 0; i < 2; i++ {
+		var aerr APIError
+		testEqual(t, true, errors.As(get(), &aerr))
+	}
+
+	testEqual(t, CircuitOpen, client.CircuitState())
+
+	// fails fast
+	err := get()
+	testEqual(t, true, errors.Is(err, ErrCircuitOpen))
+	testEqual(t, CircuitOpenError{RetryAt: now.Add(time.Minute)}, err)
+	testEqual(t, 2, calls)
+
+	// the probe fails, which opens the circuit again
+	now = now.Add(time.Minute)
+	testEqual(t, false, errors.Is(get(), ErrCircuitOpen))
+	testEqual(t, CircuitOpen, client.CircuitState())
+	testEqual(t, true, errors.Is(get(), ErrCircuitOpen))
+	testEqual(t, 3, calls)
+
+	// the probe succeeds, which closes the circuit
+	now = now.Add(time.Minute)
+	healthy = true
+	testErrCheck(t, "GetUserWithContext", "", get())
+	testEqual(t, CircuitClosed, client.CircuitState())
+	testErrCheck(t, "GetUserWithContext", "", get())
+	testEqual(t, 5, calls)
+
+	testEqual(t, []string{
+		"closed->open",
+		"open->half-open",
+		"half-open->open",
+		"open->half-open",
+		"half-open->closed",
+	}, transitions)
+}
+
+func TestCircuitBreaker_clientErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/PU1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+	WithCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 1})(client)
+
+	for i := 0; i < 3; i++ {
+		_, err := client.GetUserWithContext(context.Background(), "PU1", GetUserOptions{})
+		testEqual(t, true, errors.Is(err, ErrNotFound))
+	}
+
+	testEqual(t, CircuitClosed, client.CircuitState())
+}
+
+func TestCircuitBreaker_canceledProbe(t *testing.T) {
+	b := newCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 1, OpenTimeout: time.Second})
+
+	now := time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC)
+	b.now = func() time.Time { return now }
+
+	testErrCheck(t, "allow", "", b.allow())
+	b.record(nil, errors.New("connection refused"))
+	testEqual(t, CircuitOpen, b.currentState())
+
+	now = now.Add(time.Second)
+	testErrCheck(t, "allow", "", b.allow())
+
+	// a single probe at a time
+	testEqual(t, true, errors.Is(b.allow(), ErrCircuitOpen))
+
+	b.record(nil, context.Canceled)
+	testEqual(t, CircuitHalfOpen, b.currentState())
+	testErrCheck(t, "allow", "", b.allow())
+}
+
+func TestCircuitBreaker_tokenSourceErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var calls int
+
+	mux.HandleFunc("/users/PU1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"user": {"id": "PU1"}}`))
+	})
+
+	client := defaultTestClient(server.URL, "")
+	WithCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 1})(client)
+	WithTokenSource(staticTokenSource{err: errors.New("token endpoint unavailable")})(client)
+
+	for i := 0; i < 3; i++ {
+		_, err := client.GetUserWithContext(context.Background(), "PU1", GetUserOptions{})
+		testErrCheck(t, "GetUserWithContext", "token endpoint unavailable", err)
+	}
+
+	// the API was never called, so it isn't considered unhealthy
+	testEqual(t, CircuitClosed, client.CircuitState())
+	testEqual(t, 0, calls)
+}
@@ -12,6 +12,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -22,6 +23,8 @@ import (
 	"strings"
 	"sync/atomic"
 	"time"
+
+	"github.com/PagerDuty/go-pagerduty/oauth"
 )
 
 // Version is current version of this client.
@@ -30,19 +33,42 @@ const Version = "1.8.0-alpha"
 const (
 	apiEndpoint         = "https://api.pagerduty.com"
 	v2EventsAPIEndpoint = "https://events.pagerduty.com"
+	slackAPIEndpoint    = "https://app.pagerduty.com/integration-slack"
 )
 
-// The type of authentication to use with the API client
-type authType int
+// AuthStyle is the style of the Authorization header the client sends to the
+// REST API.
+type AuthStyle int
 
 const (
-	// Account/user API token authentication
-	apiToken authType = iota
+	// AuthStyleToken sends the account or user API token as
+	// "Token token=<token>". This is the default.
+	AuthStyleToken AuthStyle = iota
 
-	// OAuth token authentication
-	oauthToken
+	// AuthStyleBearer sends an OAuth access token as "Bearer <token>".
+	AuthStyleBearer
 )
 
+// TokenSource provides the token used to authenticate against the REST API.
+// It's called before every request, so implementations should cache tokens
+// until they expire. *oauth.TokenSource satisfies this interface.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// RefreshableTokenSource is a TokenSource able to obtain a new token when the
+// API rejects the current one. When the client uses one, a request failing
+// with a 401 Unauthorized response is retried once after calling
+// InvalidateToken with the rejected token. *oauth.TokenSource satisfies this
+// interface.
+type RefreshableTokenSource interface {
+	TokenSource
+
+	// InvalidateToken marks token as no longer valid, so that the next call
+	// to Token obtains a new one.
+	InvalidateToken(token string)
+}
+
 // APIObject represents generic api json response that is shared by most
 // domain objects (like escalation)
 type APIObject struct {
@@ -270,12 +296,35 @@ type Client struct {
 	lastRequest  *atomic.Value
 	lastResponse *atomic.Value
 
-	authToken           string
-	apiEndpoint         string
-	v2EventsAPIEndpoint string
+	authToken              string
+	apiEndpoint            string
+	v2EventsAPIEndpoint    string
+	slackAPIEndpoint       string
+	platformStatusEndpoint string
 
 	// Authentication type to use for API
-	authType authType
+	authStyle   AuthStyle
+	tokenSource TokenSource
+
+	// email address sent in the From header of REST API requests which don't
+	// set one
+	defaultFrom *atomic.Value
+
+	// strict decoding of the responses, see WithStrictDecoding and
+	// WithUnknownFieldsHandler
+	disallowUnknownFields bool
+	unknownFieldsHandler  UnknownFieldsHandler
+
+	// maximum number of pages fetched concurrently by offset pagination, see
+	// WithParallelPagination
+	pageParallelism int
+
+	// cache of the lookups of rarely changing objects, see WithCache
+	cache    Cache
+	cacheTTL time.Duration
+
+	// circuit breaker of the REST API requests, see WithCircuitBreaker
+	breaker *circuitBreaker
 
 	// HTTPClient is the HTTP client used for making requests against the
 	// PagerDuty API. You can use either *http.Client here, or your own
@@ -286,14 +335,17 @@ type Client struct {
 // NewClient creates an API client using an account/user API token
 func NewClient(authToken string, options ...ClientOptions) *Client {
 	client := Client{
-		debugFlag:           new(uint64),
-		lastRequest:         &atomic.Value{},
-		lastResponse:        &atomic.Value{},
-		authToken:           authToken,
-		apiEndpoint:         apiEndpoint,
-		v2EventsAPIEndpoint: v2EventsAPIEndpoint,
-		authType:            apiToken,
-		HTTPClient:          defaultHTTPClient,
+		debugFlag:              new(uint64),
+		lastRequest:            &atomic.Value{},
+		lastResponse:           &atomic.Value{},
+		authToken:              authToken,
+		apiEndpoint:            apiEndpoint,
+		v2EventsAPIEndpoint:    v2EventsAPIEndpoint,
+		slackAPIEndpoint:       slackAPIEndpoint,
+		platformStatusEndpoint: platformStatusEndpoint,
+		authStyle:              AuthStyleToken,
+		defaultFrom:            &atomic.Value{},
+		HTTPClient:             defaultHTTPClient,
 	}
 
 	for _, opt := range options {
@@ -305,7 +357,7 @@ func NewClient(authToken string, options ...ClientOptions) *Client {
 
 // NewOAuthClient creates an API client using an OAuth token
 func NewOAuthClient(authToken string, options ...ClientOptions) *Client {
-	return NewClient(authToken, WithOAuth())
+	return NewClient(authToken, append(options, WithOAuth())...)
 }
 
 // ClientOptions allows for options to be passed into the Client for customization
@@ -325,13 +377,131 @@ func WithV2EventsAPIEndpoint(endpoint string) ClientOptions {
 	}
 }
 
+// WithSlackAPIEndpoint allows for a custom Slack integration API endpoint,
+// which manages the Slack connections, to be passed into the client.
+func WithSlackAPIEndpoint(endpoint string) ClientOptions {
+	return func(c *Client) {
+		c.slackAPIEndpoint = endpoint
+	}
+}
+
+// WithRegion sets the REST API, V2 Events API and Slack integration API
+// endpoints of the client to those of the given service region, such as
+// oauth.RegionEU. It's overridden by WithAPIEndpoint, WithV2EventsAPIEndpoint
+// and WithSlackAPIEndpoint when passed after it.
+func WithRegion(region oauth.Region) ClientOptions {
+	return func(c *Client) {
+		c.apiEndpoint, c.v2EventsAPIEndpoint = regionEndpoints(region)
+
+		c.slackAPIEndpoint = slackAPIEndpoint
+		if region != oauth.RegionUS && region != "" {
+			c.slackAPIEndpoint = "https://app." + string(region) + ".pagerduty.com/integration-slack"
+		}
+	}
+}
+
+// regionEndpoints returns the REST API and V2 Events API endpoints of a
+// service region.
+func regionEndpoints(region oauth.Region) (api, events string) {
+	if region == oauth.RegionUS || region == "" {
+		return apiEndpoint, v2EventsAPIEndpoint
+	}
+
+	return "https://api." + string(region) + ".pagerduty.com", "https://events." + string(region) + ".pagerduty.com"
+}
+
 // WithOAuth allows for an OAuth token to be passed into the the client
 func WithOAuth() ClientOptions {
 	return func(c *Client) {
-		c.authType = oauthToken
+		c.authStyle = AuthStyleBearer
+	}
+}
+
+// WithAuthStyle sets the style of the Authorization header sent to the REST
+// API. The default is AuthStyleToken.
+func WithAuthStyle(style AuthStyle) ClientOptions {
+	return func(c *Client) {
+		c.authStyle = style
+	}
+}
+
+// WithTokenSource sets the TokenSource used to obtain the token sent to the
+// REST API, instead of the static token passed to NewClient. It should be
+// combined with WithAuthStyle if the token isn't an API token.
+func WithTokenSource(ts TokenSource) ClientOptions {
+	return func(c *Client) {
+		c.tokenSource = ts
+	}
+}
+
+// WithOAuthToken configures the client to authenticate with an OAuth token
+// obtained using conf, such as by conf.Exchange. The token is refreshed using
+// its refresh token when it expires, or when the API rejects it, and the
+// rejected request is retried once. If onRefresh is not nil, it's called with
+// every refreshed token so that it can be persisted.
+//
+// The authToken passed to NewClient is ignored when using this option.
+func WithOAuthToken(conf *oauth.Config, token *oauth.Token, onRefresh func(*oauth.Token)) ClientOptions {
+	return func(c *Client) {
+		ts := conf.TokenSource(token)
+		ts.OnRefresh = onRefresh
+
+		c.tokenSource = ts
+		c.authStyle = AuthStyleBearer
+	}
+}
+
+// WithScopedOAuthApp configures the client to authenticate as a PagerDuty
+// scoped OAuth app, by exchanging its client ID and secret for an access token
+// with the requested scopes. The scopes must include the account scope, as
+// returned by oauth.AccountScope. Tokens are obtained lazily, and a new one is
+// obtained whenever the current one expires.
+//
+// The authToken passed to NewClient is ignored when using this option.
+func WithScopedOAuthApp(clientID, clientSecret string, scopes []string) ClientOptions {
+	return func(c *Client) {
+		conf := &oauth.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			Scopes:       scopes,
+		}
+
+		c.tokenSource = conf.ClientCredentialsTokenSource()
+		c.authStyle = AuthStyleBearer
 	}
 }
 
+// WithDefaultFrom sets the email address sent in the From header of REST API
+// requests that don't set one, such as when using an account-level token to
+// act on behalf of a user. See also Client.UseCurrentUserAsFrom.
+func WithDefaultFrom(email string) ClientOptions {
+	return func(c *Client) {
+		c.defaultFrom.Store(email)
+	}
+}
+
+// DefaultFrom returns the email address sent in the From header of REST API
+// requests that don't set one, as set by WithDefaultFrom or
+// UseCurrentUserAsFrom.
+func (c *Client) DefaultFrom() string {
+	if c.defaultFrom == nil {
+		return ""
+	}
+
+	from, _ := c.defaultFrom.Load().(string)
+	return from
+}
+
+// checkFrom returns an error if neither from nor the default From email
+// address of the client is set, for the requests that require a From header.
+func (c *Client) checkFrom(from string) error {
+	if len(from) == 0 && len(c.DefaultFrom()) == 0 {
+		return errors.New("From email address must be set, or a default set with WithDefaultFrom")
+	}
+
+	return nil
+}
+
 // DebugFlag represents a set of debug bit flags that can be bitwise-ORed
 // together to configure the different behaviors. This allows us to expand
 // functionality in the future without introducing breaking changes.
@@ -440,11 +610,52 @@ func (c *Client) LastAPIResponse() (*http.Response, bool) {
 // assumes any request body is in JSON format and sets the Content-Type to
 // application/json.
 func (c *Client) Do(r *http.Request, authRequired bool) (*http.Response, error) {
-	c.prepRequest(r, authRequired, nil)
+	if err := c.prepRequest(r, authRequired, nil); err != nil {
+		return nil, err
+	}
 
 	return c.HTTPClient.Do(r)
 }
 
+// DoWithContext makes an authenticated request against the REST API, which
+// allows for calling endpoints this package doesn't yet provide methods for.
+// The path is relative to the API endpoint (e.g., "/incidents/PABC123").
+//
+// If body is not nil, it's encoded as the JSON request body. If out is not
+// nil, the JSON response body is decoded into it. Error responses from the API
+// are returned as an APIError, just like for the other methods on the client.
+//
+// The returned *http.Response is provided for inspecting the status code and
+// headers; its body has already been consumed and closed.
+func (c *Client) DoWithContext(ctx context.Context, method, path string, body, out interface{}, headers map[string]string) (*http.Response, error) {
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(data)
+	}
+
+	resp, err := c.do(ctx, method, path, r, headers)
+	if err != nil {
+		return resp, err
+	}
+
+	if out == nil {
+		orb := resp.Body
+		defer func() { _ = orb.Close() }() // explicitly discard error
+		_, _ = io.Copy(ioutil.Discard, orb)
+		return resp, nil
+	}
+
+	if err = c.decodeJSON(resp, out); err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
 func (c *Client) delete(ctx context.Context, path string) (*http.Response, error) {
 	return c.do(ctx, http.MethodDelete, path, nil, nil)
 }
@@ -478,7 +689,7 @@ const (
 	contentTypeHeader = "application/json"
 )
 
-func (c *Client) prepRequest(req *http.Request, authRequired bool, headers map[string]string) {
+func (c *Client) prepRequest(req *http.Request, authRequired bool, headers map[string]string) error {
 	req.Header.Set("Accept", acceptHeader)
 
 	for k, v := range headers {
@@ -486,16 +697,33 @@ func (c *Client) prepRequest(req *http.Request, authRequired bool, headers map[s
 	}
 
 	if authRequired {
-		switch c.authType {
-		case oauthToken:
-			req.Header.Set("Authorization", "Bearer "+c.authToken)
+		if len(req.Header.Get("From")) == 0 {
+			if from := c.DefaultFrom(); len(from) > 0 {
+				req.Header.Set("From", from)
+			}
+		}
+
+		token := c.authToken
+
+		if c.tokenSource != nil {
+			var err error
+			if token, err = c.tokenSource.Token(req.Context()); err != nil {
+				return fmt.Errorf("failed to obtain auth token: %w", err)
+			}
+		}
+
+		switch c.authStyle {
+		case AuthStyleBearer:
+			req.Header.Set("Authorization", "Bearer "+token)
 		default:
-			req.Header.Set("Authorization", "Token token="+c.authToken)
+			req.Header.Set("Authorization", "Token token="+token)
 		}
 	}
 
 	req.Header.Set("User-Agent", userAgentHeader)
 	req.Header.Set("Content-Type", contentTypeHeader)
+
+	return nil
 }
 
 func dupeRequest(r *http.Request) (*http.Request, error) {
@@ -520,6 +748,7 @@ func dupeRequest(r *http.Request) (*http.Request, error) {
 func (c *Client) doWithEndpoint(ctx context.Context, endpoint, method, path string, authRequired bool, body io.Reader, headers map[string]string) (*http.Response, error) {
 	var dreq *http.Request
 	var resp *http.Response
+	var err error
 
 	// so that the last request and response can be nil if there was an error
 	// before the request could be fully processed by the origin, we defer these
@@ -536,30 +765,140 @@ func (c *Client) doWithEndpoint(ctx context.Context, endpoint, method, path stri
 		}()
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, endpoint+path, body)
+	// when the token source can obtain a new token, the body is buffered so
+	// that the request can be retried if the API rejects the current token
+	var rts RefreshableTokenSource
+	if authRequired {
+		rts, _ = c.tokenSource.(RefreshableTokenSource)
+	}
+
+	var bodyBytes []byte
+	if rts != nil && body != nil {
+		if bodyBytes, err = ioutil.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	newRequest := func() (*http.Request, error) {
+		if bodyBytes != nil {
+			body = bytes.NewReader(bodyBytes)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, endpoint+path, body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build request: %w", err)
+		}
+
+		if err := c.prepRequest(req, authRequired, headers); err != nil {
+			return nil, err
+		}
+
+		// if in debug mode, copy request before making it
+		if c.debugCaptureRequest() {
+			if dreq, err = dupeRequest(req); err != nil {
+				return nil, fmt.Errorf("failed to duplicate request for debug capture: %w", err)
+			}
+		}
+
+		return req, nil
+	}
+
+	req, err := newRequest()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build request: %w", err)
+		return nil, err
 	}
 
-	c.prepRequest(req, authRequired, headers)
+	resp, err = c.send(req)
 
-	// if in debug mode, copy request before making it
-	if c.debugCaptureRequest() {
-		if dreq, err = dupeRequest(req); err != nil {
-			return nil, fmt.Errorf("failed to duplicate request for debug capture: %w", err)
+	if err == nil && rts != nil && resp.StatusCode == http.StatusUnauthorized {
+		// the token was rejected, likely because it was revoked or expired
+		// early, so retry the request once with a new one
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		rts.InvalidateToken(authHeaderToken(req.Header.Get("Authorization")))
+
+		if req, err = newRequest(); err != nil {
+			return nil, err
 		}
+
+		resp, err = c.send(req)
 	}
 
-	resp, err = c.HTTPClient.Do(req)
+	if errors.Is(err, ErrCircuitOpen) {
+		return nil, err
+	}
+
+	if hook := responseHookFromContext(ctx); hook != nil && err == nil {
+		hook(resp)
+	}
 
 	return c.checkResponse(resp, err)
 }
 
+// send makes the request with the HTTP client, through the circuit breaker if
+// any. Only the outcome of the request itself is recorded by the breaker, so
+// that failing to build the request, such as failing to obtain a token, doesn't
+// open the circuit.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if c.breaker == nil {
+		return c.HTTPClient.Do(req)
+	}
+
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	c.breaker.record(resp, err)
+
+	return resp, err
+}
+
+// authHeaderToken returns the token of an Authorization header value.
+func authHeaderToken(v string) string {
+	if t := strings.TrimPrefix(v, "Bearer "); len(t) < len(v) {
+		return t
+	}
+
+	return strings.TrimPrefix(v, "Token token=")
+}
+
 func (c *Client) do(ctx context.Context, method, path string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	// only GET requests are retried, as they have no body to send again
+	if p, ok := retryPolicyFromContext(ctx); ok && method == http.MethodGet {
+		var resp *http.Response
+
+		err := p.Do(ctx, func() (err error) {
+			resp, err = c.doOnce(ctx, method, path, body, headers)
+			return err
+		})
+
+		return resp, err
+	}
+
+	return c.doOnce(ctx, method, path, body, headers)
+}
+
+func (c *Client) doOnce(ctx context.Context, method, path string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	if c.cache != nil && cacheablePath(path) {
+		return c.doCached(ctx, method, path, body, headers)
+	}
+
 	return c.doWithEndpoint(ctx, c.apiEndpoint, method, path, true, body, headers)
 }
 
 func (c *Client) decodeJSON(resp *http.Response, payload interface{}) error {
+	body, err := c.readBody(resp)
+	if err != nil {
+		return err
+	}
+
+	return c.unmarshalJSON(resp, "", body, payload)
+}
+
+// readBody reads and closes the body of the response.
+func (c *Client) readBody(resp *http.Response) ([]byte, error) {
 	// close the original response body, and not the copy we may make if
 	// debugCaptureResponse is true
 	orb := resp.Body
@@ -567,19 +906,80 @@ func (c *Client) decodeJSON(resp *http.Response, payload interface{}) error {
 
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	if c.debugCaptureResponse() { // reset body as we capture the response elsewhere
 		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
 	}
 
-	return json.Unmarshal(body, payload)
+	if resp.Request != nil {
+		if hook := rawJSONHookFromContext(resp.Request.Context()); hook != nil {
+			hook(resp.Request.URL.Path, body)
+		}
+	}
+
+	return body, nil
+}
+
+// unmarshalJSON decodes data, the value of the field of the JSON response at
+// fieldPath, or the whole response if it's empty, into payload, honoring the
+// strict decoding options of the client.
+func (c *Client) unmarshalJSON(resp *http.Response, fieldPath string, data []byte, payload interface{}) error {
+	if c.unknownFieldsHandler != nil {
+		if fields := unknownFields(data, payload, fieldPath); len(fields) > 0 {
+			var path string
+			if resp.Request != nil {
+				path = resp.Request.URL.Path
+			}
+
+			c.unknownFieldsHandler(path, fields)
+		}
+	}
+
+	if c.disallowUnknownFields {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+
+		return dec.Decode(payload)
+	}
+
+	return json.Unmarshal(data, payload)
+}
+
+// decodeEnvelope decodes into v the object wrapped in the rootNode field of
+// the JSON response, which is how the API returns single objects. v must be a
+// pointer. err is the error of the request, returned as is if it's not nil.
+func decodeEnvelope(c *Client, resp *http.Response, err error, rootNode string, v interface{}) error {
+	if err != nil {
+		return err
+	}
+
+	body, err := c.readBody(resp)
+	if err != nil {
+		return DecodeError{Field: rootNode, Err: err}
+	}
+
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return DecodeError{Field: rootNode, Err: err}
+	}
+
+	data, ok := envelope[rootNode]
+	if !ok {
+		return MissingFieldError{Field: rootNode}
+	}
+
+	if err := c.unmarshalJSON(resp, rootNode, data, v); err != nil {
+		return DecodeError{Field: rootNode, Err: err}
+	}
+
+	return nil
 }
 
 func (c *Client) checkResponse(resp *http.Response, err error) (*http.Response, error) {
 	if err != nil {
-		return resp, fmt.Errorf("error calling the API endpoint: %v", err)
+		return resp, fmt.Errorf("error calling the API endpoint: %w", err)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
@@ -642,6 +1042,11 @@ func (c *Client) pagedGet(ctx context.Context, basePath string, handler response
 	var nextOffset uint
 
 	basePrefix := getBasePrefix(basePath)
+	// The total is needed to fetch the remaining pages concurrently.
+	if c.pageParallelism > 1 {
+		basePrefix += "total=true&"
+	}
+
 	// While there are more pages, keep adjusting the offset to get all results.
 	for stillMore, nextOffset = true, 0; stillMore; {
 		response, err := c.do(ctx, http.MethodGet, fmt.Sprintf("%soffset=%d", basePrefix, nextOffset), nil, nil)
@@ -655,6 +1060,10 @@ func (c *Client) pagedGet(ctx context.Context, basePath string, handler response
 			return err
 		}
 
+		if c.pageParallelism > 1 && pageInfo.More && pageInfo.Total > 0 && pageInfo.Limit > 0 {
+			return c.pagedGetParallel(ctx, basePrefix, pageInfo, handler)
+		}
+
 		// Bump the offset as necessary and set whether more results exist.
 		nextOffset = pageInfo.Offset + pageInfo.Limit
 		stillMore = pageInfo.More
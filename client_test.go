@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -13,6 +14,7 @@ import (
 	"sync/atomic"
 	"testing"
 
+	"github.com/PagerDuty/go-pagerduty/oauth"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -40,13 +42,16 @@ func teardown() {
 
 func defaultTestClient(serverURL, authToken string) *Client {
 	return &Client{
-		v2EventsAPIEndpoint: serverURL,
-		apiEndpoint:         serverURL,
-		authToken:           authToken,
-		HTTPClient:          defaultHTTPClient,
-		debugFlag:           new(uint64),
-		lastRequest:         &atomic.Value{},
-		lastResponse:        &atomic.Value{},
+		v2EventsAPIEndpoint:    serverURL,
+		apiEndpoint:            serverURL,
+		slackAPIEndpoint:       serverURL,
+		platformStatusEndpoint: serverURL,
+		authToken:              authToken,
+		HTTPClient:             defaultHTTPClient,
+		debugFlag:              new(uint64),
+		lastRequest:            &atomic.Value{},
+		lastResponse:           &atomic.Value{},
+		defaultFrom:            &atomic.Value{},
 	}
 }
 
@@ -682,6 +687,248 @@ func TestClient_Do(t *testing.T) {
 	}
 }
 
+func TestClient_DoWithContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/unwrapped/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+
+		if got := r.Header.Get("Authorization"); got != "Token token=foo" {
+			t.Errorf("Authorization = %q, want %q", got, "Token token=foo")
+		}
+
+		if got := r.Header.Get("From"); got != "foo@example.com" {
+			t.Errorf("From = %q, want %q", got, "foo@example.com")
+		}
+
+		b, err := ioutil.ReadAll(r.Body)
+		testErrCheck(t, "ioutil.ReadAll()", "", err)
+
+		if got := string(b); got != `{"name":"foo"}` {
+			t.Errorf("body = %s, want %s", got, `{"name":"foo"}`)
+		}
+
+		_, _ = w.Write([]byte(`{"thing":{"id":"1","name":"foo"}}`))
+	})
+
+	mux.HandleFunc("/unwrapped/2", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":2100,"message":"Not Found"}}`))
+	})
+
+	c := defaultTestClient(server.URL, "foo")
+
+	var out struct {
+		Thing struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"thing"`
+	}
+
+	in := map[string]string{"name": "foo"}
+	headers := map[string]string{"From": "foo@example.com"}
+
+	resp, err := c.DoWithContext(context.Background(), http.MethodPut, "/unwrapped/1", in, &out, headers)
+	testErrCheck(t, "c.DoWithContext()", "", err)
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("resp.StatusCode = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	if out.Thing.ID != "1" || out.Thing.Name != "foo" {
+		t.Errorf("out.Thing = %+v, want {ID:1 Name:foo}", out.Thing)
+	}
+
+	_, err = c.DoWithContext(context.Background(), http.MethodGet, "/unwrapped/2", nil, nil, nil)
+
+	var aerr APIError
+	if !errors.As(err, &aerr) {
+		t.Fatalf("err = %v, want APIError", err)
+	}
+
+	if !aerr.NotFound() {
+		t.Errorf("aerr.NotFound() = false, want true")
+	}
+}
+
+type staticTokenSource struct {
+	token string
+	err   error
+}
+
+func (s staticTokenSource) Token(context.Context) (string, error) {
+	return s.token, s.err
+}
+
+func TestClient_authStyle(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var got string
+
+	mux.HandleFunc("/auth", func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse form: %s", err)
+		}
+
+		if gt := r.PostForm.Get("grant_type"); gt != "client_credentials" {
+			t.Errorf("grant_type = %q, want client_credentials", gt)
+		}
+
+		_, _ = w.Write([]byte(`{"access_token":"scoped","token_type":"bearer","expires_in":3600}`))
+	})
+
+	conf := &oauth.Config{
+		ClientID:     "id",
+		ClientSecret: "secret",
+		Scopes:       []string{oauth.AccountScope(oauth.RegionUS, "acme"), "incidents.read"},
+		TokenURL:     server.URL + "/oauth/token",
+	}
+
+	tests := []struct {
+		name    string
+		opts    []ClientOptions
+		want    string
+		wantErr string
+	}{
+		{
+			name: "default",
+			want: "Token token=foo",
+		},
+		{
+			name: "bearer",
+			opts: []ClientOptions{WithAuthStyle(AuthStyleBearer)},
+			want: "Bearer foo",
+		},
+		{
+			name: "oauth",
+			opts: []ClientOptions{WithOAuth()},
+			want: "Bearer foo",
+		},
+		{
+			name: "token_source",
+			opts: []ClientOptions{WithTokenSource(staticTokenSource{token: "bar"})},
+			want: "Token token=bar",
+		},
+		{
+			name:    "token_source_error",
+			opts:    []ClientOptions{WithTokenSource(staticTokenSource{err: errors.New("nope")})},
+			wantErr: "failed to obtain auth token: nope",
+		},
+		{
+			name: "client_credentials",
+			opts: []ClientOptions{
+				WithTokenSource(conf.ClientCredentialsTokenSource()),
+				WithAuthStyle(AuthStyleBearer),
+			},
+			want: "Bearer scoped",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = ""
+
+			c := NewClient("foo", append([]ClientOptions{WithAPIEndpoint(server.URL)}, tt.opts...)...)
+
+			_, err := c.get(context.Background(), "/auth")
+			if !testErrCheck(t, "c.get()", tt.wantErr, err) {
+				return
+			}
+
+			testEqual(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_tokenRefresh(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"access_token":"new","refresh_token":"refresh","expires_in":3600}`))
+	})
+
+	var calls int
+
+	mux.HandleFunc("/things", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		calls++
+
+		b, err := ioutil.ReadAll(r.Body)
+		testErrCheck(t, "ioutil.ReadAll()", "", err)
+		testEqual(t, `{"name":"foo"}`, string(b))
+
+		if r.Header.Get("Authorization") != "Bearer new" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	conf := &oauth.Config{
+		ClientID: "id",
+		TokenURL: server.URL + "/oauth/token",
+	}
+
+	var persisted *oauth.Token
+
+	c := NewClient("",
+		WithAPIEndpoint(server.URL),
+		WithOAuthToken(conf, &oauth.Token{AccessToken: "revoked", RefreshToken: "refresh"}, func(t *oauth.Token) {
+			persisted = t
+		}),
+	)
+
+	_, err := c.post(context.Background(), "/things", map[string]string{"name": "foo"}, nil)
+	testErrCheck(t, "c.post()", "", err)
+
+	testEqual(t, 2, calls)
+
+	if persisted == nil || persisted.AccessToken != "new" {
+		t.Fatalf("persisted = %+v, want token with access token new", persisted)
+	}
+}
+
+func TestWithScopedOAuthApp(t *testing.T) {
+	c := NewClient("", WithScopedOAuthApp("id", "secret", []string{"as_account-us.acme"}))
+
+	if c.authStyle != AuthStyleBearer {
+		t.Errorf("c.authStyle = %d, want %d", c.authStyle, AuthStyleBearer)
+	}
+
+	if _, ok := c.tokenSource.(*oauth.TokenSource); !ok {
+		t.Errorf("c.tokenSource = %T, want *oauth.TokenSource", c.tokenSource)
+	}
+}
+
+func TestWithRegion(t *testing.T) {
+	c := NewClient("", WithRegion(oauth.RegionEU))
+	testEqual(t, "https://api.eu.pagerduty.com", c.apiEndpoint)
+	testEqual(t, "https://events.eu.pagerduty.com", c.v2EventsAPIEndpoint)
+	testEqual(t, "https://app.eu.pagerduty.com/integration-slack", c.slackAPIEndpoint)
+
+	c = NewClient("", WithRegion(oauth.RegionEU), WithV2EventsAPIEndpoint("https://relay.example.com"))
+	testEqual(t, "https://api.eu.pagerduty.com", c.apiEndpoint)
+	testEqual(t, "https://relay.example.com", c.v2EventsAPIEndpoint)
+
+	ec := NewEventsAPIV2Client(WithEventsAPIV2ClientRegion(oauth.RegionUS))
+	testEqual(t, "https://events.pagerduty.com", ec.endpoint)
+
+	ec = NewEventsAPIV2Client(WithEventsAPIV2ClientRegion(oauth.RegionEU))
+	testEqual(t, "https://events.eu.pagerduty.com", ec.endpoint)
+}
+
 func TestNullAPIErrorObject_UnmarshalJSON(t *testing.T) {
 	tests := []struct {
 		name string
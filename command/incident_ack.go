@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/PagerDuty/go-pagerduty"
+	log "github.com/sirupsen/logrus"
+	"github.com/mitchellh/cli"
+	"strings"
+)
+
+type IncidentAck struct {
+	Meta
+}
+
+func IncidentAckCommand() (cli.Command, error) {
+	return &IncidentAck{}, nil
+}
+
+func (c *IncidentAck) Help() string {
+	helpText := `
+	pd incident ack Acknowledge one or more incidents
+
+	Options:
+
+		 -id        Incident ID (can be specified multiple times)
+		 -output    Output format: table (default), json or yaml
+
+	` + c.Meta.Help()
+	return strings.TrimSpace(helpText)
+}
+
+func (c *IncidentAck) Synopsis() string {
+	return "Acknowledge one or more incidents"
+}
+
+func (c *IncidentAck) Run(args []string) int {
+	return runIncidentStatusChange(&c.Meta, "incident ack", pagerduty.IncidentStatusAcknowledged, c.Help, args)
+}
+
+// runIncidentStatusChange implements the commands that move incidents to a
+// new status, such as ack and resolve.
+func runIncidentStatusChange(m *Meta, name string, status pagerduty.IncidentStatus, help func() string, args []string) int {
+	var ids []string
+	flags := m.FlagSet(name)
+	flags.Usage = func() { fmt.Println(help()) }
+	flags.Var((*ArrayFlags)(&ids), "id", "Incident ID (can be specified multiple times)")
+	output := outputFlag(flags)
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := m.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if len(ids) == 0 {
+		log.Error("You must provide at least one incident id")
+		return -1
+	}
+	if m.From == "" {
+		log.Error("You must provide the email address of the acting user with -from")
+		return -1
+	}
+	opts := make([]pagerduty.ManageIncidentsOptions, 0, len(ids))
+	for _, id := range ids {
+		opts = append(opts, pagerduty.ManageIncidentsOptions{ID: id, Status: status})
+	}
+	client := m.Client()
+	resp, err := client.ManageIncidentsWithContext(context.Background(), m.From, opts)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := printOutput(*output, resp.Incidents, incidentTable(resp.Incidents...)); err != nil {
+		log.Error(err)
+		return -1
+	}
+	return 0
+}
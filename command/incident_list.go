@@ -5,7 +5,6 @@ import (
 	"github.com/PagerDuty/go-pagerduty"
 	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
-	"gopkg.in/yaml.v2"
 	"strings"
 )
 
@@ -21,7 +20,15 @@ func (c *IncidentList) Help() string {
 	helpText := `
 	pd incident list List incidents
 
-	`
+	Options:
+
+		 -include
+		 -team-id
+		 -time-zone
+		 -sort-by
+		 -output    Output format: table (default), json or yaml
+
+	` + c.Meta.Help()
 	return strings.TrimSpace(helpText)
 }
 
@@ -40,6 +47,7 @@ func (c *IncidentList) Run(args []string) int {
 	flags.Var((*ArrayFlags)(&teamIDs), "team-id", "Only show for team ID (can be specified multiple times)")
 	flags.StringVar(&timeZone, "time-zone", "", "Time Zone")
 	flags.StringVar(&sortBy, "sort-by", "", "sort by")
+	output := outputFlag(flags)
 
 	if err := flags.Parse(args); err != nil {
 		log.Error(err)
@@ -56,19 +64,23 @@ func (c *IncidentList) Run(args []string) int {
 		SortBy:   sortBy,
 		Includes: includes,
 	}
-	if incidentList, err := client.ListIncidents(opts); err != nil {
+	incidentList, err := client.ListIncidents(opts)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := printOutput(*output, incidentList.Incidents, incidentTable(incidentList.Incidents...)); err != nil {
 		log.Error(err)
 		return -1
-	} else {
-		for i, incident := range incidentList.Incidents {
-			fmt.Println("Entry: ", i+1)
-			data, err := yaml.Marshal(incident)
-			if err != nil {
-				log.Error(err)
-				return -1
-			}
-			fmt.Println(string(data))
-		}
 	}
 	return 0
 }
+
+// incidentTable renders one incident per row.
+func incidentTable(incidents ...pagerduty.Incident) *table {
+	t := &table{header: []string{"ID", "NUMBER", "STATUS", "URGENCY", "SERVICE", "CREATED", "TITLE"}}
+	for _, i := range incidents {
+		t.append(i.ID, fmt.Sprint(i.IncidentNumber), string(i.Status), string(i.Urgency), i.Service.Summary, i.CreatedAt.String(), i.Title)
+	}
+	return t
+}
@@ -1,11 +1,16 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"github.com/PagerDuty/go-pagerduty"
+	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"strings"
 )
 
 type IncidentNoteCreate struct {
+	Meta
 }
 
 func IncidentNoteCreateCommand() (cli.Command, error) {
@@ -14,7 +19,15 @@ func IncidentNoteCreateCommand() (cli.Command, error) {
 
 func (c *IncidentNoteCreate) Help() string {
 	helpText := `
-	`
+	pd incident note create Add a note to an incident
+
+	Options:
+
+		 -id        Incident ID
+		 -content   Text of the note
+		 -output    Output format: table (default), json or yaml
+
+	` + c.Meta.Help()
 	return strings.TrimSpace(helpText)
 }
 
@@ -23,5 +36,36 @@ func (c *IncidentNoteCreate) Synopsis() string {
 }
 
 func (c *IncidentNoteCreate) Run(args []string) int {
+	flags := c.Meta.FlagSet("incident note create")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	id := flags.String("id", "", "Incident ID")
+	content := flags.String("content", "", "Text of the note")
+	output := outputFlag(flags)
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if *id == "" {
+		log.Error("You must provide an incident id")
+		return -1
+	}
+	if *content == "" {
+		log.Error("You must provide the note content")
+		return -1
+	}
+	client := c.Meta.Client()
+	note, err := client.CreateIncidentNoteWithContext(context.Background(), *id, pagerduty.IncidentNote{Content: *content})
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := printOutput(*output, note, incidentNoteTable(*note)); err != nil {
+		log.Error(err)
+		return -1
+	}
 	return 0
 }
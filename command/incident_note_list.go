@@ -1,11 +1,16 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"github.com/PagerDuty/go-pagerduty"
+	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"strings"
 )
 
 type IncidentNoteList struct {
+	Meta
 }
 
 func IncidentNoteListCommand() (cli.Command, error) {
@@ -14,7 +19,14 @@ func IncidentNoteListCommand() (cli.Command, error) {
 
 func (c *IncidentNoteList) Help() string {
 	helpText := `
-	`
+	pd incident note list List the notes of an incident
+
+	Options:
+
+		 -id        Incident ID
+		 -output    Output format: table (default), json or yaml
+
+	` + c.Meta.Help()
 	return strings.TrimSpace(helpText)
 }
 
@@ -23,5 +35,40 @@ func (c *IncidentNoteList) Synopsis() string {
 }
 
 func (c *IncidentNoteList) Run(args []string) int {
+	flags := c.Meta.FlagSet("incident note list")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	id := flags.String("id", "", "Incident ID")
+	output := outputFlag(flags)
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if *id == "" {
+		log.Error("You must provide an incident id")
+		return -1
+	}
+	client := c.Meta.Client()
+	notes, err := client.ListIncidentNotesWithContext(context.Background(), *id)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := printOutput(*output, notes, incidentNoteTable(notes...)); err != nil {
+		log.Error(err)
+		return -1
+	}
 	return 0
 }
+
+// incidentNoteTable renders one note per row.
+func incidentNoteTable(notes ...pagerduty.IncidentNote) *table {
+	t := &table{header: []string{"ID", "CREATED", "USER", "CONTENT"}}
+	for _, n := range notes {
+		t.append(n.ID, n.CreatedAt.String(), n.User.Summary, n.Content)
+	}
+	return t
+}
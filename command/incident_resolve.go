@@ -0,0 +1,36 @@
+package main
+
+import (
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/mitchellh/cli"
+	"strings"
+)
+
+type IncidentResolve struct {
+	Meta
+}
+
+func IncidentResolveCommand() (cli.Command, error) {
+	return &IncidentResolve{}, nil
+}
+
+func (c *IncidentResolve) Help() string {
+	helpText := `
+	pd incident resolve Resolve one or more incidents
+
+	Options:
+
+		 -id        Incident ID (can be specified multiple times)
+		 -output    Output format: table (default), json or yaml
+
+	` + c.Meta.Help()
+	return strings.TrimSpace(helpText)
+}
+
+func (c *IncidentResolve) Synopsis() string {
+	return "Resolve one or more incidents"
+}
+
+func (c *IncidentResolve) Run(args []string) int {
+	return runIncidentStatusChange(&c.Meta, "incident resolve", pagerduty.IncidentStatusResolved, c.Help, args)
+}
@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"strings"
 )
 
 type IncidentShow struct {
+	Meta
 }
 
 func IncidentShowCommand() (cli.Command, error) {
@@ -14,7 +18,14 @@ func IncidentShowCommand() (cli.Command, error) {
 
 func (c *IncidentShow) Help() string {
 	helpText := `
-	`
+	pd incident show Show detailed information about an incident
+
+	Options:
+
+		 -id        Incident ID
+		 -output    Output format: table (default), json or yaml
+
+	` + c.Meta.Help()
 	return strings.TrimSpace(helpText)
 }
 
@@ -23,5 +34,31 @@ func (c *IncidentShow) Synopsis() string {
 }
 
 func (c *IncidentShow) Run(args []string) int {
+	flags := c.Meta.FlagSet("incident show")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	id := flags.String("id", "", "Incident ID")
+	output := outputFlag(flags)
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if *id == "" {
+		log.Error("You must provide an incident id")
+		return -1
+	}
+	client := c.Meta.Client()
+	incident, err := client.GetIncidentWithContext(context.Background(), *id)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := printOutput(*output, incident, incidentTable(*incident)); err != nil {
+		log.Error(err)
+		return -1
+	}
 	return 0
 }
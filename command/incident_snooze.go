@@ -1,11 +1,16 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"strings"
+	"time"
 )
 
 type IncidentSnooze struct {
+	Meta
 }
 
 func IncidentSnoozeCommand() (cli.Command, error) {
@@ -14,7 +19,15 @@ func IncidentSnoozeCommand() (cli.Command, error) {
 
 func (c *IncidentSnooze) Help() string {
 	helpText := `
-	`
+	pd incident snooze Snooze an acknowledged incident
+
+	Options:
+
+		 -id        Incident ID
+		 -duration  How long to snooze the incident for, e.g. 30m or 4h (default 1h)
+		 -output    Output format: table (default), json or yaml
+
+	` + c.Meta.Help()
 	return strings.TrimSpace(helpText)
 }
 
@@ -23,5 +36,36 @@ func (c *IncidentSnooze) Synopsis() string {
 }
 
 func (c *IncidentSnooze) Run(args []string) int {
+	flags := c.Meta.FlagSet("incident snooze")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	id := flags.String("id", "", "Incident ID")
+	duration := flags.Duration("duration", time.Hour, "How long to snooze the incident for")
+	output := outputFlag(flags)
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if *id == "" {
+		log.Error("You must provide an incident id")
+		return -1
+	}
+	if *duration < time.Second {
+		log.Error("The snooze duration must be at least one second")
+		return -1
+	}
+	client := c.Meta.Client()
+	incident, err := client.SnoozeIncidentWithContext(context.Background(), *id, uint(duration.Seconds()))
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := printOutput(*output, incident, incidentTable(*incident)); err != nil {
+		log.Error(err)
+		return -1
+	}
 	return 0
 }
@@ -35,11 +35,14 @@ func loadCommands() map[string]cli.CommandFactory {
 
 		"event-v2 manage": EventV2ManageCommand,
 
+		"incident ack":         IncidentAckCommand,
 		"incident list":        IncidentListCommand,
 		"incident manage":      IncidentManageCommand,
 		"incident show":        IncidentShowCommand,
+		"incident get":         IncidentShowCommand,
 		"incident note list":   IncidentNoteListCommand,
 		"incident note create": IncidentNoteCreateCommand,
+		"incident resolve":     IncidentResolveCommand,
 		"incident snooze":      IncidentSnoozeCommand,
 
 		"log-entry list": LogEntryListCommand,
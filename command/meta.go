@@ -31,6 +31,7 @@ func (a *ArrayFlags) Set(v string) error {
 type Meta struct {
 	Authtoken string
 	Loglevel  string
+	From      string
 }
 
 type FlagSetFlags uint
@@ -39,11 +40,16 @@ func (m *Meta) FlagSet(n string) *flag.FlagSet {
 	f := flag.NewFlagSet(n, flag.ContinueOnError)
 	f.StringVar(&m.Authtoken, "authtoken", "", "PagerDuty API authentication token")
 	f.StringVar(&m.Loglevel, "loglevel", "", "Logging level")
+	f.StringVar(&m.From, "from", "", "Email address of the user acting on incidents")
 	return f
 }
 
 func (m *Meta) Client() *pagerduty.Client {
-	return pagerduty.NewClient(m.Authtoken)
+	var opts []pagerduty.ClientOptions
+	if m.From != "" {
+		opts = append(opts, pagerduty.WithDefaultFrom(m.From))
+	}
+	return pagerduty.NewClient(m.Authtoken, opts...)
 }
 
 func (m *Meta) Help() string {
@@ -52,6 +58,7 @@ func (m *Meta) Help() string {
 
 	-authtoken PagerDuty API authentication token
 	-loglevel Logging level
+	-from Email address of the user acting on incidents
 `
 	return strings.TrimSpace(helpText)
 }
@@ -108,5 +115,8 @@ func (m *Meta) loadConfig() error {
 	if m.Loglevel == "" {
 		m.Loglevel = other.Loglevel
 	}
+	if m.From == "" {
+		m.From = other.From
+	}
 	return nil
 }
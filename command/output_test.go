@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestWriteOutput(t *testing.T) {
+	notes := []pagerduty.IncidentNote{
+		{
+			ID:        "PN1",
+			User:      pagerduty.APIObject{ID: "PU1", Type: "user_reference", Summary: "Jane Doe"},
+			Content:   "Rolled back the deploy",
+			CreatedAt: pagerduty.NewAPITime(time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)),
+		},
+		{
+			ID:        "PN2",
+			User:      pagerduty.APIObject{ID: "PU2", Type: "user_reference", Summary: "John"},
+			Content:   "Resolved",
+			CreatedAt: pagerduty.NewAPITime(time.Date(2022, 3, 1, 12, 30, 0, 0, time.UTC)),
+		},
+	}
+
+	for _, format := range []string{outputTable, outputJSON, outputYAML} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeOutput(&buf, format, notes, incidentNoteTable(notes...)); err != nil {
+				t.Fatalf("writeOutput() unexpected error: %s", err)
+			}
+
+			golden := filepath.Join("testdata", "output."+format+".golden")
+
+			if *update {
+				if err := ioutil.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+					t.Fatalf("failed to update golden file: %s", err)
+				}
+			}
+
+			want, err := ioutil.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %s", err)
+			}
+
+			if got := buf.String(); got != string(want) {
+				t.Fatalf("output does not match %s, run go test -update to update it:\n%s", golden, got)
+			}
+		})
+	}
+
+	if err := writeOutput(ioutil.Discard, "xml", notes, incidentNoteTable(notes...)); err == nil {
+		t.Error("writeOutput() expected an error for an unknown format")
+	}
+}
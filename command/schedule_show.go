@@ -1,11 +1,18 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/PagerDuty/go-pagerduty"
+	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"strings"
+	"time"
 )
 
 type ScheduleShow struct {
+	Meta
 }
 
 func ScheduleShowCommand() (cli.Command, error) {
@@ -14,7 +21,21 @@ func ScheduleShowCommand() (cli.Command, error) {
 
 func (c *ScheduleShow) Help() string {
 	helpText := `
-	`
+	pd schedule show Show the final rendered schedule, or who was on call at a given time
+
+	Usage: pd schedule show [options] <id>
+
+	Options:
+
+		 -id         Schedule ID
+		 -at         Only show who is on call at this time, e.g. "2022-03-01 03:12"
+		 -range      Render the schedule between two times, e.g. "2022-03-01,2022-03-08"
+		 -time-zone  Time zone of times without an offset and of the output (default local)
+		 -output     Output format: table (default), json or yaml
+
+	Times are RFC 3339 or YYYY-MM-DD[ HH:MM[:SS]].
+
+	` + c.Meta.Help()
 	return strings.TrimSpace(helpText)
 }
 
@@ -23,5 +44,120 @@ func (c *ScheduleShow) Synopsis() string {
 }
 
 func (c *ScheduleShow) Run(args []string) int {
+	flags := c.Meta.FlagSet("schedule show")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	id := flags.String("id", "", "Schedule ID")
+	at := flags.String("at", "", "Only show who is on call at this time")
+	timeRange := flags.String("range", "", "Render the schedule between two times")
+	timeZone := flags.String("time-zone", "", "Time zone")
+	output := outputFlag(flags)
+	if err := parseFlagsAndID(flags, args, id); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if *id == "" {
+		log.Error("You must provide a schedule id")
+		return -1
+	}
+	if *at != "" && *timeRange != "" {
+		log.Error("-at and -range can not be used together")
+		return -1
+	}
+	loc := time.Local
+	if *timeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(*timeZone); err != nil {
+			log.Error(err)
+			return -1
+		}
+	}
+
+	o := pagerduty.GetScheduleOptions{TimeZone: loc.String()}
+	var atTime time.Time
+	switch {
+	case *at != "":
+		t, err := parseTime(*at, loc)
+		if err != nil {
+			log.Error(err)
+			return -1
+		}
+		atTime = t
+		o.Since = t.Format(time.RFC3339)
+		o.Until = t.Add(time.Second).Format(time.RFC3339)
+	case *timeRange != "":
+		since, until, err := parseTimeRange(*timeRange, loc)
+		if err != nil {
+			log.Error(err)
+			return -1
+		}
+		o.Since = since.Format(time.RFC3339)
+		o.Until = until.Format(time.RFC3339)
+	}
+	if o.TimeZone == "Local" {
+		// The API only understands IANA names, so let it use the schedule's own.
+		o.TimeZone = ""
+	}
+
+	client := c.Meta.Client()
+	schedule, err := client.GetScheduleWithContext(context.Background(), *id, o)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	entries, err := schedule.FinalSchedule.Entries()
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+
+	var v interface{} = schedule
+	if !atTime.IsZero() {
+		var onCall []pagerduty.RenderedScheduleEntry
+		var contained []pagerduty.ScheduleEntry
+		for i, e := range entries {
+			if e.Contains(atTime) {
+				onCall = append(onCall, schedule.FinalSchedule.RenderedScheduleEntries[i])
+				contained = append(contained, e)
+			}
+		}
+		v, entries = onCall, contained
+	}
+	if err := printOutput(*output, v, scheduleEntryTable(entries, loc)); err != nil {
+		log.Error(err)
+		return -1
+	}
 	return 0
 }
+
+// parseFlagsAndID parses args, in which the id can be given as an argument
+// instead of with its flag, followed by the other flags.
+func parseFlagsAndID(flags *flag.FlagSet, args []string, id *string) error {
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *id == "" && flags.NArg() > 0 {
+		// Parsing stops at the id, so parse the flags following it too.
+		*id = flags.Arg(0)
+		if err := flags.Parse(flags.Args()[1:]); err != nil {
+			return err
+		}
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+	return nil
+}
+
+// scheduleEntryTable renders one schedule entry per row, with times in loc.
+func scheduleEntryTable(entries []pagerduty.ScheduleEntry, loc *time.Location) *table {
+	const layout = "Mon 2006-01-02 15:04 MST"
+	t := &table{header: []string{"START", "END", "USER", "USER ID"}}
+	for _, e := range entries {
+		t.append(e.Start.In(loc).Format(layout), e.End.In(loc).Format(layout), e.User.Summary, e.User.ID)
+	}
+	return t
+}
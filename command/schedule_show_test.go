@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"testing"
+)
+
+func TestParseFlagsAndID(t *testing.T) {
+	for _, args := range [][]string{
+		{"PS1", "-at", "2022-03-01"},
+		{"-at", "2022-03-01", "PS1"},
+		{"-id", "PS1", "-at", "2022-03-01"},
+	} {
+		flags := flag.NewFlagSet("test", flag.ContinueOnError)
+		id := flags.String("id", "", "")
+		at := flags.String("at", "", "")
+		if err := parseFlagsAndID(flags, args, id); err != nil {
+			t.Fatalf("parseFlagsAndID(%q): %s", args, err)
+		}
+		if *id != "PS1" || *at != "2022-03-01" {
+			t.Errorf("parseFlagsAndID(%q): id = %q, at = %q", args, *id, *at)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"PS1", "PS2"},
+		{"-id", "PS1", "PS2"},
+		{"PS1", "-at", "2022-03-01", "PS2"},
+	} {
+		flags := flag.NewFlagSet("test", flag.ContinueOnError)
+		flags.SetOutput(ioutil.Discard)
+		id := flags.String("id", "", "")
+		flags.String("at", "", "")
+		if err := parseFlagsAndID(flags, args, id); err == nil {
+			t.Errorf("parseFlagsAndID(%q): expected an error", args)
+		}
+	}
+}
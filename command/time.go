@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// timeLayouts are the layouts accepted for times given on the command line.
+// Layouts without an offset are interpreted in the requested time zone.
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseTime parses a time given on the command line in one of timeLayouts.
+func parseTime(s string, loc *time.Location) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q: use RFC 3339 or YYYY-MM-DD[ HH:MM[:SS]]", s)
+}
+
+// parseTimeRange parses a range given on the command line as two times
+// separated by a comma, such as "2022-03-01,2022-03-08".
+func parseTimeRange(s string, loc *time.Location) (time.Time, time.Time, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid range %q: use START,END", s)
+	}
+	start, err := parseTime(parts[0], loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err := parseTime(parts[1], loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid range %q: end must be after start", s)
+	}
+	return start, end, nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	want := time.Date(2022, time.March, 1, 3, 12, 0, 0, loc)
+	for _, s := range []string{"2022-03-01 03:12", "2022-03-01T03:12:00", "2022-03-01T03:12:00-05:00"} {
+		got, err := parseTime(s, loc)
+		if err != nil {
+			t.Fatalf("parseTime(%q): %s", s, err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("parseTime(%q) = %s, want %s", s, got, want)
+		}
+	}
+	if _, err := parseTime("last tuesday", loc); err == nil {
+		t.Error("expected an error for an unsupported time")
+	}
+}
+
+func TestParseTimeRange(t *testing.T) {
+	start, end, err := parseTimeRange("2022-03-01, 2022-03-08", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Errorf("start = %s, want %s", start, want)
+	}
+	if want := time.Date(2022, time.March, 8, 0, 0, 0, 0, time.UTC); !end.Equal(want) {
+		t.Errorf("end = %s, want %s", end, want)
+	}
+	for _, s := range []string{"2022-03-01", "2022-03-08,2022-03-01", "2022-03-01,nope"} {
+		if _, _, err := parseTimeRange(s, time.UTC); err == nil {
+			t.Errorf("parseTimeRange(%q): expected an error", s)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package pagerduty
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TimeRange is a range of time, which includes its start but not its end.
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns how long the range lasts.
+func (r TimeRange) Duration() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// CoverageOverlap is a range of time during which several users are on call
+// at once.
+type CoverageOverlap struct {
+	TimeRange
+
+	// Users are the users on call during the range, sorted by ID.
+	Users []APIObject
+}
+
+// CoverageReport is the on-call coverage of a window of time, as returned by
+// AnalyzeCoverage.
+type CoverageReport struct {
+	// Window is the analyzed window of time.
+	Window TimeRange
+
+	// Gaps are the ranges of time during which nobody is on call.
+	Gaps []TimeRange
+
+	// Overlaps are the ranges of time during which more than one user is on
+	// call.
+	Overlaps []CoverageOverlap
+
+	// UserCoverage is how long each user is on call during the window, keyed
+	// by user ID. Time covered by several users counts for each of them.
+	UserCoverage map[string]time.Duration
+
+	// Covered is how long at least one user is on call during the window.
+	Covered time.Duration
+}
+
+// CoverageRatio returns the fraction of the window during which at least one
+// user is on call, between 0 and 1.
+func (r CoverageReport) CoverageRatio() float64 {
+	d := r.Window.Duration()
+	if d <= 0 {
+		return 0
+	}
+
+	return float64(r.Covered) / float64(d)
+}
+
+// AnalyzeCoverage reports the gaps, overlaps, and per-user coverage of
+// entries during window. Entries are clipped to the window. They can come from
+// the rendered final schedule of a schedule, or be combined from several
+// schedules or escalation levels to find double coverage.
+func AnalyzeCoverage(entries []ScheduleEntry, window TimeRange) CoverageReport {
+	report := CoverageReport{
+		Window:       window,
+		UserCoverage: make(map[string]time.Duration),
+	}
+
+	if !window.End.After(window.Start) {
+		return report
+	}
+
+	type boundary struct {
+		at    time.Time
+		start bool
+		entry int
+	}
+
+	var bounds []boundary
+
+	for i, e := range entries {
+		start, end := e.Start, e.End
+
+		if start.Before(window.Start) {
+			start = window.Start
+		}
+
+		if end.After(window.End) {
+			end = window.End
+		}
+
+		if !end.After(start) {
+			continue
+		}
+
+		bounds = append(bounds, boundary{at: start, start: true, entry: i}, boundary{at: end, entry: i})
+	}
+
+	sort.SliceStable(bounds, func(i, j int) bool { return bounds[i].at.Before(bounds[j].at) })
+
+	active := make(map[int]struct{})
+	cursor := window.Start
+
+	// emit records the state between cursor and at, merging it with the
+	// previous gap or overlap when they're contiguous and identical
+	emit := func(at time.Time) {
+		if !at.After(cursor) {
+			return
+		}
+
+		d := at.Sub(cursor)
+
+		users := activeUsers(entries, active)
+		for _, u := range users {
+			report.UserCoverage[u.ID] += d
+		}
+
+		switch {
+		case len(users) == 0:
+			if n := len(report.Gaps); n > 0 && report.Gaps[n-1].End.Equal(cursor) {
+				report.Gaps[n-1].End = at
+			} else {
+				report.Gaps = append(report.Gaps, TimeRange{Start: cursor, End: at})
+			}
+
+			return
+
+		case len(users) > 1:
+			n := len(report.Overlaps)
+			if n > 0 && report.Overlaps[n-1].End.Equal(cursor) && sameUsers(report.Overlaps[n-1].Users, users) {
+				report.Overlaps[n-1].End = at
+			} else {
+				report.Overlaps = append(report.Overlaps, CoverageOverlap{TimeRange: TimeRange{Start: cursor, End: at}, Users: users})
+			}
+		}
+
+		report.Covered += d
+	}
+
+	for _, b := range bounds {
+		emit(b.at)
+		cursor = b.at
+
+		if b.start {
+			active[b.entry] = struct{}{}
+		} else {
+			delete(active, b.entry)
+		}
+	}
+
+	emit(window.End)
+
+	return report
+}
+
+// activeUsers returns the distinct users of the active entries, sorted by ID.
+func activeUsers(entries []ScheduleEntry, active map[int]struct{}) []APIObject {
+	seen := make(map[string]struct{}, len(active))
+	users := make([]APIObject, 0, len(active))
+
+	for i := range active {
+		u := entries[i].User
+		if _, ok := seen[u.ID]; ok {
+			continue
+		}
+
+		seen[u.ID] = struct{}{}
+		users = append(users, u)
+	}
+
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	return users
+}
+
+func sameUsers(a, b []APIObject) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].ID != b[i].ID {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AnalyzeScheduleCoverageWithContext reports the gaps and per-user coverage
+// of the final schedule of a schedule during window. As the final schedule has
+// a single user on call at any time, the report has no overlaps.
+func (c *Client) AnalyzeScheduleCoverageWithContext(ctx context.Context, scheduleID string, window TimeRange) (*CoverageReport, error) {
+	s, err := c.GetScheduleWithContext(ctx, scheduleID, GetScheduleOptions{
+		Since: window.Start.Format(time.RFC3339),
+		Until: window.End.Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.FinalSchedule.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	r := AnalyzeCoverage(entries, window)
+
+	return &r, nil
+}
+
+// EscalationLevelCoverage is the coverage of a level of an escalation policy.
+type EscalationLevelCoverage struct {
+	Level  uint
+	Report CoverageReport
+}
+
+// AnalyzeEscalationPolicyCoverageWithContext reports the coverage of each level
+// of an escalation policy during window, sorted by level. Overlaps are users on
+// call at the same level at once, such as through several schedules. Users
+// permanently on call, because they're direct targets of the policy, cover the
+// whole window.
+func (c *Client) AnalyzeEscalationPolicyCoverageWithContext(ctx context.Context, escalationPolicyID string, window TimeRange) ([]EscalationLevelCoverage, error) {
+	onCalls, err := c.ListOnCallsPaginated(ctx, ListOnCallOptions{
+		EscalationPolicyIDs: []string{escalationPolicyID},
+		Since:               window.Start.Format(time.RFC3339),
+		Until:               window.End.Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byLevel := make(map[uint][]ScheduleEntry)
+
+	for _, oc := range onCalls {
+		e := ScheduleEntry{
+			Start: window.Start,
+			End:   window.End,
+			User:  oc.User.APIObject,
+		}
+
+		if len(strings.TrimSpace(oc.Start)) > 0 && len(strings.TrimSpace(oc.End)) > 0 {
+			if e.Start, e.End, err = parseTimeRange(oc.Start, oc.End); err != nil {
+				return nil, err
+			}
+		}
+
+		byLevel[oc.EscalationLevel] = append(byLevel[oc.EscalationLevel], e)
+	}
+
+	levels := make([]EscalationLevelCoverage, 0, len(byLevel))
+	for l, entries := range byLevel {
+		levels = append(levels, EscalationLevelCoverage{Level: l, Report: AnalyzeCoverage(entries, window)})
+	}
+
+	sort.Slice(levels, func(i, j int) bool { return levels[i].Level < levels[j].Level })
+
+	return levels, nil
+}
@@ -0,0 +1,80 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAnalyzeCoverage(t *testing.T) {
+	day := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return day.Add(time.Duration(h) * time.Hour) }
+
+	alice := APIObject{ID: "PU1"}
+	bob := APIObject{ID: "PU2"}
+
+	entries := []ScheduleEntry{
+		// starts before the window, so it's clipped
+		{Start: at(-4), End: at(8), User: alice},
+		{Start: at(6), End: at(12), User: bob},
+		{Start: at(14), End: at(20), User: alice},
+		{Start: at(20), End: at(22), User: alice},
+	}
+
+	got := AnalyzeCoverage(entries, TimeRange{Start: at(0), End: at(24)})
+
+	want := CoverageReport{
+		Window: TimeRange{Start: at(0), End: at(24)},
+		Gaps: []TimeRange{
+			{Start: at(12), End: at(14)},
+			{Start: at(22), End: at(24)},
+		},
+		Overlaps: []CoverageOverlap{
+			{TimeRange: TimeRange{Start: at(6), End: at(8)}, Users: []APIObject{alice, bob}},
+		},
+		UserCoverage: map[string]time.Duration{
+			"PU1": 16 * time.Hour,
+			"PU2": 6 * time.Hour,
+		},
+		Covered: 20 * time.Hour,
+	}
+
+	testEqual(t, want, got)
+	testEqual(t, 20.0/24, got.CoverageRatio())
+}
+
+func TestClient_AnalyzeEscalationPolicyCoverage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/oncalls", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "2021-01-01T00:00:00Z", r.URL.Query().Get("since"))
+		testEqual(t, "2021-01-02T00:00:00Z", r.URL.Query().Get("until"))
+
+		_, _ = w.Write([]byte(`{"oncalls": [
+			{"escalation_level": 2, "user": {"id": "PU3"}},
+			{"escalation_level": 1, "user": {"id": "PU1"}, "start": "2021-01-01T00:00:00Z", "end": "2021-01-01T12:00:00Z"},
+			{"escalation_level": 1, "user": {"id": "PU2"}, "start": "2021-01-01T12:00:00Z", "end": "2021-01-01T20:00:00Z"}
+		]}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	window := TimeRange{
+		Start: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	res, err := client.AnalyzeEscalationPolicyCoverageWithContext(context.Background(), "PEP1", window)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 2, len(res))
+	testEqual(t, uint(1), res[0].Level)
+	testEqual(t, []TimeRange{{Start: window.Start.Add(20 * time.Hour), End: window.End}}, res[0].Report.Gaps)
+	testEqual(t, uint(2), res[1].Level)
+	testEqual(t, 24*time.Hour, res[1].Report.Covered)
+}
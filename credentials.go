@@ -0,0 +1,110 @@
+package pagerduty
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// CredentialType is the kind of credentials the client authenticates with.
+type CredentialType string
+
+const (
+	// CredentialTypeUser is a user-level API token, or an OAuth token
+	// obtained on behalf of a user. Requests are made as that user.
+	CredentialTypeUser CredentialType = "user"
+
+	// CredentialTypeAccount is an account-level API token, or a scoped OAuth
+	// app token. Requests aren't associated with a user, so endpoints such as
+	// GET /users/me can't be used and some endpoints require a From header.
+	CredentialTypeAccount CredentialType = "account"
+)
+
+// CredentialInfo describes the credentials of the client, as returned by
+// ValidateCredentials.
+type CredentialInfo struct {
+	// Type is the kind of credentials.
+	Type CredentialType
+
+	// User is the user the credentials belong to. It's nil for account-level
+	// credentials.
+	User *User
+
+	// Subdomain is the PagerDuty subdomain of the account, such as "acme" for
+	// acme.pagerduty.com. It's empty if it couldn't be determined, which can
+	// happen with account-level credentials lacking access to users.
+	Subdomain string
+}
+
+// ValidateCredentials makes cheap authenticated calls to the REST API, to
+// verify the credentials of the client are valid and to describe them. It's
+// meant for applications to fail fast at startup when they're misconfigured.
+//
+// If the API rejects the credentials, the returned error wraps the APIError
+// with a 401 Unauthorized status code.
+func (c *Client) ValidateCredentials(ctx context.Context) (*CredentialInfo, error) {
+	u, err := c.GetCurrentUserWithContext(ctx, GetCurrentUserOptions{})
+	if err == nil {
+		return &CredentialInfo{
+			Type:      CredentialTypeUser,
+			User:      u,
+			Subdomain: subdomainFromHTMLURL(u.HTMLURL),
+		}, nil
+	}
+
+	var aerr APIError
+	if !errors.As(err, &aerr) {
+		return nil, fmt.Errorf("failed to validate credentials: %w", err)
+	}
+
+	switch aerr.StatusCode {
+	case http.StatusUnauthorized:
+		return nil, fmt.Errorf("credentials were rejected by the API: %w", err)
+
+	case http.StatusBadRequest, http.StatusForbidden:
+		// account-level credentials can't identify a user, so /users/me fails
+		// and we confirm the credentials work with another endpoint
+
+	default:
+		return nil, fmt.Errorf("failed to validate credentials: %w", err)
+	}
+
+	if _, err := c.ListAbilitiesWithContext(ctx); err != nil {
+		if errors.As(err, &aerr) && aerr.StatusCode == http.StatusUnauthorized {
+			return nil, fmt.Errorf("credentials were rejected by the API: %w", err)
+		}
+
+		return nil, fmt.Errorf("failed to validate credentials: %w", err)
+	}
+
+	info := &CredentialInfo{Type: CredentialTypeAccount}
+
+	// the subdomain is only known from the URL of objects, so it's
+	// best-effort as the credentials may not have access to users
+	if lr, err := c.ListUsersWithContext(ctx, ListUsersOptions{Limit: 1}); err == nil && len(lr.Users) > 0 {
+		info.Subdomain = subdomainFromHTMLURL(lr.Users[0].HTMLURL)
+	}
+
+	return info, nil
+}
+
+// subdomainFromHTMLURL returns the account subdomain of the HTML URL of an
+// object, such as "acme" for https://acme.pagerduty.com/users/PABC123.
+func subdomainFromHTMLURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil {
+		return ""
+	}
+
+	host := u.Hostname()
+
+	i := strings.IndexByte(host, '.')
+	if i <= 0 || !strings.HasSuffix(host, ".pagerduty.com") {
+		return ""
+	}
+
+	return host[:i]
+}
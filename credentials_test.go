@@ -0,0 +1,120 @@
+package pagerduty
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestClient_ValidateCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		want    *CredentialInfo
+		wantErr string
+	}{
+		{
+			name:  "user",
+			token: "user",
+			want: &CredentialInfo{
+				Type:      CredentialTypeUser,
+				User:      &User{APIObject: APIObject{ID: "1", HTMLURL: "https://acme.pagerduty.com/users/1"}},
+				Subdomain: "acme",
+			},
+		},
+		{
+			name:  "account",
+			token: "account",
+			want: &CredentialInfo{
+				Type:      CredentialTypeAccount,
+				Subdomain: "acme",
+			},
+		},
+		{
+			name:    "invalid",
+			token:   "invalid",
+			wantErr: "credentials were rejected by the API",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			auth := func(w http.ResponseWriter, r *http.Request) bool {
+				if r.Header.Get("Authorization") == "Token token=invalid" {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusUnauthorized)
+					_, _ = w.Write([]byte(`{"error":{"code":2006,"message":"Invalid Credentials"}}`))
+					return false
+				}
+
+				return true
+			}
+
+			mux.HandleFunc("/users/me", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, http.MethodGet)
+
+				if !auth(w, r) {
+					return
+				}
+
+				if r.Header.Get("Authorization") == "Token token=account" {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = w.Write([]byte(`{"error":{"code":2001,"message":"Invalid Input Provided"}}`))
+					return
+				}
+
+				_, _ = w.Write([]byte(`{"user":{"id":"1","html_url":"https://acme.pagerduty.com/users/1"}}`))
+			})
+
+			mux.HandleFunc("/abilities", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, http.MethodGet)
+
+				if auth(w, r) {
+					_, _ = w.Write([]byte(`{"abilities":["sso"]}`))
+				}
+			})
+
+			mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, http.MethodGet)
+
+				if auth(w, r) {
+					_, _ = w.Write([]byte(`{"users":[{"id":"1","html_url":"https://acme.eu.pagerduty.com/users/1"}]}`))
+				}
+			})
+
+			c := defaultTestClient(server.URL, tt.token)
+
+			got, err := c.ValidateCredentials(context.Background())
+			if !testErrCheck(t, "c.ValidateCredentials()", tt.wantErr, err) {
+				var aerr APIError
+				if len(tt.wantErr) > 0 && !errors.As(err, &aerr) {
+					t.Fatalf("err = %v, want wrapped APIError", err)
+				}
+
+				return
+			}
+
+			testEqual(t, tt.want, got)
+		})
+	}
+}
+
+func Test_subdomainFromHTMLURL(t *testing.T) {
+	tests := map[string]string{
+		"https://acme.pagerduty.com/users/1":    "acme",
+		"https://acme.eu.pagerduty.com/users/1": "acme",
+		"https://example.com/users/1":           "",
+		"":                                      "",
+	}
+
+	for in, want := range tests {
+		if got := subdomainFromHTMLURL(in); got != want {
+			t.Errorf("subdomainFromHTMLURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
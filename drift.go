@@ -0,0 +1,154 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownFieldsHandler is called with the path of a request and the fields of
+// its JSON response that aren't decoded by the structs of the package, such
+// as "incident.new_field" or "incidents[].assignments[].new_field".
+type UnknownFieldsHandler func(path string, fields []string)
+
+// WithStrictDecoding makes the client fail to decode the responses of the API
+// which have fields that aren't decoded by the structs of the package, to
+// detect the API drifting from the package, such as in tests. It shouldn't be
+// used in production, as PagerDuty adds fields to its responses without
+// notice.
+func WithStrictDecoding() ClientOptions {
+	return func(c *Client) {
+		c.disallowUnknownFields = true
+	}
+}
+
+// WithUnknownFieldsHandler sets a handler called when a response of the API has
+// fields that aren't decoded by the structs of the package, to report the API
+// drifting from the package without failing the requests.
+func WithUnknownFieldsHandler(h UnknownFieldsHandler) ClientOptions {
+	return func(c *Client) {
+		c.unknownFieldsHandler = h
+	}
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// unknownFields returns the sorted paths of the fields of the JSON data that
+// aren't decoded into v, prefixed with path, the path of the data in the
+// response, if not empty.
+func unknownFields(data []byte, v interface{}, path string) []string {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil
+	}
+
+	fields := make(map[string]struct{})
+	collectUnknownFields(doc, reflect.TypeOf(v), path, fields)
+
+	paths := make([]string, 0, len(fields))
+	for p := range fields {
+		paths = append(paths, p)
+	}
+
+	sort.Strings(paths)
+
+	return paths
+}
+
+func collectUnknownFields(doc interface{}, t reflect.Type, path string, fields map[string]struct{}) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// types decoding themselves can't be inspected
+	if t.Implements(jsonUnmarshalerType) || reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := doc.(map[string]interface{})
+		if !ok {
+			return
+		}
+
+		known := jsonFields(t)
+
+		for k, v := range obj {
+			ft, ok := known[k]
+			if !ok {
+				fields[joinFieldPath(path, k)] = struct{}{}
+				continue
+			}
+
+			collectUnknownFields(v, ft, joinFieldPath(path, k), fields)
+		}
+
+	case reflect.Map:
+		if obj, ok := doc.(map[string]interface{}); ok {
+			for k, v := range obj {
+				collectUnknownFields(v, t.Elem(), joinFieldPath(path, k), fields)
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		if arr, ok := doc.([]interface{}); ok {
+			for _, v := range arr {
+				collectUnknownFields(v, t.Elem(), path+"[]", fields)
+			}
+		}
+	}
+}
+
+// jsonFields returns the types of the fields of a struct by their JSON names,
+// including the fields of its embedded structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+
+		if f.Anonymous && len(name) == 0 && ft.Kind() == reflect.Struct {
+			for k, v := range jsonFields(ft) {
+				if _, ok := fields[k]; !ok {
+					fields[k] = v
+				}
+			}
+
+			continue
+		}
+
+		if len(f.PkgPath) > 0 { // unexported
+			continue
+		}
+
+		if len(name) == 0 {
+			name = f.Name
+		}
+
+		fields[name] = f.Type
+	}
+
+	return fields
+}
+
+func joinFieldPath(path, field string) string {
+	if len(path) == 0 {
+		return field
+	}
+
+	return path + "." + field
+}
@@ -0,0 +1,63 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestUnknownFields(t *testing.T) {
+	var v ListIncidentsResponse
+
+	data := []byte(`{
+		"incidents": [
+			{"id": "PI1", "type": "incident", "new_field": 1, "assignments": [{"at": "", "assignee": {"id": "PU1", "new_ref_field": true}}]},
+			{"id": "PI2", "new_field": 2}
+		],
+		"limit": 25,
+		"total_pages": 1
+	}`)
+
+	want := []string{
+		"incidents[].assignments[].assignee.new_ref_field",
+		"incidents[].new_field",
+		"total_pages",
+	}
+
+	testEqual(t, want, unknownFields(data, &v, ""))
+}
+
+func TestClient_UnknownFieldsHandler(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/PS1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"service": {"id": "PS1", "name": "foo", "new_field": "bar"}}`))
+	})
+
+	var (
+		gotPath   string
+		gotFields []string
+	)
+
+	client := NewClient("foo",
+		WithAPIEndpoint(server.URL),
+		WithUnknownFieldsHandler(func(path string, fields []string) {
+			gotPath, gotFields = path, fields
+		}),
+	)
+
+	res, err := client.GetServiceWithContext(context.Background(), "PS1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "foo", res.Name)
+	testEqual(t, "/services/PS1", gotPath)
+	testEqual(t, []string{"service.new_field"}, gotFields)
+
+	client = NewClient("foo", WithAPIEndpoint(server.URL), WithStrictDecoding())
+
+	_, err = client.GetServiceWithContext(context.Background(), "PS1", nil)
+	testErrCheck(t, "GetServiceWithContext()", `unknown field "new_field"`, err)
+}
@@ -0,0 +1,59 @@
+package pagerduty
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Endpoint is a REST API endpoint, as listed in the PagerDuty API reference,
+// along with whether it's supported by this package.
+type Endpoint struct {
+	// Method is the HTTP method of the endpoint, such as GET.
+	Method string
+
+	// Path is the path of the endpoint, such as /services/{id}.
+	Path string
+
+	// Implemented is true if this package provides methods calling the
+	// endpoint.
+	Implemented bool
+
+	// Deprecated is true if the endpoint is deprecated in the API reference.
+	Deprecated bool
+}
+
+// Endpoints returns the REST API endpoints known to this package, so that
+// consumers can check whether an endpoint they depend on is supported. The
+// list is generated from the PagerDuty OpenAPI specification by the
+// internal/cmd/pdcoverage tool. When it's generated without the specification,
+// only the implemented endpoints are listed.
+func Endpoints() []Endpoint {
+	e := make([]Endpoint, len(endpoints))
+	copy(e, endpoints)
+	return e
+}
+
+var endpointPathParam = regexp.MustCompile(`\{[^}]*\}`)
+
+// LookupEndpoint returns the Endpoint for the given method and path. The names
+// of the path parameters don't need to match the API reference, so
+// /services/{service_id} finds the /services/{id} endpoint.
+func LookupEndpoint(method, path string) (Endpoint, bool) {
+	method = strings.ToUpper(method)
+	path = endpointPathParam.ReplaceAllString(path, "{}")
+
+	for _, e := range endpoints {
+		if e.Method == method && endpointPathParam.ReplaceAllString(e.Path, "{}") == path {
+			return e, true
+		}
+	}
+
+	return Endpoint{}, false
+}
+
+// EndpointImplemented returns whether this package provides methods calling
+// the given REST API endpoint.
+func EndpointImplemented(method, path string) bool {
+	e, ok := LookupEndpoint(method, path)
+	return ok && e.Implemented
+}
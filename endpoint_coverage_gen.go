@@ -0,0 +1,260 @@
+// Code generated by pdcoverage. DO NOT EDIT.
+
+// Generated without the OpenAPI specification, so only the implemented
+// endpoints are listed.
+
+package pagerduty
+
+var endpoints = []Endpoint{
+	{Method: "GET", Path: "/abilities", Implemented: true},
+	{Method: "GET", Path: "/abilities/{id}", Implemented: true},
+	{Method: "GET", Path: "/addons", Implemented: true},
+	{Method: "POST", Path: "/addons", Implemented: true},
+	{Method: "DELETE", Path: "/addons/{id}", Implemented: true},
+	{Method: "GET", Path: "/addons/{id}", Implemented: true},
+	{Method: "PUT", Path: "/addons/{id}", Implemented: true},
+	{Method: "POST", Path: "/analytics/metrics/incidents/all", Implemented: true},
+	{Method: "POST", Path: "/analytics/metrics/incidents/escalation_policies", Implemented: true},
+	{Method: "POST", Path: "/analytics/metrics/incidents/services", Implemented: true},
+	{Method: "POST", Path: "/analytics/metrics/incidents/teams", Implemented: true},
+	{Method: "POST", Path: "/analytics/metrics/responders/all", Implemented: true},
+	{Method: "POST", Path: "/analytics/metrics/responders/teams", Implemented: true},
+	{Method: "POST", Path: "/analytics/raw/incidents", Implemented: true},
+	{Method: "GET", Path: "/analytics/raw/incidents/{id}", Implemented: true},
+	{Method: "POST", Path: "/analytics/raw/responders/{id}/incidents", Implemented: true},
+	{Method: "GET", Path: "/audit/records", Implemented: true},
+	{Method: "POST", Path: "/automation_actions/actions/{id}/invocations", Implemented: true},
+	{Method: "GET", Path: "/automation_actions/invocations/{id}", Implemented: true},
+	{Method: "GET", Path: "/business_services", Implemented: true},
+	{Method: "POST", Path: "/business_services", Implemented: true},
+	{Method: "GET", Path: "/business_services/impacts", Implemented: true},
+	{Method: "DELETE", Path: "/business_services/priority_thresholds", Implemented: true},
+	{Method: "GET", Path: "/business_services/priority_thresholds", Implemented: true},
+	{Method: "PUT", Path: "/business_services/priority_thresholds", Implemented: true},
+	{Method: "DELETE", Path: "/business_services/{id}", Implemented: true},
+	{Method: "GET", Path: "/business_services/{id}", Implemented: true},
+	{Method: "PUT", Path: "/business_services/{id}", Implemented: true},
+	{Method: "DELETE", Path: "/business_services/{id}/account_subscription", Implemented: true},
+	{Method: "GET", Path: "/business_services/{id}/account_subscription", Implemented: true},
+	{Method: "POST", Path: "/business_services/{id}/account_subscription", Implemented: true},
+	{Method: "GET", Path: "/business_services/{id}/subscribers", Implemented: true},
+	{Method: "POST", Path: "/business_services/{id}/subscribers", Implemented: true},
+	{Method: "POST", Path: "/business_services/{id}/unsubscribe", Implemented: true},
+	{Method: "GET", Path: "/change_events", Implemented: true},
+	{Method: "GET", Path: "/change_events/{id}", Implemented: true},
+	{Method: "PUT", Path: "/change_events/{id}", Implemented: true},
+	{Method: "GET", Path: "/escalation_policies", Implemented: true},
+	{Method: "POST", Path: "/escalation_policies", Implemented: true},
+	{Method: "DELETE", Path: "/escalation_policies/{id}", Implemented: true},
+	{Method: "GET", Path: "/escalation_policies/{id}", Implemented: true},
+	{Method: "PUT", Path: "/escalation_policies/{id}", Implemented: true},
+	{Method: "POST", Path: "/escalation_policies/{id}/change_tags", Implemented: true},
+	{Method: "GET", Path: "/escalation_policies/{id}/escalation_rules", Implemented: true},
+	{Method: "POST", Path: "/escalation_policies/{id}/escalation_rules", Implemented: true},
+	{Method: "DELETE", Path: "/escalation_policies/{id}/escalation_rules/{id}", Implemented: true},
+	{Method: "GET", Path: "/escalation_policies/{id}/escalation_rules/{id}", Implemented: true},
+	{Method: "PUT", Path: "/escalation_policies/{id}/escalation_rules/{id}", Implemented: true},
+	{Method: "GET", Path: "/escalation_policies/{id}/tags", Implemented: true},
+	{Method: "GET", Path: "/event_orchestrations", Implemented: true},
+	{Method: "POST", Path: "/event_orchestrations", Implemented: true},
+	{Method: "GET", Path: "/event_orchestrations/services/{id}", Implemented: true},
+	{Method: "PUT", Path: "/event_orchestrations/services/{id}", Implemented: true},
+	{Method: "GET", Path: "/event_orchestrations/services/{id}/active", Implemented: true},
+	{Method: "PUT", Path: "/event_orchestrations/services/{id}/active", Implemented: true},
+	{Method: "DELETE", Path: "/event_orchestrations/{id}", Implemented: true},
+	{Method: "GET", Path: "/event_orchestrations/{id}", Implemented: true},
+	{Method: "PUT", Path: "/event_orchestrations/{id}", Implemented: true},
+	{Method: "GET", Path: "/event_orchestrations/{id}/cache_variables", Implemented: true},
+	{Method: "POST", Path: "/event_orchestrations/{id}/cache_variables", Implemented: true},
+	{Method: "DELETE", Path: "/event_orchestrations/{id}/cache_variables/{id}", Implemented: true},
+	{Method: "GET", Path: "/event_orchestrations/{id}/cache_variables/{id}", Implemented: true},
+	{Method: "PUT", Path: "/event_orchestrations/{id}/cache_variables/{id}", Implemented: true},
+	{Method: "GET", Path: "/event_orchestrations/{id}/global", Implemented: true},
+	{Method: "PUT", Path: "/event_orchestrations/{id}/global", Implemented: true},
+	{Method: "GET", Path: "/event_orchestrations/{id}/integrations", Implemented: true},
+	{Method: "POST", Path: "/event_orchestrations/{id}/integrations", Implemented: true},
+	{Method: "POST", Path: "/event_orchestrations/{id}/integrations/migration", Implemented: true},
+	{Method: "DELETE", Path: "/event_orchestrations/{id}/integrations/{id}", Implemented: true},
+	{Method: "GET", Path: "/event_orchestrations/{id}/integrations/{id}", Implemented: true},
+	{Method: "PUT", Path: "/event_orchestrations/{id}/integrations/{id}", Implemented: true},
+	{Method: "GET", Path: "/event_orchestrations/{id}/router", Implemented: true},
+	{Method: "PUT", Path: "/event_orchestrations/{id}/router", Implemented: true},
+	{Method: "GET", Path: "/event_orchestrations/{id}/unrouted", Implemented: true},
+	{Method: "PUT", Path: "/event_orchestrations/{id}/unrouted", Implemented: true},
+	{Method: "GET", Path: "/extension_schemas", Implemented: true},
+	{Method: "GET", Path: "/extension_schemas/{id}", Implemented: true},
+	{Method: "GET", Path: "/extensions", Implemented: true},
+	{Method: "POST", Path: "/extensions", Implemented: true},
+	{Method: "DELETE", Path: "/extensions/{id}", Implemented: true},
+	{Method: "GET", Path: "/extensions/{id}", Implemented: true},
+	{Method: "PUT", Path: "/extensions/{id}", Implemented: true},
+	{Method: "POST", Path: "/extensions/{id}/enable", Implemented: true},
+	{Method: "GET", Path: "/incident_workflows/triggers", Implemented: true},
+	{Method: "POST", Path: "/incident_workflows/triggers", Implemented: true},
+	{Method: "DELETE", Path: "/incident_workflows/triggers/{id}", Implemented: true},
+	{Method: "GET", Path: "/incident_workflows/triggers/{id}", Implemented: true},
+	{Method: "PUT", Path: "/incident_workflows/triggers/{id}", Implemented: true},
+	{Method: "POST", Path: "/incident_workflows/triggers/{id}/services", Implemented: true},
+	{Method: "DELETE", Path: "/incident_workflows/triggers/{id}/services/{id}", Implemented: true},
+	{Method: "GET", Path: "/incidents", Implemented: true},
+	{Method: "POST", Path: "/incidents", Implemented: true},
+	{Method: "PUT", Path: "/incidents", Implemented: true},
+	{Method: "GET", Path: "/incidents/custom_fields", Implemented: true},
+	{Method: "POST", Path: "/incidents/custom_fields", Implemented: true},
+	{Method: "DELETE", Path: "/incidents/custom_fields/{id}", Implemented: true},
+	{Method: "GET", Path: "/incidents/custom_fields/{id}", Implemented: true},
+	{Method: "PUT", Path: "/incidents/custom_fields/{id}", Implemented: true},
+	{Method: "GET", Path: "/incidents/custom_fields/{id}/field_options", Implemented: true},
+	{Method: "POST", Path: "/incidents/custom_fields/{id}/field_options", Implemented: true},
+	{Method: "DELETE", Path: "/incidents/custom_fields/{id}/field_options/{id}", Implemented: true},
+	{Method: "PUT", Path: "/incidents/custom_fields/{id}/field_options/{id}", Implemented: true},
+	{Method: "GET", Path: "/incidents/{id}", Implemented: true},
+	{Method: "GET", Path: "/incidents/{id}/alerts", Implemented: true},
+	{Method: "PUT", Path: "/incidents/{id}/alerts", Implemented: true},
+	{Method: "GET", Path: "/incidents/{id}/alerts/{id}", Implemented: true},
+	{Method: "GET", Path: "/incidents/{id}/log_entries", Implemented: true},
+	{Method: "PUT", Path: "/incidents/{id}/merge", Implemented: true},
+	{Method: "GET", Path: "/incidents/{id}/notes", Implemented: true},
+	{Method: "POST", Path: "/incidents/{id}/notes", Implemented: true},
+	{Method: "GET", Path: "/incidents/{id}/related_change_events", Implemented: true},
+	{Method: "POST", Path: "/incidents/{id}/responder_requests", Implemented: true},
+	{Method: "POST", Path: "/incidents/{id}/snooze", Implemented: true},
+	{Method: "POST", Path: "/incidents/{id}/status_updates", Implemented: true},
+	{Method: "GET", Path: "/incidents/{id}/status_updates/subscribers", Implemented: true},
+	{Method: "POST", Path: "/incidents/{id}/status_updates/subscribers", Implemented: true},
+	{Method: "POST", Path: "/incidents/{id}/status_updates/unsubscribe", Implemented: true},
+	{Method: "GET", Path: "/integration-jira-cloud/accounts_mappings", Implemented: true},
+	{Method: "GET", Path: "/integration-jira-cloud/accounts_mappings/{id}", Implemented: true},
+	{Method: "GET", Path: "/integration-jira-cloud/accounts_mappings/{id}/rules", Implemented: true},
+	{Method: "POST", Path: "/integration-jira-cloud/accounts_mappings/{id}/rules", Implemented: true},
+	{Method: "DELETE", Path: "/integration-jira-cloud/accounts_mappings/{id}/rules/{id}", Implemented: true},
+	{Method: "GET", Path: "/integration-jira-cloud/accounts_mappings/{id}/rules/{id}", Implemented: true},
+	{Method: "PUT", Path: "/integration-jira-cloud/accounts_mappings/{id}/rules/{id}", Implemented: true},
+	{Method: "GET", Path: "/log_entries", Implemented: true},
+	{Method: "GET", Path: "/log_entries/{id}", Implemented: true},
+	{Method: "GET", Path: "/maintenance_windows", Implemented: true},
+	{Method: "POST", Path: "/maintenance_windows", Implemented: true},
+	{Method: "DELETE", Path: "/maintenance_windows/{id}", Implemented: true},
+	{Method: "GET", Path: "/maintenance_windows/{id}", Implemented: true},
+	{Method: "PUT", Path: "/maintenance_windows/{id}", Implemented: true},
+	{Method: "GET", Path: "/notifications", Implemented: true},
+	{Method: "GET", Path: "/oncalls", Implemented: true},
+	{Method: "GET", Path: "/paused_incident_reports/alerts", Implemented: true},
+	{Method: "GET", Path: "/paused_incident_reports/counts", Implemented: true},
+	{Method: "GET", Path: "/priorities", Implemented: true},
+	{Method: "GET", Path: "/response_plays", Implemented: true},
+	{Method: "POST", Path: "/response_plays", Implemented: true},
+	{Method: "DELETE", Path: "/response_plays/{id}", Implemented: true},
+	{Method: "GET", Path: "/response_plays/{id}", Implemented: true},
+	{Method: "PUT", Path: "/response_plays/{id}", Implemented: true},
+	{Method: "POST", Path: "/response_plays/{id}/run", Implemented: true},
+	{Method: "GET", Path: "/rulesets", Implemented: true},
+	{Method: "POST", Path: "/rulesets", Implemented: true},
+	{Method: "DELETE", Path: "/rulesets/{id}", Implemented: true},
+	{Method: "GET", Path: "/rulesets/{id}", Implemented: true},
+	{Method: "PUT", Path: "/rulesets/{id}", Implemented: true},
+	{Method: "GET", Path: "/rulesets/{id}/rules", Implemented: true},
+	{Method: "POST", Path: "/rulesets/{id}/rules", Implemented: true},
+	{Method: "DELETE", Path: "/rulesets/{id}/rules/{id}", Implemented: true},
+	{Method: "GET", Path: "/rulesets/{id}/rules/{id}", Implemented: true},
+	{Method: "PUT", Path: "/rulesets/{id}/rules/{id}", Implemented: true},
+	{Method: "GET", Path: "/schedules", Implemented: true},
+	{Method: "POST", Path: "/schedules", Implemented: true},
+	{Method: "POST", Path: "/schedules/preview", Implemented: true},
+	{Method: "DELETE", Path: "/schedules/{id}", Implemented: true},
+	{Method: "GET", Path: "/schedules/{id}", Implemented: true},
+	{Method: "PUT", Path: "/schedules/{id}", Implemented: true},
+	{Method: "GET", Path: "/schedules/{id}/audit/records", Implemented: true},
+	{Method: "GET", Path: "/schedules/{id}/overrides", Implemented: true},
+	{Method: "POST", Path: "/schedules/{id}/overrides", Implemented: true},
+	{Method: "DELETE", Path: "/schedules/{id}/overrides/{id}", Implemented: true},
+	{Method: "GET", Path: "/schedules/{id}/users", Implemented: true},
+	{Method: "POST", Path: "/service_dependencies/associate", Implemented: true},
+	{Method: "GET", Path: "/service_dependencies/business_services/{id}", Implemented: true},
+	{Method: "POST", Path: "/service_dependencies/disassociate", Implemented: true},
+	{Method: "GET", Path: "/service_dependencies/technical_services/{id}", Implemented: true},
+	{Method: "GET", Path: "/services", Implemented: true},
+	{Method: "POST", Path: "/services", Implemented: true},
+	{Method: "DELETE", Path: "/services/{id}", Implemented: true},
+	{Method: "GET", Path: "/services/{id}", Implemented: true},
+	{Method: "PUT", Path: "/services/{id}", Implemented: true},
+	{Method: "GET", Path: "/services/{id}/change_events", Implemented: true},
+	{Method: "POST", Path: "/services/{id}/integrations", Implemented: true},
+	{Method: "DELETE", Path: "/services/{id}/integrations/{id}", Implemented: true},
+	{Method: "GET", Path: "/services/{id}/integrations/{id}", Implemented: true},
+	{Method: "PUT", Path: "/services/{id}/integrations/{id}", Implemented: true},
+	{Method: "GET", Path: "/services/{id}/rules", Implemented: true},
+	{Method: "POST", Path: "/services/{id}/rules", Implemented: true},
+	{Method: "DELETE", Path: "/services/{id}/rules/{id}", Implemented: true},
+	{Method: "GET", Path: "/services/{id}/rules/{id}", Implemented: true},
+	{Method: "PUT", Path: "/services/{id}/rules/{id}", Implemented: true},
+	{Method: "GET", Path: "/status_pages", Implemented: true},
+	{Method: "GET", Path: "/status_pages/{id}/impacts", Implemented: true},
+	{Method: "GET", Path: "/status_pages/{id}/impacts/{id}", Implemented: true},
+	{Method: "GET", Path: "/status_pages/{id}/posts", Implemented: true},
+	{Method: "POST", Path: "/status_pages/{id}/posts", Implemented: true},
+	{Method: "DELETE", Path: "/status_pages/{id}/posts/{id}", Implemented: true},
+	{Method: "GET", Path: "/status_pages/{id}/posts/{id}", Implemented: true},
+	{Method: "PUT", Path: "/status_pages/{id}/posts/{id}", Implemented: true},
+	{Method: "GET", Path: "/status_pages/{id}/posts/{id}/post_updates", Implemented: true},
+	{Method: "POST", Path: "/status_pages/{id}/posts/{id}/post_updates", Implemented: true},
+	{Method: "DELETE", Path: "/status_pages/{id}/posts/{id}/post_updates/{id}", Implemented: true},
+	{Method: "GET", Path: "/status_pages/{id}/posts/{id}/post_updates/{id}", Implemented: true},
+	{Method: "PUT", Path: "/status_pages/{id}/posts/{id}/post_updates/{id}", Implemented: true},
+	{Method: "GET", Path: "/status_pages/{id}/services", Implemented: true},
+	{Method: "GET", Path: "/status_pages/{id}/services/{id}", Implemented: true},
+	{Method: "GET", Path: "/status_pages/{id}/severities", Implemented: true},
+	{Method: "GET", Path: "/status_pages/{id}/severities/{id}", Implemented: true},
+	{Method: "GET", Path: "/status_pages/{id}/statuses", Implemented: true},
+	{Method: "GET", Path: "/status_pages/{id}/statuses/{id}", Implemented: true},
+	{Method: "GET", Path: "/status_pages/{id}/subscriptions", Implemented: true},
+	{Method: "POST", Path: "/status_pages/{id}/subscriptions", Implemented: true},
+	{Method: "DELETE", Path: "/status_pages/{id}/subscriptions/{id}", Implemented: true},
+	{Method: "GET", Path: "/status_pages/{id}/subscriptions/{id}", Implemented: true},
+	{Method: "GET", Path: "/tags", Implemented: true},
+	{Method: "POST", Path: "/tags", Implemented: true},
+	{Method: "DELETE", Path: "/tags/{id}", Implemented: true},
+	{Method: "GET", Path: "/tags/{id}", Implemented: true},
+	{Method: "GET", Path: "/tags/{id}/escalation_policies", Implemented: true},
+	{Method: "GET", Path: "/tags/{id}/teams", Implemented: true},
+	{Method: "GET", Path: "/tags/{id}/users", Implemented: true},
+	{Method: "GET", Path: "/teams", Implemented: true},
+	{Method: "POST", Path: "/teams", Implemented: true},
+	{Method: "DELETE", Path: "/teams/{id}", Implemented: true},
+	{Method: "GET", Path: "/teams/{id}", Implemented: true},
+	{Method: "PUT", Path: "/teams/{id}", Implemented: true},
+	{Method: "POST", Path: "/teams/{id}/change_tags", Implemented: true},
+	{Method: "DELETE", Path: "/teams/{id}/escalation_policies/{id}", Implemented: true},
+	{Method: "PUT", Path: "/teams/{id}/escalation_policies/{id}", Implemented: true},
+	{Method: "GET", Path: "/teams/{id}/members", Implemented: true},
+	{Method: "GET", Path: "/teams/{id}/tags", Implemented: true},
+	{Method: "DELETE", Path: "/teams/{id}/users/{id}", Implemented: true},
+	{Method: "PUT", Path: "/teams/{id}/users/{id}", Implemented: true},
+	{Method: "GET", Path: "/users", Implemented: true},
+	{Method: "POST", Path: "/users", Implemented: true},
+	{Method: "GET", Path: "/users/me", Implemented: true},
+	{Method: "DELETE", Path: "/users/{id}", Implemented: true},
+	{Method: "GET", Path: "/users/{id}", Implemented: true},
+	{Method: "PUT", Path: "/users/{id}", Implemented: true},
+	{Method: "POST", Path: "/users/{id}/change_tags", Implemented: true},
+	{Method: "GET", Path: "/users/{id}/contact_methods", Implemented: true},
+	{Method: "POST", Path: "/users/{id}/contact_methods", Implemented: true},
+	{Method: "DELETE", Path: "/users/{id}/contact_methods/{id}", Implemented: true},
+	{Method: "GET", Path: "/users/{id}/contact_methods/{id}", Implemented: true},
+	{Method: "PUT", Path: "/users/{id}/contact_methods/{id}", Implemented: true},
+	{Method: "GET", Path: "/users/{id}/notification_rules", Implemented: true},
+	{Method: "POST", Path: "/users/{id}/notification_rules", Implemented: true},
+	{Method: "DELETE", Path: "/users/{id}/notification_rules/{id}", Implemented: true},
+	{Method: "GET", Path: "/users/{id}/notification_rules/{id}", Implemented: true},
+	{Method: "PUT", Path: "/users/{id}/notification_rules/{id}", Implemented: true},
+	{Method: "GET", Path: "/users/{id}/tags", Implemented: true},
+	{Method: "GET", Path: "/vendors", Implemented: true},
+	{Method: "GET", Path: "/vendors/{id}", Implemented: true},
+	{Method: "GET", Path: "/webhook_subscriptions", Implemented: true},
+	{Method: "POST", Path: "/webhook_subscriptions", Implemented: true},
+	{Method: "DELETE", Path: "/webhook_subscriptions/{id}", Implemented: true},
+	{Method: "GET", Path: "/webhook_subscriptions/{id}", Implemented: true},
+	{Method: "PUT", Path: "/webhook_subscriptions/{id}", Implemented: true},
+	{Method: "POST", Path: "/webhook_subscriptions/{id}/enable", Implemented: true},
+	{Method: "POST", Path: "/webhook_subscriptions/{id}/ping", Implemented: true},
+}
@@ -0,0 +1,105 @@
+package pagerduty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PagerDuty/go-pagerduty/internal/coverage"
+)
+
+func TestLookupEndpoint(t *testing.T) {
+	e, ok := LookupEndpoint("get", "/services/{service_id}")
+	if !ok {
+		t.Fatal("LookupEndpoint() ok = false, want true")
+	}
+
+	if e.Method != "GET" || !e.Implemented {
+		t.Fatalf("LookupEndpoint() = %+v, want implemented GET endpoint", e)
+	}
+
+	if _, ok := LookupEndpoint("GET", "/does_not_exist"); ok {
+		t.Fatal("LookupEndpoint() ok = true, want false")
+	}
+
+	if !EndpointImplemented("POST", "/incidents/{id}/notes") {
+		t.Fatal("EndpointImplemented() = false, want true")
+	}
+
+	if !EndpointImplemented("POST", "/analytics/metrics/incidents/all") {
+		t.Fatal("EndpointImplemented() = false, want true")
+	}
+}
+
+func TestEndpoints(t *testing.T) {
+	e := Endpoints()
+	if len(e) == 0 {
+		t.Fatal("Endpoints() is empty")
+	}
+
+	// the returned slice must be a copy
+	e[0].Implemented = !e[0].Implemented
+	if endpoints[0].Implemented == e[0].Implemented {
+		t.Fatal("Endpoints() does not return a copy")
+	}
+}
+
+// TestEndpoints_upToDate fails when endpoint_coverage_gen.go doesn't match
+// the routes called by the package, such as when it's edited by hand or not
+// regenerated with make coverage after adding endpoints.
+func TestEndpoints_upToDate(t *testing.T) {
+	routes, err := coverage.ScanDir(".")
+	if err != nil {
+		t.Fatalf("coverage.ScanDir() unexpected error: %s", err)
+	}
+
+	for _, r := range routes {
+		found := false
+		for _, e := range endpoints {
+			if e.Implemented && e.Method == r.Method && routeMatches(r.Path, e.Path) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			t.Errorf("%s %s is called but isn't implemented in endpoint_coverage_gen.go", r.Method, r.Path)
+		}
+	}
+
+	for _, e := range endpoints {
+		if !e.Implemented {
+			continue
+		}
+
+		found := false
+		for _, r := range routes {
+			if e.Method == r.Method && routeMatches(r.Path, e.Path) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			t.Errorf("%s %s is implemented in endpoint_coverage_gen.go but isn't called", e.Method, e.Path)
+		}
+	}
+}
+
+// routeMatches returns whether the route path, whose dynamic segments are {},
+// matches the endpoint path.
+func routeMatches(route, path string) bool {
+	rs := strings.Split(route, "/")
+	ps := strings.Split(endpointPathParam.ReplaceAllString(path, "{}"), "/")
+
+	if len(rs) != len(ps) {
+		return false
+	}
+
+	for i := range rs {
+		if rs[i] != ps[i] && rs[i] != "{}" {
+			return false
+		}
+	}
+
+	return true
+}
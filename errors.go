@@ -0,0 +1,160 @@
+package pagerduty
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Errors that can be checked with errors.Is, instead of matching the error
+// messages.
+var (
+	// ErrNotFound matches an APIError of a resource that wasn't found.
+	ErrNotFound = errors.New("resource not found")
+
+	// ErrRateLimited matches an APIError or an EventsAPIV2Error of a request
+	// that was rate limited.
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrMissingField matches a MissingFieldError.
+	ErrMissingField = errors.New("JSON response is missing a field")
+
+	// ErrAmbiguousMatch matches a MatchError of a lookup that found several
+	// objects.
+	ErrAmbiguousMatch = errors.New("ambiguous match")
+
+	// ErrCircuitOpen matches a CircuitOpenError.
+	ErrCircuitOpen = errors.New("circuit breaker open")
+)
+
+// MissingFieldError is returned when the JSON response of the API doesn't
+// have the field wrapping the returned object.
+type MissingFieldError struct {
+	// Field is the name of the missing field.
+	Field string
+}
+
+// Error satisfies the error interface.
+func (e MissingFieldError) Error() string {
+	return fmt.Sprintf("JSON response does not have %s field", e.Field)
+}
+
+// Is returns whether target is ErrMissingField, to support errors.Is.
+func (e MissingFieldError) Is(target error) bool {
+	return target == ErrMissingField
+}
+
+// CircuitOpenError is returned instead of making a request while the circuit
+// breaker of the client is open, see WithCircuitBreaker.
+type CircuitOpenError struct {
+	// RetryAt is when the circuit breaker will let a request through to probe
+	// whether the API recovered.
+	RetryAt time.Time
+}
+
+// Error satisfies the error interface.
+func (e CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker open after consecutive PagerDuty API failures, retry at %s", e.RetryAt.Format(time.RFC3339))
+}
+
+// Is returns whether target is ErrCircuitOpen, to support errors.Is.
+func (e CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// maxNearMatches is the maximum number of similar objects listed by a
+// MatchError of a lookup which found no object.
+const maxNearMatches = 10
+
+// MatchError is returned when a lookup by name, such as FindServiceByName,
+// doesn't find exactly one object. It matches ErrNotFound if no object was
+// found, and ErrAmbiguousMatch otherwise.
+type MatchError struct {
+	// Kind is the kind of object looked up, such as "service".
+	Kind string
+
+	// Name is the name looked up.
+	Name string
+
+	// Ambiguous is whether several objects were found.
+	Ambiguous bool
+
+	// Matches are the names of the objects found if the lookup is ambiguous,
+	// or of similar objects if none was found.
+	Matches []string
+}
+
+// Error satisfies the error interface.
+func (e MatchError) Error() string {
+	var quoted []string
+	for _, m := range e.Matches {
+		quoted = append(quoted, strconv.Quote(m))
+	}
+
+	switch {
+	case len(e.Matches) == 0:
+		return fmt.Sprintf("%s %q not found", e.Kind, e.Name)
+	case e.Ambiguous:
+		return fmt.Sprintf("%s %q is ambiguous, matches: %s", e.Kind, e.Name, strings.Join(quoted, ", "))
+	default:
+		return fmt.Sprintf("%s %q not found, near matches: %s", e.Kind, e.Name, strings.Join(quoted, ", "))
+	}
+}
+
+// Is returns whether target is ErrNotFound and no object was found, or target
+// is ErrAmbiguousMatch and several objects were found, to support errors.Is.
+func (e MatchError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return !e.Ambiguous
+	case ErrAmbiguousMatch:
+		return e.Ambiguous
+	default:
+		return false
+	}
+}
+
+// DecodeError is returned when the JSON response of the API can't be decoded.
+type DecodeError struct {
+	// Field is the name of the field of the response wrapping the decoded
+	// object, if any.
+	Field string
+
+	Err error
+}
+
+// Error satisfies the error interface.
+func (e DecodeError) Error() string {
+	if len(e.Field) == 0 {
+		return fmt.Sprintf("could not decode JSON response: %v", e.Err)
+	}
+
+	return fmt.Sprintf("could not decode JSON response %s field: %v", e.Field, e.Err)
+}
+
+// Unwrap returns the decoding error, to support errors.Is and errors.As.
+func (e DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Is returns whether target is ErrNotFound and the resource wasn't found, or
+// target is ErrRateLimited and the request was rate limited, to support
+// errors.Is.
+func (a APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return a.NotFound()
+	case ErrRateLimited:
+		return a.RateLimited()
+	default:
+		return false
+	}
+}
+
+// Is returns whether target is ErrRateLimited and the event was rate limited,
+// to support errors.Is.
+func (e EventsAPIV2Error) Is(target error) bool {
+	return target == ErrRateLimited && e.RateLimited()
+}
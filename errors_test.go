@@ -0,0 +1,65 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestErrors_Is(t *testing.T) {
+	testEqual(t, true, errors.Is(APIError{StatusCode: http.StatusNotFound}, ErrNotFound))
+	testEqual(t, false, errors.Is(APIError{StatusCode: http.StatusBadRequest}, ErrNotFound))
+	testEqual(t, true, errors.Is(APIError{StatusCode: http.StatusTooManyRequests}, ErrRateLimited))
+	testEqual(t, true, errors.Is(EventsAPIV2Error{StatusCode: http.StatusTooManyRequests}, ErrRateLimited))
+	testEqual(t, false, errors.Is(EventsAPIV2Error{StatusCode: http.StatusNotFound}, ErrNotFound))
+	testEqual(t, true, errors.Is(MissingFieldError{Field: "incident"}, ErrMissingField))
+}
+
+func TestErrors_Wrapped(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/PS1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": {"code": 2100, "message": "Not Found"}}`))
+	})
+
+	mux.HandleFunc("/services/PS2", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"foo": {}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	_, err := client.GetServiceWithContext(context.Background(), "PS1", nil)
+	testEqual(t, true, errors.Is(err, ErrNotFound))
+
+	_, err = client.GetServiceWithContext(context.Background(), "PS2", nil)
+	testEqual(t, true, errors.Is(err, ErrMissingField))
+
+	var mfe MissingFieldError
+	testEqual(t, true, errors.As(err, &mfe))
+	testEqual(t, "service", mfe.Field)
+}
+
+func TestErrors_DecodeError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents/PI1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"incident": {"title": 42}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	_, err := client.GetIncidentWithContext(context.Background(), "PI1")
+
+	var de DecodeError
+	testEqual(t, true, errors.As(err, &de))
+	testEqual(t, "incident", de.Field)
+
+	var ute *json.UnmarshalTypeError
+	testEqual(t, true, errors.As(err, &ute))
+}
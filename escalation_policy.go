@@ -2,7 +2,6 @@ package pagerduty
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 
 	"github.com/google/go-querystring/query"
@@ -12,13 +11,45 @@ const (
 	escPath = "/escalation_policies"
 )
 
+// Types of the targets of an EscalationRule.
+const (
+	EscalationTargetTypeUser     = "user_reference"
+	EscalationTargetTypeSchedule = "schedule_reference"
+)
+
+// Values of the EscalationPolicy.OnCallHandoffNotifications field.
+const (
+	// OnCallHandoffNotificationsIfHasServices only notifies users of on-call
+	// handoffs when the escalation policy has services.
+	OnCallHandoffNotificationsIfHasServices = "if_has_services"
+
+	// OnCallHandoffNotificationsAlways always notifies users of on-call
+	// handoffs.
+	OnCallHandoffNotificationsAlways = "always"
+)
+
 // EscalationRule is a rule for an escalation policy to trigger.
 type EscalationRule struct {
-	ID      string      `json:"id,omitempty"`
-	Delay   uint        `json:"escalation_delay_in_minutes,omitempty"`
+	ID    string `json:"id,omitempty"`
+	Delay uint   `json:"escalation_delay_in_minutes,omitempty"`
+
+	// Targets are the users and schedules notified by the rule. Use
+	// UserEscalationTarget and ScheduleEscalationTarget to build them.
 	Targets []APIObject `json:"targets"`
 }
 
+// UserEscalationTarget returns an EscalationRule target notifying the user
+// with the given ID.
+func UserEscalationTarget(id string) APIObject {
+	return APIObject{ID: id, Type: EscalationTargetTypeUser}
+}
+
+// ScheduleEscalationTarget returns an EscalationRule target notifying the
+// user on call for the schedule with the given ID.
+func ScheduleEscalationTarget(id string) APIObject {
+	return APIObject{ID: id, Type: EscalationTargetTypeSchedule}
+}
+
 // EscalationPolicy is a collection of escalation rules.
 type EscalationPolicy struct {
 	APIObject
@@ -104,6 +135,39 @@ func (c *Client) ListEscalationPoliciesWithContext(ctx context.Context, o ListEs
 	return &result, nil
 }
 
+// ListEscalationPoliciesPaginated lists all of the existing escalation
+// policies, handling pagination of the results.
+func (c *Client) ListEscalationPoliciesPaginated(ctx context.Context, o ListEscalationPoliciesOptions) ([]EscalationPolicy, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []EscalationPolicy
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListEscalationPoliciesResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		policies = append(policies, result.EscalationPolicies...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+			Total:  result.Total,
+		}, nil
+	}
+
+	if err := c.pagedGet(ctx, escPath+"?"+v.Encode(), responseHandler); err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
 // CreateEscalationPolicy creates a new escalation policy.
 //
 // Deprecated: Use CreateEscalationPolicyWithContext instead.
@@ -267,41 +331,19 @@ func (c *Client) ListEscalationRulesWithContext(ctx context.Context, escID strin
 }
 
 func getEscalationRuleFromResponse(c *Client, resp *http.Response, err error) (*EscalationRule, error) {
-	if err != nil {
+	var t EscalationRule
+	if err := decodeEnvelope(c, resp, err, "escalation_rule", &t); err != nil {
 		return nil, err
 	}
 
-	var target map[string]EscalationRule
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
-	}
-
-	const rootNode = "escalation_rule"
-
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
-	}
-
 	return &t, nil
 }
 
 func getEscalationPolicyFromResponse(c *Client, resp *http.Response, err error) (*EscalationPolicy, error) {
-	if err != nil {
+	var t EscalationPolicy
+	if err := decodeEnvelope(c, resp, err, "escalation_policy", &t); err != nil {
 		return nil, err
 	}
 
-	var target map[string]EscalationPolicy
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
-	}
-
-	const rootNode = "escalation_policy"
-
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
-	}
-
 	return &t, nil
 }
@@ -1,7 +1,11 @@
 package pagerduty
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 	"testing"
 )
 
@@ -62,6 +66,81 @@ func TestEscalationPolicy_Create(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+func TestEscalationPolicy_ListPaginated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/escalation_policies", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "P1", r.URL.Query().Get("team_ids[]"))
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		resp := fmt.Sprintf(`{"escalation_policies": [{"id": "%d"}], "more": %t, "offset": %d, "limit": 1}`, offset, offset == 0, offset)
+		_, _ = w.Write([]byte(resp))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListEscalationPoliciesPaginated(context.Background(), ListEscalationPoliciesOptions{Limit: 1, TeamIDs: []string{"P1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []EscalationPolicy{
+		{APIObject: APIObject{ID: "0"}},
+		{APIObject: APIObject{ID: "1"}},
+	}
+
+	testEqual(t, want, res)
+}
+
+func TestEscalationPolicy_CreateWithTargets(t *testing.T) {
+	setup()
+	defer teardown()
+
+	input := EscalationPolicy{
+		Name:     "foo",
+		NumLoops: 2,
+		EscalationRules: []EscalationRule{
+			{
+				Delay:   30,
+				Targets: []APIObject{UserEscalationTarget("PU1"), ScheduleEscalationTarget("PS1")},
+			},
+		},
+		OnCallHandoffNotifications: OnCallHandoffNotificationsAlways,
+	}
+
+	mux.HandleFunc("/escalation_policies", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]EscalationPolicy
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		testEqual(t, input, body["escalation_policy"])
+
+		_, _ = w.Write([]byte(`{"escalation_policy": {"name": "foo", "id": "1"}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.CreateEscalationPolicyWithContext(context.Background(), input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &EscalationPolicy{
+		Name: "foo",
+		APIObject: APIObject{
+			ID: "1",
+		},
+	}
+
+	testEqual(t, want, res)
+}
+
 func TestEscalationPolicy_Delete(t *testing.T) {
 	setup()
 	defer teardown()
@@ -2,6 +2,7 @@ package pagerduty
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -28,12 +29,28 @@ type Orchestration struct {
 	Version      string                      `json:"version,omitempty"`
 }
 
+// RoutingKeys returns the routing keys of the integrations of the
+// orchestration, which events can be sent to with the Events API v2.
+func (o Orchestration) RoutingKeys() []string {
+	keys := make([]string, 0, len(o.Integrations))
+	for _, i := range o.Integrations {
+		if i != nil && i.Parameters != nil && len(i.Parameters.RoutingKey) > 0 {
+			keys = append(keys, i.Parameters.RoutingKey)
+		}
+	}
+
+	return keys
+}
+
 // OrchestrationIntegration is a route into an orchestration.
 type OrchestrationIntegration struct {
 	ID         string                              `json:"id,omitempty"`
+	Label      string                              `json:"label,omitempty"`
 	Parameters *OrchestrationIntegrationParameters `json:"parameters,omitempty"`
 }
 
+// OrchestrationIntegrationParameters are the parameters of an orchestration
+// integration, including the routing key of its events.
 type OrchestrationIntegrationParameters struct {
 	RoutingKey string `json:"routing_key,omitempty"`
 	Type       string `json:"type,omitempty"`
@@ -69,6 +86,16 @@ type ListOrchestrationsOptions struct {
 	SortBy string `url:"sort_by,omitempty"`
 }
 
+// Values of the SortBy field of ListOrchestrationsOptions.
+const (
+	OrchestrationSortByNameAsc       = "name:asc"
+	OrchestrationSortByNameDesc      = "name:desc"
+	OrchestrationSortByRoutesAsc     = "routes:asc"
+	OrchestrationSortByRoutesDesc    = "routes:desc"
+	OrchestrationSortByCreatedAtAsc  = "created_at:asc"
+	OrchestrationSortByCreatedAtDesc = "created_at:desc"
+)
+
 // ListOrchestrationsWithContext lists all the existing event orchestrations.
 func (c *Client) ListOrchestrationsWithContext(ctx context.Context, o ListOrchestrationsOptions) (*ListOrchestrationsResponse, error) {
 	v, err := query.Values(o)
@@ -89,6 +116,39 @@ func (c *Client) ListOrchestrationsWithContext(ctx context.Context, o ListOrches
 	return &result, nil
 }
 
+// ListOrchestrationsPaginated lists all the existing event orchestrations,
+// handling pagination.
+func (c *Client) ListOrchestrationsPaginated(ctx context.Context, o ListOrchestrationsOptions) ([]Orchestration, error) {
+	var orchestrations []Orchestration
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListOrchestrationsResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		orchestrations = append(orchestrations, result.Orchestrations...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+			Total:  result.Total,
+		}, nil
+	}
+
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.pagedGet(ctx, eoPath+"?"+v.Encode(), responseHandler); err != nil {
+		return nil, err
+	}
+
+	return orchestrations, nil
+}
+
 // CreateOrchestrationWithContext creates a new event orchestration.
 func (c *Client) CreateOrchestrationWithContext(ctx context.Context, e Orchestration) (*Orchestration, error) {
 	d := map[string]Orchestration{
@@ -131,22 +191,11 @@ func (c *Client) UpdateOrchestrationWithContext(ctx context.Context, id string,
 }
 
 func getOrchestrationFromResponse(c *Client, resp *http.Response, err error) (*Orchestration, error) {
-	if err != nil {
+	var t Orchestration
+	if err := decodeEnvelope(c, resp, err, "orchestration", &t); err != nil {
 		return nil, err
 	}
 
-	var target map[string]Orchestration
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
-	}
-
-	const rootNode = "orchestration"
-
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
-	}
-
 	return &t, nil
 }
 
@@ -163,11 +212,51 @@ type OrchestrationRouter struct {
 	Version   string                           `json:"version,omitempty"`
 }
 
+// Validate returns an error if the router would be rejected by PagerDuty:
+// it must have a "start" rule set, every rule must route events to a
+// service, and the catch-all rule must route events to "unrouted" or to a
+// service.
+func (r OrchestrationRouter) Validate() error {
+	if len(r.Sets) == 0 || r.Sets[0] == nil || r.Sets[0].ID != OrchestrationRuleSetStart {
+		return fmt.Errorf("the first rule set of a router must be %q", OrchestrationRuleSetStart)
+	}
+
+	for _, set := range r.Sets {
+		if set == nil {
+			return errors.New("router rule sets must not be nil")
+		}
+
+		for i, rule := range set.Rules {
+			if rule == nil || rule.Actions == nil || (len(rule.Actions.RouteTo) == 0 && rule.Actions.DynamicRouteTo == nil) {
+				return fmt.Errorf("rule %d of rule set %s must route events to a service", i, set.ID)
+			}
+		}
+	}
+
+	if r.CatchAll == nil || r.CatchAll.Actions == nil || len(r.CatchAll.Actions.RouteTo) == 0 {
+		return fmt.Errorf("the catch-all rule of a router must route events to %q or a service", OrchestrationRouteToUnrouted)
+	}
+
+	return nil
+}
+
+// Values of the ID field of the first rule set of an orchestration path, and
+// of the RouteTo field of the router catch-all rule for events that shouldn't
+// be routed to any service.
+const (
+	OrchestrationRuleSetStart    = "start"
+	OrchestrationRouteToUnrouted = "unrouted"
+)
+
+// OrchestrationRouterRuleSet is a set of router rules, evaluated in order
+// until one matches an event.
 type OrchestrationRouterRuleSet struct {
 	ID    string                     `json:"id,omitempty"`
 	Rules []*OrchestrationRouterRule `json:"rules,omitempty"`
 }
 
+// OrchestrationRouterRule routes the events matching any of its conditions
+// according to its actions.
 type OrchestrationRouterRule struct {
 	ID         string                              `json:"id,omitempty"`
 	Label      string                              `json:"label,omitempty"`
@@ -176,6 +265,23 @@ type OrchestrationRouterRule struct {
 	Disabled   bool                                `json:"disabled,omitempty"`
 }
 
+// NewOrchestrationRouterRule returns a rule routing the events matching any of
+// the PCL expressions to the service with the given ID.
+func NewOrchestrationRouterRule(label, serviceID string, expressions ...string) *OrchestrationRouterRule {
+	conditions := make([]*OrchestrationRouterRuleCondition, len(expressions))
+	for i, e := range expressions {
+		conditions[i] = &OrchestrationRouterRuleCondition{Expression: e}
+	}
+
+	return &OrchestrationRouterRule{
+		Label:      label,
+		Conditions: conditions,
+		Actions:    &OrchestrationRouterActions{RouteTo: serviceID},
+	}
+}
+
+// OrchestrationRouterRuleCondition is a PCL expression matching events, such
+// as "event.summary matches part 'database'".
 type OrchestrationRouterRuleCondition struct {
 	Expression string `json:"expression,omitempty"`
 }
@@ -187,9 +293,24 @@ type OrchestrationRouterCatchAllRule struct {
 
 // OrchestrationRouterActions are the actions that will be taken to change the resulting alert and incident.
 type OrchestrationRouterActions struct {
-	RouteTo string `json:"route_to,omitempty"`
+	RouteTo        string                             `json:"route_to,omitempty"`
+	DynamicRouteTo *OrchestrationRouterDynamicRouteTo `json:"dynamic_route_to,omitempty"`
 }
 
+// OrchestrationRouterDynamicRouteTo routes events to the service whose ID or
+// name is extracted from the events, instead of a fixed service.
+type OrchestrationRouterDynamicRouteTo struct {
+	LookupBy string `json:"lookup_by,omitempty"`
+	Regex    string `json:"regex,omitempty"`
+	Source   string `json:"source,omitempty"`
+}
+
+// Values of the LookupBy field of OrchestrationRouterDynamicRouteTo.
+const (
+	OrchestrationDynamicRouteLookupByServiceID   = "service_id"
+	OrchestrationDynamicRouteLookupByServiceName = "service_name"
+)
+
 // GetOrchestrationRouterOptions is the data structure used when calling the GetOrchestrationRouter API endpoint.
 type GetOrchestrationRouterOptions struct {
 }
@@ -216,22 +337,11 @@ func (c *Client) UpdateOrchestrationRouterWithContext(ctx context.Context, id st
 }
 
 func getOrchestrationRouterFromResponse(c *Client, resp *http.Response, err error) (*OrchestrationRouter, error) {
-	if err != nil {
+	var t OrchestrationRouter
+	if err := decodeEnvelope(c, resp, err, "orchestration_path", &t); err != nil {
 		return nil, err
 	}
 
-	var target map[string]OrchestrationRouter
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
-	}
-
-	const rootNode = "orchestration_path"
-
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
-	}
-
 	return &t, nil
 }
 
@@ -249,15 +359,22 @@ type ServiceOrchestration struct {
 	Version   string        `json:"version,omitempty"`
 }
 
+// ServiceOrchestrationCatchAllRule applies its actions to the events that no
+// rule matches.
 type ServiceOrchestrationCatchAllRule struct {
 	Actions *ServiceOrchestrationRuleActions `json:"actions,omitempty"`
 }
 
+// ServiceOrchestrationRuleSet is a set of service orchestration rules. Events
+// start at the "start" rule set, and the first matching rule applies, which
+// can route the event to another rule set with RouteTo.
 type ServiceOrchestrationRuleSet struct {
 	ID    string                      `json:"id,omitempty"`
 	Rules []*ServiceOrchestrationRule `json:"rules,omitempty"`
 }
 
+// ServiceOrchestrationRule applies its actions to the events matching any of
+// its conditions, or to all events if it has none.
 type ServiceOrchestrationRule struct {
 	ID         string                               `json:"id,omitempty"`
 	Label      string                               `json:"label,omitempty"`
@@ -266,27 +383,48 @@ type ServiceOrchestrationRule struct {
 	Disabled   bool                                 `json:"disabled,omitempty"`
 }
 
+// ServiceOrchestrationRuleCondition is a PCL expression matching events.
 type ServiceOrchestrationRuleCondition struct {
 	Expression string `json:"expression,omitempty"`
 }
 
 // ServiceOrchestrationRuleActions are the actions that will be taken to change the resulting alert and incident.
 type ServiceOrchestrationRuleActions struct {
-	RouteTo                    string                       `json:"route_to,omitempty"`
-	Suppress                   bool                         `json:"suppress,omitempty"`
-	Suspend                    uint                         `json:"suspend,omitempty"`
-	Priority                   string                       `json:"priority,omitempty"`
-	Annotate                   string                       `json:"annotate,omitempty"`
+	// RouteTo is the ID of the rule set to evaluate next.
+	RouteTo string `json:"route_to,omitempty"`
+
+	// Suppress creates a suppressed alert instead of an incident.
+	Suppress bool `json:"suppress,omitempty"`
+
+	// Suspend is the number of seconds to wait before creating an incident,
+	// giving a resolve event time to arrive.
+	Suspend uint `json:"suspend,omitempty"`
+
+	// Priority is the ID of the priority of the resulting incident.
+	Priority string `json:"priority,omitempty"`
+
+	// Annotate adds a note to the resulting incident.
+	Annotate string `json:"annotate,omitempty"`
+
 	PagerDutyAutomationActions []*PagerDutyAutomationAction `json:"pagerduty_automation_actions,omitempty"`
 	AutomationActions          []*AutomationAction          `json:"automation_actions,omitempty"`
-	Severity                   string                       `json:"severity,omitempty"`
-	EventAction                string                       `json:"event_action,omitempty"`
-	Variables                  []*OrchestrationVariable     `json:"variables,omitempty"`
-	Extractions                []*OrchestrationExtraction   `json:"extractions,omitempty"`
+
+	// Severity overrides the severity of the event, such as
+	// RuleActionSeverityCritical.
+	Severity string `json:"severity,omitempty"`
+
+	// EventAction overrides the action of the event, such as
+	// RuleActionEventActionResolve.
+	EventAction string `json:"event_action,omitempty"`
+
+	Variables   []*OrchestrationVariable   `json:"variables,omitempty"`
+	Extractions []*OrchestrationExtraction `json:"extractions,omitempty"`
 }
 
+// ServiceOrchestrationActive is whether a service uses its service
+// orchestration rather than its event rules to process events.
 type ServiceOrchestrationActive struct {
-	Active bool `json:"active,omitempty"`
+	Active bool `json:"active"`
 }
 
 // GetServiceOrchestrationOptions is the data structure used when calling the GetServiceOrchestration API endpoint.
@@ -327,22 +465,11 @@ func (c *Client) UpdateServiceOrchestrationActiveWithContext(ctx context.Context
 }
 
 func getServiceOrchestrationFromResponse(c *Client, resp *http.Response, err error) (*ServiceOrchestration, error) {
-	if err != nil {
+	var t ServiceOrchestration
+	if err := decodeEnvelope(c, resp, err, "orchestration_path", &t); err != nil {
 		return nil, err
 	}
 
-	var target map[string]ServiceOrchestration
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
-	}
-
-	const rootNode = "orchestration_path"
-
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
-	}
-
 	return &t, nil
 }
 
@@ -353,7 +480,7 @@ func getServiceOrchestrationActiveFromResponse(c *Client, resp *http.Response, e
 
 	var target ServiceOrchestrationActive
 	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+		return nil, fmt.Errorf("could not decode JSON response: %w", dErr)
 	}
 
 	return &target, nil
@@ -361,8 +488,12 @@ func getServiceOrchestrationActiveFromResponse(c *Client, resp *http.Response, e
 
 // OrchestrationUnrouted defines sets of rules to be applied to unrouted events.
 type OrchestrationUnrouted struct {
-	Type      string                             `json:"type,omitempty"`
-	Parent    *APIReference                      `json:"parent,omitempty"`
+	Type   string        `json:"type,omitempty"`
+	Parent *APIReference `json:"parent,omitempty"`
+
+	// Sets are the rule sets of unrouted events. Their rules only support the
+	// RouteTo, Severity, EventAction, Variables and Extractions actions, see
+	// Validate.
 	Sets      []*ServiceOrchestrationRuleSet     `json:"sets,omitempty"`
 	CatchAll  *OrchestrationUnroutedCatchAllRule `json:"catch_all,omitempty"`
 	CreatedAt string                             `json:"created_at,omitempty"`
@@ -372,15 +503,54 @@ type OrchestrationUnrouted struct {
 	Version   string                             `json:"version,omitempty"`
 }
 
+// Validate returns an error if the unrouted rules would be rejected by
+// PagerDuty: they must start with the "start" rule set, their rules can't use
+// the actions that only apply to services, such as Suppress or Priority, and
+// the catch-all rule can't route events.
+func (u OrchestrationUnrouted) Validate() error {
+	if len(u.Sets) == 0 || u.Sets[0] == nil || u.Sets[0].ID != OrchestrationRuleSetStart {
+		return fmt.Errorf("the first unrouted rule set must be %q", OrchestrationRuleSetStart)
+	}
+
+	for _, set := range u.Sets {
+		if set == nil {
+			return errors.New("unrouted rule sets must not be nil")
+		}
+
+		for i, rule := range set.Rules {
+			if rule == nil || rule.Actions == nil {
+				continue
+			}
+
+			a := rule.Actions
+			if a.Suppress || a.Suspend > 0 || len(a.Priority) > 0 || len(a.Annotate) > 0 ||
+				len(a.PagerDutyAutomationActions) > 0 || len(a.AutomationActions) > 0 {
+				return fmt.Errorf("rule %d of unrouted rule set %s has actions that only apply to services", i, set.ID)
+			}
+		}
+	}
+
+	if u.CatchAll != nil && u.CatchAll.Actions != nil && len(u.CatchAll.Actions.RouteTo) > 0 {
+		return errors.New("the unrouted catch-all rule can't route events")
+	}
+
+	return nil
+}
+
+// OrchestrationUnroutedCatchAllRule applies its actions to the unrouted events
+// that no rule matches.
 type OrchestrationUnroutedCatchAllRule struct {
 	Actions *OrchestrationUnroutedRuleActions `json:"actions,omitempty"`
 }
 
+// OrchestrationUnroutedRuleSet is a set of unrouted rules.
 type OrchestrationUnroutedRuleSet struct {
 	ID    string                       `json:"id,omitempty"`
 	Rules []*OrchestrationUnroutedRule `json:"rules,omitempty"`
 }
 
+// OrchestrationUnroutedRule applies its actions to the unrouted events
+// matching any of its conditions.
 type OrchestrationUnroutedRule struct {
 	ID         string                                `json:"id,omitempty"`
 	Label      string                                `json:"label,omitempty"`
@@ -389,6 +559,7 @@ type OrchestrationUnroutedRule struct {
 	Disabled   bool                                  `json:"disabled,omitempty"`
 }
 
+// OrchestrationUnroutedRuleCondition is a PCL expression matching events.
 type OrchestrationUnroutedRuleCondition struct {
 	Expression string `json:"expression,omitempty"`
 }
@@ -428,29 +599,133 @@ func (c *Client) UpdateOrchestrationUnroutedWithContext(ctx context.Context, id
 }
 
 func getOrchestrationUnroutedFromResponse(c *Client, resp *http.Response, err error) (*OrchestrationUnrouted, error) {
+	var t OrchestrationUnrouted
+	if err := decodeEnvelope(c, resp, err, "orchestration_path", &t); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// OrchestrationGlobal defines sets of rules to be applied to all the events
+// of an orchestration, before they are routed.
+type OrchestrationGlobal struct {
+	Type      string                           `json:"type,omitempty"`
+	Parent    *APIReference                    `json:"parent,omitempty"`
+	Sets      []*OrchestrationGlobalRuleSet    `json:"sets,omitempty"`
+	CatchAll  *OrchestrationGlobalCatchAllRule `json:"catch_all,omitempty"`
+	CreatedAt string                           `json:"created_at,omitempty"`
+	CreatedBy *APIReference                    `json:"created_by,omitempty"`
+	UpdatedAt string                           `json:"updated_at,omitempty"`
+	UpdatedBy *APIReference                    `json:"updated_by,omitempty"`
+	Version   string                           `json:"version,omitempty"`
+}
+
+// OrchestrationGlobalCatchAllRule applies its actions to the events that no
+// global rule matches.
+type OrchestrationGlobalCatchAllRule struct {
+	Actions *OrchestrationGlobalRuleActions `json:"actions,omitempty"`
+}
+
+// OrchestrationGlobalRuleSet is a set of global rules.
+type OrchestrationGlobalRuleSet struct {
+	ID    string                     `json:"id,omitempty"`
+	Rules []*OrchestrationGlobalRule `json:"rules,omitempty"`
+}
+
+// OrchestrationGlobalRule applies its actions to the events matching any of
+// its conditions, or to all events if it has none.
+type OrchestrationGlobalRule struct {
+	ID         string                              `json:"id,omitempty"`
+	Label      string                              `json:"label,omitempty"`
+	Conditions []*OrchestrationGlobalRuleCondition `json:"conditions,omitempty"`
+	Actions    *OrchestrationGlobalRuleActions     `json:"actions,omitempty"`
+	Disabled   bool                                `json:"disabled,omitempty"`
+}
+
+// OrchestrationGlobalRuleCondition is a PCL expression matching events. It
+// can reference cache variables, such as "cache_var.recent_count > 5".
+type OrchestrationGlobalRuleCondition struct {
+	Expression string `json:"expression,omitempty"`
+}
+
+// OrchestrationGlobalRuleActions are the actions that will be taken to change the resulting alert and incident.
+type OrchestrationGlobalRuleActions struct {
+	// RouteTo is the ID of the global rule set to evaluate next.
+	RouteTo string `json:"route_to,omitempty"`
+
+	// DropEvent drops the event entirely, without creating an alert.
+	DropEvent bool `json:"drop_event,omitempty"`
+
+	// Suppress creates a suppressed alert instead of an incident.
+	Suppress bool `json:"suppress,omitempty"`
+
+	// Suspend is the number of seconds to wait before creating an incident,
+	// giving a resolve event time to arrive.
+	Suspend uint `json:"suspend,omitempty"`
+
+	// Priority is the ID of the priority of the resulting incident.
+	Priority string `json:"priority,omitempty"`
+
+	// Annotate adds a note to the resulting incident.
+	Annotate string `json:"annotate,omitempty"`
+
+	AutomationActions []*AutomationAction `json:"automation_actions,omitempty"`
+
+	// Severity overrides the severity of the event, such as
+	// RuleActionSeverityCritical.
+	Severity string `json:"severity,omitempty"`
+
+	// EventAction overrides the action of the event, such as
+	// RuleActionEventActionResolve.
+	EventAction string `json:"event_action,omitempty"`
+
+	Variables   []*OrchestrationVariable   `json:"variables,omitempty"`
+	Extractions []*OrchestrationExtraction `json:"extractions,omitempty"`
+}
+
+// GetOrchestrationGlobalOptions is the data structure used when calling the GetOrchestrationGlobal API endpoint.
+type GetOrchestrationGlobalOptions struct {
+}
+
+// GetOrchestrationGlobalWithContext gets the global rules of an event orchestration.
+func (c *Client) GetOrchestrationGlobalWithContext(ctx context.Context, id string, o *GetOrchestrationGlobalOptions) (*OrchestrationGlobal, error) {
+	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
 
-	var target map[string]OrchestrationUnrouted
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	resp, err := c.get(ctx, eoPath+"/"+id+"/global"+"?"+v.Encode())
+	return getOrchestrationGlobalFromResponse(c, resp, err)
+}
+
+// UpdateOrchestrationGlobalWithContext updates the global rules of an event orchestration.
+func (c *Client) UpdateOrchestrationGlobalWithContext(ctx context.Context, id string, e OrchestrationGlobal) (*OrchestrationGlobal, error) {
+	d := map[string]OrchestrationGlobal{
+		"orchestration_path": e,
 	}
 
-	const rootNode = "orchestration_path"
+	resp, err := c.put(ctx, eoPath+"/"+id+"/global", d, nil)
+	return getOrchestrationGlobalFromResponse(c, resp, err)
+}
 
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
+func getOrchestrationGlobalFromResponse(c *Client, resp *http.Response, err error) (*OrchestrationGlobal, error) {
+	var t OrchestrationGlobal
+	if err := decodeEnvelope(c, resp, err, "orchestration_path", &t); err != nil {
+		return nil, err
 	}
 
 	return &t, nil
 }
 
+// PagerDutyAutomationAction runs a PagerDuty Automation action on the
+// resulting incident.
 type PagerDutyAutomationAction struct {
 	ActionID string `json:"action_id,omitempty"`
 }
 
+// AutomationAction sends a webhook to the URL, either automatically if
+// AutoSend is set, or when a responder runs it from the resulting incident.
 type AutomationAction struct {
 	Name       string                    `json:"name,omitempty"`
 	URL        string                    `json:"url,omitempty"`
@@ -459,11 +734,13 @@ type AutomationAction struct {
 	Parameters []*OrchestrationParameter `json:"parameters,omitempty"`
 }
 
+// OrchestrationHeader is an HTTP header of an automation action webhook.
 type OrchestrationHeader struct {
 	Key   string `json:"key,omitempty"`
 	Value string `json:"value,omitempty"`
 }
 
+// OrchestrationParameter is a parameter of an automation action webhook.
 type OrchestrationParameter struct {
 	Key   string `json:"key,omitempty"`
 	Value string `json:"value,omitempty"`
@@ -484,3 +761,41 @@ type OrchestrationVariable struct {
 	Type  string `json:"type,omitempty"`
 	Value string `json:"value,omitempty"`
 }
+
+// Values of the Type field of OrchestrationVariable.
+const (
+	OrchestrationVariableTypeRegex = "regex"
+)
+
+// NewOrchestrationRegexVariable returns a variable named name, set to the
+// first capture group of the regular expression, or the whole match if it has
+// none, applied to the event field at path, such as "event.summary".
+func NewOrchestrationRegexVariable(name, path, regex string) *OrchestrationVariable {
+	return &OrchestrationVariable{
+		Name:  name,
+		Path:  path,
+		Type:  OrchestrationVariableTypeRegex,
+		Value: regex,
+	}
+}
+
+// NewOrchestrationRegexExtraction returns an extraction setting the event
+// field target to the first capture group of the regular expression applied to
+// the event field source.
+func NewOrchestrationRegexExtraction(target, source, regex string) *OrchestrationExtraction {
+	return &OrchestrationExtraction{
+		Target: target,
+		Source: source,
+		Regex:  regex,
+	}
+}
+
+// NewOrchestrationTemplateExtraction returns an extraction setting the event
+// field target to the template, which can reference variables, such as
+// "{{variables.hostname}} is down".
+func NewOrchestrationTemplateExtraction(target, template string) *OrchestrationExtraction {
+	return &OrchestrationExtraction{
+		Target:   target,
+		Template: template,
+	}
+}
@@ -0,0 +1,116 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+)
+
+// OrchestrationCacheVariable stores a value derived from the recent events of
+// an event orchestration, which its global rules can reference in conditions
+// as "cache_var.NAME", such as to act on event rates.
+type OrchestrationCacheVariable struct {
+	ID            string                                   `json:"id,omitempty"`
+	Name          string                                   `json:"name,omitempty"`
+	Disabled      bool                                     `json:"disabled,omitempty"`
+	Conditions    []*OrchestrationCacheVariableCondition   `json:"conditions,omitempty"`
+	Configuration *OrchestrationCacheVariableConfiguration `json:"configuration,omitempty"`
+	CreatedAt     APITime                                  `json:"created_at,omitempty"`
+	CreatedBy     *APIReference                            `json:"created_by,omitempty"`
+	UpdatedAt     APITime                                  `json:"updated_at,omitempty"`
+	UpdatedBy     *APIReference                            `json:"updated_by,omitempty"`
+}
+
+// OrchestrationCacheVariableCondition is a PCL expression matching the events
+// that update a cache variable.
+type OrchestrationCacheVariableCondition struct {
+	Expression string `json:"expression,omitempty"`
+}
+
+// OrchestrationCacheVariableConfiguration defines how the value of a cache
+// variable is computed.
+type OrchestrationCacheVariableConfiguration struct {
+	Type string `json:"type,omitempty"`
+
+	// Regex and Source extract the value of a recent_value cache variable
+	// from the event field Source.
+	Regex  string `json:"regex,omitempty"`
+	Source string `json:"source,omitempty"`
+
+	// TTLSeconds is the window in which a trigger_event_count cache variable
+	// counts events.
+	TTLSeconds uint `json:"ttl_seconds,omitempty"`
+}
+
+// Values of the Type field of OrchestrationCacheVariableConfiguration.
+const (
+	OrchestrationCacheVariableTypeRecentValue       = "recent_value"
+	OrchestrationCacheVariableTypeTriggerEventCount = "trigger_event_count"
+)
+
+// ListOrchestrationCacheVariablesResponse is the data structure returned from
+// calling the ListOrchestrationCacheVariables API endpoint.
+type ListOrchestrationCacheVariablesResponse struct {
+	CacheVariables []OrchestrationCacheVariable `json:"cache_variables"`
+	Total          uint                         `json:"total,omitempty"`
+}
+
+// ListOrchestrationCacheVariablesWithContext lists the cache variables of an
+// event orchestration.
+func (c *Client) ListOrchestrationCacheVariablesWithContext(ctx context.Context, orchestrationID string) (*ListOrchestrationCacheVariablesResponse, error) {
+	resp, err := c.get(ctx, eoPath+"/"+orchestrationID+"/cache_variables")
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListOrchestrationCacheVariablesResponse
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateOrchestrationCacheVariableWithContext creates a cache variable in an
+// event orchestration.
+func (c *Client) CreateOrchestrationCacheVariableWithContext(ctx context.Context, orchestrationID string, v OrchestrationCacheVariable) (*OrchestrationCacheVariable, error) {
+	d := map[string]OrchestrationCacheVariable{
+		"cache_variable": v,
+	}
+
+	resp, err := c.post(ctx, eoPath+"/"+orchestrationID+"/cache_variables", d, nil)
+	return getOrchestrationCacheVariableFromResponse(c, resp, err)
+}
+
+// GetOrchestrationCacheVariableWithContext gets a cache variable of an event
+// orchestration.
+func (c *Client) GetOrchestrationCacheVariableWithContext(ctx context.Context, orchestrationID, id string) (*OrchestrationCacheVariable, error) {
+	resp, err := c.get(ctx, eoPath+"/"+orchestrationID+"/cache_variables/"+id)
+	return getOrchestrationCacheVariableFromResponse(c, resp, err)
+}
+
+// UpdateOrchestrationCacheVariableWithContext updates a cache variable of an
+// event orchestration.
+func (c *Client) UpdateOrchestrationCacheVariableWithContext(ctx context.Context, orchestrationID, id string, v OrchestrationCacheVariable) (*OrchestrationCacheVariable, error) {
+	d := map[string]OrchestrationCacheVariable{
+		"cache_variable": v,
+	}
+
+	resp, err := c.put(ctx, eoPath+"/"+orchestrationID+"/cache_variables/"+id, d, nil)
+	return getOrchestrationCacheVariableFromResponse(c, resp, err)
+}
+
+// DeleteOrchestrationCacheVariableWithContext deletes a cache variable of an
+// event orchestration.
+func (c *Client) DeleteOrchestrationCacheVariableWithContext(ctx context.Context, orchestrationID, id string) error {
+	_, err := c.delete(ctx, eoPath+"/"+orchestrationID+"/cache_variables/"+id)
+	return err
+}
+
+func getOrchestrationCacheVariableFromResponse(c *Client, resp *http.Response, err error) (*OrchestrationCacheVariable, error) {
+	var t OrchestrationCacheVariable
+	if err := decodeEnvelope(c, resp, err, "cache_variable", &t); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,134 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestOrchestrationCacheVariable_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/event_orchestrations/1/cache_variables", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"cache_variables": [{"id": "CV1", "name": "recent_host"}], "total": 1}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListOrchestrationCacheVariablesWithContext(context.Background(), "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &ListOrchestrationCacheVariablesResponse{
+		CacheVariables: []OrchestrationCacheVariable{{ID: "CV1", Name: "recent_host"}},
+		Total:          1,
+	}
+	testEqual(t, want, res)
+}
+
+func TestOrchestrationCacheVariable_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/event_orchestrations/1/cache_variables", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]OrchestrationCacheVariable
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		v := body["cache_variable"]
+		testEqual(t, "error_count", v.Name)
+		testEqual(t, OrchestrationCacheVariableTypeTriggerEventCount, v.Configuration.Type)
+		testEqual(t, uint(300), v.Configuration.TTLSeconds)
+
+		_, _ = w.Write([]byte(`{"cache_variable": {"id": "CV1", "name": "error_count"}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	input := OrchestrationCacheVariable{
+		Name:       "error_count",
+		Conditions: []*OrchestrationCacheVariableCondition{{Expression: "event.severity matches 'error'"}},
+		Configuration: &OrchestrationCacheVariableConfiguration{
+			Type:       OrchestrationCacheVariableTypeTriggerEventCount,
+			TTLSeconds: 300,
+		},
+	}
+
+	res, err := client.CreateOrchestrationCacheVariableWithContext(context.Background(), "1", input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, &OrchestrationCacheVariable{ID: "CV1", Name: "error_count"}, res)
+}
+
+func TestOrchestrationCacheVariable_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/event_orchestrations/1/cache_variables/CV1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"cache_variable": {"id": "CV1", "configuration": {"type": "recent_value", "regex": ".*", "source": "event.source"}, "created_at": "2021-01-01T00:00:00Z"}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.GetOrchestrationCacheVariableWithContext(context.Background(), "1", "CV1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &OrchestrationCacheVariable{
+		ID: "CV1",
+		Configuration: &OrchestrationCacheVariableConfiguration{
+			Type:   OrchestrationCacheVariableTypeRecentValue,
+			Regex:  ".*",
+			Source: "event.source",
+		},
+		CreatedAt: NewAPITime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	testEqual(t, want, res)
+}
+
+func TestOrchestrationCacheVariable_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/event_orchestrations/1/cache_variables/CV1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		_, _ = w.Write([]byte(`{"cache_variable": {"id": "CV1", "disabled": true}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.UpdateOrchestrationCacheVariableWithContext(context.Background(), "1", "CV1", OrchestrationCacheVariable{Disabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, &OrchestrationCacheVariable{ID: "CV1", Disabled: true}, res)
+}
+
+func TestOrchestrationCacheVariable_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/event_orchestrations/1/cache_variables/CV1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	if err := client.DeleteOrchestrationCacheVariableWithContext(context.Background(), "1", "CV1"); err != nil {
+		t.Fatal(err)
+	}
+}
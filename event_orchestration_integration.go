@@ -0,0 +1,111 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// ListOrchestrationIntegrationsResponse is the data structure returned from
+// calling the ListOrchestrationIntegrations API endpoint.
+type ListOrchestrationIntegrationsResponse struct {
+	Integrations []OrchestrationIntegration `json:"integrations"`
+	Total        uint                       `json:"total,omitempty"`
+}
+
+// ListOrchestrationIntegrationsWithContext lists the integrations of an event
+// orchestration, and so its routing keys.
+func (c *Client) ListOrchestrationIntegrationsWithContext(ctx context.Context, orchestrationID string) (*ListOrchestrationIntegrationsResponse, error) {
+	resp, err := c.get(ctx, eoPath+"/"+orchestrationID+"/integrations")
+	return getOrchestrationIntegrationsFromResponse(c, resp, err)
+}
+
+// CreateOrchestrationIntegrationWithContext creates an integration in an event
+// orchestration, with a new routing key. Only the Label of i is used.
+func (c *Client) CreateOrchestrationIntegrationWithContext(ctx context.Context, orchestrationID string, i OrchestrationIntegration) (*OrchestrationIntegration, error) {
+	d := map[string]OrchestrationIntegration{
+		"integration": i,
+	}
+
+	resp, err := c.post(ctx, eoPath+"/"+orchestrationID+"/integrations", d, nil)
+	return getOrchestrationIntegrationFromResponse(c, resp, err)
+}
+
+// GetOrchestrationIntegrationWithContext gets an integration of an event
+// orchestration.
+func (c *Client) GetOrchestrationIntegrationWithContext(ctx context.Context, orchestrationID, id string) (*OrchestrationIntegration, error) {
+	resp, err := c.get(ctx, eoPath+"/"+orchestrationID+"/integrations/"+id)
+	return getOrchestrationIntegrationFromResponse(c, resp, err)
+}
+
+// UpdateOrchestrationIntegrationWithContext updates the label of an
+// integration of an event orchestration. Only the Label of i is used.
+func (c *Client) UpdateOrchestrationIntegrationWithContext(ctx context.Context, orchestrationID, id string, i OrchestrationIntegration) (*OrchestrationIntegration, error) {
+	d := map[string]OrchestrationIntegration{
+		"integration": i,
+	}
+
+	resp, err := c.put(ctx, eoPath+"/"+orchestrationID+"/integrations/"+id, d, nil)
+	return getOrchestrationIntegrationFromResponse(c, resp, err)
+}
+
+// DeleteOrchestrationIntegrationWithContext deletes an integration of an event
+// orchestration. Events sent to its routing key are then rejected.
+func (c *Client) DeleteOrchestrationIntegrationWithContext(ctx context.Context, orchestrationID, id string) error {
+	_, err := c.delete(ctx, eoPath+"/"+orchestrationID+"/integrations/"+id)
+	return err
+}
+
+// Values of the SourceType field of MigrateOrchestrationIntegrationOptions.
+const (
+	OrchestrationIntegrationSourceTypeOrchestration = "orchestration"
+)
+
+// MigrateOrchestrationIntegrationOptions is the data structure used when
+// calling the MigrateOrchestrationIntegration API endpoint.
+type MigrateOrchestrationIntegrationOptions struct {
+	// SourceID is the ID of the orchestration the integration is moved from.
+	SourceID string `json:"source_id"`
+
+	// SourceType is the type of the source, OrchestrationIntegrationSourceTypeOrchestration.
+	SourceType string `json:"source_type"`
+
+	// IntegrationID is the ID of the integration to move.
+	IntegrationID string `json:"integration_id"`
+}
+
+// MigrateOrchestrationIntegrationWithContext moves an integration, and so its
+// routing key, from another event orchestration to the one with the given ID.
+// Events sent to the routing key are then processed by the destination
+// orchestration. It returns the integrations of the destination
+// orchestration.
+func (c *Client) MigrateOrchestrationIntegrationWithContext(ctx context.Context, orchestrationID string, o MigrateOrchestrationIntegrationOptions) (*ListOrchestrationIntegrationsResponse, error) {
+	if len(o.SourceType) == 0 {
+		o.SourceType = OrchestrationIntegrationSourceTypeOrchestration
+	}
+
+	resp, err := c.post(ctx, eoPath+"/"+orchestrationID+"/integrations/migration", o, nil)
+	return getOrchestrationIntegrationsFromResponse(c, resp, err)
+}
+
+func getOrchestrationIntegrationsFromResponse(c *Client, resp *http.Response, err error) (*ListOrchestrationIntegrationsResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListOrchestrationIntegrationsResponse
+	if dErr := c.decodeJSON(resp, &result); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %w", dErr)
+	}
+
+	return &result, nil
+}
+
+func getOrchestrationIntegrationFromResponse(c *Client, resp *http.Response, err error) (*OrchestrationIntegration, error) {
+	var t OrchestrationIntegration
+	if err := decodeEnvelope(c, resp, err, "integration", &t); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
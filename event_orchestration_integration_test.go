@@ -0,0 +1,148 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestOrchestrationIntegration_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/event_orchestrations/1/integrations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"integrations": [{"id": "I1", "label": "default", "parameters": {"routing_key": "R1", "type": "global"}}], "total": 1}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListOrchestrationIntegrationsWithContext(context.Background(), "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &ListOrchestrationIntegrationsResponse{
+		Integrations: []OrchestrationIntegration{
+			{ID: "I1", Label: "default", Parameters: &OrchestrationIntegrationParameters{RoutingKey: "R1", Type: "global"}},
+		},
+		Total: 1,
+	}
+	testEqual(t, want, res)
+}
+
+func TestOrchestrationIntegration_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/event_orchestrations/1/integrations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]OrchestrationIntegration
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+		testEqual(t, "payments", body["integration"].Label)
+
+		_, _ = w.Write([]byte(`{"integration": {"id": "I2", "label": "payments", "parameters": {"routing_key": "R2"}}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.CreateOrchestrationIntegrationWithContext(context.Background(), "1", OrchestrationIntegration{Label: "payments"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "R2", res.Parameters.RoutingKey)
+}
+
+func TestOrchestrationIntegration_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/event_orchestrations/1/integrations/I1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"integration": {"id": "I1"}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.GetOrchestrationIntegrationWithContext(context.Background(), "1", "I1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, &OrchestrationIntegration{ID: "I1"}, res)
+}
+
+func TestOrchestrationIntegration_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/event_orchestrations/1/integrations/I1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		_, _ = w.Write([]byte(`{"integration": {"id": "I1", "label": "renamed"}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.UpdateOrchestrationIntegrationWithContext(context.Background(), "1", "I1", OrchestrationIntegration{Label: "renamed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, &OrchestrationIntegration{ID: "I1", Label: "renamed"}, res)
+}
+
+func TestOrchestrationIntegration_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/event_orchestrations/1/integrations/I1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	if err := client.DeleteOrchestrationIntegrationWithContext(context.Background(), "1", "I1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOrchestrationIntegration_Migrate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/event_orchestrations/2/integrations/migration", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body MigrateOrchestrationIntegrationOptions
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		want := MigrateOrchestrationIntegrationOptions{
+			SourceID:      "1",
+			SourceType:    OrchestrationIntegrationSourceTypeOrchestration,
+			IntegrationID: "I1",
+		}
+		testEqual(t, want, body)
+
+		_, _ = w.Write([]byte(`{"integrations": [{"id": "I1"}], "total": 1}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.MigrateOrchestrationIntegrationWithContext(context.Background(), "2", MigrateOrchestrationIntegrationOptions{
+		SourceID:      "1",
+		IntegrationID: "I1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, []OrchestrationIntegration{{ID: "I1"}}, res.Integrations)
+}
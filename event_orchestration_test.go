@@ -2,7 +2,10 @@ package pagerduty
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 	"testing"
 )
 
@@ -37,6 +40,45 @@ func TestOrchestration_List(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+func TestOrchestration_ListPaginated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/event_orchestrations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, OrchestrationSortByNameAsc, r.URL.Query().Get("sort_by"))
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		_, _ = fmt.Fprintf(w, `{"orchestrations": [{"id": "%d"}], "more": %t, "offset": %d, "limit": 1}`, offset, offset == 0, offset)
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListOrchestrationsPaginated(context.Background(), ListOrchestrationsOptions{SortBy: OrchestrationSortByNameAsc})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Orchestration{
+		{APIObject: APIObject{ID: "0"}},
+		{APIObject: APIObject{ID: "1"}},
+	}
+
+	testEqual(t, want, res)
+}
+
+func TestOrchestration_RoutingKeys(t *testing.T) {
+	o := Orchestration{
+		Integrations: []*OrchestrationIntegration{
+			{ID: "1", Parameters: &OrchestrationIntegrationParameters{RoutingKey: "R1", Type: "global"}},
+			{ID: "2"},
+			{ID: "3", Parameters: &OrchestrationIntegrationParameters{RoutingKey: "R3", Type: "global"}},
+		},
+	}
+
+	testEqual(t, []string{"R1", "R3"}, o.RoutingKeys())
+}
+
 func TestOrchestration_Create(t *testing.T) {
 	setup()
 	defer teardown()
@@ -154,6 +196,13 @@ func TestOrchestrationRouter_Update(t *testing.T) {
 
 	mux.HandleFunc("/event_orchestrations/1/router", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "PUT")
+
+		var body map[string]OrchestrationRouter
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+		testEqual(t, "router", body["orchestration_path"].Type)
+
 		_, _ = w.Write([]byte(`{"orchestration_path": {"type": "router", "parent": {"id": "1"}}}`))
 	})
 
@@ -172,6 +221,59 @@ func TestOrchestrationRouter_Update(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+func TestOrchestrationRouter_Validate(t *testing.T) {
+	r := OrchestrationRouter{
+		Sets: []*OrchestrationRouterRuleSet{
+			{
+				ID: OrchestrationRuleSetStart,
+				Rules: []*OrchestrationRouterRule{
+					NewOrchestrationRouterRule("database", "PS1", "event.summary matches part 'database'"),
+				},
+			},
+		},
+		CatchAll: &OrchestrationRouterCatchAllRule{
+			Actions: &OrchestrationRouterActions{RouteTo: OrchestrationRouteToUnrouted},
+		},
+	}
+
+	if err := r.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	r.Sets[0].Rules = append(r.Sets[0].Rules, &OrchestrationRouterRule{Label: "nowhere"})
+	testErrCheck(t, "r.Validate()", "rule 1 of rule set start must route events to a service", r.Validate())
+
+	r.Sets[0].ID = "other"
+	testErrCheck(t, "r.Validate()", `the first rule set of a router must be "start"`, r.Validate())
+}
+
+func TestOrchestrationRouter_MarshalJSON(t *testing.T) {
+	r := OrchestrationRouter{
+		Sets: []*OrchestrationRouterRuleSet{
+			{
+				ID: OrchestrationRuleSetStart,
+				Rules: []*OrchestrationRouterRule{
+					{Actions: &OrchestrationRouterActions{
+						DynamicRouteTo: &OrchestrationRouterDynamicRouteTo{
+							LookupBy: OrchestrationDynamicRouteLookupByServiceName,
+							Regex:    "(.*)",
+							Source:   "event.custom_details.service",
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"sets":[{"id":"start","rules":[{"actions":{"dynamic_route_to":{"lookup_by":"service_name","regex":"(.*)","source":"event.custom_details.service"}}}]}]}`
+	testEqual(t, want, string(b))
+}
+
 func TestOrchestrationUnrouted_Get(t *testing.T) {
 	setup()
 	defer teardown()
@@ -221,6 +323,95 @@ func TestOrchestrationUnrouted_Update(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+func TestOrchestrationUnrouted_Validate(t *testing.T) {
+	u := OrchestrationUnrouted{
+		Sets: []*ServiceOrchestrationRuleSet{
+			{
+				ID: OrchestrationRuleSetStart,
+				Rules: []*ServiceOrchestrationRule{
+					{
+						Conditions: []*ServiceOrchestrationRuleCondition{{Expression: "event.summary matches part 'test'"}},
+						Actions:    &ServiceOrchestrationRuleActions{Severity: RuleActionSeverityInfo},
+					},
+				},
+			},
+		},
+		CatchAll: &OrchestrationUnroutedCatchAllRule{
+			Actions: &OrchestrationUnroutedRuleActions{Severity: RuleActionSeverityWarning},
+		},
+	}
+
+	if err := u.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	u.CatchAll.Actions.RouteTo = "PS1"
+	testErrCheck(t, "u.Validate()", "the unrouted catch-all rule can't route events", u.Validate())
+
+	u.Sets[0].Rules[0].Actions.Suppress = true
+	testErrCheck(t, "u.Validate()", "rule 0 of unrouted rule set start has actions that only apply to services", u.Validate())
+}
+
+func TestOrchestrationGlobal_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/event_orchestrations/1/global", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"orchestration_path": {"type": "global", "parent": {"id": "1"}}}`))
+	})
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.GetOrchestrationGlobalWithContext(context.Background(), "1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &OrchestrationGlobal{
+		Type:   "global",
+		Parent: &APIReference{ID: "1"},
+	}
+	testEqual(t, want, res)
+}
+
+func TestOrchestrationGlobal_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/event_orchestrations/1/global", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var body map[string]OrchestrationGlobal
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+		testEqual(t, true, body["orchestration_path"].Sets[0].Rules[0].Actions.DropEvent)
+
+		_, _ = w.Write([]byte(`{"orchestration_path": {"type": "global"}}`))
+	})
+	client := defaultTestClient(server.URL, "foo")
+
+	input := OrchestrationGlobal{
+		Sets: []*OrchestrationGlobalRuleSet{
+			{
+				ID: OrchestrationRuleSetStart,
+				Rules: []*OrchestrationGlobalRule{
+					{
+						Conditions: []*OrchestrationGlobalRuleCondition{{Expression: "cache_var.error_count > 5"}},
+						Actions:    &OrchestrationGlobalRuleActions{DropEvent: true},
+					},
+				},
+			},
+		},
+	}
+
+	res, err := client.UpdateOrchestrationGlobalWithContext(context.Background(), "1", input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, &OrchestrationGlobal{Type: "global"}, res)
+}
+
 func TestServiceOrchestration_Get(t *testing.T) {
 	setup()
 	defer teardown()
@@ -311,3 +502,51 @@ func TestServiceOrchestrationActive_Update(t *testing.T) {
 	}
 	testEqual(t, want, res)
 }
+
+func TestServiceOrchestrationActive_Deactivate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/event_orchestrations/services/1/active", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+		testEqual(t, map[string]interface{}{"active": false}, body)
+
+		_, _ = w.Write([]byte(`{"active": false}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+	res, err := client.UpdateServiceOrchestrationActiveWithContext(context.Background(), "1", ServiceOrchestrationActive{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, &ServiceOrchestrationActive{Active: false}, res)
+}
+
+func TestServiceOrchestrationRuleActions_MarshalJSON(t *testing.T) {
+	a := ServiceOrchestrationRuleActions{
+		Suspend:  300,
+		Severity: RuleActionSeverityCritical,
+		Variables: []*OrchestrationVariable{
+			NewOrchestrationRegexVariable("hostname", "event.summary", "host (.*) is down"),
+		},
+		Extractions: []*OrchestrationExtraction{
+			NewOrchestrationRegexExtraction("event.group", "event.source", "^(\\w+)-"),
+			NewOrchestrationTemplateExtraction("event.summary", "{{variables.hostname}} is down"),
+		},
+	}
+
+	b, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"suspend":300,"severity":"critical",` +
+		`"variables":[{"name":"hostname","path":"event.summary","type":"regex","value":"host (.*) is down"}],` +
+		`"extractions":[{"target":"event.group","regex":"^(\\w+)-","source":"event.source"},{"target":"event.summary","template":"{{variables.hostname}} is down"}]}`
+	testEqual(t, want, string(b))
+}
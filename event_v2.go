@@ -5,7 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 )
 
@@ -25,7 +24,7 @@ type V2Event struct {
 type V2Payload struct {
 	Summary   string      `json:"summary"`
 	Source    string      `json:"source"`
-	Severity  string      `json:"severity"`
+	Severity  Severity    `json:"severity"`
 	Timestamp string      `json:"timestamp,omitempty"`
 	Component string      `json:"component,omitempty"`
 	Group     string      `json:"group,omitempty"`
@@ -41,7 +40,9 @@ type V2EventResponse struct {
 	Errors   []string `json:"errors,omitempty"`
 }
 
-const v2eventEndPoint = "https://events.pagerduty.com/v2/enqueue"
+// defaultEventsAPIV2Client is the client used by the package-level functions
+// sending events.
+var defaultEventsAPIV2Client = NewEventsAPIV2Client()
 
 // ManageEvent handles the trigger, acknowledge, and resolve methods for an
 // event.
@@ -172,54 +173,7 @@ type EventsAPIV2ErrorObject struct {
 
 // ManageEventWithContext handles the trigger, acknowledge, and resolve methods for an event.
 func ManageEventWithContext(ctx context.Context, e V2Event) (*V2EventResponse, error) {
-	data, err := json.Marshal(e)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v2eventEndPoint, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
-	}
-
-	req.Header.Set("User-Agent", "go-pagerduty/"+Version)
-	req.Header.Set("Content-Type", "application/json")
-
-	// TODO(theckman): switch to a package-local default client
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() { _ = resp.Body.Close() }() // explicitly discard error
-	if resp.StatusCode != http.StatusAccepted {
-		b, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, EventsAPIV2Error{
-				StatusCode: resp.StatusCode,
-				message:    fmt.Sprintf("HTTP response with status code: %d: error: %s", resp.StatusCode, err),
-			}
-		}
-		// now try to decode the response body into the error object.
-		var eae EventsAPIV2Error
-		err = json.Unmarshal(b, &eae)
-		if err != nil {
-			eae = EventsAPIV2Error{
-				StatusCode: resp.StatusCode,
-				message:    fmt.Sprintf("HTTP response with status code: %d, JSON unmarshal object body failed: %s, body: %s", resp.StatusCode, err, string(b)),
-			}
-			return nil, eae
-		}
-
-		eae.StatusCode = resp.StatusCode
-		return nil, eae
-	}
-
-	var eventResponse V2EventResponse
-	if err := json.NewDecoder(resp.Body).Decode(&eventResponse); err != nil {
-		return nil, err
-	}
-	return &eventResponse, nil
+	return defaultEventsAPIV2Client.SendWithContext(ctx, e)
 }
 
 // ManageEvent handles the trigger, acknowledge, and resolve methods for an
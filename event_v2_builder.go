@@ -0,0 +1,219 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Values of the Severity field of V2Payload.
+const (
+	V2SeverityCritical = "critical"
+	V2SeverityError    = "error"
+	V2SeverityWarning  = "warning"
+	V2SeverityInfo     = "info"
+)
+
+// Limits of the Events API V2. Events exceeding them are rejected or truncated
+// by PagerDuty.
+const (
+	// MaxV2EventSize is the maximum size in bytes of an event, once encoded
+	// to JSON.
+	MaxV2EventSize = 512 * 1024
+
+	// MaxV2DedupKeyLength is the maximum length of the dedup key of an event.
+	MaxV2DedupKeyLength = 255
+
+	// MaxV2SummaryLength is the maximum length of the summary of an event.
+	MaxV2SummaryLength = maxV2SummaryLength
+)
+
+// Severity is the severity of an alert, such as V2SeverityCritical.
+type Severity string
+
+// IsValid returns whether s is a known severity.
+func (s Severity) IsValid() bool {
+	switch s {
+	case V2SeverityCritical, V2SeverityError, V2SeverityWarning, V2SeverityInfo:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidV2Severity returns whether s is a severity accepted by the Events API
+// V2.
+func ValidV2Severity(s string) bool {
+	return Severity(s).IsValid()
+}
+
+// Validate returns an error if the event would be rejected or altered by the
+// Events API V2, such as if its payload is missing required fields or if it
+// exceeds the size limits.
+func (e V2Event) Validate() error {
+	if len(e.RoutingKey) == 0 {
+		return errors.New("event routing key must be set")
+	}
+
+	if len(e.DedupKey) > MaxV2DedupKeyLength {
+		return fmt.Errorf("event dedup key is %d characters long, the maximum is %d", len(e.DedupKey), MaxV2DedupKeyLength)
+	}
+
+	switch e.Action {
+	case V2EventActionTrigger:
+		if err := e.Payload.validate(); err != nil {
+			return err
+		}
+
+	case V2EventActionAcknowledge, V2EventActionResolve:
+		if len(e.DedupKey) == 0 {
+			return fmt.Errorf("%s event must have a dedup key", e.Action)
+		}
+
+	default:
+		return fmt.Errorf("invalid event action %q", e.Action)
+	}
+
+	for _, i := range e.Images {
+		if img, ok := i.(V2Image); ok && !strings.HasPrefix(img.Src, "https://") {
+			return fmt.Errorf("event image source %q must use HTTPS", img.Src)
+		}
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	if len(data) > MaxV2EventSize {
+		return fmt.Errorf("event is %d bytes long, the maximum is %d", len(data), MaxV2EventSize)
+	}
+
+	return nil
+}
+
+func (p *V2Payload) validate() error {
+	if p == nil {
+		return errors.New("trigger event must have a payload")
+	}
+
+	if len(p.Summary) == 0 {
+		return errors.New("event summary must be set")
+	}
+
+	if len(p.Summary) > MaxV2SummaryLength {
+		return fmt.Errorf("event summary is %d characters long, the maximum is %d", len(p.Summary), MaxV2SummaryLength)
+	}
+
+	if len(p.Source) == 0 {
+		return errors.New("event source must be set")
+	}
+
+	if !p.Severity.IsValid() {
+		return fmt.Errorf("invalid event severity %q", p.Severity)
+	}
+
+	return nil
+}
+
+// V2EventBuilder builds a trigger V2Event. Its methods can be chained, and the
+// event is validated by Build, e.g.:
+//
+//	e, err := NewV2EventBuilder(routingKey, "disk full", "host1", V2SeverityError).
+//		DedupKey("host1/disk").
+//		Detail("free", "0B").
+//		Link("https://grafana.example.com/d/host1", "Dashboard").
+//		Build()
+type V2EventBuilder struct {
+	event   V2Event
+	details map[string]interface{}
+}
+
+// NewV2EventBuilder returns a builder of a trigger event with the given
+// summary, source and severity.
+func NewV2EventBuilder(routingKey, summary, source string, severity Severity) *V2EventBuilder {
+	return &V2EventBuilder{
+		event: V2Event{
+			RoutingKey: routingKey,
+			Action:     V2EventActionTrigger,
+			Payload: &V2Payload{
+				Summary:  summary,
+				Source:   source,
+				Severity: severity,
+			},
+		},
+	}
+}
+
+// DedupKey sets the dedup key of the event.
+func (b *V2EventBuilder) DedupKey(k string) *V2EventBuilder {
+	b.event.DedupKey = k
+	return b
+}
+
+// Timestamp sets the timestamp of the event, in ISO 8601 format.
+func (b *V2EventBuilder) Timestamp(ts string) *V2EventBuilder {
+	b.event.Payload.Timestamp = ts
+	return b
+}
+
+// Component sets the component of the event, such as the name of the
+// affected part of the system.
+func (b *V2EventBuilder) Component(c string) *V2EventBuilder {
+	b.event.Payload.Component = c
+	return b
+}
+
+// Group sets the group of the event, such as the cluster of the source.
+func (b *V2EventBuilder) Group(g string) *V2EventBuilder {
+	b.event.Payload.Group = g
+	return b
+}
+
+// Class sets the class of the event, such as the type of the problem.
+func (b *V2EventBuilder) Class(c string) *V2EventBuilder {
+	b.event.Payload.Class = c
+	return b
+}
+
+// Detail adds a custom detail to the event.
+func (b *V2EventBuilder) Detail(key string, value interface{}) *V2EventBuilder {
+	if b.details == nil {
+		b.details = make(map[string]interface{})
+	}
+
+	b.details[key] = value
+
+	return b
+}
+
+// Image adds an image to the event. href and alt are optional.
+func (b *V2EventBuilder) Image(src, href, alt string) *V2EventBuilder {
+	b.event.Images = append(b.event.Images, V2Image{Src: src, Href: href, Alt: alt})
+	return b
+}
+
+// Link adds a link to the event. text is optional.
+func (b *V2EventBuilder) Link(href, text string) *V2EventBuilder {
+	b.event.Links = append(b.event.Links, V2Link{Href: href, Text: text})
+	return b
+}
+
+// Build returns the event, or an error if it's invalid.
+func (b *V2EventBuilder) Build() (V2Event, error) {
+	e := b.event
+
+	p := *e.Payload
+	if b.details != nil {
+		p.Details = b.details
+	}
+
+	e.Payload = &p
+
+	if err := e.Validate(); err != nil {
+		return V2Event{}, err
+	}
+
+	return e, nil
+}
@@ -0,0 +1,85 @@
+package pagerduty
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestV2EventBuilder(t *testing.T) {
+	e, err := NewV2EventBuilder("key", "disk full", "host1", V2SeverityError).
+		DedupKey("host1/disk").
+		Detail("free", "0B").
+		Image("https://grafana.example.com/disk.png", "", "disk").
+		Link("https://grafana.example.com/d/host1", "Dashboard").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := V2Event{
+		RoutingKey: "key",
+		Action:     V2EventActionTrigger,
+		DedupKey:   "host1/disk",
+		Images:     []interface{}{V2Image{Src: "https://grafana.example.com/disk.png", Alt: "disk"}},
+		Links:      []interface{}{V2Link{Href: "https://grafana.example.com/d/host1", Text: "Dashboard"}},
+		Payload: &V2Payload{
+			Summary:  "disk full",
+			Source:   "host1",
+			Severity: V2SeverityError,
+			Details:  map[string]interface{}{"free": "0B"},
+		},
+	}
+
+	testEqual(t, want, e)
+}
+
+func TestV2Event_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		b    *V2EventBuilder
+		want string
+	}{
+		{
+			name: "severity",
+			b:    NewV2EventBuilder("key", "disk full", "host1", "fatal"),
+			want: `invalid event severity "fatal"`,
+		},
+		{
+			name: "summary",
+			b:    NewV2EventBuilder("key", strings.Repeat("a", MaxV2SummaryLength+1), "host1", V2SeverityInfo),
+			want: "event summary is 1025 characters long",
+		},
+		{
+			name: "dedup_key",
+			b:    NewV2EventBuilder("key", "disk full", "host1", V2SeverityInfo).DedupKey(strings.Repeat("a", 256)),
+			want: "event dedup key is 256 characters long",
+		},
+		{
+			name: "image",
+			b:    NewV2EventBuilder("key", "disk full", "host1", V2SeverityInfo).Image("http://example.com/a.png", "", ""),
+			want: "must use HTTPS",
+		},
+		{
+			name: "size",
+			b:    NewV2EventBuilder("key", "disk full", "host1", V2SeverityInfo).Detail("log", strings.Repeat("a", MaxV2EventSize)),
+			want: "the maximum is 524288",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.b.Build()
+			testErrCheck(t, "Build()", tt.want, err)
+		})
+	}
+
+	err := V2Event{RoutingKey: "key", Action: V2EventActionResolve}.Validate()
+	testErrCheck(t, "Validate()", "resolve event must have a dedup key", err)
+}
+
+func TestSeverity_IsValid(t *testing.T) {
+	testEqual(t, true, Severity(RuleActionSeverityCritical).IsValid())
+	testEqual(t, false, Severity("fatal").IsValid())
+}
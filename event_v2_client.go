@@ -0,0 +1,276 @@
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/PagerDuty/go-pagerduty/oauth"
+)
+
+// Values of the Action field of V2Event.
+const (
+	V2EventActionTrigger     = "trigger"
+	V2EventActionAcknowledge = "acknowledge"
+	V2EventActionResolve     = "resolve"
+)
+
+// V2Image is an image attached to an alert, to be used as an element of the
+// Images field of V2Event.
+type V2Image struct {
+	// Src is the URL of the image. It must use HTTPS.
+	Src string `json:"src"`
+
+	// Href is an optional URL the image links to.
+	Href string `json:"href,omitempty"`
+
+	// Alt is an optional alternative text of the image.
+	Alt string `json:"alt,omitempty"`
+}
+
+// V2Link is a link attached to an alert, to be used as an element of the
+// Links field of V2Event.
+type V2Link struct {
+	Href string `json:"href"`
+	Text string `json:"text,omitempty"`
+}
+
+// EventsAPIV2Client is a client of the Events API V2, which sends alerts to
+// PagerDuty services with routing keys rather than with an API token. Unlike
+// Client, it doesn't need any credentials.
+type EventsAPIV2Client struct {
+	endpoint    string
+	retryPolicy EventsAPIV2RetryPolicy
+	dedupCache  *EventDedupCache
+
+	// HTTPClient is the HTTP client used for making requests against the
+	// Events API V2. You can use either *http.Client here, or your own
+	// implementation.
+	HTTPClient HTTPClient
+}
+
+// EventsAPIV2ClientOptions allows for options to be passed into the
+// EventsAPIV2Client for customization.
+type EventsAPIV2ClientOptions func(*EventsAPIV2Client)
+
+// WithEventsAPIV2ClientEndpoint allows for a custom Events API V2 endpoint to
+// be passed into the client, such as the URL of a proxy.
+func WithEventsAPIV2ClientEndpoint(endpoint string) EventsAPIV2ClientOptions {
+	return func(c *EventsAPIV2Client) {
+		c.endpoint = endpoint
+	}
+}
+
+// WithEventsAPIV2ClientRegion sets the endpoint of the client to the Events
+// API V2 of the given service region, such as oauth.RegionEU.
+func WithEventsAPIV2ClientRegion(region oauth.Region) EventsAPIV2ClientOptions {
+	return func(c *EventsAPIV2Client) {
+		_, c.endpoint = regionEndpoints(region)
+	}
+}
+
+// NewEventsAPIV2Client creates an Events API V2 client.
+func NewEventsAPIV2Client(options ...EventsAPIV2ClientOptions) *EventsAPIV2Client {
+	c := EventsAPIV2Client{
+		endpoint:   v2EventsAPIEndpoint,
+		HTTPClient: defaultHTTPClient,
+	}
+
+	for _, opt := range options {
+		opt(&c)
+	}
+
+	return &c
+}
+
+// SendWithContext sends an event to the Events API V2. If the API rejects the
+// event, the error is an EventsAPIV2Error.
+//
+// If the client has an EventDedupCache and the event is suppressed by it, the
+// event isn't sent and the Status of the response is V2EventStatusSuppressed.
+func (c *EventsAPIV2Client) SendWithContext(ctx context.Context, e V2Event) (*V2EventResponse, error) {
+	var result V2EventResponse
+
+	err := encodeEventsAPIV2Payload(e, func(data []byte) error {
+		// the cache compares the encoded event, so that it isn't encoded twice
+		if c.dedupCache != nil && c.dedupCache.suppress(e, data) {
+			result = V2EventResponse{Status: V2EventStatusSuppressed, DedupKey: e.DedupKey}
+			return nil
+		}
+
+		err := c.postEncoded(ctx, "/v2/enqueue", len(e.DedupKey) > 0, data, &result)
+		if err != nil && c.dedupCache != nil {
+			c.dedupCache.Forget(e.DedupKey)
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateChangeEventWithContext sends a change event, such as a deploy, to the
+// service of the routing key. The RoutingKey field of e is overridden. If the
+// API rejects the event, the error is an EventsAPIV2Error.
+func (c *EventsAPIV2Client) CreateChangeEventWithContext(ctx context.Context, routingKey string, e ChangeEvent) (*ChangeEventResponse, error) {
+	e.RoutingKey = routingKey
+
+	var result ChangeEventResponse
+	if err := c.post(ctx, changeEventPath, false, e, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// post sends the payload to the path, retrying according to the retry policy
+// of the client. idempotent is whether the payload can be received twice
+// without side effects.
+func (c *EventsAPIV2Client) post(ctx context.Context, path string, idempotent bool, payload, result interface{}) error {
+	return encodeEventsAPIV2Payload(payload, func(data []byte) error {
+		return c.postEncoded(ctx, path, idempotent, data, result)
+	})
+}
+
+// postEncoded is like post, with a payload already encoded.
+func (c *EventsAPIV2Client) postEncoded(ctx context.Context, path string, idempotent bool, data []byte, result interface{}) error {
+	return c.retryPolicy.do(ctx, idempotent, func() error {
+		return c.postOnce(ctx, path, data, result)
+	})
+}
+
+// eventsAPIV2Encoder is a buffer with a JSON encoder writing to it.
+type eventsAPIV2Encoder struct {
+	buf bytes.Buffer
+	enc *json.Encoder
+}
+
+// eventsAPIV2EncoderPool pools the encoders of the payloads of the requests,
+// as forwarders send events at high rates.
+var eventsAPIV2EncoderPool = sync.Pool{
+	New: func() interface{} {
+		e := new(eventsAPIV2Encoder)
+		e.enc = json.NewEncoder(&e.buf)
+
+		return e
+	},
+}
+
+// encodeEventsAPIV2Payload encodes payload to JSON with a pooled encoder, and
+// calls f with the encoded payload, which must not be retained after f
+// returns.
+func encodeEventsAPIV2Payload(payload interface{}, f func(data []byte) error) error {
+	e := eventsAPIV2EncoderPool.Get().(*eventsAPIV2Encoder)
+	defer eventsAPIV2EncoderPool.Put(e)
+
+	e.buf.Reset()
+
+	if err := e.enc.Encode(payload); err != nil {
+		return err
+	}
+
+	return f(e.buf.Bytes())
+}
+
+// eventsAPIV2ResponsePool pools the buffers responses are read in, as
+// forwarders send events at high rates.
+var eventsAPIV2ResponsePool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+func (c *EventsAPIV2Client) postOnce(ctx context.Context, path string, data []byte, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+path, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", userAgentHeader)
+	req.Header.Set("Content-Type", contentTypeHeader)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }() // explicitly discard error
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return eventsAPIV2ErrorFromResponse(resp)
+	}
+
+	buf := eventsAPIV2ResponsePool.Get().(*bytes.Buffer)
+	buf.Reset()
+
+	defer eventsAPIV2ResponsePool.Put(buf)
+
+	// reading the body until EOF also lets the connection be reused
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return json.Unmarshal(buf.Bytes(), result)
+}
+
+// TriggerWithContext triggers an alert with the given payload on the service
+// of the routing key. If dedupKey is empty, PagerDuty generates one, which is
+// returned in the response and needed to acknowledge or resolve the alert.
+func (c *EventsAPIV2Client) TriggerWithContext(ctx context.Context, routingKey, dedupKey string, p V2Payload) (*V2EventResponse, error) {
+	return c.SendWithContext(ctx, V2Event{
+		RoutingKey: routingKey,
+		Action:     V2EventActionTrigger,
+		DedupKey:   dedupKey,
+		Payload:    &p,
+	})
+}
+
+// AcknowledgeWithContext acknowledges the alert with the given dedup key on
+// the service of the routing key.
+func (c *EventsAPIV2Client) AcknowledgeWithContext(ctx context.Context, routingKey, dedupKey string) (*V2EventResponse, error) {
+	return c.SendWithContext(ctx, V2Event{
+		RoutingKey: routingKey,
+		Action:     V2EventActionAcknowledge,
+		DedupKey:   dedupKey,
+	})
+}
+
+// ResolveWithContext resolves the alert with the given dedup key on the
+// service of the routing key.
+func (c *EventsAPIV2Client) ResolveWithContext(ctx context.Context, routingKey, dedupKey string) (*V2EventResponse, error) {
+	return c.SendWithContext(ctx, V2Event{
+		RoutingKey: routingKey,
+		Action:     V2EventActionResolve,
+		DedupKey:   dedupKey,
+	})
+}
+
+// eventsAPIV2ErrorFromResponse builds the EventsAPIV2Error of a failed
+// response.
+func eventsAPIV2ErrorFromResponse(resp *http.Response) EventsAPIV2Error {
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return EventsAPIV2Error{
+			StatusCode: resp.StatusCode,
+			message:    fmt.Sprintf("HTTP response with status code: %d: error: %s", resp.StatusCode, err),
+		}
+	}
+
+	// now try to decode the response body into the error object.
+	var eae EventsAPIV2Error
+	if err := json.Unmarshal(b, &eae); err != nil {
+		return EventsAPIV2Error{
+			StatusCode: resp.StatusCode,
+			message:    fmt.Sprintf("HTTP response with status code: %d, JSON unmarshal object body failed: %s, body: %s", resp.StatusCode, err, string(b)),
+		}
+	}
+
+	eae.StatusCode = resp.StatusCode
+
+	return eae
+}
@@ -0,0 +1,119 @@
+package pagerduty
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// V2EventStatusSuppressed is the Status of the V2EventResponse returned by an
+// EventsAPIV2Client when an event is suppressed by its EventDedupCache, and
+// isn't sent.
+const V2EventStatusSuppressed = "suppressed"
+
+// EventDedupCache suppresses the trigger events identical to one sent with the
+// same dedup key within a window, to reduce the volume of events sent by
+// chatty producers. Acknowledge and resolve events are never suppressed, and
+// reset the window of their dedup key. Events without a dedup key are never
+// suppressed either.
+//
+// It's safe for concurrent use, and can be shared by several clients with
+// WithEventsAPIV2ClientDedupCache.
+type EventDedupCache struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	entries   map[string]eventDedupEntry
+	lastPrune time.Time
+}
+
+type eventDedupEntry struct {
+	sum    [sha256.Size]byte
+	sentAt time.Time
+}
+
+// NewEventDedupCache creates an EventDedupCache suppressing identical trigger
+// events for window after they're sent.
+func NewEventDedupCache(window time.Duration) *EventDedupCache {
+	return &EventDedupCache{
+		window:  window,
+		now:     time.Now,
+		entries: make(map[string]eventDedupEntry),
+	}
+}
+
+// WithEventsAPIV2ClientDedupCache sets the cache used by the client to
+// suppress duplicate trigger events.
+func WithEventsAPIV2ClientDedupCache(cache *EventDedupCache) EventsAPIV2ClientOptions {
+	return func(c *EventsAPIV2Client) {
+		c.dedupCache = cache
+	}
+}
+
+// Suppress returns whether e should be suppressed. Otherwise, it records e as
+// sent.
+func (c *EventDedupCache) Suppress(e V2Event) bool {
+	var suppressed bool
+
+	_ = encodeEventsAPIV2Payload(e, func(data []byte) error {
+		suppressed = c.suppress(e, data)
+		return nil
+	})
+
+	return suppressed
+}
+
+// suppress is like Suppress, with the event already encoded to data.
+func (c *EventDedupCache) suppress(e V2Event, data []byte) bool {
+	if len(e.DedupKey) == 0 {
+		return false
+	}
+
+	now := c.now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.prune(now)
+
+	if e.Action != V2EventActionTrigger {
+		delete(c.entries, e.DedupKey)
+		return false
+	}
+
+	sum := sha256.Sum256(data)
+
+	if entry, ok := c.entries[e.DedupKey]; ok && entry.sum == sum && now.Sub(entry.sentAt) < c.window {
+		return true
+	}
+
+	c.entries[e.DedupKey] = eventDedupEntry{sum: sum, sentAt: now}
+
+	return false
+}
+
+// Forget removes the dedup key from the cache, so that the next trigger event
+// with it is sent, such as after failing to send an event.
+func (c *EventDedupCache) Forget(dedupKey string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, dedupKey)
+}
+
+// prune removes the expired entries, at most once per window. c.mu must be
+// held.
+func (c *EventDedupCache) prune(now time.Time) {
+	if now.Sub(c.lastPrune) < c.window {
+		return
+	}
+
+	for k, entry := range c.entries {
+		if now.Sub(entry.sentAt) >= c.window {
+			delete(c.entries, k)
+		}
+	}
+
+	c.lastPrune = now
+}
@@ -0,0 +1,75 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestEventDedupCache_Suppress(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	c := NewEventDedupCache(time.Minute)
+	c.now = func() time.Time { return now }
+
+	trigger := V2Event{RoutingKey: "key", Action: V2EventActionTrigger, DedupKey: "a", Payload: &V2Payload{Summary: "disk full"}}
+
+	testEqual(t, false, c.Suppress(trigger))
+	testEqual(t, true, c.Suppress(trigger))
+
+	// a different payload isn't a duplicate
+	changed := trigger
+	changed.Payload = &V2Payload{Summary: "disk still full"}
+	testEqual(t, false, c.Suppress(changed))
+	testEqual(t, true, c.Suppress(changed))
+
+	// the window expired
+	now = now.Add(time.Minute)
+	testEqual(t, false, c.Suppress(changed))
+
+	// resolving the alert resets the window
+	testEqual(t, false, c.Suppress(V2Event{Action: V2EventActionResolve, DedupKey: "a"}))
+	testEqual(t, false, c.Suppress(changed))
+
+	c.Forget("a")
+	testEqual(t, false, c.Suppress(changed))
+
+	testEqual(t, false, c.Suppress(V2Event{Action: V2EventActionTrigger}))
+	testEqual(t, false, c.Suppress(V2Event{Action: V2EventActionTrigger}))
+}
+
+func TestEventsAPIV2Client_DedupCache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent int
+
+	mux.HandleFunc("/v2/enqueue", func(w http.ResponseWriter, r *http.Request) {
+		sent++
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status": "success", "dedup_key": "a"}`))
+	})
+
+	client := NewEventsAPIV2Client(
+		WithEventsAPIV2ClientEndpoint(server.URL),
+		WithEventsAPIV2ClientDedupCache(NewEventDedupCache(time.Hour)),
+	)
+
+	p := V2Payload{Summary: "disk full", Source: "host1", Severity: V2SeverityError}
+
+	res, err := client.TriggerWithContext(context.Background(), "key", "a", p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "success", res.Status)
+
+	res, err = client.TriggerWithContext(context.Background(), "key", "a", p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, V2EventStatusSuppressed, res.Status)
+	testEqual(t, 1, sent)
+}
@@ -0,0 +1,207 @@
+package pagerduty
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// KubernetesObjectReference identifies the Kubernetes object an event or
+// condition is about. It mirrors the fields of the Kubernetes
+// ObjectReference type that are needed to build a V2 event, so that this
+// package doesn't have to depend on the Kubernetes client libraries.
+type KubernetesObjectReference struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// KubernetesEvent is the subset of a Kubernetes Event (core/v1 or
+// events.k8s.io/v1) used to build a V2 event. Type is expected to be either
+// "Normal" or "Warning", as in Kubernetes.
+type KubernetesEvent struct {
+	InvolvedObject KubernetesObjectReference
+	Type           string
+	Reason         string
+	Message        string
+	Source         string
+	Count          int32
+	LastTimestamp  time.Time
+}
+
+// KubernetesCondition is the subset of a Kubernetes object status condition
+// (e.g., a Node's Ready condition) used to build a V2 event.
+type KubernetesCondition struct {
+	Type               string
+	Status             string
+	Reason             string
+	Message            string
+	LastTransitionTime time.Time
+}
+
+// NewV2EventFromKubernetesEvent builds a trigger V2Event from a Kubernetes
+// Event. The dedup key is derived from the namespace, kind, name, and reason of
+// the event so that repeated occurrences of the same event are grouped into a
+// single alert.
+//
+// Warning events have a severity of warning, and all other events have a
+// severity of info. The Source field of the event is used as the payload
+// source, falling back to "kubernetes" if it's empty.
+func NewV2EventFromKubernetesEvent(routingKey string, e KubernetesEvent) V2Event {
+	obj := e.InvolvedObject
+
+	severity := Severity(V2SeverityInfo)
+	if e.Type == "Warning" {
+		severity = V2SeverityWarning
+	}
+
+	details := kubernetesEventDetails(obj)
+	details["type"] = e.Type
+	details["reason"] = e.Reason
+	details["message"] = e.Message
+	if e.Count > 0 {
+		details["count"] = e.Count
+	}
+
+	source := e.Source
+	if len(source) == 0 {
+		source = kubernetesDefaultSource
+	}
+
+	var timestamp string
+	if !e.LastTimestamp.IsZero() {
+		timestamp = e.LastTimestamp.UTC().Format(time.RFC3339)
+	}
+
+	return V2Event{
+		RoutingKey: routingKey,
+		Action:     "trigger",
+		DedupKey:   KubernetesDedupKey(obj, e.Reason),
+		Payload: &V2Payload{
+			Summary:   kubernetesSummary(obj, e.Reason, e.Message),
+			Source:    source,
+			Severity:  severity,
+			Timestamp: timestamp,
+			Component: obj.Name,
+			Group:     obj.Namespace,
+			Class:     obj.Kind,
+			Details:   details,
+		},
+	}
+}
+
+// NewV2EventFromKubernetesCondition builds a V2Event from a status condition
+// of a Kubernetes object. If the condition's Status equals healthyStatus (e.g.,
+// "True" for a Node's Ready condition, or "False" for its MemoryPressure
+// condition), a resolve event is returned. Otherwise, a trigger event with a
+// severity of error is returned.
+//
+// The dedup key is derived from the namespace, kind, and name of the object
+// and the condition type, so that the trigger and resolve events for one
+// condition target the same alert.
+func NewV2EventFromKubernetesCondition(routingKey string, obj KubernetesObjectReference, c KubernetesCondition, healthyStatus string) V2Event {
+	dedupKey := KubernetesDedupKey(obj, c.Type)
+
+	if c.Status == healthyStatus {
+		return V2Event{
+			RoutingKey: routingKey,
+			Action:     "resolve",
+			DedupKey:   dedupKey,
+		}
+	}
+
+	details := kubernetesEventDetails(obj)
+	details["condition"] = c.Type
+	details["status"] = c.Status
+	details["reason"] = c.Reason
+	details["message"] = c.Message
+
+	var timestamp string
+	if !c.LastTransitionTime.IsZero() {
+		timestamp = c.LastTransitionTime.UTC().Format(time.RFC3339)
+	}
+
+	msg := c.Message
+	if len(msg) == 0 {
+		msg = c.Type + " is " + c.Status
+	}
+
+	return V2Event{
+		RoutingKey: routingKey,
+		Action:     "trigger",
+		DedupKey:   dedupKey,
+		Payload: &V2Payload{
+			Summary:   kubernetesSummary(obj, c.Type, msg),
+			Source:    kubernetesDefaultSource,
+			Severity:  "error",
+			Timestamp: timestamp,
+			Component: obj.Name,
+			Group:     obj.Namespace,
+			Class:     obj.Kind,
+			Details:   details,
+		},
+	}
+}
+
+// KubernetesDedupKey returns the dedup key used for events about the given
+// object, in the form namespace/kind/name/reason. Cluster-scoped objects,
+// which have no namespace, omit the leading namespace element.
+func KubernetesDedupKey(obj KubernetesObjectReference, reason string) string {
+	parts := make([]string, 0, 4)
+	if len(obj.Namespace) > 0 {
+		parts = append(parts, obj.Namespace)
+	}
+
+	parts = append(parts, obj.Kind, obj.Name, reason)
+
+	return strings.Join(parts, "/")
+}
+
+// kubernetesDefaultSource is the V2Payload source used when the Kubernetes
+// event doesn't specify the component that reported it.
+const kubernetesDefaultSource = "kubernetes"
+
+// maxV2SummaryLength is the maximum length of a V2Payload summary accepted
+// by the Events API. Longer summaries are truncated by PagerDuty.
+const maxV2SummaryLength = 1024
+
+func kubernetesSummary(obj KubernetesObjectReference, reason, message string) string {
+	name := obj.Kind + " " + obj.Name
+	if len(obj.Namespace) > 0 {
+		name = obj.Kind + " " + obj.Namespace + "/" + obj.Name
+	}
+
+	s := reason + ": " + name
+	if len(message) > 0 {
+		s += ": " + message
+	}
+
+	return truncateUTF8(s, maxV2SummaryLength)
+}
+
+// truncateUTF8 truncates s to at most n bytes, without cutting a multi-byte
+// UTF-8 character in half.
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return s[:n]
+}
+
+func kubernetesEventDetails(obj KubernetesObjectReference) map[string]interface{} {
+	d := map[string]interface{}{
+		"kind": obj.Kind,
+		"name": obj.Name,
+	}
+
+	if len(obj.Namespace) > 0 {
+		d["namespace"] = obj.Namespace
+	}
+
+	return d
+}
@@ -0,0 +1,121 @@
+package pagerduty
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestNewV2EventFromKubernetesEvent(t *testing.T) {
+	ts := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	e := KubernetesEvent{
+		InvolvedObject: KubernetesObjectReference{
+			Kind:      "Pod",
+			Namespace: "default",
+			Name:      "web-0",
+		},
+		Type:          "Warning",
+		Reason:        "BackOff",
+		Message:       "Back-off restarting failed container",
+		Count:         3,
+		LastTimestamp: ts,
+	}
+
+	got := NewV2EventFromKubernetesEvent("abc123", e)
+
+	want := V2Event{
+		RoutingKey: "abc123",
+		Action:     "trigger",
+		DedupKey:   "default/Pod/web-0/BackOff",
+		Payload: &V2Payload{
+			Summary:   "BackOff: Pod default/web-0: Back-off restarting failed container",
+			Source:    "kubernetes",
+			Severity:  "warning",
+			Timestamp: "2023-01-02T03:04:05Z",
+			Component: "web-0",
+			Group:     "default",
+			Class:     "Pod",
+			Details: map[string]interface{}{
+				"kind":      "Pod",
+				"namespace": "default",
+				"name":      "web-0",
+				"type":      "Warning",
+				"reason":    "BackOff",
+				"message":   "Back-off restarting failed container",
+				"count":     int32(3),
+			},
+		},
+	}
+
+	testEqual(t, want, got)
+}
+
+func TestNewV2EventFromKubernetesCondition(t *testing.T) {
+	node := KubernetesObjectReference{Kind: "Node", Name: "node-1"}
+
+	t.Run("unhealthy", func(t *testing.T) {
+		c := KubernetesCondition{
+			Type:   "Ready",
+			Status: "False",
+			Reason: "KubeletNotReady",
+		}
+
+		got := NewV2EventFromKubernetesCondition("abc123", node, c, "True")
+
+		want := V2Event{
+			RoutingKey: "abc123",
+			Action:     "trigger",
+			DedupKey:   "Node/node-1/Ready",
+			Payload: &V2Payload{
+				Summary:   "Ready: Node node-1: Ready is False",
+				Source:    "kubernetes",
+				Severity:  "error",
+				Component: "node-1",
+				Class:     "Node",
+				Details: map[string]interface{}{
+					"kind":      "Node",
+					"name":      "node-1",
+					"condition": "Ready",
+					"status":    "False",
+					"reason":    "KubeletNotReady",
+					"message":   "",
+				},
+			},
+		}
+
+		testEqual(t, want, got)
+	})
+
+	t.Run("healthy", func(t *testing.T) {
+		c := KubernetesCondition{
+			Type:   "Ready",
+			Status: "True",
+		}
+
+		got := NewV2EventFromKubernetesCondition("abc123", node, c, "True")
+
+		want := V2Event{
+			RoutingKey: "abc123",
+			Action:     "resolve",
+			DedupKey:   "Node/node-1/Ready",
+		}
+
+		testEqual(t, want, got)
+	})
+}
+
+func TestKubernetesSummary_truncated(t *testing.T) {
+	pod := KubernetesObjectReference{Kind: "Pod", Name: "web-10"}
+
+	// "é" is 2 bytes, and the prefix "BackOff: Pod web-10: " 21, so the
+	// limit falls in the middle of one
+	message := strings.Repeat("é", maxV2SummaryLength)
+
+	got := kubernetesSummary(pod, "BackOff", message)
+
+	testEqual(t, true, utf8.ValidString(got))
+	testEqual(t, true, len(got) <= maxV2SummaryLength)
+	testEqual(t, true, len(got) >= maxV2SummaryLength-1)
+}
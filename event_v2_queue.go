@@ -0,0 +1,191 @@
+package pagerduty
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrEventQueueClosed is returned by EventQueue.Shutdown when the queue was
+// already shut down.
+var ErrEventQueueClosed = errors.New("event queue is closed")
+
+// EventQueueConfig configures an EventQueue.
+type EventQueueConfig struct {
+	// Workers is the number of events sent concurrently. It defaults to 4.
+	Workers int
+
+	// Size is the number of events that can wait to be sent, beyond which
+	// events are dropped. It defaults to 1000.
+	Size int
+
+	// OnDelivery, if set, is called by the workers after each event is sent,
+	// with the error of the last attempt if it couldn't be delivered.
+	OnDelivery func(e V2Event, res *V2EventResponse, err error)
+
+	// OnDrop, if set, is called when an event is dropped because the queue is
+	// full or closed.
+	OnDrop func(e V2Event)
+}
+
+// EventQueue sends events asynchronously with a pool of workers, for
+// high-throughput alert forwarders which can't block on the Events API.
+// Transient failures are retried according to the retry policy of its
+// EventsAPIV2Client, see WithEventsAPIV2ClientRetryPolicy.
+type EventQueue struct {
+	client *EventsAPIV2Client
+	config EventQueueConfig
+
+	events chan V2Event
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	closed  bool
+	pending int
+	idle    chan struct{}
+	dropped uint64
+}
+
+// NewEventQueue creates an EventQueue sending events with client, and starts
+// its workers. It must be shut down with Shutdown.
+func NewEventQueue(client *EventsAPIV2Client, config EventQueueConfig) *EventQueue {
+	if config.Workers <= 0 {
+		config.Workers = 4
+	}
+
+	if config.Size <= 0 {
+		config.Size = 1000
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	q := &EventQueue{
+		client: client,
+		config: config,
+		events: make(chan V2Event, config.Size),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	q.wg.Add(config.Workers)
+	for i := 0; i < config.Workers; i++ {
+		go q.work()
+	}
+
+	return q
+}
+
+// Enqueue queues an event to be sent without blocking. It returns false if
+// the event was dropped because the queue is full or closed.
+func (q *EventQueue) Enqueue(e V2Event) bool {
+	q.mu.Lock()
+
+	if !q.closed {
+		select {
+		case q.events <- e:
+			if q.pending == 0 {
+				q.idle = make(chan struct{})
+			}
+
+			q.pending++
+			q.mu.Unlock()
+
+			return true
+
+		default:
+		}
+	}
+
+	q.dropped++
+	q.mu.Unlock()
+
+	if q.config.OnDrop != nil {
+		q.config.OnDrop(e)
+	}
+
+	return false
+}
+
+// Dropped returns the number of events dropped since the queue was created.
+func (q *EventQueue) Dropped() uint64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.dropped
+}
+
+// Flush waits until all the events queued so far are sent, or until ctx is
+// done.
+func (q *EventQueue) Flush(ctx context.Context) error {
+	q.mu.Lock()
+	if q.pending == 0 {
+		q.mu.Unlock()
+		return nil
+	}
+
+	idle := q.idle
+	q.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown stops accepting events and waits until the queued events are
+// sent. If ctx is done first, the events being sent are canceled, the
+// remaining ones are reported as failed to OnDelivery, and the error of ctx
+// is returned.
+func (q *EventQueue) Shutdown(ctx context.Context) error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return ErrEventQueueClosed
+	}
+
+	q.closed = true
+	close(q.events)
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		q.cancel()
+		return nil
+
+	case <-ctx.Done():
+		q.cancel()
+		<-done
+
+		return ctx.Err()
+	}
+}
+
+func (q *EventQueue) work() {
+	defer q.wg.Done()
+
+	for e := range q.events {
+		res, err := q.client.SendWithContext(q.ctx, e)
+
+		if q.config.OnDelivery != nil {
+			q.config.OnDelivery(e, res, err)
+		}
+
+		q.mu.Lock()
+		q.pending--
+		if q.pending == 0 {
+			close(q.idle)
+		}
+		q.mu.Unlock()
+	}
+}
@@ -0,0 +1,114 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEventQueue(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/enqueue", func(w http.ResponseWriter, r *http.Request) {
+		var e V2Event
+		if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status": "success", "dedup_key": "` + e.DedupKey + `"}`))
+	})
+
+	var (
+		mu        sync.Mutex
+		delivered []string
+	)
+
+	q := NewEventQueue(NewEventsAPIV2Client(WithEventsAPIV2ClientEndpoint(server.URL)), EventQueueConfig{
+		Workers: 2,
+		OnDelivery: func(e V2Event, res *V2EventResponse, err error) {
+			if err != nil {
+				t.Errorf("failed to send event %s: %s", e.DedupKey, err)
+				return
+			}
+
+			mu.Lock()
+			delivered = append(delivered, res.DedupKey)
+			mu.Unlock()
+		},
+	})
+
+	for _, k := range []string{"a", "b", "c"} {
+		testEqual(t, true, q.Enqueue(V2Event{RoutingKey: "key", Action: V2EventActionResolve, DedupKey: k}))
+	}
+
+	if err := q.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	testEqual(t, 3, len(delivered))
+	mu.Unlock()
+
+	if err := q.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, false, q.Enqueue(V2Event{}))
+	testEqual(t, uint64(1), q.Dropped())
+	testErrCheck(t, "q.Shutdown()", ErrEventQueueClosed.Error(), q.Shutdown(context.Background()))
+}
+
+func TestEventQueue_ShutdownTimeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	started := make(chan struct{}, 2)
+	unblock := make(chan struct{})
+	defer close(unblock)
+
+	mux.HandleFunc("/v2/enqueue", func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+
+		select {
+		case <-unblock:
+		case <-r.Context().Done():
+		}
+	})
+
+	var dropped int
+
+	errs := make(chan error, 2)
+
+	q := NewEventQueue(NewEventsAPIV2Client(WithEventsAPIV2ClientEndpoint(server.URL)), EventQueueConfig{
+		Workers:    1,
+		Size:       1,
+		OnDelivery: func(e V2Event, res *V2EventResponse, err error) { errs <- err },
+		OnDrop:     func(e V2Event) { dropped++ },
+	})
+
+	testEqual(t, true, q.Enqueue(V2Event{DedupKey: "a"}))
+
+	// wait for the worker to send the first event, so that the second one
+	// fills the queue
+	<-started
+
+	testEqual(t, true, q.Enqueue(V2Event{DedupKey: "b"}))
+	testEqual(t, false, q.Enqueue(V2Event{DedupKey: "c"}))
+	testEqual(t, 1, dropped)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	testErrCheck(t, "q.Shutdown()", context.DeadlineExceeded.Error(), q.Shutdown(ctx))
+
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err == nil {
+			t.Fatal("expected a delivery error")
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package pagerduty
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// EventsAPIV2RetryPolicy configures how the EventsAPIV2Client retries sending
+// events that failed temporarily, such as when it's rate limited or when the
+// Events API returns a 5xx status code. It's separate from the REST API, as
+// sending an event with a dedup key is idempotent.
+//
+// Events whose delivery is unknown, because of a network error after the
+// request may have been received, are only retried if they have a dedup key,
+// to not create duplicate alerts.
+type EventsAPIV2RetryPolicy struct {
+	// MaxAttempts is the maximum number of times an event is sent, including
+	// the first attempt. Values below 2 disable retries.
+	MaxAttempts int
+
+	// MinBackoff is the time waited before the first retry. It doubles after
+	// every attempt.
+	MinBackoff time.Duration
+
+	// MaxBackoff is the maximum time waited between two attempts.
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction of the backoff that's randomized, between 0 and
+	// 1, so that many senders failing at once don't retry in lockstep.
+	Jitter float64
+}
+
+// DefaultEventsAPIV2RetryPolicy is a retry policy suited to most senders,
+// following the recommendations of PagerDuty to retry with backoff for about
+// a minute.
+var DefaultEventsAPIV2RetryPolicy = EventsAPIV2RetryPolicy{
+	MaxAttempts: 5,
+	MinBackoff:  time.Second,
+	MaxBackoff:  30 * time.Second,
+	Jitter:      0.2,
+}
+
+// WithEventsAPIV2ClientRetryPolicy sets the retry policy of the client. By
+// default, events aren't retried.
+func WithEventsAPIV2ClientRetryPolicy(p EventsAPIV2RetryPolicy) EventsAPIV2ClientOptions {
+	return func(c *EventsAPIV2Client) {
+		c.retryPolicy = p
+	}
+}
+
+// backoff returns the time to wait before attempt n, starting at 2 for the
+// first retry.
+func (p EventsAPIV2RetryPolicy) backoff(n int) time.Duration {
+	return retryBackoff(n, p.MinBackoff, p.MaxBackoff, p.Jitter)
+}
+
+// retryable returns whether the error of a send may succeed if retried.
+// idempotent is whether the event can be sent twice without side effects.
+func (p EventsAPIV2RetryPolicy) retryable(err error, idempotent bool) bool {
+	var eae EventsAPIV2Error
+	if errors.As(err, &eae) {
+		return eae.Temporary()
+	}
+
+	// the request may have been received, and canceled requests must not be
+	// retried
+	return idempotent && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// do calls send until it succeeds, returns an error that isn't retryable, or
+// the attempts are exhausted. It returns the error of the last attempt.
+func (p EventsAPIV2RetryPolicy) do(ctx context.Context, idempotent bool, send func() error) error {
+	err := send()
+
+	for n := 2; n <= p.MaxAttempts && err != nil && p.retryable(err, idempotent); n++ {
+		t := time.NewTimer(p.backoff(n))
+
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return err
+
+		case <-t.C:
+		}
+
+		err = send()
+	}
+
+	return err
+}
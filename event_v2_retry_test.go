@@ -0,0 +1,80 @@
+package pagerduty
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestEventsAPIV2RetryPolicy_Backoff(t *testing.T) {
+	p := EventsAPIV2RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	testEqual(t, time.Second, p.backoff(2))
+	testEqual(t, 2*time.Second, p.backoff(3))
+	testEqual(t, 4*time.Second, p.backoff(4))
+	testEqual(t, 5*time.Second, p.backoff(5))
+
+	p.Jitter = 0.5
+
+	for i := 0; i < 10; i++ {
+		if d := p.backoff(3); d < time.Second || d > 2*time.Second {
+			t.Fatalf("p.backoff(3) = %s, want between 1s and 2s", d)
+		}
+	}
+}
+
+func TestEventsAPIV2RetryPolicy_Retryable(t *testing.T) {
+	var p EventsAPIV2RetryPolicy
+
+	testEqual(t, true, p.retryable(EventsAPIV2Error{StatusCode: http.StatusTooManyRequests}, false))
+	testEqual(t, false, p.retryable(EventsAPIV2Error{StatusCode: http.StatusBadRequest}, true))
+	testEqual(t, true, p.retryable(errors.New("connection reset"), true))
+	testEqual(t, false, p.retryable(errors.New("connection reset"), false))
+	testEqual(t, false, p.retryable(context.Canceled, true))
+}
+
+func TestEventsAPIV2Client_Retry(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var attempts int
+
+	mux.HandleFunc("/v2/enqueue", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+
+		if attempts < 3 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"status": "throttle exceeded", "message": "Requests for this service are arriving too quickly."}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status": "success", "dedup_key": "abc"}`))
+	})
+
+	client := NewEventsAPIV2Client(
+		WithEventsAPIV2ClientEndpoint(server.URL),
+		WithEventsAPIV2ClientRetryPolicy(EventsAPIV2RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}),
+	)
+
+	res, err := client.AcknowledgeWithContext(context.Background(), "key", "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 3, attempts)
+	testEqual(t, "abc", res.DedupKey)
+
+	attempts = -10
+
+	_, err = client.AcknowledgeWithContext(context.Background(), "key", "abc")
+
+	var eae EventsAPIV2Error
+	if !errors.As(err, &eae) || !eae.RateLimited() {
+		t.Fatalf("err = %v, want rate limited EventsAPIV2Error", err)
+	}
+
+	testEqual(t, -7, attempts)
+}
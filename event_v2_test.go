@@ -1,7 +1,9 @@
 package pagerduty
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -381,3 +383,121 @@ func TestEventsAPIV2Error_UnmarshalJSON(t *testing.T) {
 		t.Error("exepcted error not seen")
 	}
 }
+
+func TestEventsAPIV2Client_Trigger(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/enqueue", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testEqual(t, "", r.Header.Get("Authorization"))
+
+		var e V2Event
+		if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		testEqual(t, V2EventActionTrigger, e.Action)
+		testEqual(t, "disk full", e.Payload.Summary)
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status": "success", "dedup_key": "abc", "message": "Event processed"}`))
+	})
+
+	client := NewEventsAPIV2Client(WithEventsAPIV2ClientEndpoint(server.URL))
+
+	res, err := client.TriggerWithContext(context.Background(), "key", "", V2Payload{Summary: "disk full", Source: "host1", Severity: "error"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "abc", res.DedupKey)
+}
+
+func TestEventsAPIV2Client_Error(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/enqueue", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"status": "invalid event", "message": "Event object is invalid", "errors": ["'dedup_key' is missing"]}`))
+	})
+
+	client := NewEventsAPIV2Client(WithEventsAPIV2ClientEndpoint(server.URL))
+
+	_, err := client.ResolveWithContext(context.Background(), "key", "")
+
+	var eae EventsAPIV2Error
+	if !errors.As(err, &eae) {
+		t.Fatalf("err = %v, want EventsAPIV2Error", err)
+	}
+
+	testEqual(t, true, eae.BadRequest())
+	testEqual(t, "Event object is invalid", eae.APIError.ErrorObject.Message)
+}
+
+func BenchmarkEventsAPIV2Client_Send(b *testing.B) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/enqueue", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "success", "dedup_key": "yes", "message": "Event processed"}`))
+	})
+
+	client := NewEventsAPIV2Client(WithEventsAPIV2ClientEndpoint(server.URL))
+
+	e := V2Event{
+		RoutingKey: "key",
+		Action:     V2EventActionTrigger,
+		Payload: &V2Payload{
+			Summary:  "disk full",
+			Source:   "db1",
+			Severity: V2SeverityCritical,
+			Details:  map[string]string{"disk": "/dev/sda1"},
+		},
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := client.SendWithContext(context.Background(), e); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkEncodeEventsAPIV2Payload(b *testing.B) {
+	e := V2Event{
+		RoutingKey: "key",
+		Action:     V2EventActionTrigger,
+		Payload: &V2Payload{
+			Summary:  "disk full",
+			Source:   "db1",
+			Severity: V2SeverityCritical,
+			Details:  map[string]string{"disk": "/dev/sda1"},
+		},
+	}
+
+	b.Run("marshal", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal(e); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			if err := encodeEventsAPIV2Payload(e, func([]byte) error { return nil }); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,219 @@
+// Package export provides functionality for backing up the configuration of a
+// PagerDuty account, such as for disaster recovery.
+//
+// Export walks the teams, users, escalation policies, schedules, services and
+// their integrations, and rulesets of an account into a Snapshot, which can be
+// written as JSON or YAML with Write and read back with Read:
+//
+//	snap, err := export.Export(ctx, client, export.Options{})
+//	if err != nil {
+//		return err
+//	}
+//	return export.Write(f, snap, export.FormatYAML)
+package export
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// SnapshotVersion is the version of the format of the snapshots written by
+// this package.
+const SnapshotVersion = 1
+
+// Values of the Resources field of Options.
+const (
+	ResourceTeams              = "teams"
+	ResourceUsers              = "users"
+	ResourceEscalationPolicies = "escalation_policies"
+	ResourceSchedules          = "schedules"
+	ResourceServices           = "services"
+	ResourceRulesets           = "rulesets"
+)
+
+// AllResources are the resources exported when Options.Resources is empty.
+var AllResources = []string{
+	ResourceTeams,
+	ResourceUsers,
+	ResourceEscalationPolicies,
+	ResourceSchedules,
+	ResourceServices,
+	ResourceRulesets,
+}
+
+// Snapshot is the configuration of a PagerDuty account at a point in time.
+type Snapshot struct {
+	Version            int                          `json:"version"`
+	CreatedAt          time.Time                    `json:"created_at"`
+	Teams              []pagerduty.Team             `json:"teams,omitempty"`
+	Users              []pagerduty.User             `json:"users,omitempty"`
+	EscalationPolicies []pagerduty.EscalationPolicy `json:"escalation_policies,omitempty"`
+	Schedules          []pagerduty.Schedule         `json:"schedules,omitempty"`
+
+	// Services include their integrations.
+	Services []pagerduty.Service `json:"services,omitempty"`
+	Rulesets []Ruleset           `json:"rulesets,omitempty"`
+}
+
+// Ruleset is a global event ruleset with its rules.
+type Ruleset struct {
+	pagerduty.Ruleset
+	Rules []*pagerduty.RulesetRule `json:"rules,omitempty"`
+}
+
+// Options are the options of Export.
+type Options struct {
+	// Resources are the kinds of resources to export, which are all of
+	// AllResources if empty.
+	Resources []string
+
+	// MaxRetries is the number of times a request which failed temporarily,
+	// such as by being rate limited, is retried with the backoff of
+	// pagerduty.DefaultRetryPolicy. It defaults to 5, and negative values
+	// disable retries.
+	MaxRetries int
+}
+
+// retryPolicy is the policy of the retries of the requests, whose attempts are
+// set by the MaxRetries field of Options. It's a variable to be shortened by
+// tests.
+var retryPolicy = pagerduty.DefaultRetryPolicy
+
+// Export walks the resources of the account of the client into a Snapshot.
+// Lists are paginated, and requests which are rate limited are retried.
+func Export(ctx context.Context, client *pagerduty.Client, o Options) (*Snapshot, error) {
+	resources := o.Resources
+	if len(resources) == 0 {
+		resources = AllResources
+	}
+
+	maxRetries := o.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = 5
+	}
+
+	e := exporter{client: client, retryPolicy: retryPolicy}
+	e.retryPolicy.MaxAttempts = maxRetries + 1
+
+	s := &Snapshot{
+		Version:   SnapshotVersion,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	for _, r := range resources {
+		var err error
+
+		switch r {
+		case ResourceTeams:
+			err = e.retryPolicy.Do(ctx, func() (err error) {
+				s.Teams, err = client.ListTeamsPaginated(ctx, pagerduty.ListTeamOptions{})
+				return err
+			})
+
+		case ResourceUsers:
+			err = e.retryPolicy.Do(ctx, func() (err error) {
+				s.Users, err = client.ListUsersPaginated(ctx, pagerduty.ListUsersOptions{
+					Includes: []string{pagerduty.UserIncludeContactMethods, pagerduty.UserIncludeNotificationRules},
+				})
+				return err
+			})
+
+		case ResourceEscalationPolicies:
+			err = e.retryPolicy.Do(ctx, func() (err error) {
+				s.EscalationPolicies, err = client.ListEscalationPoliciesPaginated(ctx, pagerduty.ListEscalationPoliciesOptions{})
+				return err
+			})
+
+		case ResourceSchedules:
+			s.Schedules, err = e.schedules(ctx)
+
+		case ResourceServices:
+			err = e.retryPolicy.Do(ctx, func() (err error) {
+				s.Services, err = client.ListServicesPaginated(ctx, pagerduty.ListServiceOptions{
+					Includes: []string{"integrations"},
+				})
+				return err
+			})
+
+		case ResourceRulesets:
+			s.Rulesets, err = e.rulesets(ctx)
+
+		default:
+			return nil, fmt.Errorf("unknown resource %q", r)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to export %s: %w", r, err)
+		}
+	}
+
+	return s, nil
+}
+
+type exporter struct {
+	client      *pagerduty.Client
+	retryPolicy pagerduty.RetryPolicy
+}
+
+// schedules lists the schedules, then gets each of them as the list doesn't
+// include their layers.
+func (e exporter) schedules(ctx context.Context) ([]pagerduty.Schedule, error) {
+	var list []pagerduty.Schedule
+
+	err := e.retryPolicy.Do(ctx, func() (err error) {
+		list, err = e.client.ListSchedulesPaginated(ctx, pagerduty.ListSchedulesOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := make([]pagerduty.Schedule, len(list))
+
+	for i, s := range list {
+		var sched *pagerduty.Schedule
+
+		err := e.retryPolicy.Do(ctx, func() (err error) {
+			sched, err = e.client.GetScheduleWithContext(ctx, s.ID, pagerduty.GetScheduleOptions{})
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("schedule %s: %w", s.ID, err)
+		}
+
+		schedules[i] = *sched
+	}
+
+	return schedules, nil
+}
+
+func (e exporter) rulesets(ctx context.Context) ([]Ruleset, error) {
+	var list []*pagerduty.Ruleset
+
+	err := e.retryPolicy.Do(ctx, func() (err error) {
+		list, err = e.client.ListRulesetsPaginated(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rulesets := make([]Ruleset, len(list))
+
+	for i, rs := range list {
+		rulesets[i].Ruleset = *rs
+
+		err := e.retryPolicy.Do(ctx, func() (err error) {
+			rulesets[i].Rules, err = e.client.ListRulesetRulesPaginated(ctx, rs.ID)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("ruleset %s: %w", rs.ID, err)
+		}
+	}
+
+	return rulesets, nil
+}
@@ -0,0 +1,178 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+func newTestServer(t *testing.T) (*httptest.Server, *pagerduty.Client) {
+	t.Helper()
+
+	var teamsCalls int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/teams", func(w http.ResponseWriter, r *http.Request) {
+		teamsCalls++
+		if teamsCalls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error": {"code": 2020, "message": "Rate Limit Exceeded"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"teams": [{"id": "PT1", "name": "SRE"}]}`))
+	})
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query()["include[]"]; len(got) != 2 {
+			t.Errorf("users include = %v, want contact methods and notification rules", got)
+		}
+		if r.URL.Query().Get("offset") != "1" {
+			_, _ = w.Write([]byte(`{"users": [{"id": "PU1", "name": "Ada", "email": "ada@example.com"}], "more": true, "limit": 1}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"users": [{"id": "PU2", "name": "Bob", "email": "bob@example.com"}], "offset": 1, "limit": 1}`))
+	})
+	mux.HandleFunc("/escalation_policies", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"escalation_policies": [{"id": "PEP1", "name": "Primary"}]}`))
+	})
+	mux.HandleFunc("/schedules", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"schedules": [{"id": "PS1"}]}`))
+	})
+	mux.HandleFunc("/schedules/PS1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"schedule": {"id": "PS1", "name": "Primary", "schedule_layers": [{"name": "Layer 1"}]}}`))
+	})
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("include[]"); got != "integrations" {
+			t.Errorf("services include = %q, want integrations", got)
+		}
+		_, _ = w.Write([]byte(`{"services": [{"id": "PSV1", "name": "API", "integrations": [{"id": "PI1", "name": "Events"}]}]}`))
+	})
+	mux.HandleFunc("/rulesets/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rulesets/PR1/rules" {
+			_, _ = w.Write([]byte(`{"rules": [{"id": "PRR1", "position": 0}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"rulesets": [{"id": "PR1", "name": "Default"}]}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server, pagerduty.NewClient("token", pagerduty.WithAPIEndpoint(server.URL))
+}
+
+func TestExport(t *testing.T) {
+	defer func(p pagerduty.RetryPolicy) { retryPolicy = p }(retryPolicy)
+	retryPolicy = pagerduty.RetryPolicy{MinBackoff: time.Millisecond}
+
+	_, client := newTestServer(t)
+
+	s, err := Export(context.Background(), client, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Version != SnapshotVersion || s.CreatedAt.IsZero() {
+		t.Errorf("version = %d, created at = %s", s.Version, s.CreatedAt)
+	}
+	if len(s.Teams) != 1 || s.Teams[0].Name != "SRE" {
+		t.Errorf("teams = %+v", s.Teams)
+	}
+	if len(s.Users) != 2 || s.Users[1].Email != "bob@example.com" {
+		t.Errorf("users = %+v", s.Users)
+	}
+	if len(s.EscalationPolicies) != 1 {
+		t.Errorf("escalation policies = %+v", s.EscalationPolicies)
+	}
+	if len(s.Schedules) != 1 || len(s.Schedules[0].ScheduleLayers) != 1 {
+		t.Errorf("schedules = %+v", s.Schedules)
+	}
+	if len(s.Services) != 1 || len(s.Services[0].Integrations) != 1 {
+		t.Errorf("services = %+v", s.Services)
+	}
+	if len(s.Rulesets) != 1 || s.Rulesets[0].Name != "Default" || len(s.Rulesets[0].Rules) != 1 {
+		t.Errorf("rulesets = %+v", s.Rulesets)
+	}
+}
+
+func TestExport_resources(t *testing.T) {
+	_, client := newTestServer(t)
+
+	s, err := Export(context.Background(), client, Options{Resources: []string{ResourceServices}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(s.Services) != 1 || s.Teams != nil || s.Users != nil {
+		t.Errorf("snapshot = %+v, want only services", s)
+	}
+
+	if _, err := Export(context.Background(), client, Options{Resources: []string{"nope"}}); err == nil {
+		t.Error("expected an error for an unknown resource")
+	}
+}
+
+func TestExport_rateLimited(t *testing.T) {
+	_, client := newTestServer(t)
+
+	_, err := Export(context.Background(), client, Options{Resources: []string{ResourceTeams}, MaxRetries: -1})
+	if !errors.Is(err, pagerduty.ErrRateLimited) {
+		t.Fatalf("error = %v, want rate limited", err)
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	s := &Snapshot{
+		Version:   SnapshotVersion,
+		CreatedAt: time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC),
+		Teams:     []pagerduty.Team{{APIObject: pagerduty.APIObject{ID: "PT1"}, Name: "SRE"}},
+		Services: []pagerduty.Service{{
+			APIObject:    pagerduty.APIObject{ID: "PSV1"},
+			Name:         "API",
+			Integrations: []pagerduty.Integration{{APIObject: pagerduty.APIObject{ID: "PI1"}, Name: "Events"}},
+		}},
+		Rulesets: []Ruleset{{
+			Ruleset: pagerduty.Ruleset{ID: "PR1", Name: "Default"},
+			Rules:   []*pagerduty.RulesetRule{{ID: "PRR1"}},
+		}},
+	}
+
+	for _, format := range []string{FormatJSON, FormatYAML} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, s, format); err != nil {
+				t.Fatal(err)
+			}
+
+			if format == FormatYAML && !strings.Contains(buf.String(), "created_at:") {
+				t.Errorf("YAML doesn't use the API field names:\n%s", buf.String())
+			}
+
+			got, err := Read(&buf, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, s) {
+				t.Errorf("read %+v, want %+v", got, s)
+			}
+		})
+	}
+
+	if err := Write(&bytes.Buffer{}, s, "xml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestRead_version(t *testing.T) {
+	if _, err := Read(strings.NewReader(`{"version": 99}`), FormatJSON); err == nil {
+		t.Error("expected an error for a newer snapshot version")
+	}
+}
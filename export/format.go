@@ -0,0 +1,145 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Values of the format of Write and Read.
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// Write writes the snapshot to w in the format. The YAML format uses the same
+// field names as the JSON one, which are those of the PagerDuty API.
+func Write(w io.Writer, s *Snapshot, format string) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(s)
+
+	case FormatYAML:
+		data, err := json.Marshal(s)
+		if err != nil {
+			return err
+		}
+
+		// JSON is YAML, and decoding it into a MapSlice keeps the order of
+		// the fields
+		var doc yaml.MapSlice
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+
+		data, err = yaml.Marshal(doc)
+		if err != nil {
+			return err
+		}
+
+		_, err = w.Write(data)
+		return err
+
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// Read reads a snapshot written by Write in the format.
+func Read(r io.Reader, format string) (*Snapshot, error) {
+	var s Snapshot
+
+	switch format {
+	case FormatJSON:
+		if err := json.NewDecoder(r).Decode(&s); err != nil {
+			return nil, err
+		}
+
+	case FormatYAML:
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := UnmarshalYAML(data, &s); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+
+	if s.Version > SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", s.Version)
+	}
+
+	return &s, nil
+}
+
+// UnmarshalYAML decodes YAML data into v using its JSON field names, so that
+// the structs of the pagerduty package can be read from YAML.
+func UnmarshalYAML(data []byte, v interface{}) error {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	doc, err := jsonCompatible(doc)
+	if err != nil {
+		return err
+	}
+
+	data, err = json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// jsonCompatible converts the maps decoded by the yaml package, which have
+// interface{} keys, to maps with string keys.
+func jsonCompatible(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+
+		for k, e := range v {
+			ks, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("unsupported non-string key %v", k)
+			}
+
+			c, err := jsonCompatible(e)
+			if err != nil {
+				return nil, err
+			}
+
+			m[ks] = c
+		}
+
+		return m, nil
+
+	case []interface{}:
+		s := make([]interface{}, len(v))
+
+		for i, e := range v {
+			c, err := jsonCompatible(e)
+			if err != nil {
+				return nil, err
+			}
+
+			s[i] = c
+		}
+
+		return s, nil
+
+	default:
+		return v, nil
+	}
+}
@@ -2,6 +2,7 @@ package pagerduty
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -20,6 +21,47 @@ type Extension struct {
 	TemporarilyDisabled bool        `json:"temporarily_disabled,omitempty"`
 }
 
+// NewExtension returns an extension of the extension schema with the given ID,
+// attached to the services with the given IDs. Its EndpointURL and Config
+// should then be set according to the schema.
+func NewExtension(name, schemaID string, serviceIDs ...string) *Extension {
+	objects := make([]APIObject, len(serviceIDs))
+	for i, id := range serviceIDs {
+		objects[i] = APIObject{ID: id, Type: "service_reference"}
+	}
+
+	return &Extension{
+		Name:             name,
+		ExtensionObjects: objects,
+		ExtensionSchema:  APIObject{ID: schemaID, Type: "extension_schema_reference"},
+	}
+}
+
+// DecodeConfig decodes the Config of the extension, as returned by the API,
+// into v, such as a *ServiceNowExtensionConfig.
+func (e Extension) DecodeConfig(v interface{}) error {
+	b, err := json.Marshal(e.Config)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
+
+// ServiceNowExtensionConfig is the Config of ServiceNow extensions.
+type ServiceNowExtensionConfig struct {
+	User     string `json:"snow_user"`
+	Password string `json:"snow_password,omitempty"`
+
+	// SyncOptions is either "manual_sync" or "sync_all".
+	SyncOptions string `json:"sync_options"`
+
+	// Target is the URL of the ServiceNow webhook processor.
+	Target   string `json:"target"`
+	TaskType string `json:"task_type"`
+	Referer  string `json:"referer"`
+}
+
 // ListExtensionResponse represents the single response from the PagerDuty API
 // when listing extensions.
 type ListExtensionResponse struct {
@@ -80,6 +122,38 @@ func (c *Client) ListExtensionsWithContext(ctx context.Context, o ListExtensionO
 	return &result, nil
 }
 
+// ListExtensionsPaginated lists all the extensions, handling pagination.
+func (c *Client) ListExtensionsPaginated(ctx context.Context, o ListExtensionOptions) ([]Extension, error) {
+	var extensions []Extension
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListExtensionResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		extensions = append(extensions, result.Extensions...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+			Total:  result.Total,
+		}, nil
+	}
+
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.pagedGet(ctx, "/extensions?"+v.Encode(), responseHandler); err != nil {
+		return nil, err
+	}
+
+	return extensions, nil
+}
+
 // CreateExtension creates a single extension.
 //
 // Deprecated: Use CreateExtensionWithContext instead.
@@ -89,7 +163,11 @@ func (c *Client) CreateExtension(e *Extension) (*Extension, error) {
 
 // CreateExtensionWithContext creates a single extension.
 func (c *Client) CreateExtensionWithContext(ctx context.Context, e *Extension) (*Extension, error) {
-	resp, err := c.post(ctx, "/extensions", e, nil)
+	d := map[string]*Extension{
+		"extension": e,
+	}
+
+	resp, err := c.post(ctx, "/extensions", d, nil)
 	return getExtensionFromResponse(c, resp, err)
 }
 
@@ -128,31 +206,59 @@ func (c *Client) UpdateExtension(id string, e *Extension) (*Extension, error) {
 
 // UpdateExtensionWithContext updates an extension by its ID.
 func (c *Client) UpdateExtensionWithContext(ctx context.Context, id string, e *Extension) (*Extension, error) {
-	resp, err := c.put(ctx, "/extensions/"+id, e, nil)
+	d := map[string]*Extension{
+		"extension": e,
+	}
+
+	resp, err := c.put(ctx, "/extensions/"+id, d, nil)
 	return getExtensionFromResponse(c, resp, err)
 }
 
 // EnableExtension enables a temporarily disabled extension by its ID.
+//
+// Deprecated: Use EnableExtensionWithContext instead.
 func (c *Client) EnableExtension(ctx context.Context, id string) (*Extension, error) {
+	return c.EnableExtensionWithContext(ctx, id)
+}
+
+// EnableExtensionWithContext enables an extension that PagerDuty temporarily
+// disabled after errors, by its ID.
+func (c *Client) EnableExtensionWithContext(ctx context.Context, id string) (*Extension, error) {
 	resp, err := c.post(ctx, "/extensions/"+id+"/enable", nil, nil)
 	return getExtensionFromResponse(c, resp, err)
 }
 
-func getExtensionFromResponse(c *Client, resp *http.Response, err error) (*Extension, error) {
+// EnableTemporarilyDisabledExtensionsWithContext enables all the extensions
+// listed with o that PagerDuty temporarily disabled after errors. It returns
+// the extensions it enabled, which are partial if an error occurs.
+func (c *Client) EnableTemporarilyDisabledExtensionsWithContext(ctx context.Context, o ListExtensionOptions) ([]Extension, error) {
+	extensions, err := c.ListExtensionsPaginated(ctx, o)
 	if err != nil {
 		return nil, err
 	}
 
-	var target map[string]Extension
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
+	var enabled []Extension
+
+	for _, e := range extensions {
+		if !e.TemporarilyDisabled {
+			continue
+		}
+
+		res, err := c.EnableExtensionWithContext(ctx, e.ID)
+		if err != nil {
+			return enabled, fmt.Errorf("failed to enable extension %s: %w", e.ID, err)
+		}
+
+		enabled = append(enabled, *res)
 	}
 
-	const rootNode = "extension"
+	return enabled, nil
+}
 
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
+func getExtensionFromResponse(c *Client, resp *http.Response, err error) (*Extension, error) {
+	var t Extension
+	if err := decodeEnvelope(c, resp, err, "extension", &t); err != nil {
+		return nil, err
 	}
 
 	return &t, nil
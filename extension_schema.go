@@ -2,8 +2,8 @@ package pagerduty
 
 import (
 	"context"
-	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/google/go-querystring/query"
 )
@@ -81,6 +81,59 @@ func (c *Client) ListExtensionSchemasWithContext(ctx context.Context, o ListExte
 	return &result, nil
 }
 
+// ListExtensionSchemasPaginated lists all of the extension schemas, handling
+// pagination.
+func (c *Client) ListExtensionSchemasPaginated(ctx context.Context, o ListExtensionSchemaOptions) ([]ExtensionSchema, error) {
+	var schemas []ExtensionSchema
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListExtensionSchemaResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		schemas = append(schemas, result.ExtensionSchemas...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+			Total:  result.Total,
+		}, nil
+	}
+
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.pagedGet(ctx, "/extension_schemas?"+v.Encode(), responseHandler); err != nil {
+		return nil, err
+	}
+
+	return schemas, nil
+}
+
+// GetExtensionSchemaByLabel gets the extension schema with the given label,
+// such as "ServiceNow (v7)" or "Generic V2 Webhook", ignoring case, so that
+// extensions can be created without hardcoding schema IDs, which differ
+// between accounts. It returns a MatchError matching ErrNotFound if there's no
+// such schema.
+func (c *Client) GetExtensionSchemaByLabel(ctx context.Context, label string) (*ExtensionSchema, error) {
+	schemas, err := c.ListExtensionSchemasPaginated(ctx, ListExtensionSchemaOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range schemas {
+		if strings.EqualFold(s.Label, label) {
+			return &s, nil
+		}
+	}
+
+	return nil, MatchError{Kind: "extension schema", Name: label}
+}
+
 // GetExtensionSchema gets a single extension schema.
 //
 // Deprecated: Use GetExtensionSchemaWithContext instead.
@@ -95,21 +148,10 @@ func (c *Client) GetExtensionSchemaWithContext(ctx context.Context, id string) (
 }
 
 func getExtensionSchemaFromResponse(c *Client, resp *http.Response, err error) (*ExtensionSchema, error) {
-	if err != nil {
+	var t ExtensionSchema
+	if err := decodeEnvelope(c, resp, err, "extension_schema", &t); err != nil {
 		return nil, err
 	}
 
-	var target map[string]ExtensionSchema
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
-	}
-
-	const rootNode = "extension_schema"
-
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
-	}
-
 	return &t, nil
 }
@@ -1,6 +1,8 @@
 package pagerduty
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"testing"
 )
@@ -75,3 +77,32 @@ func TestExtensionSchema_Get(t *testing.T) {
 	}
 	testEqual(t, want, res)
 }
+
+func TestExtensionSchema_GetByLabel(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/extension_schemas", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		if r.URL.Query().Get("offset") == "" {
+			_, _ = w.Write([]byte(`{"extension_schemas": [{"id": "PD1", "label": "Slack"}], "more": true, "offset": 0, "limit": 1}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"extension_schemas": [{"id": "PD2", "label": "Generic V2 Webhook"}], "more": false, "offset": 1, "limit": 1}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.GetExtensionSchemaByLabel(context.Background(), "generic v2 webhook")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "PD2", res.ID)
+
+	_, err = client.GetExtensionSchemaByLabel(context.Background(), "Jira")
+	testErrCheck(t, "GetExtensionSchemaByLabel()", `extension schema "Jira" not found`, err)
+	testEqual(t, true, errors.Is(err, ErrNotFound))
+}
@@ -3,7 +3,9 @@ package pagerduty
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 	"testing"
 )
 
@@ -61,9 +63,10 @@ func TestExtension_Create(t *testing.T) {
 	input2 := &Extension{Name: "bar", EndpointURL: "expected_url"}
 
 	mux.HandleFunc("/extensions", func(w http.ResponseWriter, r *http.Request) {
-		var got map[string]interface{}
+		var body map[string]map[string]interface{}
 
-		err := json.NewDecoder(r.Body).Decode(&got)
+		err := json.NewDecoder(r.Body).Decode(&body)
+		got := body["extension"]
 
 		testErrCheck(t, "Extension_Create()", "", err)
 		name := got["name"]
@@ -159,9 +162,10 @@ func TestExtension_Update(t *testing.T) {
 	input2 := &Extension{Name: "foo", EndpointURL: "expected_url"}
 
 	mux.HandleFunc("/extensions/1", func(w http.ResponseWriter, r *http.Request) {
-		var got map[string]interface{}
+		var body map[string]map[string]interface{}
 
-		err := json.NewDecoder(r.Body).Decode(&got)
+		err := json.NewDecoder(r.Body).Decode(&body)
+		got := body["extension"]
 
 		testErrCheck(t, "Extension_Update()", "", err)
 		testNoEndpointURL(t, got)
@@ -171,9 +175,10 @@ func TestExtension_Update(t *testing.T) {
 	})
 
 	mux.HandleFunc("/extensions/2", func(w http.ResponseWriter, r *http.Request) {
-		var got map[string]interface{}
+		var body map[string]map[string]interface{}
 
-		err := json.NewDecoder(r.Body).Decode(&got)
+		err := json.NewDecoder(r.Body).Decode(&body)
+		got := body["extension"]
 		testErrCheck(t, "Extension_Update()", "", err)
 
 		testGotExpectedURL(t, "expected_url", got)
@@ -235,7 +240,7 @@ func TestExtension_Enable(t *testing.T) {
 
 	client := defaultTestClient(server.URL, "foo")
 
-	res, err := client.EnableExtension(context.Background(), "1")
+	res, err := client.EnableExtensionWithContext(context.Background(), "1")
 
 	want := &Extension{
 		Name: "foo",
@@ -249,3 +254,85 @@ func TestExtension_Enable(t *testing.T) {
 	}
 	testEqual(t, want, res)
 }
+
+func TestExtension_ListPaginated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/extensions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "PS1", r.URL.Query().Get("extension_object_id"))
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		_, _ = fmt.Fprintf(w, `{"extensions": [{"id": "%d"}], "more": %t, "offset": %d, "limit": 1}`, offset, offset == 0, offset)
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListExtensionsPaginated(context.Background(), ListExtensionOptions{ExtensionObjectID: "PS1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Extension{
+		{APIObject: APIObject{ID: "0"}},
+		{APIObject: APIObject{ID: "1"}},
+	}
+	testEqual(t, want, res)
+}
+
+func TestNewExtension(t *testing.T) {
+	e := NewExtension("ServiceNow", "PSCHEMA", "PS1", "PS2")
+
+	testEqual(t, APIObject{ID: "PSCHEMA", Type: "extension_schema_reference"}, e.ExtensionSchema)
+	testEqual(t, []APIObject{{ID: "PS1", Type: "service_reference"}, {ID: "PS2", Type: "service_reference"}}, e.ExtensionObjects)
+}
+
+func TestExtension_DecodeConfig(t *testing.T) {
+	var e Extension
+	if err := json.Unmarshal([]byte(`{"config": {"snow_user": "pd", "sync_options": "manual_sync", "target": "https://example.service-now.com", "task_type": "incident", "referer": "None"}}`), &e); err != nil {
+		t.Fatal(err)
+	}
+
+	var got ServiceNowExtensionConfig
+	if err := e.DecodeConfig(&got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := ServiceNowExtensionConfig{
+		User:        "pd",
+		SyncOptions: "manual_sync",
+		Target:      "https://example.service-now.com",
+		TaskType:    "incident",
+		Referer:     "None",
+	}
+	testEqual(t, want, got)
+}
+
+func TestExtension_EnableTemporarilyDisabled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/extensions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"extensions": [{"id": "1", "temporarily_disabled": true}, {"id": "2"}]}`))
+	})
+
+	mux.HandleFunc("/extensions/1/enable", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		_, _ = w.Write([]byte(`{"extension": {"id": "1"}}`))
+	})
+
+	mux.HandleFunc("/extensions/2/enable", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("enabled extension 2, which isn't disabled")
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.EnableTemporarilyDisabledExtensionsWithContext(context.Background(), ListExtensionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, []Extension{{APIObject: APIObject{ID: "1"}}}, res)
+}
@@ -0,0 +1,220 @@
+// Package format provides functionality for rendering PagerDuty incidents as
+// chat messages, such as Slack Block Kit messages with Slack and Microsoft
+// Teams Adaptive Cards with Teams.
+//
+// The messages have buttons to act on the incident, whose value is an
+// ActionValue encoded in JSON, which is sent back to the application when a
+// button is clicked:
+//
+//	var v format.ActionValue
+//	if err := json.Unmarshal([]byte(slackAction.Value), &v); err != nil {
+//		return err
+//	}
+//	if v.Action == format.ActionAcknowledge {
+//		// acknowledge incident v.IncidentID
+//	}
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// Values of the ID field of Action.
+const (
+	ActionAcknowledge = "acknowledge"
+	ActionResolve     = "resolve"
+	ActionView        = "view"
+)
+
+// Values of the Style field of Action.
+const (
+	StylePrimary = "primary"
+	StyleDanger  = "danger"
+)
+
+// Action is a button of a message.
+type Action struct {
+	// ID identifies the action, such as ActionAcknowledge.
+	ID string
+
+	// Text is the label of the button.
+	Text string
+
+	// URL is opened by the button, if set, instead of the action being sent
+	// to the application.
+	URL string
+
+	// Style is the style of the button, if any, such as StylePrimary.
+	Style string
+}
+
+// ActionValue is the value of the buttons of the actions without a URL,
+// encoded in JSON, for the application to know what to do when one is
+// clicked.
+type ActionValue struct {
+	Action     string `json:"action"`
+	IncidentID string `json:"incident_id"`
+}
+
+// defaultMaxItems is the default of Options.MaxItems.
+const defaultMaxItems = 5
+
+// Options are the options of the formatting of an incident.
+type Options struct {
+	// Notes are the notes of the incident to show, the most recent last, as
+	// returned by ListIncidentNotesWithContext.
+	Notes []pagerduty.IncidentNote
+
+	// Alerts are the alerts of the incident to show.
+	Alerts []pagerduty.IncidentAlert
+
+	// MaxItems is the maximum number of notes and of alerts shown, which
+	// defaults to 5. The most recent notes and the first alerts are shown.
+	MaxItems int
+
+	// Actions are the buttons of the message. They default to
+	// DefaultActions.
+	Actions []Action
+}
+
+// DefaultActions returns the default actions of the message of an incident:
+// acknowledging it unless it's acknowledged, resolving it, and opening it
+// in PagerDuty.
+func DefaultActions(i *pagerduty.Incident) []Action {
+	var actions []Action
+
+	if i.Status != pagerduty.IncidentStatusResolved {
+		if i.Status != pagerduty.IncidentStatusAcknowledged {
+			actions = append(actions, Action{ID: ActionAcknowledge, Text: "Acknowledge", Style: StylePrimary})
+		}
+
+		actions = append(actions, Action{ID: ActionResolve, Text: "Resolve", Style: StyleDanger})
+	}
+
+	if i.HTMLURL != "" {
+		actions = append(actions, Action{ID: ActionView, Text: "View in PagerDuty", URL: i.HTMLURL})
+	}
+
+	return actions
+}
+
+// field is a labeled value of the message of an incident.
+type field struct {
+	label string
+	value string
+}
+
+// incidentView is what the messages of an incident show, in the order they
+// show it.
+type incidentView struct {
+	title   string
+	status  pagerduty.IncidentStatus
+	fields  []field
+	alerts  []string
+	notes   []string
+	actions []Action
+	id      string
+}
+
+func newIncidentView(i *pagerduty.Incident, o Options) incidentView {
+	v := incidentView{
+		title:   i.Title,
+		status:  i.Status,
+		actions: o.Actions,
+		id:      i.ID,
+	}
+
+	if i.IncidentNumber > 0 {
+		v.title = fmt.Sprintf("[#%d] %s", i.IncidentNumber, i.Title)
+	}
+
+	if v.actions == nil {
+		v.actions = DefaultActions(i)
+	}
+
+	v.fields = append(v.fields, field{"Status", statusText(i.Status)})
+
+	if i.Urgency != "" {
+		u := string(i.Urgency)
+		v.fields = append(v.fields, field{"Urgency", strings.ToUpper(u[:1]) + u[1:]})
+	}
+
+	if i.Priority != nil && i.Priority.Name != "" {
+		v.fields = append(v.fields, field{"Priority", i.Priority.Name})
+	}
+
+	if i.Service.Summary != "" {
+		v.fields = append(v.fields, field{"Service", i.Service.Summary})
+	}
+
+	if len(i.Assignments) > 0 {
+		var assignees []string
+		for _, a := range i.Assignments {
+			assignees = append(assignees, a.Assignee.Summary)
+		}
+
+		v.fields = append(v.fields, field{"Assigned to", strings.Join(assignees, ", ")})
+	}
+
+	if !i.CreatedAt.IsZero() {
+		v.fields = append(v.fields, field{"Created", i.CreatedAt.String()})
+	}
+
+	max := o.MaxItems
+	if max <= 0 {
+		max = defaultMaxItems
+	}
+
+	for n, a := range o.Alerts {
+		if n == max {
+			v.alerts = append(v.alerts, fmt.Sprintf("and %d more", len(o.Alerts)-max))
+			break
+		}
+
+		s := a.Summary
+		if a.Severity != "" {
+			s = fmt.Sprintf("[%s] %s", a.Severity, s)
+		}
+
+		v.alerts = append(v.alerts, s)
+	}
+
+	notes := o.Notes
+	if len(notes) > max {
+		notes = notes[len(notes)-max:]
+	}
+
+	for _, n := range notes {
+		if n.User.Summary == "" {
+			v.notes = append(v.notes, n.Content)
+			continue
+		}
+
+		v.notes = append(v.notes, n.User.Summary+": "+n.Content)
+	}
+
+	return v
+}
+
+func statusText(status pagerduty.IncidentStatus) string {
+	switch status {
+	case pagerduty.IncidentStatusTriggered:
+		return "Triggered"
+	case pagerduty.IncidentStatusAcknowledged:
+		return "Acknowledged"
+	case pagerduty.IncidentStatusResolved:
+		return "Resolved"
+	default:
+		return string(status)
+	}
+}
+
+// actionValue returns the encoded ActionValue of an action.
+func actionValue(action, incidentID string) string {
+	data, _ := json.Marshal(ActionValue{Action: action, IncidentID: incidentID}) // can't fail
+	return string(data)
+}
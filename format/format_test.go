@@ -0,0 +1,173 @@
+package format
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+func testIncident() *pagerduty.Incident {
+	return &pagerduty.Incident{
+		APIObject:      pagerduty.APIObject{ID: "PI1", HTMLURL: "https://acme.pagerduty.com/incidents/PI1"},
+		IncidentNumber: 42,
+		Title:          "Disk full on <db-1>",
+		Status:         pagerduty.IncidentStatusTriggered,
+		Urgency:        pagerduty.UrgencyHigh,
+		Priority:       &pagerduty.Priority{Name: "P1"},
+		Service:        pagerduty.APIObject{Summary: "Database"},
+		Assignments: []pagerduty.Assignment{
+			{Assignee: pagerduty.APIObject{Summary: "Ada"}},
+			{Assignee: pagerduty.APIObject{Summary: "Bob"}},
+		},
+		CreatedAt: pagerduty.NewAPITime(time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)),
+	}
+}
+
+func testOptions() Options {
+	return Options{
+		MaxItems: 2,
+		Alerts: []pagerduty.IncidentAlert{
+			{APIObject: pagerduty.APIObject{Summary: "disk 95%"}, Severity: "critical"},
+			{APIObject: pagerduty.APIObject{Summary: "disk 99%"}},
+			{APIObject: pagerduty.APIObject{Summary: "disk 100%"}},
+		},
+		Notes: []pagerduty.IncidentNote{
+			{Content: "looking"},
+			{Content: "rotating logs", User: pagerduty.APIObject{Summary: "Ada"}},
+			{Content: "fixed"},
+		},
+	}
+}
+
+func TestSlack(t *testing.T) {
+	m := Slack(testIncident(), testOptions())
+
+	if m.Text != "[#42] Disk full on <db-1> (Triggered)" {
+		t.Errorf("text = %q", m.Text)
+	}
+
+	var types []string
+	for _, b := range m.Blocks {
+		types = append(types, b.Type)
+	}
+
+	if want := []string{"header", "section", "section", "section", "actions"}; !reflect.DeepEqual(types, want) {
+		t.Fatalf("block types = %v, want %v", types, want)
+	}
+
+	if got := m.Blocks[1].Fields[0].Text; got != "*Status*\nTriggered" {
+		t.Errorf("first field = %q", got)
+	}
+
+	if got := m.Blocks[1].Fields[4].Text; got != "*Assigned to*\nAda, Bob" {
+		t.Errorf("assignees field = %q", got)
+	}
+
+	if got, want := m.Blocks[2].Text.Text, "*Alerts*\n• [critical] disk 95%\n• disk 99%\n• and 1 more"; got != want {
+		t.Errorf("alerts = %q, want %q", got, want)
+	}
+
+	if got, want := m.Blocks[3].Text.Text, "*Notes*\n• Ada: rotating logs\n• fixed"; got != want {
+		t.Errorf("notes = %q, want %q", got, want)
+	}
+
+	buttons := m.Blocks[4].Elements
+	if len(buttons) != 3 {
+		t.Fatalf("got %d buttons, want 3", len(buttons))
+	}
+
+	var v ActionValue
+	if err := json.Unmarshal([]byte(buttons[0].Value), &v); err != nil {
+		t.Fatal(err)
+	}
+
+	if v != (ActionValue{Action: ActionAcknowledge, IncidentID: "PI1"}) || buttons[0].Style != StylePrimary {
+		t.Errorf("acknowledge button = %+v, value %+v", buttons[0], v)
+	}
+
+	if buttons[2].URL == "" || buttons[2].Value != "" {
+		t.Errorf("view button = %+v", buttons[2])
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(data), `"fields":null`) {
+		t.Errorf("empty fields are encoded: %s", data)
+	}
+}
+
+func TestSlack_escaping(t *testing.T) {
+	i := testIncident()
+	i.Service.Summary = "A&B <prod>"
+
+	m := Slack(i, Options{})
+	if got := m.Blocks[1].Fields[3].Text; got != "*Service*\nA&amp;B &lt;prod&gt;" {
+		t.Errorf("service field = %q", got)
+	}
+
+	i.Title = strings.Repeat("é", 200)
+	m = Slack(i, Options{})
+	if n := len([]rune(m.Blocks[0].Text.Text)); n != slackMaxHeaderLength {
+		t.Errorf("header length = %d, want %d", n, slackMaxHeaderLength)
+	}
+}
+
+func TestTeams(t *testing.T) {
+	m := Teams(testIncident(), testOptions())
+
+	if len(m.Attachments) != 1 || m.Attachments[0].ContentType != adaptiveCardContentType {
+		t.Fatalf("attachments = %+v", m.Attachments)
+	}
+
+	card := m.Attachments[0].Content
+	if card.Body[0].Text != "[#42] Disk full on <db-1>" || card.Body[0].Color != "Attention" {
+		t.Errorf("title = %+v", card.Body[0])
+	}
+
+	if got := card.Body[1].Facts[2]; got != (AdaptiveCardFact{Title: "Priority", Value: "P1"}) {
+		t.Errorf("priority fact = %+v", got)
+	}
+
+	if got := card.Body[3].Text; got != "- [critical] disk 95%\n- disk 99%\n- and 1 more" {
+		t.Errorf("alerts = %q", got)
+	}
+
+	want := []AdaptiveCardAction{
+		{Type: "Action.Submit", ID: ActionAcknowledge, Title: "Acknowledge", Style: "positive", Data: &ActionValue{Action: ActionAcknowledge, IncidentID: "PI1"}},
+		{Type: "Action.Submit", ID: ActionResolve, Title: "Resolve", Style: "destructive", Data: &ActionValue{Action: ActionResolve, IncidentID: "PI1"}},
+		{Type: "Action.OpenUrl", ID: ActionView, Title: "View in PagerDuty", URL: "https://acme.pagerduty.com/incidents/PI1"},
+	}
+
+	if !reflect.DeepEqual(card.Actions, want) {
+		t.Errorf("actions = %+v, want %+v", card.Actions, want)
+	}
+}
+
+func TestDefaultActions(t *testing.T) {
+	i := testIncident()
+
+	ids := func() []string {
+		var ids []string
+		for _, a := range DefaultActions(i) {
+			ids = append(ids, a.ID)
+		}
+		return ids
+	}
+
+	i.Status = pagerduty.IncidentStatusAcknowledged
+	if got := ids(); !reflect.DeepEqual(got, []string{ActionResolve, ActionView}) {
+		t.Errorf("acknowledged actions = %v", got)
+	}
+
+	i.Status = pagerduty.IncidentStatusResolved
+	if got := ids(); !reflect.DeepEqual(got, []string{ActionView}) {
+		t.Errorf("resolved actions = %v", got)
+	}
+}
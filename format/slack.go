@@ -0,0 +1,129 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// Slack limits the length of the text of header blocks, and the number of
+// fields of section blocks.
+const (
+	slackMaxHeaderLength = 150
+	slackMaxFields       = 10
+)
+
+// SlackMessage is a Slack message made of Block Kit blocks, which can be
+// encoded in JSON as the payload of chat.postMessage or of incoming webhooks.
+type SlackMessage struct {
+	// Text is the fallback text of the message, shown in notifications.
+	Text   string       `json:"text"`
+	Blocks []SlackBlock `json:"blocks"`
+}
+
+// SlackBlock is a Block Kit block.
+type SlackBlock struct {
+	Type     string         `json:"type"`
+	Text     *SlackText     `json:"text,omitempty"`
+	Fields   []SlackText    `json:"fields,omitempty"`
+	Elements []SlackElement `json:"elements,omitempty"`
+}
+
+// SlackText is a Block Kit text object, whose type is "plain_text" or
+// "mrkdwn".
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// SlackElement is a Block Kit button element.
+type SlackElement struct {
+	Type     string     `json:"type"`
+	Text     *SlackText `json:"text,omitempty"`
+	ActionID string     `json:"action_id,omitempty"`
+	Value    string     `json:"value,omitempty"`
+	URL      string     `json:"url,omitempty"`
+	Style    string     `json:"style,omitempty"`
+}
+
+// Slack renders an incident as a Slack message. The action_id of its buttons
+// is the ID of their Action, and the value of those without a URL is an
+// ActionValue.
+func Slack(i *pagerduty.Incident, o Options) SlackMessage {
+	v := newIncidentView(i, o)
+
+	title := v.title
+	if r := []rune(title); len(r) > slackMaxHeaderLength {
+		title = string(r[:slackMaxHeaderLength-1]) + "…"
+	}
+
+	m := SlackMessage{
+		Text: fmt.Sprintf("%s (%s)", v.title, statusText(v.status)),
+		Blocks: []SlackBlock{
+			{Type: "header", Text: &SlackText{Type: "plain_text", Text: title}},
+		},
+	}
+
+	fields := SlackBlock{Type: "section"}
+	for _, f := range v.fields {
+		if len(fields.Fields) == slackMaxFields {
+			break
+		}
+
+		fields.Fields = append(fields.Fields, SlackText{Type: "mrkdwn", Text: "*" + f.label + "*\n" + slackEscape(f.value)})
+	}
+
+	m.Blocks = append(m.Blocks, fields)
+
+	if len(v.alerts) > 0 {
+		m.Blocks = append(m.Blocks, slackList("Alerts", v.alerts))
+	}
+
+	if len(v.notes) > 0 {
+		m.Blocks = append(m.Blocks, slackList("Notes", v.notes))
+	}
+
+	if len(v.actions) > 0 {
+		actions := SlackBlock{Type: "actions"}
+
+		for _, a := range v.actions {
+			e := SlackElement{
+				Type:     "button",
+				Text:     &SlackText{Type: "plain_text", Text: a.Text},
+				ActionID: a.ID,
+				URL:      a.URL,
+				Style:    a.Style,
+			}
+
+			if a.URL == "" {
+				e.Value = actionValue(a.ID, v.id)
+			}
+
+			actions.Elements = append(actions.Elements, e)
+		}
+
+		m.Blocks = append(m.Blocks, actions)
+	}
+
+	return m
+}
+
+// slackList returns a section block listing items under a title.
+func slackList(title string, items []string) SlackBlock {
+	var b strings.Builder
+	b.WriteString("*" + title + "*")
+
+	for _, item := range items {
+		b.WriteString("\n• " + slackEscape(item))
+	}
+
+	return SlackBlock{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: b.String()}}
+}
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// slackEscape escapes the control characters of mrkdwn text.
+func slackEscape(s string) string {
+	return slackEscaper.Replace(s)
+}
@@ -0,0 +1,147 @@
+package format
+
+import (
+	"strings"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// Values of the ContentType field of TeamsAttachment, and of the Schema and
+// Version fields of AdaptiveCard.
+const (
+	adaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+	adaptiveCardSchema      = "http://adaptivecards.io/schemas/adaptive-card.json"
+	adaptiveCardVersion     = "1.4"
+)
+
+// TeamsMessage is a Microsoft Teams message with an Adaptive Card, which can
+// be encoded in JSON as the payload of incoming webhooks or bots.
+type TeamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []TeamsAttachment `json:"attachments"`
+}
+
+// TeamsAttachment is an attachment of a TeamsMessage.
+type TeamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     AdaptiveCard `json:"content"`
+}
+
+// AdaptiveCard is an Adaptive Card.
+type AdaptiveCard struct {
+	Schema  string                `json:"$schema"`
+	Type    string                `json:"type"`
+	Version string                `json:"version"`
+	Body    []AdaptiveCardElement `json:"body"`
+	Actions []AdaptiveCardAction  `json:"actions,omitempty"`
+}
+
+// AdaptiveCardElement is a TextBlock or FactSet element of an Adaptive Card.
+type AdaptiveCardElement struct {
+	Type   string             `json:"type"`
+	Text   string             `json:"text,omitempty"`
+	Size   string             `json:"size,omitempty"`
+	Weight string             `json:"weight,omitempty"`
+	Color  string             `json:"color,omitempty"`
+	Wrap   bool               `json:"wrap,omitempty"`
+	Facts  []AdaptiveCardFact `json:"facts,omitempty"`
+}
+
+// AdaptiveCardFact is a fact of a FactSet.
+type AdaptiveCardFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// AdaptiveCardAction is an Action.Submit or Action.OpenUrl action of an
+// Adaptive Card.
+type AdaptiveCardAction struct {
+	Type  string       `json:"type"`
+	ID    string       `json:"id,omitempty"`
+	Title string       `json:"title"`
+	URL   string       `json:"url,omitempty"`
+	Style string       `json:"style,omitempty"`
+	Data  *ActionValue `json:"data,omitempty"`
+}
+
+// Teams renders an incident as a Microsoft Teams message. The id of its
+// actions is the ID of their Action, and the data of those without a URL is an
+// ActionValue.
+func Teams(i *pagerduty.Incident, o Options) TeamsMessage {
+	v := newIncidentView(i, o)
+
+	card := AdaptiveCard{
+		Schema:  adaptiveCardSchema,
+		Type:    "AdaptiveCard",
+		Version: adaptiveCardVersion,
+		Body: []AdaptiveCardElement{
+			{Type: "TextBlock", Text: v.title, Size: "Medium", Weight: "Bolder", Color: teamsStatusColor(v.status), Wrap: true},
+		},
+	}
+
+	facts := AdaptiveCardElement{Type: "FactSet"}
+	for _, f := range v.fields {
+		facts.Facts = append(facts.Facts, AdaptiveCardFact{Title: f.label, Value: f.value})
+	}
+
+	card.Body = append(card.Body, facts)
+
+	if len(v.alerts) > 0 {
+		card.Body = append(card.Body, teamsList("Alerts", v.alerts)...)
+	}
+
+	if len(v.notes) > 0 {
+		card.Body = append(card.Body, teamsList("Notes", v.notes)...)
+	}
+
+	for _, a := range v.actions {
+		ca := AdaptiveCardAction{ID: a.ID, Title: a.Text, Style: teamsActionStyle(a.Style)}
+
+		if a.URL != "" {
+			ca.Type = "Action.OpenUrl"
+			ca.URL = a.URL
+		} else {
+			ca.Type = "Action.Submit"
+			ca.Data = &ActionValue{Action: a.ID, IncidentID: v.id}
+		}
+
+		card.Actions = append(card.Actions, ca)
+	}
+
+	return TeamsMessage{
+		Type:        "message",
+		Attachments: []TeamsAttachment{{ContentType: adaptiveCardContentType, Content: card}},
+	}
+}
+
+// teamsList returns the text blocks listing items under a title.
+func teamsList(title string, items []string) []AdaptiveCardElement {
+	return []AdaptiveCardElement{
+		{Type: "TextBlock", Text: title, Weight: "Bolder", Wrap: true},
+		{Type: "TextBlock", Text: "- " + strings.Join(items, "\n- "), Wrap: true},
+	}
+}
+
+func teamsStatusColor(status pagerduty.IncidentStatus) string {
+	switch status {
+	case "triggered":
+		return "Attention"
+	case "acknowledged":
+		return "Warning"
+	case "resolved":
+		return "Good"
+	default:
+		return ""
+	}
+}
+
+func teamsActionStyle(style string) string {
+	switch style {
+	case StylePrimary:
+		return "positive"
+	case StyleDanger:
+		return "destructive"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,196 @@
+package pagerduty
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ICalendarOptions are the options for rendering iCalendar documents, with
+// WriteScheduleICalendar and WriteOnCallsICalendar.
+type ICalendarOptions struct {
+	// Name is the name of the calendar, shown by calendar applications when
+	// subscribing to it. It's omitted if empty.
+	Name string
+
+	// ProductID identifies the application generating the calendar. It
+	// defaults to the go-pagerduty client.
+	ProductID string
+
+	// Timestamp is when the calendar was generated, which is used for the
+	// DTSTAMP of the events. It defaults to the current time.
+	Timestamp time.Time
+}
+
+// icalEvent is a single VEVENT of an iCalendar document.
+type icalEvent struct {
+	uid         string
+	start       time.Time
+	end         time.Time
+	summary     string
+	description string
+	url         string
+}
+
+// WriteScheduleICalendar writes the final schedule of s, as returned by
+// GetScheduleWithContext for a time range, as an iCalendar (RFC 5545)
+// document with one event for each on-call shift.
+func WriteScheduleICalendar(w io.Writer, s Schedule, o ICalendarOptions) error {
+	entries, err := s.FinalSchedule.Entries()
+	if err != nil {
+		return err
+	}
+
+	if len(o.Name) == 0 {
+		o.Name = s.Name
+	}
+
+	events := make([]icalEvent, len(entries))
+
+	for i, e := range entries {
+		events[i] = icalEvent{
+			uid:         icalUID(s.ID, e.User.ID, e.Start),
+			start:       e.Start,
+			end:         e.End,
+			summary:     "On call: " + e.User.Summary,
+			description: "On call for the " + s.Name + " schedule.",
+			url:         s.HTMLURL,
+		}
+	}
+
+	return writeICalendar(w, o, events)
+}
+
+// WriteOnCallsICalendar writes on-call entries, such as the ones of a user
+// across schedules as returned by ListOnCallsPaginated with UserIDs, as an
+// iCalendar (RFC 5545) document with one event for each entry. Entries
+// without a start or end, which are permanently on call, are skipped.
+func WriteOnCallsICalendar(w io.Writer, onCalls []OnCall, o ICalendarOptions) error {
+	events := make([]icalEvent, 0, len(onCalls))
+
+	for _, oc := range onCalls {
+		if len(oc.Start) == 0 || len(oc.End) == 0 {
+			continue
+		}
+
+		start, end, err := parseTimeRange(oc.Start, oc.End)
+		if err != nil {
+			return err
+		}
+
+		// users on call directly for an escalation policy have no schedule
+		source, sourceID, url := oc.EscalationPolicy.Summary, oc.EscalationPolicy.ID, oc.EscalationPolicy.HTMLURL
+		if len(oc.Schedule.ID) > 0 {
+			source, sourceID, url = oc.Schedule.Summary, oc.Schedule.ID, oc.Schedule.HTMLURL
+		}
+
+		events = append(events, icalEvent{
+			uid:         icalUID(sourceID+"-"+oc.EscalationPolicy.ID, oc.User.ID, start),
+			start:       start,
+			end:         end,
+			summary:     "On call: " + source,
+			description: fmt.Sprintf("%s is on call at level %d of the %s escalation policy.", oc.User.Summary, oc.EscalationLevel, oc.EscalationPolicy.Summary),
+			url:         url,
+		})
+	}
+
+	return writeICalendar(w, o, events)
+}
+
+// icalTimeLayout is the layout of UTC date-times in iCalendar documents.
+const icalTimeLayout = "20060102T150405Z"
+
+func icalUID(sourceID, userID string, start time.Time) string {
+	return fmt.Sprintf("%s-%s-%s@pagerduty.com", sourceID, userID, start.UTC().Format(icalTimeLayout))
+}
+
+func writeICalendar(w io.Writer, o ICalendarOptions, events []icalEvent) error {
+	if len(o.ProductID) == 0 {
+		o.ProductID = "-//PagerDuty//go-pagerduty " + Version + "//EN"
+	}
+
+	if o.Timestamp.IsZero() {
+		o.Timestamp = time.Now()
+	}
+
+	stamp := o.Timestamp.UTC().Format(icalTimeLayout)
+
+	bw := bufio.NewWriter(w)
+
+	writeICalLine(bw, "BEGIN:VCALENDAR")
+	writeICalLine(bw, "VERSION:2.0")
+	writeICalLine(bw, "PRODID:"+escapeICalText(o.ProductID))
+	writeICalLine(bw, "CALSCALE:GREGORIAN")
+	writeICalLine(bw, "METHOD:PUBLISH")
+
+	if len(o.Name) > 0 {
+		writeICalLine(bw, "X-WR-CALNAME:"+escapeICalText(o.Name))
+	}
+
+	for _, e := range events {
+		writeICalLine(bw, "BEGIN:VEVENT")
+		writeICalLine(bw, "UID:"+e.uid)
+		writeICalLine(bw, "DTSTAMP:"+stamp)
+		writeICalLine(bw, "DTSTART:"+e.start.UTC().Format(icalTimeLayout))
+		writeICalLine(bw, "DTEND:"+e.end.UTC().Format(icalTimeLayout))
+		writeICalLine(bw, "SUMMARY:"+escapeICalText(e.summary))
+
+		if len(e.description) > 0 {
+			writeICalLine(bw, "DESCRIPTION:"+escapeICalText(e.description))
+		}
+
+		if len(e.url) > 0 {
+			writeICalLine(bw, "URL:"+e.url)
+		}
+
+		writeICalLine(bw, "END:VEVENT")
+	}
+
+	writeICalLine(bw, "END:VCALENDAR")
+
+	return bw.Flush()
+}
+
+// icalMaxLineLength is the maximum length of a line in octets, excluding the
+// line break, after which it must be folded.
+const icalMaxLineLength = 75
+
+// writeICalLine writes a content line, folding it into several lines if it's
+// too long. Errors are reported by the Flush method of the bufio.Writer.
+func writeICalLine(w *bufio.Writer, line string) {
+	limit := icalMaxLineLength
+
+	for len(line) > limit {
+		// don't split UTF-8 sequences across lines
+		i := limit
+		for i > 0 && line[i]&0xC0 == 0x80 {
+			i--
+		}
+
+		_, _ = w.WriteString(line[:i])
+		_, _ = w.WriteString("\r\n ")
+
+		line = line[i:]
+
+		// continuation lines start with a space, which counts toward the limit
+		limit = icalMaxLineLength - 1
+	}
+
+	_, _ = w.WriteString(line)
+	_, _ = w.WriteString("\r\n")
+}
+
+var icalTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// escapeICalText escapes a TEXT property value.
+func escapeICalText(s string) string {
+	return icalTextEscaper.Replace(s)
+}
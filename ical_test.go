@@ -0,0 +1,126 @@
+package pagerduty
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteScheduleICalendar(t *testing.T) {
+	s := Schedule{
+		APIObject: APIObject{ID: "PS1", HTMLURL: "https://acme.pagerduty.com/schedules/PS1"},
+		Name:      "Primary, EU",
+		FinalSchedule: ScheduleLayer{
+			RenderedScheduleEntries: []RenderedScheduleEntry{
+				{
+					Start: "2021-01-01T09:00:00+01:00",
+					End:   "2021-01-02T09:00:00+01:00",
+					User:  APIObject{ID: "PU1", Summary: "Alice"},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+
+	err := WriteScheduleICalendar(&buf, s, ICalendarOptions{
+		ProductID: "-//Acme//Portal//EN",
+		Timestamp: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Acme//Portal//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		`X-WR-CALNAME:Primary\, EU`,
+		"BEGIN:VEVENT",
+		"UID:PS1-PU1-20210101T080000Z@pagerduty.com",
+		"DTSTAMP:20210101T000000Z",
+		"DTSTART:20210101T080000Z",
+		"DTEND:20210102T080000Z",
+		"SUMMARY:On call: Alice",
+		`DESCRIPTION:On call for the Primary\, EU schedule.`,
+		"URL:https://acme.pagerduty.com/schedules/PS1",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	testEqual(t, want, buf.String())
+}
+
+func TestWriteOnCallsICalendar(t *testing.T) {
+	onCalls := []OnCall{
+		{
+			User:             User{APIObject: APIObject{ID: "PU1", Summary: "Alice"}},
+			Schedule:         Schedule{APIObject: APIObject{ID: "PS1", Summary: "Primary"}},
+			EscalationPolicy: EscalationPolicy{APIObject: APIObject{ID: "PEP1", Summary: "Web"}},
+			EscalationLevel:  1,
+			Start:            "2021-01-01T00:00:00Z",
+			End:              "2021-01-02T00:00:00Z",
+		},
+		{
+			// permanently on call, so skipped
+			User:             User{APIObject: APIObject{ID: "PU1", Summary: "Alice"}},
+			EscalationPolicy: EscalationPolicy{APIObject: APIObject{ID: "PEP2", Summary: "DB"}},
+			EscalationLevel:  2,
+		},
+	}
+
+	var buf bytes.Buffer
+
+	err := WriteOnCallsICalendar(&buf, onCalls, ICalendarOptions{Name: "Alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+
+	testEqual(t, 1, strings.Count(out, "BEGIN:VEVENT"))
+
+	for _, want := range []string{
+		"X-WR-CALNAME:Alice\r\n",
+		"UID:PS1-PEP1-PU1-20210101T000000Z@pagerduty.com\r\n",
+		"SUMMARY:On call: Primary\r\n",
+		"DESCRIPTION:Alice is on call at level 1 of the Web escalation policy.\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func Test_writeICalLine(t *testing.T) {
+	var buf bytes.Buffer
+
+	line := "DESCRIPTION:" + strings.Repeat("é", 70)
+
+	bw := bufio.NewWriter(&buf)
+	writeICalLine(bw, line)
+	_ = bw.Flush()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+
+	var unfolded string
+
+	for i, l := range lines {
+		if len(l) > icalMaxLineLength {
+			t.Errorf("line %d is %d octets long, want at most %d", i, len(l), icalMaxLineLength)
+		}
+
+		if i > 0 {
+			l = strings.TrimPrefix(l, " ")
+		}
+
+		unfolded += l
+	}
+
+	testEqual(t, line, unfolded)
+}
@@ -2,26 +2,28 @@ package pagerduty
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/google/go-querystring/query"
 )
 
 // Acknowledgement is the data structure of an acknowledgement of an incident.
 type Acknowledgement struct {
-	At           string    `json:"at,omitempty"`
+	At           APITime   `json:"at,omitempty"`
 	Acknowledger APIObject `json:"acknowledger,omitempty"`
 }
 
 // PendingAction is the data structure for any pending actions on an incident.
 type PendingAction struct {
-	Type string `json:"type,omitempty"`
-	At   string `json:"at,omitempty"`
+	Type string  `json:"type,omitempty"`
+	At   APITime `json:"at,omitempty"`
 }
 
 // Assignment is the data structure for an assignment of an incident
 type Assignment struct {
-	At       string    `json:"at,omitempty"`
+	At       APITime   `json:"at,omitempty"`
 	Assignee APIObject `json:"assignee,omitempty"`
 }
 
@@ -58,11 +60,11 @@ type Assignee struct {
 
 // Occurrence is the data around whether this is a reocurring issue.
 type Occurrence struct {
-	Count     uint   `json:"count,omitempty"`
-	Frequency uint   `json:"frequency,omitempty"`
-	Category  string `json:"category,omitempty"`
-	Since     string `json:"since,omitempty"`
-	Until     string `json:"until,omitempty"`
+	Count     uint    `json:"count,omitempty"`
+	Frequency uint    `json:"frequency,omitempty"`
+	Category  string  `json:"category,omitempty"`
+	Since     APITime `json:"since,omitempty"`
+	Until     APITime `json:"until,omitempty"`
 }
 
 // FirstTriggerLogEntry is the first LogEntry
@@ -71,26 +73,46 @@ type FirstTriggerLogEntry struct {
 	Incident APIObject `json:"incident,omitempty"`
 }
 
+// Values of the Incident.Status field.
+const (
+	IncidentStatusTriggered    = "triggered"
+	IncidentStatusAcknowledged = "acknowledged"
+	IncidentStatusResolved     = "resolved"
+)
+
+// IncidentStatus is the status of an incident, such as IncidentStatusResolved.
+type IncidentStatus string
+
+// IsValid returns whether s is a known incident status.
+func (s IncidentStatus) IsValid() bool {
+	switch s {
+	case IncidentStatusTriggered, IncidentStatusAcknowledged, IncidentStatusResolved:
+		return true
+	default:
+		return false
+	}
+}
+
 // Incident is a normalized, de-duplicated event generated by a PagerDuty integration.
 type Incident struct {
 	APIObject
 	IncidentNumber       uint                 `json:"incident_number,omitempty"`
 	Title                string               `json:"title,omitempty"`
 	Description          string               `json:"description,omitempty"`
-	CreatedAt            string               `json:"created_at,omitempty"`
+	CreatedAt            APITime              `json:"created_at,omitempty"`
 	PendingActions       []PendingAction      `json:"pending_actions,omitempty"`
 	IncidentKey          string               `json:"incident_key,omitempty"`
 	Service              APIObject            `json:"service,omitempty"`
 	Assignments          []Assignment         `json:"assignments,omitempty"`
 	Acknowledgements     []Acknowledgement    `json:"acknowledgements,omitempty"`
-	LastStatusChangeAt   string               `json:"last_status_change_at,omitempty"`
+	LastStatusChangeAt   APITime              `json:"last_status_change_at,omitempty"`
 	LastStatusChangeBy   APIObject            `json:"last_status_change_by,omitempty"`
 	FirstTriggerLogEntry FirstTriggerLogEntry `json:"first_trigger_log_entry,omitempty"`
 	EscalationPolicy     APIObject            `json:"escalation_policy,omitempty"`
 	Teams                []APIObject          `json:"teams,omitempty"`
 	Priority             *Priority            `json:"priority,omitempty"`
-	Urgency              string               `json:"urgency,omitempty"`
-	Status               string               `json:"status,omitempty"`
+	Urgency              Urgency              `json:"urgency,omitempty"`
+	Status               IncidentStatus       `json:"status,omitempty"`
 	ResolveReason        ResolveReason        `json:"resolve_reason,omitempty"`
 	AlertCounts          AlertCounts          `json:"alert_counts,omitempty"`
 	Body                 IncidentBody         `json:"body,omitempty"`
@@ -100,8 +122,8 @@ type Incident struct {
 	Occurrence           *Occurrence          `json:"occurrence,omitempty"`
 	IncidentResponders   []IncidentResponders `json:"incidents_responders,omitempty"`
 	ResponderRequests    []ResponderRequest   `json:"responder_requests,omitempty"`
-	ResolvedAt           string               `json:"resolved_at,omitempty"`
-	UpdatedAt            string               `json:"updated_at,omitempty"`
+	ResolvedAt           APITime              `json:"resolved_at,omitempty"`
+	UpdatedAt            APITime              `json:"updated_at,omitempty"`
 }
 
 // ListIncidentsResponse is the response structure when calling the ListIncident API endpoint.
@@ -131,8 +153,8 @@ type ListIncidentsOptions struct {
 	// total count of items in the collection.
 	Total bool `url:"total,omitempty"`
 
-	Since       string   `url:"since,omitempty"`
-	Until       string   `url:"until,omitempty"`
+	Since       APITime  `url:"since,omitempty"`
+	Until       APITime  `url:"until,omitempty"`
 	DateRange   string   `url:"date_range,omitempty"`
 	Statuses    []string `url:"statuses,omitempty,brackets"`
 	IncidentKey string   `url:"incident_key,omitempty"`
@@ -202,7 +224,7 @@ type CreateIncidentOptions struct {
 	Title            string            `json:"title"`
 	Service          *APIReference     `json:"service"`
 	Priority         *APIReference     `json:"priority"`
-	Urgency          string            `json:"urgency,omitempty"`
+	Urgency          Urgency           `json:"urgency,omitempty"`
 	IncidentKey      string            `json:"incident_key,omitempty"`
 	Body             *APIDetails       `json:"body,omitempty"`
 	EscalationPolicy *APIReference     `json:"escalation_policy,omitempty"`
@@ -210,6 +232,32 @@ type CreateIncidentOptions struct {
 	ConferenceBridge *ConferenceBridge `json:"conference_bridge,omitempty"`
 }
 
+// Validate returns an error if the incident can't be created, such as if it
+// has no service or an invalid urgency.
+func (o CreateIncidentOptions) Validate() error {
+	if len(o.Title) == 0 {
+		return errors.New("incident title must be set")
+	}
+
+	if o.Service == nil || len(o.Service.ID) == 0 {
+		return errors.New("incident service reference must be set")
+	}
+
+	if len(o.Urgency) > 0 && !o.Urgency.IsValid() {
+		return fmt.Errorf("invalid incident urgency %q", o.Urgency)
+	}
+
+	if o.Priority != nil && len(o.Priority.ID) == 0 {
+		return errors.New("incident priority reference must have an ID")
+	}
+
+	if o.EscalationPolicy != nil && len(o.Assignments) > 0 {
+		return errors.New("incident can't have both an escalation policy and assignments")
+	}
+
+	return nil
+}
+
 // ManageIncidentsOptions is the structure used when PUTing updates to incidents to the ManageIncidents func
 type ManageIncidentsOptions struct {
 	ID string `json:"id"`
@@ -222,7 +270,7 @@ type ManageIncidentsOptions struct {
 	// incident_reference. Any other value will be overwritten. This will be
 	// removed in v2.0.0.
 	Type             string            `json:"type"`
-	Status           string            `json:"status,omitempty"`
+	Status           IncidentStatus    `json:"status,omitempty"`
 	Title            string            `json:"title,omitempty"`
 	Priority         *APIReference     `json:"priority,omitempty"`
 	Assignments      []Assignee        `json:"assignments,omitempty"`
@@ -232,6 +280,28 @@ type ManageIncidentsOptions struct {
 	ConferenceBridge *ConferenceBridge `json:"conference_bridge,omitempty"`
 }
 
+// Validate returns an error if the incident can't be updated, such as if it
+// has no ID or an invalid status.
+func (o ManageIncidentsOptions) Validate() error {
+	if len(o.ID) == 0 {
+		return errors.New("incident ID must be set")
+	}
+
+	if len(o.Status) > 0 && o.Status != IncidentStatusAcknowledged && o.Status != IncidentStatusResolved {
+		return fmt.Errorf("invalid status %q of incident %s, it can only be acknowledged or resolved", o.Status, o.ID)
+	}
+
+	if len(o.Resolution) > 0 && o.Status != IncidentStatusResolved {
+		return fmt.Errorf("incident %s must be resolved to have a resolution", o.ID)
+	}
+
+	if o.Priority != nil && len(o.Priority.ID) == 0 {
+		return fmt.Errorf("priority reference of incident %s must have an ID", o.ID)
+	}
+
+	return nil
+}
+
 // MergeIncidentsOptions is the structure used when merging incidents with MergeIncidents func
 type MergeIncidentsOptions struct {
 	ID   string `json:"id"`
@@ -249,6 +319,18 @@ func (c *Client) CreateIncident(from string, o *CreateIncidentOptions) (*Inciden
 // CreateIncidentWithContext creates an incident synchronously without a
 // corresponding event from a monitoring service.
 func (c *Client) CreateIncidentWithContext(ctx context.Context, from string, o *CreateIncidentOptions) (*Incident, error) {
+	if err := c.checkFrom(from); err != nil {
+		return nil, err
+	}
+
+	if o == nil {
+		return nil, errors.New("incident options must not be nil")
+	}
+
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
 	h := map[string]string{
 		"From": from,
 	}
@@ -284,6 +366,16 @@ func (c *Client) ManageIncidents(from string, incidents []ManageIncidentsOptions
 // ManageIncidentsWithContext acknowledges, resolves, escalates, or reassigns
 // one or more incidents.
 func (c *Client) ManageIncidentsWithContext(ctx context.Context, from string, incidents []ManageIncidentsOptions) (*ListIncidentsResponse, error) {
+	if err := c.checkFrom(from); err != nil {
+		return nil, err
+	}
+
+	for _, o := range incidents {
+		if err := o.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	// see: https://github.com/PagerDuty/go-pagerduty/issues/390
 	for i := range incidents {
 		incidents[i].Type = "incident"
@@ -350,20 +442,12 @@ func (c *Client) GetIncident(id string) (*Incident, error) {
 // GetIncidentWithContext shows detailed information about an incident.
 func (c *Client) GetIncidentWithContext(ctx context.Context, id string) (*Incident, error) {
 	resp, err := c.get(ctx, "/incidents/"+id)
-	if err != nil {
-		return nil, err
-	}
 
-	var result map[string]Incident
-	if err := c.decodeJSON(resp, &result); err != nil {
+	var i Incident
+	if err := decodeEnvelope(c, resp, err, "incident", &i); err != nil {
 		return nil, err
 	}
 
-	i, ok := result["incident"]
-	if !ok {
-		return nil, fmt.Errorf("JSON response does not have incident field")
-	}
-
 	return &i, nil
 }
 
@@ -372,7 +456,7 @@ type IncidentNote struct {
 	ID        string    `json:"id,omitempty"`
 	User      APIObject `json:"user,omitempty"`
 	Content   string    `json:"content,omitempty"`
-	CreatedAt string    `json:"created_at,omitempty"`
+	CreatedAt APITime   `json:"created_at,omitempty"`
 }
 
 // CreateIncidentNoteResponse is returned from the API as a response to creating an incident note.
@@ -390,27 +474,19 @@ func (c *Client) ListIncidentNotes(id string) ([]IncidentNote, error) {
 // ListIncidentNotesWithContext lists existing notes for the specified incident.
 func (c *Client) ListIncidentNotesWithContext(ctx context.Context, id string) ([]IncidentNote, error) {
 	resp, err := c.get(ctx, "/incidents/"+id+"/notes")
-	if err != nil {
-		return nil, err
-	}
 
-	var result map[string][]IncidentNote
-	if err := c.decodeJSON(resp, &result); err != nil {
+	var notes []IncidentNote
+	if err := decodeEnvelope(c, resp, err, "notes", &notes); err != nil {
 		return nil, err
 	}
 
-	notes, ok := result["notes"]
-	if !ok {
-		return nil, fmt.Errorf("JSON response does not have notes field")
-	}
-
 	return notes, nil
 }
 
 // IncidentAlert is a alert for the specified incident.
 type IncidentAlert struct {
 	APIObject
-	CreatedAt   string                 `json:"created_at,omitempty"`
+	CreatedAt   APITime                `json:"created_at,omitempty"`
 	Status      string                 `json:"status,omitempty"`
 	AlertKey    string                 `json:"alert_key,omitempty"`
 	Service     APIObject              `json:"service,omitempty"`
@@ -498,6 +574,39 @@ func (c *Client) ListIncidentAlertsWithContext(ctx context.Context, id string, o
 	return &result, err
 }
 
+// ListIncidentAlertsPaginated lists the alerts of the specified incident,
+// handling pagination of the results.
+func (c *Client) ListIncidentAlertsPaginated(ctx context.Context, id string, o ListIncidentAlertsOptions) ([]IncidentAlert, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var alerts []IncidentAlert
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListAlertsResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		alerts = append(alerts, result.Alerts...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+			Total:  result.Total,
+		}, nil
+	}
+
+	if err := c.pagedGet(ctx, "/incidents/"+id+"/alerts?"+v.Encode(), responseHandler); err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+}
+
 // CreateIncidentNoteWithResponse creates a new note for the specified incident.
 //
 // Deprecated: Use CreateIncidentNoteWithContext instead.
@@ -607,8 +716,8 @@ type ListIncidentLogEntriesOptions struct {
 	Includes   []string `url:"include,omitempty,brackets"`
 	IsOverview bool     `url:"is_overview,omitempty"`
 	TimeZone   string   `url:"time_zone,omitempty"`
-	Since      string   `url:"since,omitempty"`
-	Until      string   `url:"until,omitempty"`
+	Since      APITime  `url:"since,omitempty"`
+	Until      APITime  `url:"until,omitempty"`
 }
 
 // ListIncidentLogEntries lists existing log entries for the specified incident.
@@ -639,15 +748,48 @@ func (c *Client) ListIncidentLogEntriesWithContext(ctx context.Context, id strin
 	return &result, nil
 }
 
+// ListIncidentLogEntriesPaginated lists existing log entries for the
+// specified incident, handling pagination of the results.
+func (c *Client) ListIncidentLogEntriesPaginated(ctx context.Context, id string, o ListIncidentLogEntriesOptions) ([]LogEntry, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []LogEntry
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListIncidentLogEntriesResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		entries = append(entries, result.LogEntries...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+			Total:  result.Total,
+		}, nil
+	}
+
+	if err := c.pagedGet(ctx, "/incidents/"+id+"/log_entries?"+v.Encode(), responseHandler); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
 // IncidentResponders contains details about responders to an incident.
 type IncidentResponders struct {
 	State       string    `json:"state"`
 	User        APIObject `json:"user"`
 	Incident    APIObject `json:"incident"`
-	UpdatedAt   string    `json:"updated_at"`
+	UpdatedAt   APITime   `json:"updated_at"`
 	Message     string    `json:"message"`
 	Requester   APIObject `json:"requester"`
-	RequestedAt string    `json:"requested_at"`
+	RequestedAt APITime   `json:"requested_at"`
 }
 
 // ResponderRequestResponse is the response from the API when requesting someone
@@ -667,6 +809,30 @@ type ResponderRequestTargetWrapper struct {
 	Target ResponderRequestTarget `json:"responder_request_target"`
 }
 
+// Validate returns an error if the responder request can't be created, such as
+// if it has no requester or no target.
+func (o ResponderRequestOptions) Validate() error {
+	if len(o.RequesterID) == 0 {
+		return errors.New("responder request requester ID must be set")
+	}
+
+	if len(o.Message) == 0 {
+		return errors.New("responder request message must be set")
+	}
+
+	if len(o.Targets) == 0 {
+		return errors.New("responder request must have at least one target")
+	}
+
+	for _, t := range o.Targets {
+		if len(t.Target.ID) == 0 {
+			return errors.New("responder request target must have an ID")
+		}
+	}
+
+	return nil
+}
+
 // ResponderRequestOptions defines the input options for the Create Responder function.
 type ResponderRequestOptions struct {
 	From        string                   `json:"-"`
@@ -679,7 +845,7 @@ type ResponderRequestOptions struct {
 type ResponderRequest struct {
 	Incident    Incident                 `json:"incident"`
 	Requester   User                     `json:"requester,omitempty"`
-	RequestedAt string                   `json:"request_at,omitempty"`
+	RequestedAt APITime                  `json:"request_at,omitempty"`
 	Message     string                   `json:"message,omitempty"`
 	Targets     []ResponderRequestTargetWrapper `json:"responder_request_targets"`
 }
@@ -693,6 +859,14 @@ func (c *Client) ResponderRequest(id string, o ResponderRequestOptions) (*Respon
 
 // ResponderRequestWithContext will submit a request to have a responder join an incident.
 func (c *Client) ResponderRequestWithContext(ctx context.Context, id string, o ResponderRequestOptions) (*ResponderRequestResponse, error) {
+	if err := c.checkFrom(o.From); err != nil {
+		return nil, err
+	}
+
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
 	h := map[string]string{
 		"From": o.From,
 	}
@@ -755,7 +929,7 @@ func (c *Client) ManageIncidentAlerts(ctx context.Context, incidentID, from stri
 type IncidentStatusUpdate struct {
 	ID        string    `json:"id"`
 	Message   string    `json:"message"`
-	CreatedAt string    `json:"created_at"`
+	CreatedAt APITime   `json:"created_at"`
 	Sender    APIObject `json:"sender"`
 }
 
@@ -0,0 +1,221 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/go-querystring/query"
+)
+
+// IncidentCustomField is the definition of a custom field of incidents.
+type IncidentCustomField struct {
+	ID          string `json:"id,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Summary     string `json:"summary,omitempty"`
+	Self        string `json:"self,omitempty"`
+	Name        string `json:"name,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// DataType is the type of the values of the field, such as
+	// IncidentCustomFieldDataTypeString. It can't be updated.
+	DataType string `json:"data_type,omitempty"`
+
+	// FieldType is whether the field has one or multiple values, and whether
+	// they're restricted to its FieldOptions, such as
+	// IncidentCustomFieldFieldTypeSingleValueFixed. It can't be updated.
+	FieldType string `json:"field_type,omitempty"`
+
+	DefaultValue interface{}                 `json:"default_value,omitempty"`
+	FieldOptions []IncidentCustomFieldOption `json:"field_options,omitempty"`
+	CreatedAt    APITime                     `json:"created_at,omitempty"`
+	UpdatedAt    APITime                     `json:"updated_at,omitempty"`
+}
+
+// Values of the DataType field of IncidentCustomField and
+// IncidentCustomFieldOptionData.
+const (
+	IncidentCustomFieldDataTypeBoolean  = "boolean"
+	IncidentCustomFieldDataTypeInteger  = "integer"
+	IncidentCustomFieldDataTypeFloat    = "float"
+	IncidentCustomFieldDataTypeString   = "string"
+	IncidentCustomFieldDataTypeDateTime = "datetime"
+	IncidentCustomFieldDataTypeURL      = "url"
+)
+
+// Values of the FieldType field of IncidentCustomField.
+const (
+	IncidentCustomFieldFieldTypeSingleValue      = "single_value"
+	IncidentCustomFieldFieldTypeSingleValueFixed = "single_value_fixed"
+	IncidentCustomFieldFieldTypeMultiValue       = "multi_value"
+	IncidentCustomFieldFieldTypeMultiValueFixed  = "multi_value_fixed"
+)
+
+// IncidentCustomFieldOption is one of the allowed values of a custom field
+// whose FieldType is fixed.
+type IncidentCustomFieldOption struct {
+	ID        string                        `json:"id,omitempty"`
+	Type      string                        `json:"type,omitempty"`
+	Data      IncidentCustomFieldOptionData `json:"data"`
+	CreatedAt APITime                       `json:"created_at,omitempty"`
+	UpdatedAt APITime                       `json:"updated_at,omitempty"`
+}
+
+// IncidentCustomFieldOptionData is the value of a custom field option.
+type IncidentCustomFieldOptionData struct {
+	DataType string `json:"data_type"`
+	Value    string `json:"value"`
+}
+
+// Values of the Includes field of ListIncidentCustomFieldsOptions and
+// GetIncidentCustomFieldOptions.
+const (
+	IncidentCustomFieldIncludeFieldOptions = "field_options"
+)
+
+// ListIncidentCustomFieldsOptions is the data structure used when calling the
+// ListIncidentCustomFields API endpoint.
+type ListIncidentCustomFieldsOptions struct {
+	Includes []string `url:"include,omitempty,brackets"`
+}
+
+// ListIncidentCustomFieldsResponse is the data structure returned from calling
+// the ListIncidentCustomFields API endpoint.
+type ListIncidentCustomFieldsResponse struct {
+	Fields []IncidentCustomField `json:"fields"`
+}
+
+// ListIncidentCustomFieldsWithContext lists the custom fields of incidents.
+func (c *Client) ListIncidentCustomFieldsWithContext(ctx context.Context, o ListIncidentCustomFieldsOptions) (*ListIncidentCustomFieldsResponse, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, "/incidents/custom_fields?"+v.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListIncidentCustomFieldsResponse
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateIncidentCustomFieldWithContext creates a custom field of incidents.
+// The FieldOptions of fixed fields can be created along with it.
+func (c *Client) CreateIncidentCustomFieldWithContext(ctx context.Context, f IncidentCustomField) (*IncidentCustomField, error) {
+	d := map[string]IncidentCustomField{
+		"field": f,
+	}
+
+	resp, err := c.post(ctx, "/incidents/custom_fields", d, nil)
+	return getIncidentCustomFieldFromResponse(c, resp, err)
+}
+
+// GetIncidentCustomFieldOptions is the data structure used when calling the
+// GetIncidentCustomField API endpoint.
+type GetIncidentCustomFieldOptions struct {
+	Includes []string `url:"include,omitempty,brackets"`
+}
+
+// GetIncidentCustomFieldWithContext gets a custom field of incidents.
+func (c *Client) GetIncidentCustomFieldWithContext(ctx context.Context, id string, o GetIncidentCustomFieldOptions) (*IncidentCustomField, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, "/incidents/custom_fields/"+id+"?"+v.Encode())
+	return getIncidentCustomFieldFromResponse(c, resp, err)
+}
+
+// UpdateIncidentCustomFieldWithContext updates the display name, description
+// or default value of a custom field of incidents.
+func (c *Client) UpdateIncidentCustomFieldWithContext(ctx context.Context, id string, f IncidentCustomField) (*IncidentCustomField, error) {
+	d := map[string]IncidentCustomField{
+		"field": f,
+	}
+
+	resp, err := c.put(ctx, "/incidents/custom_fields/"+id, d, nil)
+	return getIncidentCustomFieldFromResponse(c, resp, err)
+}
+
+// DeleteIncidentCustomFieldWithContext deletes a custom field of incidents,
+// along with its values.
+func (c *Client) DeleteIncidentCustomFieldWithContext(ctx context.Context, id string) error {
+	_, err := c.delete(ctx, "/incidents/custom_fields/"+id)
+	return err
+}
+
+// ListIncidentCustomFieldOptionsResponse is the data structure returned from
+// calling the ListIncidentCustomFieldOptions API endpoint.
+type ListIncidentCustomFieldOptionsResponse struct {
+	FieldOptions []IncidentCustomFieldOption `json:"field_options"`
+}
+
+// ListIncidentCustomFieldOptionsWithContext lists the options of a fixed
+// custom field of incidents.
+func (c *Client) ListIncidentCustomFieldOptionsWithContext(ctx context.Context, fieldID string) (*ListIncidentCustomFieldOptionsResponse, error) {
+	resp, err := c.get(ctx, "/incidents/custom_fields/"+fieldID+"/field_options")
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListIncidentCustomFieldOptionsResponse
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateIncidentCustomFieldOptionWithContext adds an option to a fixed custom
+// field of incidents.
+func (c *Client) CreateIncidentCustomFieldOptionWithContext(ctx context.Context, fieldID string, o IncidentCustomFieldOption) (*IncidentCustomFieldOption, error) {
+	d := map[string]IncidentCustomFieldOption{
+		"field_option": o,
+	}
+
+	resp, err := c.post(ctx, "/incidents/custom_fields/"+fieldID+"/field_options", d, nil)
+	return getIncidentCustomFieldOptionFromResponse(c, resp, err)
+}
+
+// UpdateIncidentCustomFieldOptionWithContext updates an option of a fixed
+// custom field of incidents.
+func (c *Client) UpdateIncidentCustomFieldOptionWithContext(ctx context.Context, fieldID, id string, o IncidentCustomFieldOption) (*IncidentCustomFieldOption, error) {
+	d := map[string]IncidentCustomFieldOption{
+		"field_option": o,
+	}
+
+	resp, err := c.put(ctx, "/incidents/custom_fields/"+fieldID+"/field_options/"+id, d, nil)
+	return getIncidentCustomFieldOptionFromResponse(c, resp, err)
+}
+
+// DeleteIncidentCustomFieldOptionWithContext deletes an option of a fixed
+// custom field of incidents.
+func (c *Client) DeleteIncidentCustomFieldOptionWithContext(ctx context.Context, fieldID, id string) error {
+	_, err := c.delete(ctx, "/incidents/custom_fields/"+fieldID+"/field_options/"+id)
+	return err
+}
+
+func getIncidentCustomFieldFromResponse(c *Client, resp *http.Response, err error) (*IncidentCustomField, error) {
+	var t IncidentCustomField
+	if err := decodeEnvelope(c, resp, err, "field", &t); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func getIncidentCustomFieldOptionFromResponse(c *Client, resp *http.Response, err error) (*IncidentCustomFieldOption, error) {
+	var t IncidentCustomFieldOption
+	if err := decodeEnvelope(c, resp, err, "field_option", &t); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,174 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIncidentCustomField_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents/custom_fields", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, []string{IncidentCustomFieldIncludeFieldOptions}, r.URL.Query()["include[]"])
+		_, _ = w.Write([]byte(`{"fields": [{"id": "F1", "name": "environment", "data_type": "string", "field_type": "single_value_fixed", "field_options": [{"id": "O1", "data": {"data_type": "string", "value": "production"}}], "created_at": "2021-01-01T00:00:00Z"}]}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListIncidentCustomFieldsWithContext(context.Background(), ListIncidentCustomFieldsOptions{
+		Includes: []string{IncidentCustomFieldIncludeFieldOptions},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &ListIncidentCustomFieldsResponse{
+		Fields: []IncidentCustomField{
+			{
+				ID:        "F1",
+				Name:      "environment",
+				DataType:  IncidentCustomFieldDataTypeString,
+				FieldType: IncidentCustomFieldFieldTypeSingleValueFixed,
+				FieldOptions: []IncidentCustomFieldOption{
+					{ID: "O1", Data: IncidentCustomFieldOptionData{DataType: "string", Value: "production"}},
+				},
+				CreatedAt: NewAPITime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+	testEqual(t, want, res)
+}
+
+func TestIncidentCustomField_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents/custom_fields", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]IncidentCustomField
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+		testEqual(t, "environment", body["field"].Name)
+
+		_, _ = w.Write([]byte(`{"field": {"id": "F1", "name": "environment"}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.CreateIncidentCustomFieldWithContext(context.Background(), IncidentCustomField{
+		Name:        "environment",
+		DisplayName: "Environment",
+		DataType:    IncidentCustomFieldDataTypeString,
+		FieldType:   IncidentCustomFieldFieldTypeSingleValue,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, &IncidentCustomField{ID: "F1", Name: "environment"}, res)
+}
+
+func TestIncidentCustomField_GetUpdateDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents/custom_fields/F1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"field": {"id": "F1", "default_value": "staging"}}`))
+		case http.MethodPut:
+			_, _ = w.Write([]byte(`{"field": {"id": "F1", "display_name": "Env"}}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.GetIncidentCustomFieldWithContext(context.Background(), "F1", GetIncidentCustomFieldOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "staging", res.DefaultValue)
+
+	res, err = client.UpdateIncidentCustomFieldWithContext(context.Background(), "F1", IncidentCustomField{DisplayName: "Env"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "Env", res.DisplayName)
+
+	if err := client.DeleteIncidentCustomFieldWithContext(context.Background(), "F1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIncidentCustomField_Options(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents/custom_fields/F1/field_options", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"field_options": [{"id": "O1", "data": {"data_type": "string", "value": "production"}}]}`))
+		case http.MethodPost:
+			var body map[string]IncidentCustomFieldOption
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode request body: %s", err)
+			}
+			testEqual(t, "staging", body["field_option"].Data.Value)
+
+			_, _ = w.Write([]byte(`{"field_option": {"id": "O2", "data": {"data_type": "string", "value": "staging"}}}`))
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	mux.HandleFunc("/incidents/custom_fields/F1/field_options/O2", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			_, _ = w.Write([]byte(`{"field_option": {"id": "O2", "data": {"data_type": "string", "value": "stage"}}}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+	ctx := context.Background()
+
+	list, err := client.ListIncidentCustomFieldOptionsWithContext(ctx, "F1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, 1, len(list.FieldOptions))
+
+	data := IncidentCustomFieldOptionData{DataType: IncidentCustomFieldDataTypeString, Value: "staging"}
+
+	created, err := client.CreateIncidentCustomFieldOptionWithContext(ctx, "F1", IncidentCustomFieldOption{Data: data})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "O2", created.ID)
+
+	data.Value = "stage"
+
+	updated, err := client.UpdateIncidentCustomFieldOptionWithContext(ctx, "F1", "O2", IncidentCustomFieldOption{Data: data})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "stage", updated.Data.Value)
+
+	if err := client.DeleteIncidentCustomFieldOptionWithContext(ctx, "F1", "O2"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,312 @@
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-querystring/query"
+)
+
+// Values of the Format field of IncidentExportOptions.
+const (
+	IncidentExportFormatCSV   = "csv"
+	IncidentExportFormatJSONL = "jsonl"
+)
+
+// Values of the Fields field of IncidentExportOptions. References to other
+// objects, such as the service or the assignees of an incident, are exported
+// as their names.
+const (
+	IncidentExportFieldID               = "id"
+	IncidentExportFieldNumber           = "incident_number"
+	IncidentExportFieldTitle            = "title"
+	IncidentExportFieldStatus           = "status"
+	IncidentExportFieldUrgency          = "urgency"
+	IncidentExportFieldPriority         = "priority"
+	IncidentExportFieldService          = "service"
+	IncidentExportFieldEscalationPolicy = "escalation_policy"
+	IncidentExportFieldAssignees        = "assignees"
+	IncidentExportFieldTeams            = "teams"
+	IncidentExportFieldCreatedAt        = "created_at"
+	IncidentExportFieldResolvedAt       = "resolved_at"
+	IncidentExportFieldURL              = "html_url"
+)
+
+// IncidentExportFieldNotes is the field of the notes of an incident, added
+// by the IncludeNotes field of IncidentExportOptions.
+const IncidentExportFieldNotes = "notes"
+
+// DefaultIncidentExportFields are the fields exported when the Fields field
+// of IncidentExportOptions is empty.
+var DefaultIncidentExportFields = []string{
+	IncidentExportFieldNumber,
+	IncidentExportFieldTitle,
+	IncidentExportFieldStatus,
+	IncidentExportFieldUrgency,
+	IncidentExportFieldPriority,
+	IncidentExportFieldService,
+	IncidentExportFieldAssignees,
+	IncidentExportFieldCreatedAt,
+	IncidentExportFieldResolvedAt,
+}
+
+// IncidentExportOptions is the data structure used when calling the
+// ExportIncidentsWithContext API helper.
+type IncidentExportOptions struct {
+	// Format is the format of the export, such as IncidentExportFormatCSV.
+	Format string
+
+	// Fields are the fields exported, in order, such as
+	// IncidentExportFieldTitle. They default to DefaultIncidentExportFields.
+	Fields []string
+
+	// IncludeNotes exports the notes of the incidents, which requires a
+	// request for each incident.
+	IncludeNotes bool
+
+	// ListOptions selects the incidents exported. Its pagination fields are
+	// ignored.
+	ListOptions ListIncidentsOptions
+}
+
+// exportedIncidentNote is a note of an exported incident.
+type exportedIncidentNote struct {
+	CreatedAt APITime `json:"created_at"`
+	User      string  `json:"user"`
+	Content   string  `json:"content"`
+}
+
+// ExportIncidentsWithContext writes the incidents selected by o to w, as CSV
+// with a header row, or as JSON Lines with one object per incident. The
+// incidents are written as their pages are listed, rather than once all of
+// them are, and the pages are listed concurrently by clients created with
+// WithParallelPagination. Requests that are rate limited, or that fail with a
+// 5xx status code, are retried according to DefaultRetryPolicy. It returns the
+// number of incidents written.
+func (c *Client) ExportIncidentsWithContext(ctx context.Context, w io.Writer, o IncidentExportOptions) (int, error) {
+	fields := o.Fields
+	if len(fields) == 0 {
+		fields = DefaultIncidentExportFields
+	}
+
+	if o.IncludeNotes {
+		fields = append(fields[:len(fields):len(fields)], IncidentExportFieldNotes)
+	}
+
+	for _, f := range fields {
+		if _, ok := incidentExportFieldValue(Incident{}, nil, f); !ok {
+			return 0, fmt.Errorf("unknown incident export field %q", f)
+		}
+	}
+
+	var write func(values []interface{}) error
+
+	switch o.Format {
+	case IncidentExportFormatCSV:
+		cw := csv.NewWriter(w)
+
+		if err := cw.Write(fields); err != nil {
+			return 0, err
+		}
+
+		write = func(values []interface{}) error {
+			record := make([]string, len(values))
+			for i, v := range values {
+				record[i] = incidentExportCSVValue(v)
+			}
+
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+
+			// flushed for every incident, so that the export is streamed
+			cw.Flush()
+
+			return cw.Error()
+		}
+
+	case IncidentExportFormatJSONL:
+		write = func(values []interface{}) error {
+			line, err := incidentExportJSONLine(fields, values)
+			if err != nil {
+				return err
+			}
+
+			_, err = w.Write(line)
+
+			return err
+		}
+
+	default:
+		return 0, fmt.Errorf("unknown incident export format %q", o.Format)
+	}
+
+	// the pagination is done by pagedGet
+	lo := o.ListOptions
+	lo.Offset, lo.Total = 0, false
+
+	v, err := query.Values(lo)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx = contextWithRetryPolicy(ctx, incidentExportRetryPolicy)
+
+	var n int
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListIncidentsResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		for _, i := range result.Incidents {
+			var notes []IncidentNote
+
+			if o.IncludeNotes {
+				var err error
+				if notes, err = c.ListIncidentNotesWithContext(ctx, i.ID); err != nil {
+					return APIListObject{}, fmt.Errorf("failed to list notes of incident %s: %w", i.ID, err)
+				}
+			}
+
+			values := make([]interface{}, len(fields))
+			for j, f := range fields {
+				values[j], _ = incidentExportFieldValue(i, notes, f)
+			}
+
+			if err := write(values); err != nil {
+				return APIListObject{}, err
+			}
+
+			n++
+		}
+
+		return result.APIListObject, nil
+	}
+
+	err = c.pagedGet(ctx, "/incidents?"+v.Encode(), responseHandler)
+
+	return n, err
+}
+
+// incidentExportFieldValue returns the value of a field of an exported
+// incident, and whether the field exists.
+func incidentExportFieldValue(i Incident, notes []IncidentNote, field string) (interface{}, bool) {
+	switch field {
+	case IncidentExportFieldID:
+		return i.ID, true
+	case IncidentExportFieldNumber:
+		return i.IncidentNumber, true
+	case IncidentExportFieldTitle:
+		return i.Title, true
+	case IncidentExportFieldStatus:
+		return i.Status, true
+	case IncidentExportFieldUrgency:
+		return i.Urgency, true
+	case IncidentExportFieldPriority:
+		if i.Priority == nil {
+			return "", true
+		}
+
+		return i.Priority.Summary, true
+	case IncidentExportFieldService:
+		return i.Service.Summary, true
+	case IncidentExportFieldEscalationPolicy:
+		return i.EscalationPolicy.Summary, true
+	case IncidentExportFieldAssignees:
+		assignees := make([]string, len(i.Assignments))
+		for n, a := range i.Assignments {
+			assignees[n] = a.Assignee.Summary
+		}
+
+		return assignees, true
+	case IncidentExportFieldTeams:
+		teams := make([]string, len(i.Teams))
+		for n, t := range i.Teams {
+			teams[n] = t.Summary
+		}
+
+		return teams, true
+	case IncidentExportFieldCreatedAt:
+		return i.CreatedAt, true
+	case IncidentExportFieldResolvedAt:
+		return i.ResolvedAt, true
+	case IncidentExportFieldURL:
+		return i.HTMLURL, true
+	case IncidentExportFieldNotes:
+		exported := make([]exportedIncidentNote, len(notes))
+		for n, note := range notes {
+			exported[n] = exportedIncidentNote{CreatedAt: note.CreatedAt, User: note.User.Summary, Content: note.Content}
+		}
+
+		return exported, true
+	default:
+		return nil, false
+	}
+}
+
+// incidentExportCSVValue formats the value of a field for CSV, with lists
+// joined by commas, and notes on separate lines.
+func incidentExportCSVValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case []string:
+		return strings.Join(v, ", ")
+	case []exportedIncidentNote:
+		lines := make([]string, len(v))
+		for i, n := range v {
+			lines[i] = fmt.Sprintf("%s %s: %s", n.CreatedAt, n.User, n.Content)
+		}
+
+		return strings.Join(lines, "\n")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// incidentExportJSONLine encodes the values of the fields of an exported
+// incident as a JSON object, with the fields in order, followed by a newline.
+func incidentExportJSONLine(fields []string, values []interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, f := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		k, err := json.Marshal(f)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := json.Marshal(values[i])
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+
+	buf.WriteString("}\n")
+
+	return buf.Bytes(), nil
+}
+
+// incidentExportRetryPolicy is how the requests of incident exports are
+// retried.
+var incidentExportRetryPolicy = DefaultRetryPolicy
@@ -0,0 +1,100 @@
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIncident_ExportIncidents(t *testing.T) {
+	setup()
+	defer teardown()
+
+	defer func(p RetryPolicy) { incidentExportRetryPolicy = p }(incidentExportRetryPolicy)
+	incidentExportRetryPolicy = RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}
+
+	var rateLimited bool
+
+	mux.HandleFunc("/incidents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, []string{"resolved"}, r.URL.Query()["statuses[]"])
+
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			_, _ = w.Write([]byte(`{"incidents": [{"id": "PI1", "incident_number": 1, "title": "Disk, full", "service": {"summary": "DB"}, "assignments": [{"assignee": {"summary": "Jane"}}, {"assignee": {"summary": "John"}}]}], "offset": 0, "limit": 1, "total": 2, "more": true}`))
+
+		case "1":
+			if !rateLimited {
+				rateLimited = true
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"incidents": [{"id": "PI2", "incident_number": 2, "title": "Down", "priority": {"summary": "P1"}}], "offset": 1, "limit": 1, "total": 2, "more": false}`))
+
+		default:
+			t.Errorf("unexpected offset %s", r.URL.Query().Get("offset"))
+		}
+	})
+
+	mux.HandleFunc("/incidents/PI1/notes", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"notes": [{"content": "Cleaned up", "created_at": "2021-01-01T00:00:00Z", "user": {"summary": "Jane"}}]}`))
+	})
+
+	mux.HandleFunc("/incidents/PI2/notes", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"notes": []}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	o := IncidentExportOptions{
+		Format:       IncidentExportFormatCSV,
+		Fields:       []string{IncidentExportFieldNumber, IncidentExportFieldTitle, IncidentExportFieldPriority, IncidentExportFieldService, IncidentExportFieldAssignees},
+		IncludeNotes: true,
+		ListOptions:  ListIncidentsOptions{Statuses: []string{"resolved"}},
+	}
+
+	var buf bytes.Buffer
+
+	n, err := client.ExportIncidentsWithContext(context.Background(), &buf, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 2, n)
+	testEqual(t, "incident_number,title,priority,service,assignees,notes\n"+
+		"1,\"Disk, full\",,DB,\"Jane, John\",2021-01-01T00:00:00Z Jane: Cleaned up\n"+
+		"2,Down,P1,,,\n", buf.String())
+
+	buf.Reset()
+	rateLimited = false
+
+	o.Format = IncidentExportFormatJSONL
+	o.Fields = []string{IncidentExportFieldID, IncidentExportFieldAssignees}
+	o.IncludeNotes = false
+
+	if _, err := client.ExportIncidentsWithContext(context.Background(), &buf, o); err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, `{"id":"PI1","assignees":["Jane","John"]}`+"\n"+`{"id":"PI2","assignees":[]}`+"\n", buf.String())
+
+	// the pages are listed concurrently, and still written in order
+	buf.Reset()
+	rateLimited = false
+
+	WithParallelPagination(2)(client)
+
+	if _, err := client.ExportIncidentsWithContext(context.Background(), &buf, o); err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, `{"id":"PI1","assignees":["Jane","John"]}`+"\n"+`{"id":"PI2","assignees":[]}`+"\n", buf.String())
+
+	o.Fields = []string{"foo"}
+
+	_, err = client.ExportIncidentsWithContext(context.Background(), &buf, o)
+	testErrCheck(t, "ExportIncidentsWithContext()", `unknown incident export field "foo"`, err)
+}
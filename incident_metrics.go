@@ -0,0 +1,135 @@
+package pagerduty
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// IncidentResponseMetrics are the response metrics of an incident, derived
+// from its log entries by ComputeIncidentResponseMetrics. They're available
+// to accounts without the analytics add-on.
+type IncidentResponseMetrics struct {
+	// TriggeredAt is when the incident was triggered.
+	TriggeredAt time.Time
+
+	// AcknowledgedAt is when the incident was first acknowledged, or zero if
+	// it wasn't.
+	AcknowledgedAt time.Time
+
+	// ResolvedAt is when the incident was last resolved, or zero if it
+	// wasn't.
+	ResolvedAt time.Time
+
+	// TimeToAcknowledge is the time from the trigger of the incident to its
+	// first acknowledgement, or zero if it wasn't acknowledged.
+	TimeToAcknowledge time.Duration
+
+	// TimeToResolve is the time from the trigger of the incident to its last
+	// resolution, or zero if it wasn't resolved.
+	TimeToResolve time.Duration
+
+	// AcknowledgementCount is the number of times the incident was
+	// acknowledged.
+	AcknowledgementCount int
+
+	// ReassignmentCount is the number of times the incident was assigned to
+	// other users, excluding escalations.
+	ReassignmentCount int
+
+	// EscalationCount is the number of times the incident was escalated,
+	// either manually or because it wasn't acknowledged in time.
+	EscalationCount int
+}
+
+// Acknowledged returns whether the incident was acknowledged.
+func (m IncidentResponseMetrics) Acknowledged() bool {
+	return !m.AcknowledgedAt.IsZero()
+}
+
+// Resolved returns whether the incident was resolved.
+func (m IncidentResponseMetrics) Resolved() bool {
+	return !m.ResolvedAt.IsZero()
+}
+
+// ComputeIncidentResponseMetrics derives the response metrics of an incident
+// from all of its log entries, in any order, such as returned by
+// ListIncidentLogEntriesPaginated. It returns an error if there's no trigger
+// log entry, or if a log entry has no creation time.
+func ComputeIncidentResponseMetrics(entries []LogEntry) (IncidentResponseMetrics, error) {
+	type timedEntry struct {
+		at    time.Time
+		entry LogEntry
+	}
+
+	timed := make([]timedEntry, len(entries))
+
+	for i, e := range entries {
+		if e.CreatedAt.IsZero() {
+			return IncidentResponseMetrics{}, fmt.Errorf("missing creation time of log entry %s", e.ID)
+		}
+
+		timed[i] = timedEntry{at: e.CreatedAt.Time, entry: e}
+	}
+
+	sort.SliceStable(timed, func(i, j int) bool { return timed[i].at.Before(timed[j].at) })
+
+	var m IncidentResponseMetrics
+
+	for _, t := range timed {
+		switch t.entry.Type {
+		case LogEntryTypeTrigger:
+			if m.TriggeredAt.IsZero() {
+				m.TriggeredAt = t.at
+			}
+
+		case LogEntryTypeAcknowledge:
+			m.AcknowledgementCount++
+
+			if m.AcknowledgedAt.IsZero() {
+				m.AcknowledgedAt = t.at
+			}
+
+		case LogEntryTypeResolve:
+			m.ResolvedAt = t.at
+
+		case LogEntryTypeAssign:
+			m.ReassignmentCount++
+
+		case LogEntryTypeEscalate:
+			m.EscalationCount++
+		}
+	}
+
+	if m.TriggeredAt.IsZero() {
+		return IncidentResponseMetrics{}, errors.New("log entries have no trigger log entry")
+	}
+
+	if m.Acknowledged() {
+		m.TimeToAcknowledge = m.AcknowledgedAt.Sub(m.TriggeredAt)
+	}
+
+	if m.Resolved() {
+		m.TimeToResolve = m.ResolvedAt.Sub(m.TriggeredAt)
+	}
+
+	return m, nil
+}
+
+// GetIncidentResponseMetricsWithContext lists the log entries of an incident,
+// and derives its response metrics from them.
+func (c *Client) GetIncidentResponseMetricsWithContext(ctx context.Context, incidentID string) (*IncidentResponseMetrics, error) {
+	entries, err := c.ListIncidentLogEntriesPaginated(ctx, incidentID, ListIncidentLogEntriesOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := ComputeIncidentResponseMetrics(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
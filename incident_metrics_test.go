@@ -0,0 +1,83 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestComputeIncidentResponseMetrics(t *testing.T) {
+	entry := func(typ, createdAt string) LogEntry {
+		at, err := ParseAPITime(createdAt)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return LogEntry{CommonLogEntryField: CommonLogEntryField{
+			APIObject: APIObject{ID: "PL1", Type: typ},
+			CreatedAt: at,
+		}}
+	}
+
+	// in the reverse chronological order returned by the API
+	entries := []LogEntry{
+		entry(LogEntryTypeResolve, "2021-01-01T01:00:00Z"),
+		entry(LogEntryTypeAcknowledge, "2021-01-01T00:20:00Z"),
+		entry(LogEntryTypeAssign, "2021-01-01T00:15:00Z"),
+		entry(LogEntryTypeAcknowledge, "2021-01-01T00:10:00Z"),
+		entry(LogEntryTypeEscalate, "2021-01-01T00:05:00Z"),
+		entry(LogEntryTypeNotify, "2021-01-01T00:00:01Z"),
+		entry(LogEntryTypeTrigger, "2021-01-01T00:00:00Z"),
+	}
+
+	got, err := ComputeIncidentResponseMetrics(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	triggered := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	want := IncidentResponseMetrics{
+		TriggeredAt:          triggered,
+		AcknowledgedAt:       triggered.Add(10 * time.Minute),
+		ResolvedAt:           triggered.Add(time.Hour),
+		TimeToAcknowledge:    10 * time.Minute,
+		TimeToResolve:        time.Hour,
+		AcknowledgementCount: 2,
+		ReassignmentCount:    1,
+		EscalationCount:      1,
+	}
+
+	testEqual(t, want, got)
+
+	_, err = ComputeIncidentResponseMetrics(entries[:1])
+	testErrCheck(t, "ComputeIncidentResponseMetrics()", "no trigger log entry", err)
+
+	_, err = ComputeIncidentResponseMetrics([]LogEntry{entry(LogEntryTypeTrigger, "")})
+	testErrCheck(t, "ComputeIncidentResponseMetrics()", "missing creation time of log entry PL1", err)
+}
+
+func TestIncident_GetIncidentResponseMetrics(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents/PI1/log_entries", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"log_entries": [
+			{"type": "acknowledge_log_entry", "created_at": "2021-01-01T00:02:00Z"},
+			{"type": "trigger_log_entry", "created_at": "2021-01-01T00:00:00Z"}
+		]}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.GetIncidentResponseMetricsWithContext(context.Background(), "PI1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 2*time.Minute, res.TimeToAcknowledge)
+	testEqual(t, true, res.Acknowledged())
+	testEqual(t, false, res.Resolved())
+}
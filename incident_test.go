@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestIncident_List(t *testing.T) {
@@ -47,6 +48,7 @@ func TestIncident_Create(t *testing.T) {
 
 	input := &CreateIncidentOptions{
 		Title:   "foo",
+		Service: &APIReference{ID: "PS1", Type: "service_reference"},
 		Urgency: "low",
 	}
 
@@ -98,6 +100,13 @@ func TestIncident_Create(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+func TestIncident_Create_nilOptions(t *testing.T) {
+	client := defaultTestClient("http://localhost", "foo")
+
+	_, err := client.CreateIncidentWithContext(context.Background(), "foo@bar.com", nil)
+	testErrCheck(t, "CreateIncidentWithContext()", "incident options must not be nil", err)
+}
+
 func TestIncident_Manage_status(t *testing.T) {
 	setup()
 	defer teardown()
@@ -698,7 +707,7 @@ func TestIncident_SnoozeIncidentWithResponse(t *testing.T) {
 		PendingActions: []PendingAction{
 			{
 				Type: "unacknowledge",
-				At:   "2019-12-31T16:58:35Z",
+				At:   NewAPITime(time.Date(2019, 12, 31, 16, 58, 35, 0, time.UTC)),
 			},
 		},
 	}
@@ -768,8 +777,8 @@ func TestIncident_ListLogEntriesSinceUntil(t *testing.T) {
 		Includes:   []string{},
 		IsOverview: true,
 		TimeZone:   "UTC",
-		Since:      "2020-03-27T22:40:00-0700",
-		Until:      "2020-03-28T22:50:00-0700",
+		Since:      NewAPITime(time.Date(2020, 3, 27, 22, 40, 0, 0, time.FixedZone("", -7*60*60))),
+		Until:      NewAPITime(time.Date(2020, 3, 28, 22, 50, 0, 0, time.FixedZone("", -7*60*60))),
 	}
 	res, err := client.ListIncidentLogEntries(id, entriesOpts)
 
@@ -1124,3 +1133,73 @@ func TestIncident_RemoveIncidentNotificationSubscribersWithContext(t *testing.T)
 	}
 	testEqual(t, want, res)
 }
+
+func TestIncidentStatus_IsValid(t *testing.T) {
+	testEqual(t, true, IncidentStatus(IncidentStatusAcknowledged).IsValid())
+	testEqual(t, false, IncidentStatus("snoozed").IsValid())
+}
+
+func TestIncident_Validate(t *testing.T) {
+	service := ServiceRef("PS1")
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "create_ok",
+			err:  CreateIncidentOptions{Title: "foo", Service: &service, Urgency: UrgencyHigh}.Validate(),
+		},
+		{
+			name: "create_service",
+			err:  CreateIncidentOptions{Title: "foo"}.Validate(),
+			want: "incident service reference must be set",
+		},
+		{
+			name: "create_urgency",
+			err:  CreateIncidentOptions{Title: "foo", Service: &service, Urgency: "hgih"}.Validate(),
+			want: `invalid incident urgency "hgih"`,
+		},
+		{
+			name: "manage_status",
+			err:  ManageIncidentsOptions{ID: "PI1", Status: IncidentStatusTriggered}.Validate(),
+			want: `invalid status "triggered" of incident PI1`,
+		},
+		{
+			name: "manage_resolution",
+			err:  ManageIncidentsOptions{ID: "PI1", Resolution: "fixed"}.Validate(),
+			want: "incident PI1 must be resolved to have a resolution",
+		},
+		{
+			name: "responder_request_targets",
+			err:  ResponderRequestOptions{RequesterID: "PU1", Message: "Help"}.Validate(),
+			want: "responder request must have at least one target",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			testErrCheck(t, "Validate()", tt.want, tt.err)
+		})
+	}
+}
+
+func TestIncident_ManageIncidentsValidation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	_, err := client.ManageIncidentsWithContext(context.Background(), "", []ManageIncidentsOptions{{ID: "PI1"}})
+	testErrCheck(t, "ManageIncidentsWithContext()", "From email address must be set", err)
+
+	_, err = client.ManageIncidentsWithContext(context.Background(), "foo@bar.com", []ManageIncidentsOptions{{Status: IncidentStatusResolved}})
+	testErrCheck(t, "ManageIncidentsWithContext()", "incident ID must be set", err)
+}
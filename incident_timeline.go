@@ -0,0 +1,116 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Values of the Kind field of TimelineEntry.
+const (
+	TimelineEntryKindLogEntry     = "log_entry"
+	TimelineEntryKindNote         = "note"
+	TimelineEntryKindAlert        = "alert"
+	TimelineEntryKindStatusUpdate = "status_update"
+)
+
+// TimelineEntry is an entry of the timeline of an incident. Exactly one of
+// LogEntry, Note, Alert and StatusUpdate is set, according to Kind.
+type TimelineEntry struct {
+	// At is when the entry happened.
+	At time.Time
+
+	// Kind is the kind of the entry, such as TimelineEntryKindNote.
+	Kind string
+
+	LogEntry     *LogEntry
+	Note         *IncidentNote
+	Alert        *IncidentAlert
+	StatusUpdate *IncidentStatusUpdate
+}
+
+// BuildIncidentTimeline merges the log entries, notes and alerts of an
+// incident into a single timeline, sorted chronologically. Entries happening
+// at the same time keep the order of their kinds in the arguments.
+//
+// Status update log entries are returned as status updates, and annotate log
+// entries are omitted, as they duplicate the notes. It returns an error if an
+// entry has no creation time.
+func BuildIncidentTimeline(entries []LogEntry, notes []IncidentNote, alerts []IncidentAlert) ([]TimelineEntry, error) {
+	timeline := make([]TimelineEntry, 0, len(entries)+len(notes)+len(alerts))
+
+	add := func(createdAt APITime, kind, id string, e TimelineEntry) error {
+		if createdAt.IsZero() {
+			return fmt.Errorf("missing creation time of %s %s", kind, id)
+		}
+
+		e.At, e.Kind = createdAt.Time, kind
+		timeline = append(timeline, e)
+
+		return nil
+	}
+
+	for i := range entries {
+		l := &entries[i]
+
+		var err error
+
+		switch l.Type {
+		case LogEntryTypeAnnotate:
+			continue
+
+		case LogEntryTypeStatusUpdate:
+			err = add(l.CreatedAt, TimelineEntryKindStatusUpdate, l.ID, TimelineEntry{StatusUpdate: &IncidentStatusUpdate{
+				ID:        l.ID,
+				Message:   l.Message,
+				CreatedAt: l.CreatedAt,
+				Sender:    APIObject(l.Agent),
+			}})
+
+		default:
+			err = add(l.CreatedAt, TimelineEntryKindLogEntry, l.ID, TimelineEntry{LogEntry: l})
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range notes {
+		if err := add(notes[i].CreatedAt, TimelineEntryKindNote, notes[i].ID, TimelineEntry{Note: &notes[i]}); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range alerts {
+		if err := add(alerts[i].CreatedAt, TimelineEntryKindAlert, alerts[i].ID, TimelineEntry{Alert: &alerts[i]}); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool { return timeline[i].At.Before(timeline[j].At) })
+
+	return timeline, nil
+}
+
+// GetIncidentTimelineWithContext lists the log entries, notes and alerts of
+// an incident, and merges them into its timeline with BuildIncidentTimeline.
+func (c *Client) GetIncidentTimelineWithContext(ctx context.Context, id string) ([]TimelineEntry, error) {
+	entries, err := c.ListIncidentLogEntriesPaginated(ctx, id, ListIncidentLogEntriesOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	notes, err := c.ListIncidentNotesWithContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts, err := c.ListIncidentAlertsPaginated(ctx, id, ListIncidentAlertsOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return BuildIncidentTimeline(entries, notes, alerts)
+}
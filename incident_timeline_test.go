@@ -0,0 +1,54 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestIncident_GetIncidentTimeline(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents/PI1/log_entries", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"log_entries": [
+			{"id": "PL3", "type": "status_update_log_entry", "created_at": "2021-01-01T00:20:00Z", "message": "Investigating", "agent": {"id": "PU1", "type": "user_reference"}},
+			{"id": "PL2", "type": "annotate_log_entry", "created_at": "2021-01-01T00:10:00Z"},
+			{"id": "PL1", "type": "trigger_log_entry", "created_at": "2021-01-01T00:00:00Z"}
+		]}`))
+	})
+
+	mux.HandleFunc("/incidents/PI1/notes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"notes": [{"id": "PN1", "content": "Disk full", "created_at": "2021-01-01T00:10:00Z"}]}`))
+	})
+
+	mux.HandleFunc("/incidents/PI1/alerts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"alerts": [{"id": "PA1", "created_at": "2021-01-01T00:00:00Z"}]}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.GetIncidentTimelineWithContext(context.Background(), "PI1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var kinds []string
+	for _, e := range res {
+		kinds = append(kinds, e.Kind)
+	}
+
+	testEqual(t, []string{TimelineEntryKindLogEntry, TimelineEntryKindAlert, TimelineEntryKindNote, TimelineEntryKindStatusUpdate}, kinds)
+	testEqual(t, "PL1", res[0].LogEntry.ID)
+	testEqual(t, "Disk full", res[2].Note.Content)
+	testEqual(t, "Investigating", res[3].StatusUpdate.Message)
+	testEqual(t, "PU1", res[3].StatusUpdate.Sender.ID)
+}
+
+func TestBuildIncidentTimeline_MissingTime(t *testing.T) {
+	_, err := BuildIncidentTimeline(nil, []IncidentNote{{ID: "PN1"}}, nil)
+	testErrCheck(t, "BuildIncidentTimeline()", "missing creation time of note PN1", err)
+}
@@ -0,0 +1,213 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-querystring/query"
+)
+
+// IncidentWorkflowTrigger defines when an incident workflow is started, either
+// manually by responders or automatically when an incident of the services of
+// the trigger matches its condition.
+type IncidentWorkflowTrigger struct {
+	ID              string        `json:"id,omitempty"`
+	Type            string        `json:"type,omitempty"`
+	TriggerType     string        `json:"trigger_type,omitempty"`
+	TriggerTypeName string        `json:"trigger_type_name,omitempty"`
+	Workflow        *APIReference `json:"workflow,omitempty"`
+
+	// Condition is the PCL expression incidents must match to start a
+	// conditional workflow, such as built by IncidentWorkflowConditionMatches.
+	Condition string `json:"condition,omitempty"`
+
+	Services                  []APIReference                      `json:"services,omitempty"`
+	IsSubscribedToAllServices bool                                `json:"is_subscribed_to_all_services,omitempty"`
+	Permissions               *IncidentWorkflowTriggerPermissions `json:"permissions,omitempty"`
+}
+
+// IncidentWorkflowTriggerPermissions restricts who can start a manual
+// incident workflow.
+type IncidentWorkflowTriggerPermissions struct {
+	Restricted bool   `json:"restricted"`
+	TeamID     string `json:"team_id,omitempty"`
+}
+
+// Values of the TriggerType field of IncidentWorkflowTrigger.
+const (
+	IncidentWorkflowTriggerTypeManual      = "manual"
+	IncidentWorkflowTriggerTypeConditional = "conditional"
+)
+
+// IncidentWorkflowConditionMatches returns a PCL expression matching the
+// incidents whose field, such as "incident.priority", is value.
+func IncidentWorkflowConditionMatches(field, value string) string {
+	return fmt.Sprintf("%s matches '%s'", field, strings.ReplaceAll(value, "'", `\'`))
+}
+
+// IncidentWorkflowConditionAll returns a PCL expression matching the incidents
+// matching all the expressions.
+func IncidentWorkflowConditionAll(expressions ...string) string {
+	return joinIncidentWorkflowConditions(expressions, " and ")
+}
+
+// IncidentWorkflowConditionAny returns a PCL expression matching the incidents
+// matching any of the expressions.
+func IncidentWorkflowConditionAny(expressions ...string) string {
+	return joinIncidentWorkflowConditions(expressions, " or ")
+}
+
+func joinIncidentWorkflowConditions(expressions []string, op string) string {
+	if len(expressions) == 1 {
+		return expressions[0]
+	}
+
+	wrapped := make([]string, len(expressions))
+	for i, e := range expressions {
+		wrapped[i] = "(" + e + ")"
+	}
+
+	return strings.Join(wrapped, op)
+}
+
+// ListIncidentWorkflowTriggersOptions is the data structure used when calling
+// the ListIncidentWorkflowTriggers API endpoint.
+type ListIncidentWorkflowTriggersOptions struct {
+	IncidentWorkflowID   string `url:"incident_workflow_id,omitempty"`
+	ServiceID            string `url:"service_id,omitempty"`
+	TriggerType          string `url:"trigger_type,omitempty"`
+	WorkflowNameContains string `url:"workflow_name_contains,omitempty"`
+	IsSubscribed         bool   `url:"is_subscribed,omitempty"`
+
+	// Cursor is the cursor of the page to list. It's ignored by
+	// ListIncidentWorkflowTriggersPaginated.
+	Cursor string `url:"cursor,omitempty"`
+	Limit  uint   `url:"limit,omitempty"`
+}
+
+// ListIncidentWorkflowTriggersResponse is the data structure returned from
+// calling the ListIncidentWorkflowTriggers API endpoint.
+type ListIncidentWorkflowTriggersResponse struct {
+	Triggers   []IncidentWorkflowTrigger `json:"triggers"`
+	Limit      uint                      `json:"limit,omitempty"`
+	NextCursor *string                   `json:"next_cursor"`
+}
+
+// ListIncidentWorkflowTriggersWithContext lists incident workflow triggers.
+func (c *Client) ListIncidentWorkflowTriggersWithContext(ctx context.Context, o ListIncidentWorkflowTriggersOptions) (*ListIncidentWorkflowTriggersResponse, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, "/incident_workflows/triggers?"+v.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListIncidentWorkflowTriggersResponse
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListIncidentWorkflowTriggersPaginated lists all the incident workflow
+// triggers, handling pagination.
+func (c *Client) ListIncidentWorkflowTriggersPaginated(ctx context.Context, o ListIncidentWorkflowTriggersOptions) ([]IncidentWorkflowTrigger, error) {
+	o.Cursor = ""
+
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var triggers []IncidentWorkflowTrigger
+
+	responseHandler := func(response *http.Response) (cursor, error) {
+		var result ListIncidentWorkflowTriggersResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return cursor{}, err
+		}
+
+		triggers = append(triggers, result.Triggers...)
+
+		next := cursor{Limit: result.Limit}
+		if result.NextCursor != nil {
+			next.NextCursor = *result.NextCursor
+		}
+
+		return next, nil
+	}
+
+	if err := c.cursorGet(ctx, "/incident_workflows/triggers?"+v.Encode(), responseHandler); err != nil {
+		return nil, err
+	}
+
+	return triggers, nil
+}
+
+// CreateIncidentWorkflowTriggerWithContext creates an incident workflow
+// trigger.
+func (c *Client) CreateIncidentWorkflowTriggerWithContext(ctx context.Context, t IncidentWorkflowTrigger) (*IncidentWorkflowTrigger, error) {
+	d := map[string]IncidentWorkflowTrigger{
+		"trigger": t,
+	}
+
+	resp, err := c.post(ctx, "/incident_workflows/triggers", d, nil)
+	return getIncidentWorkflowTriggerFromResponse(c, resp, err)
+}
+
+// GetIncidentWorkflowTriggerWithContext gets an incident workflow trigger.
+func (c *Client) GetIncidentWorkflowTriggerWithContext(ctx context.Context, id string) (*IncidentWorkflowTrigger, error) {
+	resp, err := c.get(ctx, "/incident_workflows/triggers/"+id)
+	return getIncidentWorkflowTriggerFromResponse(c, resp, err)
+}
+
+// UpdateIncidentWorkflowTriggerWithContext updates an incident workflow
+// trigger. Its workflow and trigger type can't be changed.
+func (c *Client) UpdateIncidentWorkflowTriggerWithContext(ctx context.Context, id string, t IncidentWorkflowTrigger) (*IncidentWorkflowTrigger, error) {
+	d := map[string]IncidentWorkflowTrigger{
+		"trigger": t,
+	}
+
+	resp, err := c.put(ctx, "/incident_workflows/triggers/"+id, d, nil)
+	return getIncidentWorkflowTriggerFromResponse(c, resp, err)
+}
+
+// DeleteIncidentWorkflowTriggerWithContext deletes an incident workflow
+// trigger.
+func (c *Client) DeleteIncidentWorkflowTriggerWithContext(ctx context.Context, id string) error {
+	_, err := c.delete(ctx, "/incident_workflows/triggers/"+id)
+	return err
+}
+
+// AssociateServiceToIncidentWorkflowTriggerWithContext adds a service to an
+// incident workflow trigger.
+func (c *Client) AssociateServiceToIncidentWorkflowTriggerWithContext(ctx context.Context, triggerID, serviceID string) (*IncidentWorkflowTrigger, error) {
+	d := map[string]APIReference{
+		"service": ServiceRef(serviceID),
+	}
+
+	resp, err := c.post(ctx, "/incident_workflows/triggers/"+triggerID+"/services", d, nil)
+	return getIncidentWorkflowTriggerFromResponse(c, resp, err)
+}
+
+// DeleteServiceFromIncidentWorkflowTriggerWithContext removes a service from
+// an incident workflow trigger.
+func (c *Client) DeleteServiceFromIncidentWorkflowTriggerWithContext(ctx context.Context, triggerID, serviceID string) (*IncidentWorkflowTrigger, error) {
+	resp, err := c.delete(ctx, "/incident_workflows/triggers/"+triggerID+"/services/"+serviceID)
+	return getIncidentWorkflowTriggerFromResponse(c, resp, err)
+}
+
+func getIncidentWorkflowTriggerFromResponse(c *Client, resp *http.Response, err error) (*IncidentWorkflowTrigger, error) {
+	var t IncidentWorkflowTrigger
+	if err := decodeEnvelope(c, resp, err, "trigger", &t); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,157 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestIncidentWorkflowCondition(t *testing.T) {
+	got := IncidentWorkflowConditionAll(
+		IncidentWorkflowConditionAny(
+			IncidentWorkflowConditionMatches("incident.priority", "P1"),
+			IncidentWorkflowConditionMatches("incident.priority", "P2"),
+		),
+		IncidentWorkflowConditionMatches("incident.title", "customer's site"),
+	)
+
+	want := `((incident.priority matches 'P1') or (incident.priority matches 'P2')) and (incident.title matches 'customer\'s site')`
+	testEqual(t, want, got)
+}
+
+func TestIncidentWorkflowTrigger_ListPaginated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incident_workflows/triggers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "PS1", r.URL.Query().Get("service_id"))
+
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			_, _ = w.Write([]byte(`{"triggers": [{"id": "T1"}], "limit": 1, "next_cursor": "next"}`))
+		case "next":
+			_, _ = w.Write([]byte(`{"triggers": [{"id": "T2"}], "limit": 1, "next_cursor": null}`))
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("cursor"))
+		}
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListIncidentWorkflowTriggersPaginated(context.Background(), ListIncidentWorkflowTriggersOptions{ServiceID: "PS1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, []IncidentWorkflowTrigger{{ID: "T1"}, {ID: "T2"}}, res)
+}
+
+func TestIncidentWorkflowTrigger_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incident_workflows/triggers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]IncidentWorkflowTrigger
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		tr := body["trigger"]
+		testEqual(t, IncidentWorkflowTriggerTypeConditional, tr.TriggerType)
+		testEqual(t, "incident.priority matches 'P1'", tr.Condition)
+
+		_, _ = w.Write([]byte(`{"trigger": {"id": "T1", "trigger_type": "conditional"}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	input := IncidentWorkflowTrigger{
+		TriggerType: IncidentWorkflowTriggerTypeConditional,
+		Workflow:    &APIReference{ID: "PW1", Type: "incident_workflow_reference"},
+		Condition:   IncidentWorkflowConditionMatches("incident.priority", "P1"),
+		Services:    []APIReference{{ID: "PS1", Type: "service_reference"}},
+	}
+
+	res, err := client.CreateIncidentWorkflowTriggerWithContext(context.Background(), input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, &IncidentWorkflowTrigger{ID: "T1", TriggerType: IncidentWorkflowTriggerTypeConditional}, res)
+}
+
+func TestIncidentWorkflowTrigger_GetUpdateDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incident_workflows/triggers/T1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"trigger": {"id": "T1", "condition": "incident.priority matches 'P1'"}}`))
+		case http.MethodPut:
+			_, _ = w.Write([]byte(`{"trigger": {"id": "T1", "condition": "incident.priority matches 'P2'"}}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.GetIncidentWorkflowTriggerWithContext(context.Background(), "T1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "incident.priority matches 'P1'", res.Condition)
+
+	res, err = client.UpdateIncidentWorkflowTriggerWithContext(context.Background(), "T1", IncidentWorkflowTrigger{Condition: "incident.priority matches 'P2'"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "incident.priority matches 'P2'", res.Condition)
+
+	if err := client.DeleteIncidentWorkflowTriggerWithContext(context.Background(), "T1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIncidentWorkflowTrigger_Services(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incident_workflows/triggers/T1/services", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]APIReference
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+		testEqual(t, APIReference{ID: "PS2", Type: "service_reference"}, body["service"])
+
+		_, _ = w.Write([]byte(`{"trigger": {"id": "T1", "services": [{"id": "PS1"}, {"id": "PS2"}]}}`))
+	})
+
+	mux.HandleFunc("/incident_workflows/triggers/T1/services/PS1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		_, _ = w.Write([]byte(`{"trigger": {"id": "T1", "services": [{"id": "PS2"}]}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.AssociateServiceToIncidentWorkflowTriggerWithContext(context.Background(), "T1", "PS2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, 2, len(res.Services))
+
+	res, err = client.DeleteServiceFromIncidentWorkflowTriggerWithContext(context.Background(), "T1", "PS1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, []APIReference{{ID: "PS2"}}, res.Services)
+}
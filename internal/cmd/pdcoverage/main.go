@@ -0,0 +1,77 @@
+// Command pdcoverage reports which endpoints of the PagerDuty REST API are
+// implemented by the pagerduty package, and generates the Go file backing the
+// pagerduty.Endpoints function.
+//
+// Usage:
+//
+//	pdcoverage -spec openapiv3.json -out endpoint_coverage_gen.go
+//	pdcoverage -spec openapiv3.json -report
+//
+// Without -spec, only the implemented endpoints are listed, and the generated
+// file says so. The specification can be downloaded from
+// https://github.com/PagerDuty/api-schema/blob/main/reference/REST/openapiv3.json
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+
+	"github.com/PagerDuty/go-pagerduty/internal/coverage"
+	"github.com/PagerDuty/go-pagerduty/internal/openapi"
+)
+
+func main() {
+	specPath := flag.String("spec", "", "path to the PagerDuty OpenAPI specification")
+	dir := flag.String("dir", ".", "directory of the pagerduty package")
+	out := flag.String("out", "", "path of the generated Go file (default stdout)")
+	report := flag.Bool("report", false, "print a human-readable report instead of Go code")
+	flag.Parse()
+
+	if err := run(*specPath, *dir, *out, *report); err != nil {
+		fmt.Fprintf(os.Stderr, "pdcoverage: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, dir, out string, report bool) error {
+	var spec *openapi.Spec
+
+	if len(specPath) > 0 {
+		var err error
+		if spec, err = openapi.LoadFile(specPath); err != nil {
+			return err
+		}
+	}
+
+	routes, err := coverage.ScanDir(dir)
+	if err != nil {
+		return err
+	}
+
+	endpoints := coverage.Compare(spec, routes)
+
+	if report {
+		return coverage.WriteReport(os.Stdout, endpoints)
+	}
+
+	var buf bytes.Buffer
+	if err := coverage.WriteGo(&buf, "pagerduty", endpoints, spec != nil); err != nil {
+		return err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	if len(out) == 0 {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+
+	return ioutil.WriteFile(out, src, 0o644)
+}
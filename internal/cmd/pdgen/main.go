@@ -0,0 +1,82 @@
+// Command pdgen generates REST API resources of the pagerduty package from the
+// PagerDuty OpenAPI specification.
+//
+// Usage:
+//
+//	pdgen -spec openapiv3.json -config codegen.json -out zz_generated.go
+//	pdgen -spec openapiv3.json -config codegen.json -out zz_generated.go -check
+//
+// With -check, the output file isn't written, and pdgen fails if it isn't up
+// to date with the specification and the configuration, so that CI can
+// detect when the generated code drifts from the published specification.
+//
+// The specification can be downloaded from
+// https://github.com/PagerDuty/api-schema/blob/main/reference/REST/openapiv3.json
+// and the configuration format is documented by the codegen.Config type.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/PagerDuty/go-pagerduty/internal/codegen"
+	"github.com/PagerDuty/go-pagerduty/internal/openapi"
+)
+
+func main() {
+	specPath := flag.String("spec", "openapiv3.json", "path to the PagerDuty OpenAPI specification")
+	configPath := flag.String("config", "codegen.json", "path to the code generation configuration")
+	out := flag.String("out", "", "path of the generated file (default stdout)")
+	check := flag.Bool("check", false, "fail if the generated file isn't up to date instead of writing it")
+	flag.Parse()
+
+	if err := run(*specPath, *configPath, *out, *check); err != nil {
+		fmt.Fprintf(os.Stderr, "pdgen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, configPath, out string, check bool) error {
+	spec, err := openapi.LoadFile(specPath)
+	if err != nil {
+		return err
+	}
+
+	c, err := codegen.LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := codegen.Generate(&buf, spec, c); err != nil {
+		return err
+	}
+
+	if check {
+		if len(out) == 0 {
+			return errors.New("-check requires -out")
+		}
+
+		current, err := ioutil.ReadFile(out)
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(current, buf.Bytes()) {
+			return fmt.Errorf("%s is out of date, run pdgen to regenerate it", out)
+		}
+
+		return nil
+	}
+
+	if len(out) == 0 {
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	}
+
+	return ioutil.WriteFile(out, buf.Bytes(), 0o644)
+}
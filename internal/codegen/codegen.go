@@ -0,0 +1,840 @@
+// Package codegen generates the Go models, option structs, and CRUD methods of
+// REST API resources from the PagerDuty OpenAPI specification.
+//
+// What is generated is driven by a Config, which names the resources to
+// generate and allows for overriding the generated code where the
+// specification doesn't match the conventions of the pagerduty package. Code
+// that can't be described by overrides should be hand-written in a separate
+// file, with the corresponding operations or types excluded from generation.
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/PagerDuty/go-pagerduty/internal/openapi"
+)
+
+// Operation is a CRUD operation to generate for a resource.
+type Operation string
+
+const (
+	// OperationList generates the List<Plural>WithContext method.
+	OperationList Operation = "list"
+
+	// OperationGet generates the Get<Name>WithContext method.
+	OperationGet Operation = "get"
+
+	// OperationCreate generates the Create<Name>WithContext method.
+	OperationCreate Operation = "create"
+
+	// OperationUpdate generates the Update<Name>WithContext method.
+	OperationUpdate Operation = "update"
+
+	// OperationDelete generates the Delete<Name>WithContext method.
+	OperationDelete Operation = "delete"
+)
+
+// Config describes what to generate.
+type Config struct {
+	// Package is the name of the Go package of the generated code.
+	Package string `json:"package"`
+
+	// Resources are the REST API resources to generate.
+	Resources []Resource `json:"resources"`
+
+	// TypeMap maps OpenAPI component schema names to existing Go types, which
+	// are then used instead of generating a new type. Schemas whose name ends
+	// in "Reference" are mapped to APIReference by default.
+	TypeMap map[string]string `json:"type_map,omitempty"`
+}
+
+// Resource describes a single REST API resource, such as /services.
+type Resource struct {
+	// Name is the singular Go name of the resource, such as "Service".
+	Name string `json:"name"`
+
+	// Plural is the plural Go name of the resource, such as "Services".
+	Plural string `json:"plural"`
+
+	// Path is the collection path of the resource, such as "/services".
+	Path string `json:"path"`
+
+	// Schema is the name of the component schema of the resource.
+	Schema string `json:"schema"`
+
+	// RootKey is the JSON key wrapping the resource in request and response
+	// bodies, such as "service".
+	RootKey string `json:"root_key"`
+
+	// ListKey is the JSON key of the resources in list responses, such as
+	// "services".
+	ListKey string `json:"list_key"`
+
+	// Operations are the operations to generate. All operations present in
+	// the specification are generated if this is empty.
+	Operations []Operation `json:"operations,omitempty"`
+
+	// Fields overrides how individual properties of the resource schema are
+	// generated, keyed by property name.
+	Fields map[string]FieldOverride `json:"fields,omitempty"`
+}
+
+// FieldOverride overrides how a single property of a schema is generated.
+type FieldOverride struct {
+	// Name is the Go name of the field.
+	Name string `json:"name,omitempty"`
+
+	// Type is the Go type of the field.
+	Type string `json:"type,omitempty"`
+
+	// Omit excludes the property from the generated struct.
+	Omit bool `json:"omit,omitempty"`
+}
+
+// LoadConfig decodes a JSON Config from the file at path.
+func LoadConfig(path string) (*Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	var c Config
+
+	d := json.NewDecoder(f)
+	d.DisallowUnknownFields()
+
+	if err := d.Decode(&c); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	return &c, nil
+}
+
+// Generate writes the Go source for all the resources of c to w.
+func Generate(w io.Writer, spec *openapi.Spec, c *Config) error {
+	g := &generator{
+		spec:      spec,
+		config:    c,
+		generated: make(map[string]bool),
+	}
+
+	g.printf("// Code generated by pdgen from the PagerDuty OpenAPI specification. DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", c.Package)
+
+	var body bytes.Buffer
+
+	bg := &generator{
+		spec:      spec,
+		config:    c,
+		generated: g.generated,
+		buf:       &body,
+	}
+
+	for _, r := range c.Resources {
+		if err := bg.resource(r); err != nil {
+			return fmt.Errorf("resource %s: %w", r.Name, err)
+		}
+	}
+
+	g.imports(bg.needImports)
+	g.buf.Write(body.Bytes())
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated code: %w\n%s", err, g.buf.Bytes())
+	}
+
+	_, err = w.Write(src)
+	return err
+}
+
+type generator struct {
+	spec   *openapi.Spec
+	config *Config
+
+	// generated tracks which type names were already emitted
+	generated map[string]bool
+
+	// pending are the component schemas referenced by generated types which
+	// have not been emitted yet
+	pending []string
+
+	needImports map[string]bool
+
+	buf *bytes.Buffer
+}
+
+func (g *generator) printf(f string, args ...interface{}) {
+	if g.buf == nil {
+		g.buf = &bytes.Buffer{}
+	}
+
+	fmt.Fprintf(g.buf, f, args...)
+}
+
+func (g *generator) imports(m map[string]bool) {
+	if len(m) == 0 {
+		return
+	}
+
+	var std, ext []string
+	for imp := range m {
+		if strings.Contains(imp, ".") {
+			ext = append(ext, imp)
+		} else {
+			std = append(std, imp)
+		}
+	}
+
+	sort.Strings(std)
+	sort.Strings(ext)
+
+	g.printf("import (\n")
+	for _, imp := range std {
+		g.printf("\t%q\n", imp)
+	}
+
+	if len(std) > 0 && len(ext) > 0 {
+		g.printf("\n")
+	}
+
+	for _, imp := range ext {
+		g.printf("\t%q\n", imp)
+	}
+	g.printf(")\n\n")
+}
+
+func (g *generator) need(imports ...string) {
+	if g.needImports == nil {
+		g.needImports = make(map[string]bool)
+	}
+
+	for _, imp := range imports {
+		g.needImports[imp] = true
+	}
+}
+
+func (g *generator) resource(r Resource) error {
+	item, ok := g.spec.Paths[r.Path]
+	if !ok {
+		return fmt.Errorf("path %s not found", r.Path)
+	}
+
+	itemPath := r.Path + "/{id}"
+	instance := g.spec.Paths[itemPath]
+
+	ops := r.Operations
+	if len(ops) == 0 {
+		if item.Get != nil {
+			ops = append(ops, OperationList)
+		}
+
+		if item.Post != nil {
+			ops = append(ops, OperationCreate)
+		}
+
+		if instance != nil {
+			if instance.Get != nil {
+				ops = append(ops, OperationGet)
+			}
+
+			if instance.Put != nil {
+				ops = append(ops, OperationUpdate)
+			}
+
+			if instance.Delete != nil {
+				ops = append(ops, OperationDelete)
+			}
+		}
+	}
+
+	if err := g.model(r); err != nil {
+		return err
+	}
+
+	if len(r.RootKey) == 0 || len(r.ListKey) == 0 {
+		return fmt.Errorf("root_key and list_key must be set")
+	}
+
+	d := resourceData{
+		Resource: r,
+		Const:    lowerFirst(r.Plural) + "Path",
+		Desc:     strings.ToLower(splitWords(r.Name)),
+	}
+
+	g.printf("const %s = %q\n\n", d.Const, r.Path)
+
+	for _, op := range ops {
+		var (
+			tmpl *template.Template
+			err  error
+		)
+
+		switch op {
+		case OperationList:
+			if item.Get == nil {
+				return fmt.Errorf("GET %s not found", r.Path)
+			}
+
+			d.Options, err = g.options(item.Get, item.Parameters, true)
+			tmpl = listTemplate
+			g.need("context", "github.com/google/go-querystring/query")
+
+		case OperationGet:
+			if instance == nil || instance.Get == nil {
+				return fmt.Errorf("GET %s not found", itemPath)
+			}
+
+			d.GetOptions, err = g.options(instance.Get, instance.Parameters, false)
+			tmpl = getTemplate
+			g.need("context")
+
+			if len(d.GetOptions) > 0 {
+				g.need("github.com/google/go-querystring/query")
+			}
+
+		case OperationCreate:
+			if item.Post == nil {
+				return fmt.Errorf("POST %s not found", r.Path)
+			}
+
+			tmpl = createTemplate
+			g.need("context")
+
+		case OperationUpdate:
+			if instance == nil || instance.Put == nil {
+				return fmt.Errorf("PUT %s not found", itemPath)
+			}
+
+			tmpl = updateTemplate
+			g.need("context")
+
+		case OperationDelete:
+			if instance == nil || instance.Delete == nil {
+				return fmt.Errorf("DELETE %s not found", itemPath)
+			}
+
+			tmpl = deleteTemplate
+			g.need("context")
+
+		default:
+			return fmt.Errorf("unknown operation %q", op)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := tmpl.Execute(g.buf, d); err != nil {
+			return err
+		}
+	}
+
+	for _, op := range ops {
+		if op == OperationGet || op == OperationCreate || op == OperationUpdate {
+			g.need("fmt", "net/http")
+			return responseTemplate.Execute(g.buf, d)
+		}
+	}
+
+	return nil
+}
+
+func (g *generator) model(r Resource) error {
+	sc, err := g.spec.Schema(r.Schema)
+	if err != nil {
+		return err
+	}
+
+	if err := g.structType(r.Name, sc, r.Fields); err != nil {
+		return err
+	}
+
+	// emit the types of the referenced schemas, which may reference more
+	// schemas in turn
+	for len(g.pending) > 0 {
+		name := g.pending[0]
+		g.pending = g.pending[1:]
+
+		if g.generated[name] {
+			continue
+		}
+
+		sc, err := g.spec.Schema(name)
+		if err != nil {
+			return err
+		}
+
+		if err := g.structType(goName(name), sc, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// apiObjectFields are the properties provided by embedding APIObject.
+var apiObjectFields = []string{"id", "type", "summary", "self", "html_url"}
+
+func (g *generator) structType(name string, sc *openapi.Schema, overrides map[string]FieldOverride) error {
+	if g.generated[name] {
+		return nil
+	}
+
+	g.generated[name] = true
+
+	props, err := g.spec.Properties(sc)
+	if err != nil {
+		return err
+	}
+
+	embedAPIObject := true
+	for _, f := range apiObjectFields {
+		if _, ok := props[f]; !ok {
+			embedAPIObject = false
+			break
+		}
+	}
+
+	names := make([]string, 0, len(props))
+	for p := range props {
+		names = append(names, p)
+	}
+	sort.Strings(names)
+
+	var nested []func() error
+
+	g.printf("// %s is the %s object of the REST API.\n", name, splitWords(name))
+	if desc := firstSentence(sc.Description); len(desc) > 0 {
+		g.printf("//\n// %s\n", desc)
+	}
+	g.printf("type %s struct {\n", name)
+
+	if embedAPIObject {
+		g.printf("\tAPIObject\n")
+	}
+
+	for _, p := range names {
+		if embedAPIObject && contains(apiObjectFields, p) {
+			continue
+		}
+
+		o := overrides[p]
+		if o.Omit {
+			continue
+		}
+
+		fieldName := o.Name
+		if len(fieldName) == 0 {
+			fieldName = goName(p)
+		}
+
+		typ := o.Type
+		if len(typ) == 0 {
+			ps := props[p]
+			typ, err = g.goType(name+fieldName, ps)
+			if err != nil {
+				return fmt.Errorf("property %s: %w", p, err)
+			}
+
+			// inline objects become their own named types
+			if inline := inlineObject(ps); inline != nil {
+				typeName := name + fieldName
+				nested = append(nested, func() error {
+					return g.structType(typeName, inline, nil)
+				})
+			}
+		}
+
+		g.printf("\t%s %s `json:\"%s,omitempty\"`\n", fieldName, typ, p)
+	}
+
+	g.printf("}\n\n")
+
+	for _, fn := range nested {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// inlineObject returns the schema of the object defined inline by sc, either
+// directly or as the items of an array, or nil if there's none.
+func inlineObject(sc *openapi.Schema) *openapi.Schema {
+	if sc == nil || len(sc.Ref) > 0 {
+		return nil
+	}
+
+	if sc.Type == "array" {
+		return inlineObject(sc.Items)
+	}
+
+	if (sc.Type == "object" || sc.Type == "") && (len(sc.Properties) > 0 || len(sc.AllOf) > 0) {
+		return sc
+	}
+
+	return nil
+}
+
+func (g *generator) goType(inlineName string, sc *openapi.Schema) (string, error) {
+	if sc == nil {
+		return "interface{}", nil
+	}
+
+	if len(sc.Ref) > 0 {
+		name, ok := openapi.SchemaRefName(sc.Ref)
+		if !ok {
+			return "", fmt.Errorf("unsupported reference %q", sc.Ref)
+		}
+
+		if t, ok := g.config.TypeMap[name]; ok {
+			return t, nil
+		}
+
+		if strings.HasSuffix(name, "Reference") {
+			return "*APIReference", nil
+		}
+
+		if !g.generated[goName(name)] {
+			g.pending = append(g.pending, name)
+		}
+
+		return "*" + goName(name), nil
+	}
+
+	// a single-element allOf is commonly used to add a description to a
+	// reference
+	if len(sc.AllOf) == 1 && len(sc.Properties) == 0 {
+		return g.goType(inlineName, sc.AllOf[0])
+	}
+
+	switch sc.Type {
+	case "string":
+		return "string", nil
+
+	case "integer":
+		return "int", nil
+
+	case "number":
+		return "float64", nil
+
+	case "boolean":
+		return "bool", nil
+
+	case "array":
+		t, err := g.goType(inlineName, sc.Items)
+		if err != nil {
+			return "", err
+		}
+
+		return "[]" + strings.TrimPrefix(t, "*"), nil
+
+	case "object", "":
+		if inlineObject(sc) != nil {
+			return "*" + inlineName, nil
+		}
+
+		return "map[string]interface{}", nil
+	}
+
+	return "", fmt.Errorf("unsupported schema type %q", sc.Type)
+}
+
+type optionField struct {
+	Name    string
+	Type    string
+	Tag     string
+	Comment string
+}
+
+// paginationParams are emitted with the standard documentation used across
+// the package.
+var paginationParams = map[string]bool{"limit": true, "offset": true, "total": true}
+
+func (g *generator) options(op *openapi.Operation, shared []*openapi.Parameter, list bool) ([]optionField, error) {
+	params := make([]*openapi.Parameter, 0, len(shared)+len(op.Parameters))
+	params = append(params, shared...)
+	params = append(params, op.Parameters...)
+
+	var fields []optionField
+
+	for _, p := range params {
+		p, err := g.spec.ResolveParameter(p)
+		if err != nil {
+			return nil, err
+		}
+
+		if p.In != "query" || (list && paginationParams[p.Name]) {
+			continue
+		}
+
+		name := strings.TrimSuffix(p.Name, "[]")
+
+		typ, err := g.goType("", p.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+
+		tag := name + ",omitempty"
+		if strings.HasSuffix(p.Name, "[]") {
+			tag += ",brackets"
+		}
+
+		fieldName := goName(name)
+		if strings.HasPrefix(typ, "[]") && !strings.HasSuffix(fieldName, "s") {
+			fieldName += "s"
+		}
+
+		fields = append(fields, optionField{
+			Name:    fieldName,
+			Type:    typ,
+			Tag:     tag,
+			Comment: firstSentence(p.Description),
+		})
+	}
+
+	return fields, nil
+}
+
+type resourceData struct {
+	Resource
+	Const      string
+	Desc       string
+	Options    []optionField
+	GetOptions []optionField
+}
+
+var funcs = template.FuncMap{
+	"comment": func(s string) string {
+		return strings.ReplaceAll(strings.TrimSpace(s), "\n", "\n\t// ")
+	},
+}
+
+var listTemplate = template.Must(template.New("list").Funcs(funcs).Parse(`
+// List{{.Plural}}Options is the data structure used when calling the List{{.Plural}} API endpoint.
+type List{{.Plural}}Options struct {
+	// Limit is the pagination parameter that limits the number of results per
+	// page. PagerDuty defaults this value to 25 if omitted, and sets an upper
+	// bound of 100.
+	Limit uint ` + "`url:\"limit,omitempty\"`" + `
+
+	// Offset is the pagination parameter that specifies the offset at which to
+	// start pagination results. When trying to request the next page of
+	// results, the new Offset value should be currentOffset + Limit.
+	Offset uint ` + "`url:\"offset,omitempty\"`" + `
+
+	// Total is the pagination parameter to request that the API return the
+	// total count of items in the response. If this field is omitted or set to
+	// false, the total number of results will not be sent back from the PagerDuty API.
+	//
+	// Setting this to true will slow down the API response times, and so it's
+	// recommended to omit it unless you've a specific reason for wanting the
+	// total count of items in the collection.
+	Total bool ` + "`url:\"total,omitempty\"`" + `
+{{range .Options}}
+	{{- if .Comment}}
+
+	// {{comment .Comment}}
+	{{- end}}
+	{{.Name}} {{.Type}} ` + "`url:\"{{.Tag}}\"`" + `
+{{- end}}
+}
+
+// List{{.Plural}}Response is the data structure returned from calling the List{{.Plural}} API endpoint.
+type List{{.Plural}}Response struct {
+	APIListObject
+	{{.Plural}} []{{.Name}} ` + "`json:\"{{.ListKey}}\"`" + `
+}
+
+// List{{.Plural}}WithContext lists existing {{.Desc}}s.
+func (c *Client) List{{.Plural}}WithContext(ctx context.Context, o List{{.Plural}}Options) (*List{{.Plural}}Response, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, {{.Const}}+"?"+v.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var result List{{.Plural}}Response
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+`))
+
+var getTemplate = template.Must(template.New("get").Funcs(funcs).Parse(`
+{{- if .GetOptions}}
+// Get{{.Name}}Options is the data structure used when calling the Get{{.Name}} API endpoint.
+type Get{{.Name}}Options struct {
+{{- range $i, $f := .GetOptions}}
+	{{- if $f.Comment}}
+	{{- if $i}}
+{{end}}
+	// {{comment $f.Comment}}
+	{{- end}}
+	{{$f.Name}} {{$f.Type}} ` + "`url:\"{{$f.Tag}}\"`" + `
+{{- end}}
+}
+
+// Get{{.Name}}WithContext gets details about an existing {{.Desc}}.
+func (c *Client) Get{{.Name}}WithContext(ctx context.Context, id string, o *Get{{.Name}}Options) (*{{.Name}}, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, {{.Const}}+"/"+id+"?"+v.Encode())
+	return get{{.Name}}FromResponse(c, resp, err)
+}
+{{- else}}
+// Get{{.Name}}WithContext gets details about an existing {{.Desc}}.
+func (c *Client) Get{{.Name}}WithContext(ctx context.Context, id string) (*{{.Name}}, error) {
+	resp, err := c.get(ctx, {{.Const}}+"/"+id)
+	return get{{.Name}}FromResponse(c, resp, err)
+}
+{{- end}}
+`))
+
+var createTemplate = template.Must(template.New("create").Parse(`
+// Create{{.Name}}WithContext creates a new {{.Desc}}.
+func (c *Client) Create{{.Name}}WithContext(ctx context.Context, o {{.Name}}) (*{{.Name}}, error) {
+	d := map[string]{{.Name}}{
+		"{{.RootKey}}": o,
+	}
+
+	resp, err := c.post(ctx, {{.Const}}, d, nil)
+	return get{{.Name}}FromResponse(c, resp, err)
+}
+`))
+
+var updateTemplate = template.Must(template.New("update").Parse(`
+// Update{{.Name}}WithContext updates an existing {{.Desc}}.
+func (c *Client) Update{{.Name}}WithContext(ctx context.Context, o {{.Name}}) (*{{.Name}}, error) {
+	d := map[string]{{.Name}}{
+		"{{.RootKey}}": o,
+	}
+
+	resp, err := c.put(ctx, {{.Const}}+"/"+o.ID, d, nil)
+	return get{{.Name}}FromResponse(c, resp, err)
+}
+`))
+
+var deleteTemplate = template.Must(template.New("delete").Parse(`
+// Delete{{.Name}}WithContext deletes an existing {{.Desc}}.
+func (c *Client) Delete{{.Name}}WithContext(ctx context.Context, id string) error {
+	_, err := c.delete(ctx, {{.Const}}+"/"+id)
+	return err
+}
+`))
+
+var responseTemplate = template.Must(template.New("response").Parse(`
+func get{{.Name}}FromResponse(c *Client, resp *http.Response, err error) (*{{.Name}}, error) {
+	var t {{.Name}}
+	if err := decodeEnvelope(c, resp, err, "{{.RootKey}}", &t); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+`))
+
+// initialisms are the words written in all-caps in Go names.
+var initialisms = map[string]string{
+	"api":  "API",
+	"html": "HTML",
+	"http": "HTTP",
+	"id":   "ID",
+	"ids":  "IDs",
+	"ip":   "IP",
+	"json": "JSON",
+	"sms":  "SMS",
+	"ssl":  "SSL",
+	"uri":  "URI",
+	"url":  "URL",
+	"urls": "URLs",
+	"uuid": "UUID",
+}
+
+// goName converts a snake_case property name or a component schema name into
+// an exported Go name.
+func goName(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '_' || r == '-' || r == '.' || r == ' ' || r == '[' || r == ']'
+	})
+
+	var b strings.Builder
+	for _, w := range words {
+		if i, ok := initialisms[strings.ToLower(w)]; ok {
+			b.WriteString(i)
+			continue
+		}
+
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+
+	return b.String()
+}
+
+// splitWords splits a Go name into lower-case words, such as "business
+// service" for "BusinessService".
+func splitWords(s string) string {
+	var b strings.Builder
+
+	for i, r := range s {
+		if i > 0 && r >= 'A' && r <= 'Z' && !(s[i-1] >= 'A' && s[i-1] <= 'Z') {
+			b.WriteByte(' ')
+		}
+
+		b.WriteRune(r)
+	}
+
+	return strings.ToLower(b.String())
+}
+
+func lowerFirst(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func firstSentence(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.Index(s, ". "); i >= 0 {
+		s = s[:i+1]
+	}
+
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+
+	return s
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
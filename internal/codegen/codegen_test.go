@@ -0,0 +1,99 @@
+package codegen
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"testing"
+
+	"github.com/PagerDuty/go-pagerduty/internal/openapi"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestGenerate(t *testing.T) {
+	spec, err := openapi.LoadFile("testdata/spec.json")
+	if err != nil {
+		t.Fatalf("failed to load spec: %s", err)
+	}
+
+	c, err := LoadConfig("testdata/config.json")
+	if err != nil {
+		t.Fatalf("failed to load config: %s", err)
+	}
+
+	var buf bytes.Buffer
+	if err := Generate(&buf, spec, c); err != nil {
+		t.Fatalf("Generate() unexpected error: %s", err)
+	}
+
+	const golden = "testdata/widget.golden"
+
+	if *update {
+		if err := ioutil.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+			t.Fatalf("failed to update golden file: %s", err)
+		}
+	}
+
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %s", err)
+	}
+
+	if got := buf.String(); got != string(want) {
+		t.Fatalf("generated code does not match %s, run go test -update to update it:\n%s", golden, got)
+	}
+}
+
+func TestGenerate_errors(t *testing.T) {
+	spec, err := openapi.LoadFile("testdata/spec.json")
+	if err != nil {
+		t.Fatalf("failed to load spec: %s", err)
+	}
+
+	tests := []struct {
+		name     string
+		resource Resource
+	}{
+		{
+			name:     "unknown_path",
+			resource: Resource{Name: "Gizmo", Plural: "Gizmos", Path: "/gizmos", Schema: "Widget", RootKey: "gizmo", ListKey: "gizmos"},
+		},
+		{
+			name:     "unknown_schema",
+			resource: Resource{Name: "Widget", Plural: "Widgets", Path: "/widgets", Schema: "Gizmo", RootKey: "widget", ListKey: "widgets"},
+		},
+		{
+			name:     "unknown_operation",
+			resource: Resource{Name: "Widget", Plural: "Widgets", Path: "/widgets", Schema: "Widget", RootKey: "widget", ListKey: "widgets", Operations: []Operation{"patch"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{Package: "pagerduty", Resources: []Resource{tt.resource}}
+
+			if err := Generate(ioutil.Discard, spec, c); err == nil {
+				t.Fatal("Generate() error = <nil>, want error")
+			}
+		})
+	}
+}
+
+func TestGoName(t *testing.T) {
+	tests := map[string]string{
+		"name":                     "Name",
+		"html_url":                 "HTMLURL",
+		"escalation_policy":        "EscalationPolicy",
+		"team_ids":                 "TeamIDs",
+		"BusinessService":          "BusinessService",
+		"auto_resolve_timeout":     "AutoResolveTimeout",
+		"service.custom_field_key": "ServiceCustomFieldKey",
+	}
+
+	for in, want := range tests {
+		if got := goName(in); got != want {
+			t.Errorf("goName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
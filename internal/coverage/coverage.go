@@ -0,0 +1,749 @@
+// Package coverage compares the REST API routes called by the pagerduty
+// package against the PagerDuty OpenAPI specification, to report which
+// endpoints are implemented, missing, or deprecated.
+//
+// The implemented routes are found by statically analyzing the source of the
+// package: every call to one of the request helpers of the Client (get, post,
+// put, delete, do, pagedGet and cursorGet) is a route, with the path
+// reconstructed from the string constants and literals it's built from.
+// Methods of the Client passing one of their parameters as the path of a
+// request helper are request helpers themselves, so routes are found through
+// any number of wrappers. Only calls on a Client are considered, as the other
+// clients of the package call the Events API or the status page rather than
+// the REST API.
+// Dynamic path segments, such as IDs, are represented as "{}".
+package coverage
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/PagerDuty/go-pagerduty/internal/openapi"
+)
+
+// Route is a REST API route called by the package.
+type Route struct {
+	Method string
+	Path   string
+}
+
+// Status is the coverage status of an endpoint.
+type Status string
+
+const (
+	// StatusImplemented is an endpoint called by the package.
+	StatusImplemented Status = "implemented"
+
+	// StatusMissing is an endpoint of the specification not called by the
+	// package.
+	StatusMissing Status = "missing"
+
+	// StatusDeprecated is an endpoint deprecated in the specification and not
+	// called by the package. Deprecated endpoints that are implemented have a
+	// status of StatusImplemented, with Deprecated set.
+	StatusDeprecated Status = "deprecated"
+
+	// StatusUnknown is a route called by the package which is not part of the
+	// specification.
+	StatusUnknown Status = "unknown"
+)
+
+// Endpoint is a single entry of the coverage report.
+type Endpoint struct {
+	Method     string
+	Path       string
+	Status     Status
+	Deprecated bool
+}
+
+// Implemented returns whether the package calls the endpoint.
+func (e Endpoint) Implemented() bool {
+	return e.Status == StatusImplemented || e.Status == StatusUnknown
+}
+
+// request is a request made by a request helper. Its method and path may be
+// built from the parameters of the helper, see paramValue.
+type request struct {
+	method string
+	path   string
+}
+
+// requestHelpers are the request helpers of the Client, which make the HTTP
+// requests to the REST API. The functions and methods built on them are found
+// by ScanDir.
+var requestHelpers = map[string]request{
+	"get":       {method: "GET", path: paramValue(1)},
+	"post":      {method: "POST", path: paramValue(1)},
+	"put":       {method: "PUT", path: paramValue(1)},
+	"delete":    {method: "DELETE", path: paramValue(1)},
+	"pagedGet":  {method: "GET", path: paramValue(1)},
+	"cursorGet": {method: "GET", path: paramValue(1)},
+	"do":        {method: paramValue(1), path: paramValue(2)},
+}
+
+// maxHelperRequests bounds the requests of a request helper, as a helper
+// calling itself with a different path would otherwise make infinitely many.
+const maxHelperRequests = 64
+
+// clientType is the name of the type of the REST API client.
+const clientType = "Client"
+
+// ScanDir returns the routes called by the non-test Go files of the package in
+// dir, sorted by path and then method.
+func ScanDir(dir string) ([]Route, error) {
+	fset := token.NewFileSet()
+
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[Route]bool)
+
+	for _, pkg := range pkgs {
+		s := &scanner{
+			consts:    make(map[string]string),
+			argValues: make(map[string]map[string][]string),
+			methods:   make(map[string]map[request]bool, len(requestHelpers)),
+			funcs:     make(map[string]map[request]bool),
+		}
+
+		for name, r := range requestHelpers {
+			s.methods[name] = map[request]bool{r: true}
+		}
+
+		// collect string constants first, as they're used in paths
+		for _, f := range pkg.Files {
+			s.collectConsts(f)
+		}
+
+		var funcs []*ast.FuncDecl
+		for _, f := range pkg.Files {
+			for _, decl := range f.Decls {
+				if fd, ok := decl.(*ast.FuncDecl); ok && fd.Body != nil {
+					funcs = append(funcs, fd)
+				}
+			}
+		}
+
+		// scan until no new requests of the request helpers are found, as a
+		// helper may be declared after the functions calling it
+		for found := true; found; {
+			found = false
+
+			for _, fd := range funcs {
+				if s.scanFunc(fd, seen) {
+					found = true
+				}
+			}
+		}
+	}
+
+	routes := make([]Route, 0, len(seen))
+	for r := range seen {
+		routes = append(routes, r)
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+
+		return routes[i].Method < routes[j].Method
+	})
+
+	return routes, nil
+}
+
+type scanner struct {
+	consts map[string]string
+
+	// argValues are the values of the parameters of functions, by function
+	// and parameter name, listed by the constants documented as the "Values
+	// of the param argument of Function"
+	argValues map[string]map[string][]string
+
+	// methods and funcs are the requests of the request helpers of the
+	// package, by name, which are methods of the Client and functions
+	// respectively
+	methods map[string]map[request]bool
+	funcs   map[string]map[request]bool
+
+	// locals are the values assigned to the local variables of the function
+	// being scanned
+	locals map[string][]ast.Expr
+
+	// params are the indexes of the parameters of the function being scanned
+	params map[string]int
+
+	// args are the argValues of the function being scanned
+	args map[string][]string
+
+	// clients are the names of the receiver and parameters of the function
+	// being scanned which are a Client
+	clients map[string]bool
+}
+
+// scanFunc adds the routes requested by fd to seen. The requests built from
+// the parameters of fd are added to its requests as a request helper instead,
+// and scanFunc returns whether there are new ones. As the arguments of
+// exported functions aren't known, their routes are added to seen too, with
+// the parameters as dynamic segments.
+func (s *scanner) scanFunc(fd *ast.FuncDecl, seen map[Route]bool) bool {
+	s.locals = collectLocals(fd.Body)
+	s.params = make(map[string]int)
+	s.args = s.argValues[fd.Name.Name]
+	s.clients = make(map[string]bool)
+
+	helpers := s.funcs
+	if fd.Recv != nil {
+		helpers = nil
+		for _, field := range fd.Recv.List {
+			if isClient(field.Type) {
+				helpers = s.methods
+				for _, name := range field.Names {
+					s.clients[name.Name] = true
+				}
+			}
+		}
+	}
+
+	i := 0
+	for _, field := range fd.Type.Params.List {
+		for _, name := range field.Names {
+			s.params[name.Name] = i
+			if isClient(field.Type) {
+				s.clients[name.Name] = true
+			}
+			i++
+		}
+	}
+
+	name := fd.Name.Name
+	found := false
+
+	ast.Inspect(fd.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		for _, r := range s.requests(call) {
+			if hasParamValue(r.method) || hasParamValue(r.path) {
+				if helpers != nil && !helpers[name][r] && len(helpers[name]) < maxHelperRequests {
+					if helpers[name] == nil {
+						helpers[name] = make(map[request]bool)
+					}
+
+					helpers[name][r] = true
+					found = true
+				}
+
+				if !fd.Name.IsExported() || hasParamValue(r.method) {
+					continue
+				}
+			}
+
+			// paths with an empty segment are built from empty arguments,
+			// which are used when a segment is optional
+			if strings.HasPrefix(r.path, "/") && !strings.Contains(r.path, "//") {
+				seen[Route{Method: r.method, Path: cleanPath(r.path)}] = true
+			}
+		}
+
+		return true
+	})
+
+	return found
+}
+
+// isClient returns whether the type expression e is the Client, or a pointer
+// to it.
+func isClient(e ast.Expr) bool {
+	if star, ok := e.(*ast.StarExpr); ok {
+		e = star.X
+	}
+
+	id, ok := e.(*ast.Ident)
+	return ok && id.Name == clientType
+}
+
+// paramValue is the value of the parameter of index i of the function being
+// scanned. It's dynamic, and it marks the requests built from the parameter,
+// so that it can be replaced by the arguments of the calls to the function.
+func paramValue(i int) string {
+	return dynamic + "\x00" + strconv.Itoa(i) + "\x00"
+}
+
+var paramValueRE = regexp.MustCompile(`\{\}\x00([0-9]+)\x00`)
+
+func hasParamValue(s string) bool {
+	return strings.Contains(s, "\x00")
+}
+
+// argValuesDoc matches the documentation of the constants which are the
+// values of a parameter of functions, such as "Values of the entityType
+// argument of AssignTagsWithContext".
+var argValuesDoc = regexp.MustCompile(`Values of the (\w+) argument of ([^.]+)\.`)
+
+var identifier = regexp.MustCompile(`[A-Za-z_]\w*`)
+
+func (s *scanner) collectConsts(f *ast.File) {
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.CONST {
+			continue
+		}
+
+		for _, spec := range gd.Specs {
+			vs := spec.(*ast.ValueSpec)
+
+			for i, name := range vs.Names {
+				if i >= len(vs.Values) {
+					continue
+				}
+
+				if v := s.eval(vs.Values[i]); len(v) == 1 && !strings.Contains(v[0], dynamic) {
+					s.consts[name.Name] = v[0]
+				}
+			}
+		}
+
+		if gd.Doc != nil {
+			s.collectArgValues(gd)
+		}
+	}
+}
+
+// collectArgValues adds the constants of gd to argValues if they're
+// documented as the values of a parameter of functions.
+func (s *scanner) collectArgValues(gd *ast.GenDecl) {
+	m := argValuesDoc.FindStringSubmatch(gd.Doc.Text())
+	if m == nil {
+		return
+	}
+
+	var vals []string
+	for _, spec := range gd.Specs {
+		for _, name := range spec.(*ast.ValueSpec).Names {
+			if c, ok := s.consts[name.Name]; ok {
+				vals = append(vals, c)
+			}
+		}
+	}
+
+	for _, fn := range identifier.FindAllString(m[2], -1) {
+		if s.argValues[fn] == nil {
+			s.argValues[fn] = make(map[string][]string)
+		}
+
+		s.argValues[fn][m[1]] = append(s.argValues[fn][m[1]], vals...)
+	}
+}
+
+// collectLocals returns all the values assigned to each local variable of a
+// function body, so that paths built in a variable before being passed to a
+// request helper can be reconstructed.
+func collectLocals(body *ast.BlockStmt) map[string][]ast.Expr {
+	locals := make(map[string][]ast.Expr)
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		as, ok := n.(*ast.AssignStmt)
+		if !ok || len(as.Lhs) != len(as.Rhs) {
+			return true
+		}
+
+		for i, lhs := range as.Lhs {
+			if id, ok := lhs.(*ast.Ident); ok {
+				locals[id.Name] = append(locals[id.Name], as.Rhs[i])
+			}
+		}
+
+		return true
+	})
+
+	return locals
+}
+
+// dynamic replaces the parts of a path that aren't known statically.
+const dynamic = "{}"
+
+// requests returns the requests made by call, if it's a call to one of the
+// request helpers, with their parameters replaced by the arguments of call.
+func (s *scanner) requests(call *ast.CallExpr) []request {
+	var helper map[request]bool
+
+	switch fun := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		recv, ok := fun.X.(*ast.Ident)
+		if !ok || !s.clients[recv.Name] {
+			return nil
+		}
+
+		helper = s.methods[fun.Sel.Name]
+
+	case *ast.Ident:
+		helper = s.funcs[fun.Name]
+	}
+
+	var reqs []request
+
+	for r := range helper {
+		for _, m := range s.expand(r.method, call.Args, s.methodValues) {
+			for _, p := range s.expand(r.path, call.Args, s.eval) {
+				reqs = append(reqs, request{method: m, path: p})
+			}
+		}
+	}
+
+	return reqs
+}
+
+// expand returns the possible values of t with the values of its parameters
+// replaced by the values of args.
+func (s *scanner) expand(t string, args []ast.Expr, values func(ast.Expr) []string) []string {
+	vals := []string{""}
+
+	for {
+		loc := paramValueRE.FindStringSubmatchIndex(t)
+		if loc == nil {
+			break
+		}
+
+		sub := []string{dynamic}
+		if i, _ := strconv.Atoi(t[loc[2]:loc[3]]); i < len(args) {
+			sub = values(args[i])
+		}
+
+		var next []string
+		for _, v := range vals {
+			for _, a := range sub {
+				next = append(next, v+t[:loc[0]]+a)
+			}
+		}
+
+		vals = next
+		t = t[loc[1]:]
+	}
+
+	for i := range vals {
+		vals[i] += t
+	}
+
+	return vals
+}
+
+// methodValues returns the possible HTTP methods of e.
+func (s *scanner) methodValues(e ast.Expr) []string {
+	if m, ok := httpMethod(e); ok {
+		return []string{m}
+	}
+
+	if id, ok := e.(*ast.Ident); ok {
+		if i, ok := s.params[id.Name]; ok {
+			return []string{paramValue(i)}
+		}
+	}
+
+	return nil
+}
+
+func httpMethod(e ast.Expr) (string, bool) {
+	switch v := e.(type) {
+	case *ast.SelectorExpr:
+		if x, ok := v.X.(*ast.Ident); ok && x.Name == "http" && strings.HasPrefix(v.Sel.Name, "Method") {
+			return strings.ToUpper(strings.TrimPrefix(v.Sel.Name, "Method")), true
+		}
+
+	case *ast.BasicLit:
+		if v.Kind == token.STRING {
+			s, err := strconv.Unquote(v.Value)
+			return strings.ToUpper(s), err == nil
+		}
+	}
+
+	return "", false
+}
+
+var formatVerb = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z]`)
+
+// eval reconstructs the possible values of a string expression, replacing the
+// parts that aren't known statically with dynamic.
+func (s *scanner) eval(e ast.Expr) []string {
+	return s.evalDepth(e, 0)
+}
+
+// maxEvalDepth bounds the resolution of local variables, which may be assigned
+// from themselves.
+const maxEvalDepth = 8
+
+func (s *scanner) evalDepth(e ast.Expr, depth int) []string {
+	if depth > maxEvalDepth {
+		return []string{dynamic}
+	}
+
+	switch v := e.(type) {
+	case *ast.BasicLit:
+		if v.Kind != token.STRING {
+			return []string{dynamic}
+		}
+
+		str, err := strconv.Unquote(v.Value)
+		if err != nil {
+			return nil
+		}
+
+		return []string{str}
+
+	case *ast.Ident:
+		if c, ok := s.consts[v.Name]; ok {
+			return []string{c}
+		}
+
+		var vals []string
+		for _, le := range s.locals[v.Name] {
+			vals = append(vals, s.evalDepth(le, depth+1)...)
+		}
+
+		if i, ok := s.params[v.Name]; ok {
+			if args, ok := s.args[v.Name]; ok {
+				vals = append(vals, args...)
+			} else {
+				vals = append(vals, paramValue(i))
+			}
+		}
+
+		if len(vals) == 0 {
+			return []string{dynamic}
+		}
+
+		return vals
+
+	case *ast.ParenExpr:
+		return s.evalDepth(v.X, depth)
+
+	case *ast.BinaryExpr:
+		if v.Op != token.ADD {
+			return []string{dynamic}
+		}
+
+		var vals []string
+		for _, l := range s.evalDepth(v.X, depth) {
+			for _, r := range s.evalDepth(v.Y, depth) {
+				vals = append(vals, l+r)
+			}
+		}
+
+		return vals
+
+	case *ast.CallExpr:
+		// fmt.Sprintf with a constant format string
+		if sel, ok := v.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Sprintf" && len(v.Args) > 0 {
+			var vals []string
+			for _, f := range s.evalDepth(v.Args[0], depth) {
+				vals = append(vals, s.sprintf(f, v.Args[1:], depth)...)
+			}
+
+			return vals
+		}
+	}
+
+	return []string{dynamic}
+}
+
+// sprintf substitutes the format verbs of f with the possible values of args.
+func (s *scanner) sprintf(f string, args []ast.Expr, depth int) []string {
+	parts := formatVerb.Split(f, -1)
+	vals := []string{parts[0]}
+
+	for i, part := range parts[1:] {
+		sub := []string{dynamic}
+		if i < len(args) {
+			sub = s.evalDepth(args[i], depth)
+		}
+
+		var next []string
+		for _, v := range vals {
+			for _, a := range sub {
+				next = append(next, v+a+part)
+			}
+		}
+
+		vals = next
+	}
+
+	return vals
+}
+
+// cleanPath strips the query string and trailing slash of p, and makes
+// partially dynamic segments fully dynamic.
+func cleanPath(p string) string {
+	if i := strings.IndexByte(p, '?'); i >= 0 {
+		p = p[:i]
+	}
+
+	segs := strings.Split(strings.TrimSuffix(p, "/"), "/")
+	for i, seg := range segs {
+		if strings.Contains(seg, dynamic) {
+			segs[i] = dynamic
+		}
+	}
+
+	return strings.Join(segs, "/")
+}
+
+var pathParam = regexp.MustCompile(`\{[^}]*\}`)
+
+// matches returns whether the route matches the specification path. Dynamic
+// segments of the route match any segment.
+func (r Route) matches(method, specPath string) bool {
+	if r.Method != method {
+		return false
+	}
+
+	rs := strings.Split(r.Path, "/")
+	ss := strings.Split(pathParam.ReplaceAllString(specPath, dynamic), "/")
+
+	if len(rs) != len(ss) {
+		return false
+	}
+
+	for i := range rs {
+		if rs[i] != ss[i] && rs[i] != dynamic {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Compare returns the coverage of the specification by the implemented routes.
+// If spec is nil, only the implemented routes are returned.
+func Compare(spec *openapi.Spec, implemented []Route) []Endpoint {
+	var endpoints []Endpoint
+
+	matched := make(map[Route]bool, len(implemented))
+
+	if spec != nil {
+		for _, sr := range spec.Routes() {
+			e := Endpoint{
+				Method:     sr.Method,
+				Path:       sr.Path,
+				Status:     StatusMissing,
+				Deprecated: sr.Deprecated,
+			}
+
+			if sr.Deprecated {
+				e.Status = StatusDeprecated
+			}
+
+			for _, r := range implemented {
+				if r.matches(sr.Method, sr.Path) {
+					e.Status = StatusImplemented
+					matched[r] = true
+				}
+			}
+
+			endpoints = append(endpoints, e)
+		}
+	}
+
+	// without a specification there's nothing to compare against, so all
+	// routes are known to be implemented
+	status := StatusImplemented
+	if spec != nil {
+		status = StatusUnknown
+	}
+
+	for _, r := range implemented {
+		if matched[r] {
+			continue
+		}
+
+		endpoints = append(endpoints, Endpoint{
+			Method: r.Method,
+			Path:   namedParams(r.Path),
+			Status: status,
+		})
+	}
+
+	sort.SliceStable(endpoints, func(i, j int) bool {
+		if endpoints[i].Path != endpoints[j].Path {
+			return endpoints[i].Path < endpoints[j].Path
+		}
+
+		return endpoints[i].Method < endpoints[j].Method
+	})
+
+	return endpoints
+}
+
+// namedParams replaces the dynamic segments of p with {id}, to match the
+// style of the specification.
+func namedParams(p string) string {
+	return strings.ReplaceAll(p, dynamic, "{id}")
+}
+
+// WriteReport writes a human-readable summary of the coverage to w.
+func WriteReport(w io.Writer, endpoints []Endpoint) error {
+	counts := make(map[Status]int)
+	for _, e := range endpoints {
+		counts[e.Status]++
+	}
+
+	if _, err := fmt.Fprintf(w, "implemented: %d, missing: %d, deprecated: %d, unknown: %d\n\n",
+		counts[StatusImplemented], counts[StatusMissing], counts[StatusDeprecated], counts[StatusUnknown],
+	); err != nil {
+		return err
+	}
+
+	for _, e := range endpoints {
+		if _, err := fmt.Fprintf(w, "%-12s %-7s %s\n", e.Status, e.Method, e.Path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var goTemplate = template.Must(template.New("go").Parse(`// Code generated by pdcoverage. DO NOT EDIT.
+{{- if not .Spec}}
+
+// Generated without the OpenAPI specification, so only the implemented
+// endpoints are listed.
+{{- end}}
+
+package {{.Package}}
+
+var endpoints = []Endpoint{
+{{- range .Endpoints}}
+	{Method: "{{.Method}}", Path: "{{.Path}}"{{if .Implemented}}, Implemented: true{{end}}{{if .Deprecated}}, Deprecated: true{{end}}},
+{{- end}}
+}
+`))
+
+// WriteGo writes the coverage as a Go source file declaring the endpoints
+// variable used by the pagerduty.Endpoints function. spec is whether the
+// endpoints were compared against the specification.
+func WriteGo(w io.Writer, pkg string, endpoints []Endpoint, spec bool) error {
+	return goTemplate.Execute(w, struct {
+		Package   string
+		Spec      bool
+		Endpoints []Endpoint
+	}{
+		Package:   pkg,
+		Spec:      spec,
+		Endpoints: endpoints,
+	})
+}
@@ -0,0 +1,121 @@
+package coverage
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/PagerDuty/go-pagerduty/internal/openapi"
+)
+
+func TestScanDir(t *testing.T) {
+	got, err := ScanDir("testdata/pkg")
+	if err != nil {
+		t.Fatalf("ScanDir() unexpected error: %s", err)
+	}
+
+	want := []Route{
+		{Method: "GET", Path: "/tags"},
+		{Method: "GET", Path: "/teams/{}/tags"},
+		{Method: "GET", Path: "/things"},
+		{Method: "GET", Path: "/things/all"},
+		{Method: "DELETE", Path: "/things/{}"},
+		{Method: "GET", Path: "/things/{}"},
+		{Method: "PUT", Path: "/things/{}"},
+		{Method: "GET", Path: "/things/{}/audit"},
+		{Method: "POST", Path: "/things/{}/widgets"},
+		{Method: "GET", Path: "/users/{}/tags"},
+		{Method: "GET", Path: "/{}/{}/tags"},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("ScanDir() = %v, want %v", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ScanDir()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+const testSpec = `{
+  "openapi": "3.0.2",
+  "paths": {
+    "/things": {"get": {}, "post": {}},
+    "/things/{id}": {"get": {}, "delete": {"deprecated": true}},
+    "/things/{id}/widgets": {"post": {}},
+    "/users/{id}/tags": {"get": {}},
+    "/legacy": {"get": {"deprecated": true}}
+  }
+}`
+
+func TestCompare(t *testing.T) {
+	spec, err := openapi.Load(strings.NewReader(testSpec))
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %s", err)
+	}
+
+	routes, err := ScanDir("testdata/pkg")
+	if err != nil {
+		t.Fatalf("ScanDir() unexpected error: %s", err)
+	}
+
+	got := Compare(spec, routes)
+
+	want := []Endpoint{
+		{Method: "GET", Path: "/legacy", Status: StatusDeprecated, Deprecated: true},
+		{Method: "GET", Path: "/tags", Status: StatusUnknown},
+		{Method: "GET", Path: "/teams/{id}/tags", Status: StatusUnknown},
+		{Method: "GET", Path: "/things", Status: StatusImplemented},
+		{Method: "POST", Path: "/things", Status: StatusMissing},
+		{Method: "GET", Path: "/things/all", Status: StatusUnknown},
+		{Method: "DELETE", Path: "/things/{id}", Status: StatusImplemented, Deprecated: true},
+		{Method: "GET", Path: "/things/{id}", Status: StatusImplemented},
+		{Method: "PUT", Path: "/things/{id}", Status: StatusUnknown},
+		{Method: "GET", Path: "/things/{id}/audit", Status: StatusUnknown},
+		{Method: "POST", Path: "/things/{id}/widgets", Status: StatusImplemented},
+		{Method: "GET", Path: "/users/{id}/tags", Status: StatusImplemented},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Compare() = %v, want %v", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Compare()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, got); err != nil {
+		t.Fatalf("WriteReport() unexpected error: %s", err)
+	}
+
+	if !strings.HasPrefix(buf.String(), "implemented: 5, missing: 1, deprecated: 1, unknown: 5\n") {
+		t.Errorf("WriteReport() = %s", buf.String())
+	}
+}
+
+func TestWriteGo(t *testing.T) {
+	endpoints := []Endpoint{{Method: "GET", Path: "/things", Status: StatusImplemented}}
+
+	var buf bytes.Buffer
+	if err := WriteGo(&buf, "pkg", endpoints, false); err != nil {
+		t.Fatalf("WriteGo() unexpected error: %s", err)
+	}
+
+	if !strings.Contains(buf.String(), "Generated without the OpenAPI specification") {
+		t.Errorf("WriteGo() = %s, want a note about the missing specification", buf.String())
+	}
+
+	buf.Reset()
+	if err := WriteGo(&buf, "pkg", endpoints, true); err != nil {
+		t.Fatalf("WriteGo() unexpected error: %s", err)
+	}
+
+	if strings.Contains(buf.String(), "Generated without") {
+		t.Errorf("WriteGo() = %s, want no note about the specification", buf.String())
+	}
+}
@@ -0,0 +1,89 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const thingsPath = "/things"
+
+type Client struct{}
+
+func (c *Client) get(ctx context.Context, path string) (*http.Response, error) { return nil, nil }
+
+func (c *Client) post(ctx context.Context, path string, payload interface{}, headers map[string]string) (*http.Response, error) {
+	return nil, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body interface{}, headers map[string]string) (*http.Response, error) {
+	return nil, nil
+}
+
+func (c *Client) ListThings(ctx context.Context) {
+	_, _ = c.get(ctx, thingsPath+"?limit=1")
+}
+
+func (c *Client) GetThing(ctx context.Context, id string) {
+	_, _ = c.get(ctx, thingsPath+"/"+id)
+}
+
+func (c *Client) CreateWidget(ctx context.Context, thingID string) {
+	u := fmt.Sprintf("%s/%s/widgets/", thingsPath, thingID)
+	_, _ = c.post(ctx, u, nil, nil)
+}
+
+func (c *Client) DeleteThing(ctx context.Context, id string) {
+	_, _ = c.do(ctx, http.MethodDelete, thingsPath+"/"+id, nil, nil)
+}
+
+func (c *Client) ListTags(ctx context.Context, entityType, entityID string) {
+	path := "/tags"
+	if entityType != "" {
+		path = "/" + entityType + "/" + entityID + "/tags"
+	}
+
+	_, _ = c.get(ctx, path)
+}
+
+func (c *Client) ListThingAudit(ctx context.Context, id string) {
+	c.listAudit(ctx, thingsPath+"/"+id+"/audit")
+}
+
+func (c *Client) listAudit(ctx context.Context, path string) {
+	_, _ = c.get(ctx, path+"?limit=1")
+}
+
+// Values of the kind argument of ListKindTags.
+const (
+	KindUsers = "users"
+	KindTeams = "teams"
+)
+
+func (c *Client) ListKindTags(ctx context.Context, kind, id string) {
+	_, _ = c.get(ctx, "/"+kind+"/"+id+"/tags")
+}
+
+func (c *Client) ListAllThings(ctx context.Context) {
+	c.listThings(ctx, "all")
+}
+
+func (c *Client) listThings(ctx context.Context, kind string) {
+	_, _ = c.get(ctx, thingsPath+"/"+kind)
+}
+
+func (c *Client) PutThing(ctx context.Context, id string) {
+	c.send(ctx, http.MethodPut, thingsPath+"/"+id)
+}
+
+func (c *Client) send(ctx context.Context, method, path string) {
+	_, _ = c.do(ctx, method, path, nil, nil)
+}
+
+type EventsClient struct{}
+
+func (c *EventsClient) post(ctx context.Context, path string) {}
+
+func (c *EventsClient) Send(ctx context.Context) {
+	c.post(ctx, "/v2/enqueue")
+}
@@ -0,0 +1,287 @@
+// Package openapi provides a minimal model of the PagerDuty REST API OpenAPI
+// 3 specification, covering what's needed by the code generation and endpoint
+// coverage tools in this repository.
+//
+// The specification is published at
+// https://github.com/PagerDuty/api-schema/blob/main/reference/REST/openapiv3.json
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Spec is an OpenAPI 3 document.
+type Spec struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+// Info is the metadata of the API.
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Components holds the reusable objects referenced from elsewhere in the
+// document.
+type Components struct {
+	Schemas    map[string]*Schema    `json:"schemas"`
+	Parameters map[string]*Parameter `json:"parameters"`
+}
+
+// PathItem describes the operations available on a single path.
+type PathItem struct {
+	Get        *Operation   `json:"get,omitempty"`
+	Put        *Operation   `json:"put,omitempty"`
+	Post       *Operation   `json:"post,omitempty"`
+	Delete     *Operation   `json:"delete,omitempty"`
+	Patch      *Operation   `json:"patch,omitempty"`
+	Parameters []*Parameter `json:"parameters,omitempty"`
+}
+
+// Operations returns the operations of the path, keyed by upper-case HTTP
+// method.
+func (p *PathItem) Operations() map[string]*Operation {
+	ops := make(map[string]*Operation, 5)
+
+	for m, op := range map[string]*Operation{
+		"GET":    p.Get,
+		"PUT":    p.Put,
+		"POST":   p.Post,
+		"DELETE": p.Delete,
+		"PATCH":  p.Patch,
+	} {
+		if op != nil {
+			ops[m] = op
+		}
+	}
+
+	return ops
+}
+
+// Operation describes a single API operation on a path.
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Description string               `json:"description"`
+	Tags        []string             `json:"tags"`
+	Deprecated  bool                 `json:"deprecated"`
+	Parameters  []*Parameter         `json:"parameters"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter describes a single operation parameter.
+type Parameter struct {
+	Ref         string  `json:"$ref,omitempty"`
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// RequestBody describes the body of a request.
+type RequestBody struct {
+	Content map[string]*MediaType `json:"content"`
+}
+
+// Response describes a single response of an operation.
+type Response struct {
+	Ref         string                `json:"$ref,omitempty"`
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content"`
+}
+
+// MediaType holds the schema of a request or response body.
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Schema describes a data type.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	ReadOnly             bool               `json:"readOnly,omitempty"`
+	Deprecated           bool               `json:"deprecated,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"`
+}
+
+// Load decodes an OpenAPI document from r.
+func Load(r io.Reader) (*Spec, error) {
+	var s Spec
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, fmt.Errorf("failed to decode OpenAPI document: %w", err)
+	}
+
+	if !strings.HasPrefix(s.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q", s.OpenAPI)
+	}
+
+	return &s, nil
+}
+
+// LoadFile decodes the OpenAPI document at path.
+func LoadFile(path string) (*Spec, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	return Load(f)
+}
+
+const (
+	schemaRefPrefix    = "#/components/schemas/"
+	parameterRefPrefix = "#/components/parameters/"
+)
+
+// Schema returns the component schema with the given name, resolving it if
+// it's itself a reference.
+func (s *Spec) Schema(name string) (*Schema, error) {
+	sc, ok := s.Components.Schemas[name]
+	if !ok {
+		return nil, fmt.Errorf("schema %q not found", name)
+	}
+
+	return s.ResolveSchema(sc)
+}
+
+// ResolveSchema follows the $ref of sc, if any, and returns the referenced
+// schema.
+func (s *Spec) ResolveSchema(sc *Schema) (*Schema, error) {
+	for i := 0; sc != nil && len(sc.Ref) > 0; i++ {
+		if i > 32 {
+			return nil, fmt.Errorf("too many levels of schema references")
+		}
+
+		name, ok := SchemaRefName(sc.Ref)
+		if !ok {
+			return nil, fmt.Errorf("unsupported schema reference %q", sc.Ref)
+		}
+
+		next, ok := s.Components.Schemas[name]
+		if !ok {
+			return nil, fmt.Errorf("schema %q not found", name)
+		}
+
+		sc = next
+	}
+
+	return sc, nil
+}
+
+// ResolveParameter follows the $ref of p, if any, and returns the referenced
+// parameter.
+func (s *Spec) ResolveParameter(p *Parameter) (*Parameter, error) {
+	if len(p.Ref) == 0 {
+		return p, nil
+	}
+
+	if !strings.HasPrefix(p.Ref, parameterRefPrefix) {
+		return nil, fmt.Errorf("unsupported parameter reference %q", p.Ref)
+	}
+
+	name := strings.TrimPrefix(p.Ref, parameterRefPrefix)
+
+	rp, ok := s.Components.Parameters[name]
+	if !ok {
+		return nil, fmt.Errorf("parameter %q not found", name)
+	}
+
+	return rp, nil
+}
+
+// Properties returns the properties of sc, including the ones of the schemas
+// it's composed of using allOf.
+func (s *Spec) Properties(sc *Schema) (map[string]*Schema, error) {
+	sc, err := s.ResolveSchema(sc)
+	if err != nil {
+		return nil, err
+	}
+
+	props := make(map[string]*Schema, len(sc.Properties))
+
+	for _, sub := range sc.AllOf {
+		subProps, err := s.Properties(sub)
+		if err != nil {
+			return nil, err
+		}
+
+		for k, v := range subProps {
+			props[k] = v
+		}
+	}
+
+	for k, v := range sc.Properties {
+		props[k] = v
+	}
+
+	return props, nil
+}
+
+// SchemaRefName returns the component name of a schema reference, such as
+// "Service" for "#/components/schemas/Service".
+func SchemaRefName(ref string) (string, bool) {
+	if !strings.HasPrefix(ref, schemaRefPrefix) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(ref, schemaRefPrefix), true
+}
+
+// Route is a single operation of the API, identified by its method and path.
+type Route struct {
+	Method     string
+	Path       string
+	Operation  *Operation
+	Deprecated bool
+}
+
+// String satisfies fmt.Stringer.
+func (r Route) String() string {
+	return r.Method + " " + r.Path
+}
+
+// Routes returns all the operations of the document, sorted by path and then
+// method.
+func (s *Spec) Routes() []Route {
+	var routes []Route
+
+	for p, item := range s.Paths {
+		for m, op := range item.Operations() {
+			routes = append(routes, Route{
+				Method:     m,
+				Path:       p,
+				Operation:  op,
+				Deprecated: op.Deprecated,
+			})
+		}
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+
+		return routes[i].Method < routes[j].Method
+	})
+
+	return routes
+}
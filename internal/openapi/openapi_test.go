@@ -0,0 +1,88 @@
+package openapi
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+const testSpec = `{
+  "openapi": "3.0.2",
+  "paths": {
+    "/things": {
+      "get": {"operationId": "listThings"},
+      "post": {"operationId": "createThing"}
+    },
+    "/things/{id}": {
+      "delete": {"operationId": "deleteThing", "deprecated": true}
+    }
+  },
+  "components": {
+    "schemas": {
+      "Base": {"properties": {"id": {"type": "string"}}},
+      "Alias": {"$ref": "#/components/schemas/Thing"},
+      "Thing": {
+        "allOf": [{"$ref": "#/components/schemas/Base"}],
+        "properties": {"name": {"type": "string"}}
+      }
+    }
+  }
+}`
+
+func TestSpec_Routes(t *testing.T) {
+	s, err := Load(strings.NewReader(testSpec))
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %s", err)
+	}
+
+	var got []string
+	for _, r := range s.Routes() {
+		got = append(got, r.String())
+	}
+
+	want := []string{"GET /things", "POST /things", "DELETE /things/{id}"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("Routes() = %v, want %v", got, want)
+	}
+
+	if !s.Paths["/things/{id}"].Delete.Deprecated {
+		t.Fatal("DELETE /things/{id} should be deprecated")
+	}
+}
+
+func TestSpec_Properties(t *testing.T) {
+	s, err := Load(strings.NewReader(testSpec))
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %s", err)
+	}
+
+	sc, err := s.Schema("Alias")
+	if err != nil {
+		t.Fatalf("Schema() unexpected error: %s", err)
+	}
+
+	props, err := s.Properties(sc)
+	if err != nil {
+		t.Fatalf("Properties() unexpected error: %s", err)
+	}
+
+	var got []string
+	for p := range props {
+		got = append(got, p)
+	}
+	sort.Strings(got)
+
+	if strings.Join(got, ",") != "id,name" {
+		t.Fatalf("Properties() = %v, want [id name]", got)
+	}
+
+	if _, err := s.Schema("Missing"); err == nil {
+		t.Fatal("Schema(\"Missing\") error = <nil>, want error")
+	}
+}
+
+func TestLoad_version(t *testing.T) {
+	if _, err := Load(strings.NewReader(`{"swagger": "2.0"}`)); err == nil {
+		t.Fatal("Load() error = <nil>, want error")
+	}
+}
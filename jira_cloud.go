@@ -0,0 +1,292 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/go-querystring/query"
+)
+
+const jiraCloudPath = "/integration-jira-cloud/accounts_mappings"
+
+// JiraCloudAccountsMapping is the connection between a PagerDuty account and a
+// Jira Cloud instance, which rules sync incidents and issues through.
+type JiraCloudAccountsMapping struct {
+	ID        string                 `json:"id,omitempty"`
+	PagerDuty JiraCloudPagerDutySite `json:"pagerduty"`
+	JiraCloud JiraCloudSite          `json:"jira_cloud"`
+	CreatedAt APITime                `json:"created_at,omitempty"`
+	UpdatedAt APITime                `json:"updated_at,omitempty"`
+}
+
+// JiraCloudPagerDutySite is the PagerDuty account of a JiraCloudAccountsMapping.
+type JiraCloudPagerDutySite struct {
+	Subdomain string `json:"subdomain"`
+}
+
+// JiraCloudSite is the Jira Cloud instance of a JiraCloudAccountsMapping.
+type JiraCloudSite struct {
+	BaseURL string `json:"base_url"`
+}
+
+// JiraCloudRule configures how the incidents of a service are synced with the
+// issues of a Jira project.
+type JiraCloudRule struct {
+	ID                          string              `json:"id,omitempty"`
+	Name                        string              `json:"name,omitempty"`
+	AccountMapping              *APIReference       `json:"account_mapping,omitempty"`
+	Config                      JiraCloudRuleConfig `json:"config"`
+	AutocreateJQLDisabledReason string              `json:"autocreate_jql_disabled_reason,omitempty"`
+	AutocreateJQLDisabledUntil  APITime             `json:"autocreate_jql_disabled_until,omitempty"`
+	CreatedAt                   APITime             `json:"created_at,omitempty"`
+	UpdatedAt                   APITime             `json:"updated_at,omitempty"`
+}
+
+// JiraCloudRuleConfig is the configuration of a JiraCloudRule.
+type JiraCloudRuleConfig struct {
+	// Service is the service whose incidents are synced.
+	Service APIReference `json:"service"`
+
+	Jira JiraCloudRuleJiraConfig `json:"jira"`
+}
+
+// JiraCloudRuleJiraConfig is the Jira side of the configuration of a
+// JiraCloudRule.
+type JiraCloudRuleJiraConfig struct {
+	Project   JiraCloudReference `json:"project"`
+	IssueType JiraCloudReference `json:"issue_type"`
+
+	// CreateIssueOnIncidentTrigger creates an issue when an incident is
+	// triggered, rather than when it's manually linked.
+	CreateIssueOnIncidentTrigger bool `json:"create_issue_on_incident_trigger"`
+
+	// AutocreateJQL is a JQL query limiting the issues which create
+	// incidents.
+	AutocreateJQL string `json:"autocreate_jql,omitempty"`
+
+	// SyncNotesUser is the user who authors the notes synced from Jira.
+	SyncNotesUser *APIReference `json:"sync_notes_user,omitempty"`
+
+	Priorities    []JiraCloudPriorityMapping `json:"priorities,omitempty"`
+	StatusMapping *JiraCloudStatusMapping    `json:"status_mapping,omitempty"`
+	CustomFields  []JiraCloudCustomField     `json:"custom_fields,omitempty"`
+}
+
+// JiraCloudReference is a reference to a Jira object, such as a project.
+type JiraCloudReference struct {
+	ID   string `json:"id"`
+	Key  string `json:"key,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// JiraCloudPriorityMapping maps a PagerDuty priority to a Jira priority.
+type JiraCloudPriorityMapping struct {
+	PagerDutyID string `json:"pagerduty_id"`
+	JiraID      string `json:"jira_id"`
+}
+
+// JiraCloudStatusMapping maps the statuses of incidents to Jira statuses.
+type JiraCloudStatusMapping struct {
+	Triggered    *JiraCloudReference `json:"triggered,omitempty"`
+	Acknowledged *JiraCloudReference `json:"acknowledged,omitempty"`
+	Resolved     *JiraCloudReference `json:"resolved,omitempty"`
+}
+
+// JiraCloudCustomField sets a field of the issues, either from a field of the
+// incident or to a constant value.
+type JiraCloudCustomField struct {
+	Type                 string      `json:"type"`
+	SourceIncidentField  string      `json:"source_incident_field,omitempty"`
+	TargetIssueField     string      `json:"target_issue_field"`
+	TargetIssueFieldName string      `json:"target_issue_field_name,omitempty"`
+	Value                interface{} `json:"value,omitempty"`
+}
+
+// Values of the Type field of JiraCloudCustomField.
+const (
+	JiraCloudCustomFieldTypeAttribute = "attribute"
+	JiraCloudCustomFieldTypeConstant  = "const"
+	JiraCloudCustomFieldTypeJiraValue = "jira_value"
+)
+
+// ListJiraCloudOptions is the data structure used when calling the
+// ListJiraCloudAccountsMappings and ListJiraCloudRules API endpoints.
+type ListJiraCloudOptions struct {
+	// Limit is the pagination parameter that limits the number of results per
+	// page. PagerDuty defaults this value to 25 if omitted, and sets an upper
+	// bound of 100.
+	Limit uint `url:"limit,omitempty"`
+
+	// Offset is the pagination parameter that specifies the offset at which to
+	// start pagination results. When trying to request the next page of
+	// results, the new Offset value should be currentOffset + Limit.
+	Offset uint `url:"offset,omitempty"`
+}
+
+// ListJiraCloudAccountsMappingsResponse is the data structure returned from
+// calling the ListJiraCloudAccountsMappings API endpoint.
+type ListJiraCloudAccountsMappingsResponse struct {
+	APIListObject
+	AccountsMappings []JiraCloudAccountsMapping `json:"accounts_mappings"`
+}
+
+// ListJiraCloudRulesResponse is the data structure returned from calling the
+// ListJiraCloudRules API endpoint.
+type ListJiraCloudRulesResponse struct {
+	APIListObject
+	Rules []JiraCloudRule `json:"rules"`
+}
+
+// ListJiraCloudAccountsMappingsWithContext lists the Jira Cloud accounts
+// mappings of the account.
+func (c *Client) ListJiraCloudAccountsMappingsWithContext(ctx context.Context, o ListJiraCloudOptions) (*ListJiraCloudAccountsMappingsResponse, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, jiraCloudPath+"?"+v.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListJiraCloudAccountsMappingsResponse
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListJiraCloudAccountsMappingsPaginated lists the Jira Cloud accounts
+// mappings of the account, handling pagination of the results.
+func (c *Client) ListJiraCloudAccountsMappingsPaginated(ctx context.Context) ([]JiraCloudAccountsMapping, error) {
+	var mappings []JiraCloudAccountsMapping
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListJiraCloudAccountsMappingsResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		mappings = append(mappings, result.AccountsMappings...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+			Total:  result.Total,
+		}, nil
+	}
+
+	if err := c.pagedGet(ctx, jiraCloudPath, responseHandler); err != nil {
+		return nil, err
+	}
+
+	return mappings, nil
+}
+
+// GetJiraCloudAccountsMappingWithContext gets a Jira Cloud accounts mapping.
+func (c *Client) GetJiraCloudAccountsMappingWithContext(ctx context.Context, id string) (*JiraCloudAccountsMapping, error) {
+	resp, err := c.get(ctx, jiraCloudPath+"/"+id)
+	if err != nil {
+		return nil, err
+	}
+
+	var m JiraCloudAccountsMapping
+	if err = c.decodeJSON(resp, &m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// ListJiraCloudRulesWithContext lists the rules of a Jira Cloud accounts
+// mapping.
+func (c *Client) ListJiraCloudRulesWithContext(ctx context.Context, accountsMappingID string, o ListJiraCloudOptions) (*ListJiraCloudRulesResponse, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, jiraCloudPath+"/"+accountsMappingID+"/rules?"+v.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListJiraCloudRulesResponse
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListJiraCloudRulesPaginated lists the rules of a Jira Cloud accounts
+// mapping, handling pagination of the results.
+func (c *Client) ListJiraCloudRulesPaginated(ctx context.Context, accountsMappingID string) ([]JiraCloudRule, error) {
+	var rules []JiraCloudRule
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListJiraCloudRulesResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		rules = append(rules, result.Rules...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+			Total:  result.Total,
+		}, nil
+	}
+
+	if err := c.pagedGet(ctx, jiraCloudPath+"/"+accountsMappingID+"/rules", responseHandler); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// CreateJiraCloudRuleWithContext creates a rule in a Jira Cloud accounts
+// mapping. Unlike most endpoints, the rule isn't wrapped in the request and
+// response bodies.
+func (c *Client) CreateJiraCloudRuleWithContext(ctx context.Context, accountsMappingID string, r JiraCloudRule) (*JiraCloudRule, error) {
+	resp, err := c.post(ctx, jiraCloudPath+"/"+accountsMappingID+"/rules", r, nil)
+	return getJiraCloudRuleFromResponse(c, resp, err)
+}
+
+// GetJiraCloudRuleWithContext gets a rule of a Jira Cloud accounts mapping.
+func (c *Client) GetJiraCloudRuleWithContext(ctx context.Context, accountsMappingID, id string) (*JiraCloudRule, error) {
+	resp, err := c.get(ctx, jiraCloudPath+"/"+accountsMappingID+"/rules/"+id)
+	return getJiraCloudRuleFromResponse(c, resp, err)
+}
+
+// UpdateJiraCloudRuleWithContext updates a rule of a Jira Cloud accounts
+// mapping.
+func (c *Client) UpdateJiraCloudRuleWithContext(ctx context.Context, accountsMappingID, id string, r JiraCloudRule) (*JiraCloudRule, error) {
+	resp, err := c.put(ctx, jiraCloudPath+"/"+accountsMappingID+"/rules/"+id, r, nil)
+	return getJiraCloudRuleFromResponse(c, resp, err)
+}
+
+// DeleteJiraCloudRuleWithContext deletes a rule of a Jira Cloud accounts
+// mapping.
+func (c *Client) DeleteJiraCloudRuleWithContext(ctx context.Context, accountsMappingID, id string) error {
+	_, err := c.delete(ctx, jiraCloudPath+"/"+accountsMappingID+"/rules/"+id)
+	return err
+}
+
+func getJiraCloudRuleFromResponse(c *Client, resp *http.Response, err error) (*JiraCloudRule, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	var r JiraCloudRule
+	if err = c.decodeJSON(resp, &r); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
@@ -0,0 +1,166 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestJiraCloud_ListAccountsMappings(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/integration-jira-cloud/accounts_mappings", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"accounts_mappings": [{"id": "AM1", "pagerduty": {"subdomain": "acme"}, "jira_cloud": {"base_url": "https://acme.atlassian.net"}}], "limit": 25}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListJiraCloudAccountsMappingsPaginated(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []JiraCloudAccountsMapping{{
+		ID:        "AM1",
+		PagerDuty: JiraCloudPagerDutySite{Subdomain: "acme"},
+		JiraCloud: JiraCloudSite{BaseURL: "https://acme.atlassian.net"},
+	}}
+	testEqual(t, want, res)
+}
+
+func TestJiraCloud_GetAccountsMapping(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/integration-jira-cloud/accounts_mappings/AM1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"id": "AM1", "pagerduty": {"subdomain": "acme"}, "jira_cloud": {"base_url": "https://acme.atlassian.net"}, "created_at": "2021-01-01T00:00:00Z"}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.GetJiraCloudAccountsMappingWithContext(context.Background(), "AM1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "https://acme.atlassian.net", res.JiraCloud.BaseURL)
+	testEqual(t, NewAPITime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)), res.CreatedAt)
+}
+
+func TestJiraCloud_ListRules(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/integration-jira-cloud/accounts_mappings/AM1/rules", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "10", r.URL.Query().Get("limit"))
+		_, _ = w.Write([]byte(`{"rules": [{"id": "R1", "name": "API incidents", "config": {"service": {"id": "PS1", "type": "service_reference"}, "jira": {"project": {"id": "10000", "key": "OPS"}, "issue_type": {"id": "10001", "name": "Bug"}}}}], "limit": 10}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListJiraCloudRulesWithContext(context.Background(), "AM1", ListJiraCloudOptions{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 1, len(res.Rules))
+	testEqual(t, "OPS", res.Rules[0].Config.Jira.Project.Key)
+	testEqual(t, "PS1", res.Rules[0].Config.Service.ID)
+}
+
+func TestJiraCloud_CreateRule(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/integration-jira-cloud/accounts_mappings/AM1/rules", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body JiraCloudRule
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		testEqual(t, "API incidents", body.Name)
+		testEqual(t, true, body.Config.Jira.CreateIssueOnIncidentTrigger)
+		testEqual(t, "10002", body.Config.Jira.StatusMapping.Resolved.ID)
+		testEqual(t, JiraCloudCustomFieldTypeAttribute, body.Config.Jira.CustomFields[0].Type)
+
+		_, _ = w.Write([]byte(`{"id": "R1", "name": "API incidents"}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	rule := JiraCloudRule{
+		Name: "API incidents",
+		Config: JiraCloudRuleConfig{
+			Service: APIReference{ID: "PS1", Type: "service_reference"},
+			Jira: JiraCloudRuleJiraConfig{
+				Project:                      JiraCloudReference{ID: "10000"},
+				IssueType:                    JiraCloudReference{ID: "10001"},
+				CreateIssueOnIncidentTrigger: true,
+				StatusMapping: &JiraCloudStatusMapping{
+					Resolved: &JiraCloudReference{ID: "10002"},
+				},
+				CustomFields: []JiraCloudCustomField{{
+					Type:                JiraCloudCustomFieldTypeAttribute,
+					SourceIncidentField: "incident_number",
+					TargetIssueField:    "customfield_10050",
+				}},
+			},
+		},
+	}
+
+	res, err := client.CreateJiraCloudRuleWithContext(context.Background(), "AM1", rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "R1", res.ID)
+}
+
+func TestJiraCloud_UpdateRule(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/integration-jira-cloud/accounts_mappings/AM1/rules/R1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"id": "R1", "name": "API incidents"}`))
+		case http.MethodPut:
+			var body JiraCloudRule
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode request body: %s", err)
+			}
+			testEqual(t, "Renamed", body.Name)
+			_, _ = w.Write([]byte(`{"id": "R1", "name": "Renamed"}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	rule, err := client.GetJiraCloudRuleWithContext(context.Background(), "AM1", "R1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule.Name = "Renamed"
+
+	res, err := client.UpdateJiraCloudRuleWithContext(context.Background(), "AM1", "R1", *rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "Renamed", res.Name)
+
+	testErrCheck(t, "DeleteJiraCloudRuleWithContext", "", client.DeleteJiraCloudRuleWithContext(context.Background(), "AM1", "R1"))
+}
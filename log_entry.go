@@ -3,7 +3,8 @@ package pagerduty
 import (
 	"context"
 	"encoding/json"
-	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -29,7 +30,7 @@ type Context struct {
 // CommonLogEntryField is the list of shared log entry between Incident and LogEntry
 type CommonLogEntryField struct {
 	APIObject
-	CreatedAt              string            `json:"created_at,omitempty"`
+	CreatedAt              APITime           `json:"created_at,omitempty"`
 	Agent                  Agent             `json:"agent,omitempty"`
 	Channel                Channel           `json:"channel,omitempty"`
 	Teams                  []Team            `json:"teams,omitempty"`
@@ -45,6 +46,9 @@ type LogEntry struct {
 	Incident Incident  `json:"incident"`
 	Service  APIObject `json:"service"`
 	User     APIObject `json:"user"`
+
+	// Message is the message of status update log entries.
+	Message string `json:"message,omitempty"`
 }
 
 // ListLogEntryResponse is the response data when calling the ListLogEntry API endpoint.
@@ -75,13 +79,22 @@ type ListLogEntriesOptions struct {
 	Total bool `url:"total,omitempty"`
 
 	TimeZone   string   `url:"time_zone,omitempty"`
-	Since      string   `url:"since,omitempty"`
-	Until      string   `url:"until,omitempty"`
+	Since      APITime  `url:"since,omitempty"`
+	Until      APITime  `url:"until,omitempty"`
 	IsOverview bool     `url:"is_overview,omitempty"`
 	Includes   []string `url:"include,omitempty,brackets"`
 	TeamIDs    []string `url:"team_ids,omitempty,brackets"`
 }
 
+// Values of the Includes field of ListLogEntriesOptions and
+// GetLogEntryOptions.
+const (
+	LogEntryIncludeIncidents = "incidents"
+	LogEntryIncludeServices  = "services"
+	LogEntryIncludeChannels  = "channels"
+	LogEntryIncludeTeams     = "teams"
+)
+
 // ListLogEntries lists all of the incident log entries across the entire
 // account.
 //
@@ -110,20 +123,55 @@ func (c *Client) ListLogEntriesWithContext(ctx context.Context, o ListLogEntries
 	return &result, err
 }
 
+// ListLogEntriesPaginated lists all of the incident log entries across the
+// entire account, handling pagination of the results. Set IsOverview to only
+// list the most important changes, and Since and Until to limit the time
+// range.
+func (c *Client) ListLogEntriesPaginated(ctx context.Context, o ListLogEntriesOptions) ([]LogEntry, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []LogEntry
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListLogEntryResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		entries = append(entries, result.LogEntries...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+			Total:  result.Total,
+		}, nil
+	}
+
+	if err := c.pagedGet(ctx, "/log_entries?"+v.Encode(), responseHandler); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
 // GetLogEntryOptions is the data structure used when calling the GetLogEntry API endpoint.
 type GetLogEntryOptions struct {
 	TimeZone string   `url:"time_zone,omitempty"`
 	Includes []string `url:"include,omitempty,brackets"`
 }
 
-// GetLogEntry list log entries for the specified incident.
+// GetLogEntry gets a single log entry.
 //
 // Deprecated: Use GetLogEntryWithContext instead.
 func (c *Client) GetLogEntry(id string, o GetLogEntryOptions) (*LogEntry, error) {
 	return c.GetLogEntryWithContext(context.Background(), id, o)
 }
 
-// GetLogEntryWithContext list log entries for the specified incident.
+// GetLogEntryWithContext gets a single log entry.
 func (c *Client) GetLogEntryWithContext(ctx context.Context, id string, o GetLogEntryOptions) (*LogEntry, error) {
 	v, err := query.Values(o)
 	if err != nil {
@@ -131,20 +179,12 @@ func (c *Client) GetLogEntryWithContext(ctx context.Context, id string, o GetLog
 	}
 
 	resp, err := c.get(ctx, "/log_entries/"+id+"?"+v.Encode())
-	if err != nil {
-		return nil, err
-	}
 
-	var result map[string]LogEntry
-	if err := c.decodeJSON(resp, &result); err != nil {
+	var le LogEntry
+	if err := decodeEnvelope(c, resp, err, "log_entry", &le); err != nil {
 		return nil, err
 	}
 
-	le, ok := result["log_entry"]
-	if !ok {
-		return nil, fmt.Errorf("JSON response does not have log_entry field")
-	}
-
 	return &le, nil
 }
 
@@ -154,9 +194,8 @@ func (c *Channel) UnmarshalJSON(b []byte) error {
 	if err := json.Unmarshal(b, &raw); err != nil {
 		return err
 	}
-	ct, ok := raw["type"]
-	if ok {
-		c.Type = ct.(string)
+	if ct, ok := raw["type"].(string); ok {
+		c.Type = ct
 		c.Raw = raw
 	}
 
@@ -174,3 +213,172 @@ func (c *Channel) MarshalJSON() ([]byte, error) {
 
 	return json.Marshal(raw)
 }
+
+// Values of the Type field of LogEntry.
+const (
+	LogEntryTypeAcknowledge        = "acknowledge_log_entry"
+	LogEntryTypeAnnotate           = "annotate_log_entry"
+	LogEntryTypeAssign             = "assign_log_entry"
+	LogEntryTypeEscalate           = "escalate_log_entry"
+	LogEntryTypeExhaustEscalation  = "exhaust_escalation_path_log_entry"
+	LogEntryTypeNotify             = "notify_log_entry"
+	LogEntryTypePriorityChange     = "priority_change_log_entry"
+	LogEntryTypeReachAckLimit      = "reach_ack_limit_log_entry"
+	LogEntryTypeReachTriggerLimit  = "reach_trigger_limit_log_entry"
+	LogEntryTypeRepeatEscalation   = "repeat_escalation_path_log_entry"
+	LogEntryTypeResolve            = "resolve_log_entry"
+	LogEntryTypeSnooze             = "snooze_log_entry"
+	LogEntryTypeStatusUpdate       = "status_update_log_entry"
+	LogEntryTypeTrigger            = "trigger_log_entry"
+	LogEntryTypeUnacknowledge      = "unacknowledge_log_entry"
+	LogEntryTypeUrgencyChange      = "urgency_change_log_entry"
+	LogEntryTypeResponderRequest   = "responder_request_log_entry"
+	LogEntryTypeResponderAccept    = "responder_accept_log_entry"
+	LogEntryTypeResponderDecline   = "responder_decline_log_entry"
+	LogEntryTypeResponderRemove    = "responder_remove_log_entry"
+	LogEntryTypeStakeholderRequest = "stakeholder_request_log_entry"
+)
+
+// Summary returns the summary of the channel, such as the content of the note
+// of an annotate log entry, or the description of the event of a trigger log
+// entry.
+func (c Channel) Summary() string {
+	s, _ := c.Raw["summary"].(string)
+	return s
+}
+
+// Details returns the details of the channel, such as the custom details of
+// the event of a trigger log entry.
+func (c Channel) Details() map[string]interface{} {
+	d, _ := c.Raw["details"].(map[string]interface{})
+	return d
+}
+
+// TriggerLogEntry is a log entry of an incident being triggered.
+type TriggerLogEntry struct {
+	LogEntry
+
+	// Summary is the summary of the triggering event.
+	Summary string
+
+	// Details are the custom details of the triggering event.
+	Details map[string]interface{}
+
+	// ClientName and ClientURL are the monitoring client that sent the
+	// triggering event, if any.
+	ClientName string
+	ClientURL  string
+}
+
+// AcknowledgeLogEntry is a log entry of an incident being acknowledged.
+type AcknowledgeLogEntry struct {
+	LogEntry
+
+	// Timeout is how long until the incident is triggered again, or zero if
+	// it's never triggered again.
+	Timeout time.Duration
+}
+
+// ResolveLogEntry is a log entry of an incident being resolved.
+type ResolveLogEntry struct {
+	LogEntry
+}
+
+// AnnotateLogEntry is a log entry of a note being added to an incident.
+type AnnotateLogEntry struct {
+	LogEntry
+
+	// Note is the content of the note.
+	Note string
+}
+
+// StatusUpdateLogEntry is a log entry of a status update being sent for an
+// incident. Its message is in the Message field of the log entry.
+type StatusUpdateLogEntry struct {
+	LogEntry
+}
+
+// AssignLogEntry is a log entry of an incident being assigned or reassigned,
+// to the users in the Assignees field of the log entry.
+type AssignLogEntry struct {
+	LogEntry
+}
+
+// EscalateLogEntry is a log entry of an incident being escalated, to the users
+// in the Assignees field of the log entry.
+type EscalateLogEntry struct {
+	LogEntry
+}
+
+// NotifyLogEntry is a log entry of a user being notified about an incident.
+type NotifyLogEntry struct {
+	LogEntry
+
+	// NotificationType is the type of the notification, such as "sms_notification"
+	// or "email_notification".
+	NotificationType string
+
+	// Address is where the notification was sent, such as a phone number or
+	// an email address.
+	Address string
+
+	// Status is the status of the notification, such as "success".
+	Status string
+}
+
+// Typed returns the log entry decoded according to its type, as one of
+// *TriggerLogEntry, *AcknowledgeLogEntry, *ResolveLogEntry, *AnnotateLogEntry,
+// *StatusUpdateLogEntry, *AssignLogEntry, *EscalateLogEntry or
+// *NotifyLogEntry. Other types of log entries are returned as a *LogEntry.
+//
+// Callers are expected to use a type switch on the returned value.
+func (l LogEntry) Typed() interface{} {
+	switch l.Type {
+	case LogEntryTypeTrigger:
+		e := &TriggerLogEntry{
+			LogEntry: l,
+			Summary:  l.Channel.Summary(),
+			Details:  l.Channel.Details(),
+		}
+
+		e.ClientName, _ = l.Channel.Raw["client"].(string)
+		e.ClientURL, _ = l.Channel.Raw["client_url"].(string)
+
+		return e
+
+	case LogEntryTypeAcknowledge:
+		return &AcknowledgeLogEntry{
+			LogEntry: l,
+			Timeout:  time.Duration(l.AcknowledgementTimeout) * time.Second,
+		}
+
+	case LogEntryTypeResolve:
+		return &ResolveLogEntry{LogEntry: l}
+
+	case LogEntryTypeAnnotate:
+		return &AnnotateLogEntry{LogEntry: l, Note: l.Channel.Summary()}
+
+	case LogEntryTypeStatusUpdate:
+		return &StatusUpdateLogEntry{LogEntry: l}
+
+	case LogEntryTypeAssign:
+		return &AssignLogEntry{LogEntry: l}
+
+	case LogEntryTypeEscalate:
+		return &EscalateLogEntry{LogEntry: l}
+
+	case LogEntryTypeNotify:
+		e := &NotifyLogEntry{LogEntry: l}
+
+		if n, ok := l.Channel.Raw["notification"].(map[string]interface{}); ok {
+			e.NotificationType, _ = n["type"].(string)
+			e.Address, _ = n["address"].(string)
+			e.Status, _ = n["status"].(string)
+		}
+
+		return e
+
+	default:
+		return &l
+	}
+}
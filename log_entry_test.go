@@ -1,9 +1,13 @@
 package pagerduty
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 	"testing"
+	"time"
 )
 
 func TestLogEntry_List(t *testing.T) {
@@ -150,3 +154,75 @@ func TestChannel_MarhalUnmarshal(t *testing.T) {
 	}
 	testEqual(t, want, newLogEntry)
 }
+
+func TestLogEntry_ListPaginated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/log_entries", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "true", r.URL.Query().Get("is_overview"))
+		testEqual(t, "2021-01-01T00:00:00Z", r.URL.Query().Get("since"))
+		testEqual(t, []string{LogEntryIncludeIncidents}, r.URL.Query()["include[]"])
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		resp := fmt.Sprintf(`{"log_entries": [{"id": "%d", "channel": {"type": 1}}], "more": %t, "offset": %d, "limit": 1}`, offset, offset == 0, offset)
+		_, _ = w.Write([]byte(resp))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListLogEntriesPaginated(context.Background(), ListLogEntriesOptions{
+		Limit:      1,
+		Since:      NewAPITime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+		IsOverview: true,
+		Includes:   []string{LogEntryIncludeIncidents},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 2, len(res))
+	testEqual(t, "0", res[0].ID)
+	testEqual(t, "1", res[1].ID)
+}
+
+func TestLogEntry_Typed(t *testing.T) {
+	const data = `[
+		{"id": "1", "type": "trigger_log_entry", "channel": {"type": "api", "summary": "CPU high", "details": {"cpu": 99}, "client": "Nagios", "client_url": "https://nagios.example.com"}},
+		{"id": "2", "type": "acknowledge_log_entry", "acknowledgement_timeout": 1800, "channel": {"type": "web_ui"}},
+		{"id": "3", "type": "annotate_log_entry", "channel": {"type": "web_ui", "summary": "Rolling back"}},
+		{"id": "4", "type": "status_update_log_entry", "message": "Mitigated"},
+		{"id": "5", "type": "notify_log_entry", "channel": {"type": "notification", "notification": {"type": "sms_notification", "address": "+15555550100", "status": "success"}}},
+		{"id": "6", "type": "snooze_log_entry"}
+	]`
+
+	var entries []LogEntry
+	if err := json.Unmarshal([]byte(data), &entries); err != nil {
+		t.Fatal(err)
+	}
+
+	trigger, ok := entries[0].Typed().(*TriggerLogEntry)
+	if !ok {
+		t.Fatalf("entries[0].Typed() = %T, want *TriggerLogEntry", entries[0].Typed())
+	}
+
+	testEqual(t, "CPU high", trigger.Summary)
+	testEqual(t, map[string]interface{}{"cpu": 99.0}, trigger.Details)
+	testEqual(t, "Nagios", trigger.ClientName)
+	testEqual(t, "https://nagios.example.com", trigger.ClientURL)
+
+	ack := entries[1].Typed().(*AcknowledgeLogEntry)
+	testEqual(t, 30*time.Minute, ack.Timeout)
+
+	testEqual(t, "Rolling back", entries[2].Typed().(*AnnotateLogEntry).Note)
+	testEqual(t, "Mitigated", entries[3].Typed().(*StatusUpdateLogEntry).Message)
+
+	notify := entries[4].Typed().(*NotifyLogEntry)
+	testEqual(t, "sms_notification", notify.NotificationType)
+	testEqual(t, "+15555550100", notify.Address)
+	testEqual(t, "success", notify.Status)
+
+	testEqual(t, "6", entries[5].Typed().(*LogEntry).ID)
+}
@@ -2,8 +2,8 @@ package pagerduty
 
 import (
 	"context"
-	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -20,6 +20,44 @@ type MaintenanceWindow struct {
 	CreatedBy      *APIObject  `json:"created_by,omitempty"`
 }
 
+// NewMaintenanceWindow returns a maintenance window from start to end for the
+// services with the given IDs, which can be passed to
+// CreateMaintenanceWindowWithContext.
+func NewMaintenanceWindow(start, end time.Time, description string, serviceIDs ...string) MaintenanceWindow {
+	services := make([]APIObject, len(serviceIDs))
+	for i, id := range serviceIDs {
+		services[i] = APIObject{ID: id, Type: "service_reference"}
+	}
+
+	return MaintenanceWindow{
+		StartTime:   NewAPITime(start).String(),
+		EndTime:     NewAPITime(end).String(),
+		Description: description,
+		Services:    services,
+	}
+}
+
+// Times returns the start and end of the maintenance window, parsed as RFC
+// 3339 times.
+func (m MaintenanceWindow) Times() (start, end time.Time, err error) {
+	return parseTimeRange(m.StartTime, m.EndTime)
+}
+
+// Values of the Filter field of ListMaintenanceWindowsOptions.
+const (
+	MaintenanceWindowFilterPast    = "past"
+	MaintenanceWindowFilterFuture  = "future"
+	MaintenanceWindowFilterOngoing = "ongoing"
+)
+
+// Values of the Includes field of ListMaintenanceWindowsOptions and
+// GetMaintenanceWindowOptions.
+const (
+	MaintenanceWindowIncludeServices = "services"
+	MaintenanceWindowIncludeTeams    = "teams"
+	MaintenanceWindowIncludeUsers    = "users"
+)
+
 // ListMaintenanceWindowsResponse is the data structur returned from calling the ListMaintenanceWindows API endpoint.
 type ListMaintenanceWindowsResponse struct {
 	APIListObject
@@ -85,6 +123,40 @@ func (c *Client) ListMaintenanceWindowsWithContext(ctx context.Context, o ListMa
 	return &result, nil
 }
 
+// ListMaintenanceWindowsPaginated lists existing maintenance windows,
+// optionally filtered by service and/or team, or whether they are from the
+// past, present or future, handling pagination of the results.
+func (c *Client) ListMaintenanceWindowsPaginated(ctx context.Context, o ListMaintenanceWindowsOptions) ([]MaintenanceWindow, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var windows []MaintenanceWindow
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListMaintenanceWindowsResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		windows = append(windows, result.MaintenanceWindows...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+			Total:  result.Total,
+		}, nil
+	}
+
+	if err := c.pagedGet(ctx, "/maintenance_windows?"+v.Encode(), responseHandler); err != nil {
+		return nil, err
+	}
+
+	return windows, nil
+}
+
 // CreateMaintenanceWindow creates a new maintenance window for the specified
 // services.
 //
@@ -166,26 +238,21 @@ func (c *Client) UpdateMaintenanceWindow(m MaintenanceWindow) (*MaintenanceWindo
 
 // UpdateMaintenanceWindowWithContext updates an existing maintenance window.
 func (c *Client) UpdateMaintenanceWindowWithContext(ctx context.Context, m MaintenanceWindow) (*MaintenanceWindow, error) {
-	resp, err := c.put(ctx, "/maintenance_windows/"+m.ID, m, nil)
+	m.Type = "maintenance_window"
+
+	d := map[string]MaintenanceWindow{
+		"maintenance_window": m,
+	}
+
+	resp, err := c.put(ctx, "/maintenance_windows/"+m.ID, d, nil)
 	return getMaintenanceWindowFromResponse(c, resp, err)
 }
 
 func getMaintenanceWindowFromResponse(c *Client, resp *http.Response, err error) (*MaintenanceWindow, error) {
-	if err != nil {
+	var t MaintenanceWindow
+	if err := decodeEnvelope(c, resp, err, "maintenance_window", &t); err != nil {
 		return nil, err
 	}
 
-	var target map[string]MaintenanceWindow
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
-	}
-
-	const rootNode = "maintenance_window"
-
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
-	}
-
 	return &t, nil
 }
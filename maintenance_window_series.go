@@ -0,0 +1,235 @@
+package pagerduty
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// MaintenanceWindowSeries is a series of recurring maintenance windows, such
+// as every Sunday from 02:00 to 04:00 for 4 weeks.
+//
+// The windows of a series are identified by a marker appended to their
+// description, of the form "[series NAME N]" where N is the number of the
+// occurrence starting at 1, so that the series can later be listed, extended
+// or canceled.
+type MaintenanceWindowSeries struct {
+	// Name identifies the series. It can only contain letters, digits, dots,
+	// dashes and underscores.
+	Name string
+
+	// Description is the description of every window of the series, before
+	// the series marker.
+	Description string
+
+	// Start is the start of the first window. Later windows start at the same
+	// wall clock time in the location of Start, including across daylight
+	// saving time changes.
+	Start time.Time
+
+	// Duration is how long each window lasts.
+	Duration time.Duration
+
+	// IntervalDays is the number of days between the starts of two windows.
+	// It defaults to 7.
+	IntervalDays int
+
+	// Count is the number of windows of the series.
+	Count int
+
+	// ServiceIDs are the IDs of the services put in maintenance.
+	ServiceIDs []string
+}
+
+var (
+	maintenanceWindowSeriesNameRE   = regexp.MustCompile(`^[\w.-]+$`)
+	maintenanceWindowSeriesMarkerRE = regexp.MustCompile(`\[series ([\w.-]+) (\d+)\]$`)
+)
+
+// Validate returns an error if the series can't be created.
+func (s MaintenanceWindowSeries) Validate() error {
+	if !maintenanceWindowSeriesNameRE.MatchString(s.Name) {
+		return fmt.Errorf("invalid series name %q", s.Name)
+	}
+
+	if s.Start.IsZero() {
+		return errors.New("series start must be set")
+	}
+
+	if s.Duration <= 0 {
+		return errors.New("series duration must be positive")
+	}
+
+	if s.IntervalDays < 0 {
+		return errors.New("series interval must be positive")
+	}
+
+	if s.Count <= 0 {
+		return errors.New("series count must be positive")
+	}
+
+	if len(s.ServiceIDs) == 0 {
+		return errors.New("series must have at least one service")
+	}
+
+	return nil
+}
+
+// Occurrence returns window n of the series, starting at 1.
+func (s MaintenanceWindowSeries) Occurrence(n int) MaintenanceWindow {
+	interval := s.IntervalDays
+	if interval == 0 {
+		interval = 7
+	}
+
+	start := s.Start.AddDate(0, 0, interval*(n-1))
+
+	description := fmt.Sprintf("[series %s %d]", s.Name, n)
+	if len(s.Description) > 0 {
+		description = s.Description + " " + description
+	}
+
+	return NewMaintenanceWindow(start, start.Add(s.Duration), description, s.ServiceIDs...)
+}
+
+// Windows returns the windows of the series, in order.
+func (s MaintenanceWindowSeries) Windows() []MaintenanceWindow {
+	windows := make([]MaintenanceWindow, s.Count)
+	for i := range windows {
+		windows[i] = s.Occurrence(i + 1)
+	}
+
+	return windows
+}
+
+// ParseMaintenanceWindowSeriesMarker returns the name of the series and the
+// occurrence number of a maintenance window created as part of a
+// MaintenanceWindowSeries. ok is false if the window isn't part of a series.
+func ParseMaintenanceWindowSeriesMarker(m MaintenanceWindow) (name string, n int, ok bool) {
+	match := maintenanceWindowSeriesMarkerRE.FindStringSubmatch(m.Description)
+	if match == nil {
+		return "", 0, false
+	}
+
+	n, err := strconv.Atoi(match[2])
+	if err != nil {
+		return "", 0, false
+	}
+
+	return match[1], n, true
+}
+
+// CreateMaintenanceWindowSeriesWithContext creates the maintenance windows of
+// a series. It returns the windows it created, which are partial if an error
+// occurs.
+func (c *Client) CreateMaintenanceWindowSeriesWithContext(ctx context.Context, from string, s MaintenanceWindowSeries) ([]MaintenanceWindow, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c.createMaintenanceWindowOccurrences(ctx, from, s, 1, s.Count)
+}
+
+func (c *Client) createMaintenanceWindowOccurrences(ctx context.Context, from string, s MaintenanceWindowSeries, first, last int) ([]MaintenanceWindow, error) {
+	created := make([]MaintenanceWindow, 0, last-first+1)
+
+	for n := first; n <= last; n++ {
+		m, err := c.CreateMaintenanceWindowWithContext(ctx, from, s.Occurrence(n))
+		if err != nil {
+			return created, fmt.Errorf("failed to create occurrence %d of series %s: %w", n, s.Name, err)
+		}
+
+		created = append(created, *m)
+	}
+
+	return created, nil
+}
+
+// ListMaintenanceWindowSeriesWithContext lists the maintenance windows of the
+// series with the given name, sorted by occurrence number. o can filter the
+// windows further, such as by Filter. Its Query is overridden.
+func (c *Client) ListMaintenanceWindowSeriesWithContext(ctx context.Context, name string, o ListMaintenanceWindowsOptions) ([]MaintenanceWindow, error) {
+	if !maintenanceWindowSeriesNameRE.MatchString(name) {
+		return nil, fmt.Errorf("invalid series name %q", name)
+	}
+
+	o.Query = "[series " + name + " "
+
+	windows, err := c.ListMaintenanceWindowsPaginated(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	type occurrence struct {
+		n int
+		m MaintenanceWindow
+	}
+
+	var occurrences []occurrence
+
+	for _, m := range windows {
+		if seriesName, n, ok := ParseMaintenanceWindowSeriesMarker(m); ok && seriesName == name {
+			occurrences = append(occurrences, occurrence{n: n, m: m})
+		}
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool { return occurrences[i].n < occurrences[j].n })
+
+	series := make([]MaintenanceWindow, len(occurrences))
+	for i, o := range occurrences {
+		series[i] = o.m
+	}
+
+	return series, nil
+}
+
+// ExtendMaintenanceWindowSeriesWithContext creates count more windows of a
+// series, after the last one that exists. s must be the spec the series was
+// created with, its Count is ignored. It returns the windows it created.
+func (c *Client) ExtendMaintenanceWindowSeriesWithContext(ctx context.Context, from string, s MaintenanceWindowSeries, count int) ([]MaintenanceWindow, error) {
+	s.Count = count
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := c.ListMaintenanceWindowSeriesWithContext(ctx, s.Name, ListMaintenanceWindowsOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	last := 0
+	if len(existing) > 0 {
+		_, last, _ = ParseMaintenanceWindowSeriesMarker(existing[len(existing)-1])
+	}
+
+	return c.createMaintenanceWindowOccurrences(ctx, from, s, last+1, last+count)
+}
+
+// CancelMaintenanceWindowSeriesWithContext deletes the future windows of the
+// series with the given name, and ends the ongoing one. Past windows are
+// kept. It returns the IDs of the windows it deleted or ended.
+func (c *Client) CancelMaintenanceWindowSeriesWithContext(ctx context.Context, name string) ([]string, error) {
+	var canceled []string
+
+	for _, filter := range []string{MaintenanceWindowFilterOngoing, MaintenanceWindowFilterFuture} {
+		windows, err := c.ListMaintenanceWindowSeriesWithContext(ctx, name, ListMaintenanceWindowsOptions{Filter: filter})
+		if err != nil {
+			return canceled, err
+		}
+
+		for _, m := range windows {
+			if err := c.DeleteMaintenanceWindowWithContext(ctx, m.ID); err != nil {
+				return canceled, fmt.Errorf("failed to cancel maintenance window %s: %w", m.ID, err)
+			}
+
+			canceled = append(canceled, m.ID)
+		}
+	}
+
+	return canceled, nil
+}
@@ -0,0 +1,143 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestMaintenanceWindowSeries_Windows(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	s := MaintenanceWindowSeries{
+		Name:        "weekly-release",
+		Description: "Release",
+		Start:       time.Date(2021, 3, 21, 10, 0, 0, 0, paris),
+		Duration:    2 * time.Hour,
+		Count:       2,
+		ServiceIDs:  []string{"PS1"},
+	}
+
+	if err := s.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := s.Windows()
+
+	testEqual(t, 2, len(got))
+
+	// the second window is after the switch to daylight saving time
+	testEqual(t, "2021-03-21T10:00:00+01:00", got[0].StartTime)
+	testEqual(t, "2021-03-21T12:00:00+01:00", got[0].EndTime)
+	testEqual(t, "2021-03-28T10:00:00+02:00", got[1].StartTime)
+	testEqual(t, "Release [series weekly-release 2]", got[1].Description)
+
+	name, n, ok := ParseMaintenanceWindowSeriesMarker(got[1])
+	testEqual(t, "weekly-release", name)
+	testEqual(t, 2, n)
+	testEqual(t, true, ok)
+
+	_, _, ok = ParseMaintenanceWindowSeriesMarker(MaintenanceWindow{Description: "Release"})
+	testEqual(t, false, ok)
+}
+
+func TestMaintenanceWindowSeries_Validate(t *testing.T) {
+	s := MaintenanceWindowSeries{
+		Name:       "release 1",
+		Start:      time.Now(),
+		Duration:   time.Hour,
+		Count:      1,
+		ServiceIDs: []string{"PS1"},
+	}
+
+	testErrCheck(t, "s.Validate()", `invalid series name "release 1"`, s.Validate())
+}
+
+func TestMaintenanceWindow_ExtendSeries(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/maintenance_windows", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			testEqual(t, "[series release ", r.URL.Query().Get("query"))
+			_, _ = w.Write([]byte(`{"maintenance_windows": [
+				{"id": "PM2", "description": "Release [series release 2]"},
+				{"id": "PM1", "description": "Release [series release 1]"},
+				{"id": "PM9", "description": "Release [series release-eu 1]"}
+			]}`))
+
+		case http.MethodPost:
+			var body map[string]MaintenanceWindow
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode request body: %s", err)
+			}
+
+			m := body["maintenance_window"]
+			testEqual(t, "2021-01-15T02:00:00Z", m.StartTime)
+			testEqual(t, "Release [series release 3]", m.Description)
+
+			_, _ = w.Write([]byte(`{"maintenance_window": {"id": "PM3", "description": "Release [series release 3]"}}`))
+
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	s := MaintenanceWindowSeries{
+		Name:        "release",
+		Description: "Release",
+		Start:       time.Date(2021, 1, 1, 2, 0, 0, 0, time.UTC),
+		Duration:    2 * time.Hour,
+		ServiceIDs:  []string{"PS1"},
+	}
+
+	res, err := client.ExtendMaintenanceWindowSeriesWithContext(context.Background(), "foo@example.com", s, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 1, len(res))
+	testEqual(t, "PM3", res[0].ID)
+}
+
+func TestMaintenanceWindow_CancelSeries(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/maintenance_windows", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+
+		switch r.URL.Query().Get("filter") {
+		case MaintenanceWindowFilterOngoing:
+			_, _ = w.Write([]byte(`{"maintenance_windows": [{"id": "PM1", "description": "[series release 1]"}]}`))
+		case MaintenanceWindowFilterFuture:
+			_, _ = w.Write([]byte(`{"maintenance_windows": [{"id": "PM2", "description": "[series release 2]"}]}`))
+		default:
+			t.Errorf("unexpected filter %q", r.URL.Query().Get("filter"))
+		}
+	})
+
+	for _, id := range []string{"PM1", "PM2"} {
+		mux.HandleFunc("/maintenance_windows/"+id, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodDelete)
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.CancelMaintenanceWindowSeriesWithContext(context.Background(), "release")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, []string{"PM1", "PM2"}, res)
+}
@@ -1,8 +1,13 @@
 package pagerduty
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 	"testing"
+	"time"
 )
 
 // ListMaintenanceWindows
@@ -163,6 +168,14 @@ func TestMaintenanceWindow_Update(t *testing.T) {
 
 	mux.HandleFunc("/maintenance_windows/1", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "PUT")
+
+		var body map[string]MaintenanceWindow
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		testEqual(t, "foo", body["maintenance_window"].Description)
+
 		_, _ = w.Write([]byte(`{"maintenance_window": {"description": "foo", "id": "1"}}`))
 	})
 	client := defaultTestClient(server.URL, "foo")
@@ -181,3 +194,63 @@ func TestMaintenanceWindow_Update(t *testing.T) {
 	}
 	testEqual(t, want, res)
 }
+
+// ListMaintenanceWindowsPaginated
+func TestMaintenanceWindow_ListPaginated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/maintenance_windows", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, MaintenanceWindowFilterOngoing, r.URL.Query().Get("filter"))
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		resp := fmt.Sprintf(`{"maintenance_windows": [{"id": "%d"}], "more": %t, "offset": %d, "limit": 1}`, offset, offset == 0, offset)
+		_, _ = w.Write([]byte(resp))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListMaintenanceWindowsPaginated(context.Background(), ListMaintenanceWindowsOptions{
+		Limit:  1,
+		Filter: MaintenanceWindowFilterOngoing,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []MaintenanceWindow{
+		{APIObject: APIObject{ID: "0"}},
+		{APIObject: APIObject{ID: "1"}},
+	}
+
+	testEqual(t, want, res)
+}
+
+func TestNewMaintenanceWindow(t *testing.T) {
+	start := time.Date(2021, 1, 3, 2, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+
+	m := NewMaintenanceWindow(start, end, "release", "PS1", "PS2")
+
+	want := MaintenanceWindow{
+		StartTime:   "2021-01-03T02:00:00Z",
+		EndTime:     "2021-01-03T04:00:00Z",
+		Description: "release",
+		Services: []APIObject{
+			{ID: "PS1", Type: "service_reference"},
+			{ID: "PS2", Type: "service_reference"},
+		},
+	}
+
+	testEqual(t, want, m)
+
+	gotStart, gotEnd, err := m.Times()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, start, gotStart)
+	testEqual(t, end, gotEnd)
+}
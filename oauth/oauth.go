@@ -0,0 +1,426 @@
+// Package oauth provides functionality for obtaining PagerDuty OAuth 2.0
+// access tokens, so that applications can act on behalf of a PagerDuty user.
+//
+// The authorization code flow is implemented by first redirecting the user to
+// the URL returned by Config.AuthCodeURL, and then exchanging the code
+// PagerDuty sends to the redirect URL for a Token using Config.Exchange. The
+// AccessToken of the Token can be used with pagerduty.NewOAuthClient.
+//
+// Scoped OAuth apps, which act on behalf of an account rather than a user,
+// obtain tokens with Config.ClientCredentials instead. The
+// pagerduty.WithScopedOAuthApp client option does this automatically.
+//
+// See https://developer.pagerduty.com/docs/ZG9jOjExMDI5NTgz-o-auth-functionality
+// for more details.
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultAuthorizeURL is the PagerDuty endpoint users are sent to in
+	// order to authorize an application.
+	DefaultAuthorizeURL = "https://identity.pagerduty.com/oauth/authorize"
+
+	// DefaultTokenURL is the PagerDuty endpoint used to obtain access tokens.
+	DefaultTokenURL = "https://identity.pagerduty.com/oauth/token"
+
+	tokenBodyReaderLimit = 1 << 20 // 1MB
+)
+
+// HTTPClient is an interface which declares the functionality we need from an
+// HTTP client. This is to allow consumers to provide their own HTTP client as
+// needed, without restricting them to only using *http.Client.
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Config describes a PagerDuty OAuth application, as registered in the
+// PagerDuty web UI.
+type Config struct {
+	// ClientID is the ID of the PagerDuty OAuth application.
+	ClientID string
+
+	// ClientSecret is the secret of the PagerDuty OAuth application. It may be
+	// empty for public clients using PKCE.
+	ClientSecret string
+
+	// RedirectURL is the URL PagerDuty redirects the user to after they've
+	// authorized the application. It must match one of the redirect URLs
+	// configured for the application.
+	RedirectURL string
+
+	// Scopes are the permissions requested for the token, such as
+	// "incidents.read". Legacy (non-scoped) applications should use "read" or
+	// "write".
+	Scopes []string
+
+	// AuthorizeURL is the authorization endpoint. DefaultAuthorizeURL is used
+	// if this is empty.
+	AuthorizeURL string
+
+	// TokenURL is the token endpoint. DefaultTokenURL is used if this is
+	// empty.
+	TokenURL string
+
+	// HTTPClient is the HTTP client used to call the token endpoint.
+	// http.DefaultClient is used if this is nil.
+	HTTPClient HTTPClient
+}
+
+// AuthCodeURL returns the URL the user should be redirected to in order to
+// authorize the application. The state value is sent back to the redirect URL
+// by PagerDuty, and should be verified to protect against CSRF attacks.
+//
+// If codeChallenge is not empty, it's sent as a PKCE S256 code challenge. Use
+// NewCodeVerifier and CodeChallenge to generate one, and pass the verifier to
+// Exchange.
+func (c *Config) AuthCodeURL(state, codeChallenge string) string {
+	v := url.Values{}
+	v.Set("response_type", "code")
+	v.Set("client_id", c.ClientID)
+
+	if len(c.RedirectURL) > 0 {
+		v.Set("redirect_uri", c.RedirectURL)
+	}
+
+	if len(c.Scopes) > 0 {
+		v.Set("scope", strings.Join(c.Scopes, " "))
+	}
+
+	if len(state) > 0 {
+		v.Set("state", state)
+	}
+
+	if len(codeChallenge) > 0 {
+		v.Set("code_challenge", codeChallenge)
+		v.Set("code_challenge_method", "S256")
+	}
+
+	u := c.AuthorizeURL
+	if len(u) == 0 {
+		u = DefaultAuthorizeURL
+	}
+
+	if strings.Contains(u, "?") {
+		return u + "&" + v.Encode()
+	}
+
+	return u + "?" + v.Encode()
+}
+
+// Exchange converts an authorization code received on the redirect URL into a
+// Token. If a PKCE code challenge was sent by AuthCodeURL, codeVerifier must be
+// the verifier it was derived from. Otherwise, it should be empty.
+func (c *Config) Exchange(ctx context.Context, code, codeVerifier string) (*Token, error) {
+	v := url.Values{}
+	v.Set("grant_type", "authorization_code")
+	v.Set("code", code)
+
+	if len(c.RedirectURL) > 0 {
+		v.Set("redirect_uri", c.RedirectURL)
+	}
+
+	if len(codeVerifier) > 0 {
+		v.Set("code_verifier", codeVerifier)
+	}
+
+	return c.retrieveToken(ctx, v)
+}
+
+// Refresh obtains a new Token using the refresh token of a previously obtained
+// Token. If the response doesn't include a new refresh token, the one passed
+// in is kept, so that the returned Token can be refreshed again.
+func (c *Config) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	if len(refreshToken) == 0 {
+		return nil, errors.New("token cannot be refreshed without a refresh token")
+	}
+
+	v := url.Values{}
+	v.Set("grant_type", "refresh_token")
+	v.Set("refresh_token", refreshToken)
+
+	t, err := c.retrieveToken(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(t.RefreshToken) == 0 {
+		t.RefreshToken = refreshToken
+	}
+
+	return t, nil
+}
+
+// TokenSource returns a TokenSource starting with token, and refreshing it
+// using Refresh whenever it expires.
+func (c *Config) TokenSource(token *Token) *TokenSource {
+	ts := &TokenSource{token: token}
+
+	ts.fetch = func(ctx context.Context) (*Token, error) {
+		var rt string
+		if ts.token != nil {
+			rt = ts.token.RefreshToken
+		}
+
+		return c.Refresh(ctx, rt)
+	}
+
+	return ts
+}
+
+// ClientCredentials obtains a Token for a PagerDuty scoped OAuth app, using
+// the client credentials grant. The Scopes of the Config must include the
+// account scope returned by AccountScope, along with the scopes the app needs.
+func (c *Config) ClientCredentials(ctx context.Context) (*Token, error) {
+	v := url.Values{}
+	v.Set("grant_type", "client_credentials")
+
+	if len(c.Scopes) > 0 {
+		v.Set("scope", strings.Join(c.Scopes, " "))
+	}
+
+	return c.retrieveToken(ctx, v)
+}
+
+// ClientCredentialsTokenSource returns a TokenSource obtaining tokens with
+// ClientCredentials, and obtaining a new one whenever the current one expires.
+func (c *Config) ClientCredentialsTokenSource() *TokenSource {
+	return &TokenSource{fetch: c.ClientCredentials}
+}
+
+// Region is a PagerDuty service region.
+type Region string
+
+const (
+	// RegionUS is the US service region.
+	RegionUS Region = "us"
+
+	// RegionEU is the EU service region.
+	RegionEU Region = "eu"
+)
+
+// AccountScope returns the scope identifying the PagerDuty account a scoped
+// OAuth app requests a token for, such as "as_account-us.acme" for the acme
+// subdomain in the US service region.
+func AccountScope(region Region, subdomain string) string {
+	return "as_account-" + string(region) + "." + subdomain
+}
+
+func (c *Config) retrieveToken(ctx context.Context, v url.Values) (*Token, error) {
+	v.Set("client_id", c.ClientID)
+
+	if len(c.ClientSecret) > 0 {
+		v.Set("client_secret", c.ClientSecret)
+	}
+
+	u := c.TokenURL
+	if len(u) == 0 {
+		u = DefaultTokenURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, strings.NewReader(v.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var hc HTTPClient = http.DefaultClient
+	if c.HTTPClient != nil {
+		hc = c.HTTPClient
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error calling the token endpoint: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }() // explicitly discard error
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, tokenBodyReaderLimit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		e := &Error{StatusCode: resp.StatusCode}
+
+		// the error object is optional, so a decoding failure is ignored
+		_ = json.Unmarshal(body, e)
+
+		return nil, e
+	}
+
+	var t Token
+	if err := json.Unmarshal(body, &t); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	if len(t.AccessToken) == 0 {
+		return nil, errors.New("token response does not have access_token field")
+	}
+
+	if t.ExpiresIn > 0 {
+		t.Expiry = time.Now().Add(time.Duration(t.ExpiresIn) * time.Second)
+	}
+
+	return &t, nil
+}
+
+// Token is an OAuth 2.0 token returned by the PagerDuty token endpoint.
+type Token struct {
+	// AccessToken is the token used to authenticate against the PagerDuty API.
+	AccessToken string `json:"access_token"`
+
+	// TokenType is the type of the token, which is always "bearer".
+	TokenType string `json:"token_type,omitempty"`
+
+	// RefreshToken is used to obtain a new access token once AccessToken has
+	// expired. It's empty if the token can't be refreshed.
+	RefreshToken string `json:"refresh_token,omitempty"`
+
+	// Scope is the space-separated list of scopes granted to the token.
+	Scope string `json:"scope,omitempty"`
+
+	// ExpiresIn is the lifetime of the token in seconds, as returned by the
+	// token endpoint.
+	ExpiresIn int64 `json:"expires_in,omitempty"`
+
+	// Expiry is when the access token expires, computed from ExpiresIn when
+	// the token was received. The zero value means the token doesn't expire.
+	Expiry time.Time `json:"expiry,omitempty"`
+}
+
+// Scopes returns the scopes granted to the token.
+func (t *Token) Scopes() []string {
+	return strings.Fields(t.Scope)
+}
+
+// Valid returns whether the token is non-nil, has an access token, and isn't
+// expired.
+func (t *Token) Valid() bool {
+	return t != nil && len(t.AccessToken) > 0 && !t.expired()
+}
+
+// expiryDelta is how early a token is considered expired, to account for
+// clock skew and the latency of the request using it.
+const expiryDelta = 10 * time.Second
+
+func (t *Token) expired() bool {
+	if t.Expiry.IsZero() {
+		return false
+	}
+
+	return t.Expiry.Add(-expiryDelta).Before(time.Now())
+}
+
+// TokenSource provides a valid access token, obtaining a new Token when the
+// current one has expired. It's safe for concurrent use, and satisfies the
+// pagerduty.TokenSource interface.
+type TokenSource struct {
+	// OnRefresh, if not nil, is called with every new Token obtained by the
+	// TokenSource, so that it can be persisted. It's called while holding the
+	// TokenSource's lock, so it must not call its methods.
+	OnRefresh func(*Token)
+
+	mu      sync.Mutex
+	token   *Token
+	invalid bool
+	fetch   func(context.Context) (*Token, error)
+}
+
+// Token returns the current access token, obtaining a new one first if it's
+// missing, expired, or was invalidated.
+func (s *TokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.invalid || !s.token.Valid() {
+		t, err := s.fetch(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		s.token = t
+		s.invalid = false
+
+		if s.OnRefresh != nil {
+			s.OnRefresh(t)
+		}
+	}
+
+	return s.token.AccessToken, nil
+}
+
+// InvalidateToken marks the current token as invalid if its access token is
+// token, such as after the API rejected it, so that the next call to Token
+// obtains a new one. Comparing the access token avoids discarding a token
+// which was already replaced by a concurrent call.
+func (s *TokenSource) InvalidateToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && s.token.AccessToken == token {
+		s.invalid = true
+	}
+}
+
+// Error is returned when the token endpoint responds with an error, such as
+// when an authorization code is invalid or has expired.
+type Error struct {
+	// StatusCode is the HTTP response status code.
+	StatusCode int `json:"-"`
+
+	// ErrorCode is the OAuth error code, such as "invalid_grant". It may be
+	// empty if the response didn't include an error object.
+	ErrorCode string `json:"error"`
+
+	// Description is the human-readable description of the error.
+	Description string `json:"error_description"`
+}
+
+// Error satisfies the error interface.
+func (e *Error) Error() string {
+	if len(e.ErrorCode) == 0 {
+		return fmt.Sprintf("token request failed with status code %d", e.StatusCode)
+	}
+
+	if len(e.Description) == 0 {
+		return fmt.Sprintf("token request failed with status code %d: %s", e.StatusCode, e.ErrorCode)
+	}
+
+	return fmt.Sprintf("token request failed with status code %d: %s: %s", e.StatusCode, e.ErrorCode, e.Description)
+}
+
+// NewCodeVerifier returns a random PKCE code verifier, to be kept by the
+// application until the authorization code is exchanged.
+func NewCodeVerifier() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate code verifier: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CodeChallenge returns the PKCE S256 code challenge for a code verifier.
+func CodeChallenge(verifier string) string {
+	s := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(s[:])
+}
@@ -0,0 +1,312 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestConfig_AuthCodeURL(t *testing.T) {
+	c := &Config{
+		ClientID:    "id",
+		RedirectURL: "https://example.com/callback",
+		Scopes:      []string{"incidents.read", "services.read"},
+	}
+
+	u, err := url.Parse(c.AuthCodeURL("xyz", "challenge"))
+	if err != nil {
+		t.Fatalf("failed to parse URL: %s", err)
+	}
+
+	if got := u.Scheme + "://" + u.Host + u.Path; got != DefaultAuthorizeURL {
+		t.Errorf("URL = %s, want %s", got, DefaultAuthorizeURL)
+	}
+
+	want := map[string]string{
+		"response_type":         "code",
+		"client_id":             "id",
+		"redirect_uri":          "https://example.com/callback",
+		"scope":                 "incidents.read services.read",
+		"state":                 "xyz",
+		"code_challenge":        "challenge",
+		"code_challenge_method": "S256",
+	}
+
+	q := u.Query()
+	for k, v := range want {
+		if got := q.Get(k); got != v {
+			t.Errorf("query %s = %q, want %q", k, got, v)
+		}
+	}
+}
+
+func TestConfig_Exchange(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Request method: %v, want %v", r.Method, http.MethodPost)
+		}
+
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse form: %s", err)
+		}
+
+		if r.PostForm.Get("code") != "good" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"code is invalid"}`))
+			return
+		}
+
+		want := map[string]string{
+			"grant_type":    "authorization_code",
+			"client_id":     "id",
+			"client_secret": "secret",
+			"code_verifier": "verifier",
+		}
+
+		for k, v := range want {
+			if got := r.PostForm.Get(k); got != v {
+				t.Errorf("form %s = %q, want %q", k, got, v)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"token","token_type":"bearer","refresh_token":"refresh","scope":"incidents.read services.read","expires_in":3600}`))
+	})
+
+	c := &Config{
+		ClientID:     "id",
+		ClientSecret: "secret",
+		TokenURL:     server.URL + "/oauth/token",
+	}
+
+	tok, err := c.Exchange(context.Background(), "good", "verifier")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if tok.AccessToken != "token" || tok.RefreshToken != "refresh" {
+		t.Errorf("token = %+v, want access token and refresh token", tok)
+	}
+
+	if !tok.Valid() {
+		t.Error("tok.Valid() = false, want true")
+	}
+
+	if d := time.Until(tok.Expiry); d < 59*time.Minute || d > time.Hour {
+		t.Errorf("token expires in %s, want ~1h", d)
+	}
+
+	if got := tok.Scopes(); len(got) != 2 || got[0] != "incidents.read" {
+		t.Errorf("tok.Scopes() = %v, want [incidents.read services.read]", got)
+	}
+
+	_, err = c.Exchange(context.Background(), "bad", "")
+
+	var oerr *Error
+	if !errors.As(err, &oerr) {
+		t.Fatalf("err = %v, want *Error", err)
+	}
+
+	if oerr.StatusCode != http.StatusBadRequest || oerr.ErrorCode != "invalid_grant" {
+		t.Errorf("err = %+v, want 400 invalid_grant", oerr)
+	}
+}
+
+func TestToken_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		tok  *Token
+		want bool
+	}{
+		{
+			name: "nil",
+			want: false,
+		},
+		{
+			name: "empty",
+			tok:  &Token{},
+			want: false,
+		},
+		{
+			name: "no_expiry",
+			tok:  &Token{AccessToken: "token"},
+			want: true,
+		},
+		{
+			name: "expired",
+			tok:  &Token{AccessToken: "token", Expiry: time.Now().Add(-time.Minute)},
+			want: false,
+		},
+		{
+			name: "not_expired",
+			tok:  &Token{AccessToken: "token", Expiry: time.Now().Add(time.Hour)},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tok.Valid(); got != tt.want {
+				t.Fatalf("tok.Valid() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// from RFC 7636, Appendix B
+	const (
+		verifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+		want     = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+	)
+
+	if got := CodeChallenge(verifier); got != want {
+		t.Fatalf("CodeChallenge() = %s, want %s", got, want)
+	}
+
+	v, err := NewCodeVerifier()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(v) != 43 {
+		t.Fatalf("len(NewCodeVerifier()) = %d, want 43", len(v))
+	}
+}
+
+func TestConfig_ClientCredentialsTokenSource(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	var calls int
+
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse form: %s", err)
+		}
+
+		want := map[string]string{
+			"grant_type":    "client_credentials",
+			"client_id":     "id",
+			"client_secret": "secret",
+			"scope":         "as_account-eu.acme incidents.read",
+		}
+
+		for k, v := range want {
+			if got := r.PostForm.Get(k); got != v {
+				t.Errorf("form %s = %q, want %q", k, got, v)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		// the first token is already expired, to test that a new one is fetched
+		if calls == 1 {
+			_, _ = w.Write([]byte(`{"access_token":"token1","token_type":"bearer","expires_in":1}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"access_token":"token2","token_type":"bearer","expires_in":3600}`))
+	})
+
+	c := &Config{
+		ClientID:     "id",
+		ClientSecret: "secret",
+		Scopes:       []string{AccountScope(RegionEU, "acme"), "incidents.read"},
+		TokenURL:     server.URL + "/oauth/token",
+	}
+
+	ts := c.ClientCredentialsTokenSource()
+
+	for i, want := range []string{"token1", "token2", "token2"} {
+		got, err := ts.Token(context.Background())
+		if err != nil {
+			t.Fatalf("Token() unexpected error: %s", err)
+		}
+
+		if got != want {
+			t.Fatalf("Token() call %d = %q, want %q", i, got, want)
+		}
+	}
+
+	if calls != 2 {
+		t.Fatalf("token endpoint called %d times, want 2", calls)
+	}
+}
+
+func TestConfig_TokenSource(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse form: %s", err)
+		}
+
+		if got := r.PostForm.Get("grant_type"); got != "refresh_token" {
+			t.Errorf("form grant_type = %q, want refresh_token", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch rt := r.PostForm.Get("refresh_token"); rt {
+		case "refresh1":
+			_, _ = w.Write([]byte(`{"access_token":"token2","refresh_token":"refresh2","expires_in":3600}`))
+		case "refresh2":
+			// no new refresh token, so refresh2 should be kept
+			_, _ = w.Write([]byte(`{"access_token":"token3","expires_in":3600}`))
+		default:
+			t.Errorf("unexpected refresh token %q", rt)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	c := &Config{
+		ClientID: "id",
+		TokenURL: server.URL + "/oauth/token",
+	}
+
+	var refreshed []*Token
+
+	ts := c.TokenSource(&Token{AccessToken: "token1", RefreshToken: "refresh1", Expiry: time.Now().Add(-time.Minute)})
+	ts.OnRefresh = func(t *Token) { refreshed = append(refreshed, t) }
+
+	got, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token() unexpected error: %s", err)
+	}
+
+	if got != "token2" {
+		t.Fatalf("Token() = %q, want token2", got)
+	}
+
+	// invalidating a token which was already replaced is a no-op
+	ts.InvalidateToken("token1")
+
+	if got, _ = ts.Token(context.Background()); got != "token2" {
+		t.Fatalf("Token() = %q, want token2", got)
+	}
+
+	ts.InvalidateToken("token2")
+
+	if got, _ = ts.Token(context.Background()); got != "token3" {
+		t.Fatalf("Token() = %q, want token3", got)
+	}
+
+	if len(refreshed) != 2 || refreshed[1].RefreshToken != "refresh2" {
+		t.Fatalf("refreshed = %+v, want 2 tokens with the last having refresh2", refreshed)
+	}
+}
@@ -0,0 +1,334 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Scope is a permission granted to an OAuth token, made of a resource and an
+// access level, such as "incidents.read".
+type Scope string
+
+// Legacy scopes, granting access to all resources. They're used by OAuth apps
+// created before scoped OAuth was available.
+const (
+	ScopeRead  Scope = "read"
+	ScopeWrite Scope = "write"
+)
+
+// Published scopes of the REST API. See
+// https://developer.pagerduty.com/docs/e518101fde5f3-obtaining-an-app-o-auth-token
+// for the list of scopes required by each endpoint.
+const (
+	ScopeAbilitiesRead                    Scope = "abilities.read"
+	ScopeAddonsRead                       Scope = "addons.read"
+	ScopeAddonsWrite                      Scope = "addons.write"
+	ScopeAnalyticsRead                    Scope = "analytics.read"
+	ScopeAuditRecordsRead                 Scope = "audit_records.read"
+	ScopeChangeEventsRead                 Scope = "change_events.read"
+	ScopeChangeEventsWrite                Scope = "change_events.write"
+	ScopeCustomFieldsRead                 Scope = "custom_fields.read"
+	ScopeCustomFieldsWrite                Scope = "custom_fields.write"
+	ScopeEscalationPoliciesRead           Scope = "escalation_policies.read"
+	ScopeEscalationPoliciesWrite          Scope = "escalation_policies.write"
+	ScopeEventOrchestrationsRead          Scope = "event_orchestrations.read"
+	ScopeEventOrchestrationsWrite         Scope = "event_orchestrations.write"
+	ScopeExtensionSchemasRead             Scope = "extension_schemas.read"
+	ScopeExtensionsRead                   Scope = "extensions.read"
+	ScopeExtensionsWrite                  Scope = "extensions.write"
+	ScopeIncidentWorkflowsRead            Scope = "incident_workflows.read"
+	ScopeIncidentWorkflowsWrite           Scope = "incident_workflows.write"
+	ScopeIncidentWorkflowInstancesWrite   Scope = "incident_workflows:instances.write"
+	ScopeIncidentsRead                    Scope = "incidents.read"
+	ScopeIncidentsWrite                   Scope = "incidents.write"
+	ScopeLicensesRead                     Scope = "licenses.read"
+	ScopeMaintenanceWindowsRead           Scope = "maintenance_windows.read"
+	ScopeMaintenanceWindowsWrite          Scope = "maintenance_windows.write"
+	ScopeNotificationsRead                Scope = "notifications.read"
+	ScopeOncallsRead                      Scope = "oncalls.read"
+	ScopePrioritiesRead                   Scope = "priorities.read"
+	ScopeResponsePlaysRead                Scope = "response_plays.read"
+	ScopeResponsePlaysWrite               Scope = "response_plays.write"
+	ScopeSchedulesRead                    Scope = "schedules.read"
+	ScopeSchedulesWrite                   Scope = "schedules.write"
+	ScopeServicesRead                     Scope = "services.read"
+	ScopeServicesWrite                    Scope = "services.write"
+	ScopeStandardsRead                    Scope = "standards.read"
+	ScopeStandardsWrite                   Scope = "standards.write"
+	ScopeStatusDashboardsRead             Scope = "status_dashboards.read"
+	ScopeStatusPagesRead                  Scope = "status_pages.read"
+	ScopeStatusPagesWrite                 Scope = "status_pages.write"
+	ScopeSubscribersRead                  Scope = "subscribers.read"
+	ScopeSubscribersWrite                 Scope = "subscribers.write"
+	ScopeTagsRead                         Scope = "tags.read"
+	ScopeTagsWrite                        Scope = "tags.write"
+	ScopeTeamsRead                        Scope = "teams.read"
+	ScopeTeamsWrite                       Scope = "teams.write"
+	ScopeTemplatesRead                    Scope = "templates.read"
+	ScopeTemplatesWrite                   Scope = "templates.write"
+	ScopeUsersRead                        Scope = "users.read"
+	ScopeUsersWrite                       Scope = "users.write"
+	ScopeUserContactMethodsRead           Scope = "users:contact_methods.read"
+	ScopeUserContactMethodsWrite          Scope = "users:contact_methods.write"
+	ScopeUserSessionsRead                 Scope = "users:sessions.read"
+	ScopeUserSessionsWrite                Scope = "users:sessions.write"
+	ScopeVendorsRead                      Scope = "vendors.read"
+	ScopeWebhookSubscriptionsRead         Scope = "webhook_subscriptions.read"
+	ScopeWebhookSubscriptionsWrite        Scope = "webhook_subscriptions.write"
+	ScopeWorkflowIntegrationsRead         Scope = "workflow_integrations.read"
+	ScopeWorkflowIntegrationConnsRead     Scope = "workflow_integrations:connections.read"
+	ScopeWorkflowIntegrationConnsWrite    Scope = "workflow_integrations:connections.write"
+	ScopeAutomationActionsRead            Scope = "automation_actions.read"
+	ScopeAutomationActionsWrite           Scope = "automation_actions.write"
+	ScopeAutomationActionInvocationsRead  Scope = "automation_actions:invocations.read"
+	ScopeAutomationActionInvocationsWrite Scope = "automation_actions:invocations.write"
+)
+
+// Resource returns the resource part of the scope, such as "incidents" for
+// "incidents.read". It's empty for the legacy scopes.
+func (s Scope) Resource() string {
+	i := strings.LastIndexByte(string(s), '.')
+	if i < 0 {
+		return ""
+	}
+
+	return string(s[:i])
+}
+
+// Access returns the access level of the scope, which is "read" or "write".
+func (s Scope) Access() string {
+	i := strings.LastIndexByte(string(s), '.')
+	return string(s[i+1:])
+}
+
+// ReadScope returns the read scope of the resource, such as "services.read".
+func ReadScope(resource string) Scope {
+	return Scope(resource + ".read")
+}
+
+// WriteScope returns the write scope of the resource, such as
+// "services.write".
+func WriteScope(resource string) Scope {
+	return Scope(resource + ".write")
+}
+
+// ScopeSet is a set of scopes.
+type ScopeSet map[Scope]struct{}
+
+// NewScopeSet returns a ScopeSet holding scopes.
+func NewScopeSet(scopes ...Scope) ScopeSet {
+	s := make(ScopeSet, len(scopes))
+	s.Add(scopes...)
+
+	return s
+}
+
+// ParseScopes returns the ScopeSet of a space-separated list of scopes, as
+// used by the token endpoint.
+func ParseScopes(s string) ScopeSet {
+	fields := strings.Fields(s)
+
+	set := make(ScopeSet, len(fields))
+	for _, f := range fields {
+		set[Scope(f)] = struct{}{}
+	}
+
+	return set
+}
+
+// Add adds scopes to the set.
+func (s ScopeSet) Add(scopes ...Scope) {
+	for _, sc := range scopes {
+		s[sc] = struct{}{}
+	}
+}
+
+// Union returns a new ScopeSet holding the scopes of both sets.
+func (s ScopeSet) Union(o ScopeSet) ScopeSet {
+	u := make(ScopeSet, len(s)+len(o))
+
+	for sc := range s {
+		u[sc] = struct{}{}
+	}
+
+	for sc := range o {
+		u[sc] = struct{}{}
+	}
+
+	return u
+}
+
+// Allows returns whether the set grants the access of scope. The write scope
+// of a resource doesn't grant its read scope, but the legacy "write" scope
+// grants all scopes, and the legacy "read" scope grants all read scopes.
+func (s ScopeSet) Allows(scope Scope) bool {
+	if _, ok := s[scope]; ok {
+		return true
+	}
+
+	if _, ok := s[ScopeWrite]; ok {
+		return true
+	}
+
+	if _, ok := s[ScopeRead]; ok && scope.Access() == "read" {
+		return true
+	}
+
+	return false
+}
+
+// Missing returns the scopes of required that the set doesn't allow, sorted.
+func (s ScopeSet) Missing(required ...Scope) []Scope {
+	var missing []Scope
+
+	for _, sc := range required {
+		if !s.Allows(sc) {
+			missing = append(missing, sc)
+		}
+	}
+
+	sort.Slice(missing, func(i, j int) bool { return missing[i] < missing[j] })
+
+	return missing
+}
+
+// Strings returns the scopes of the set as strings, sorted, which is the form
+// expected by Config.Scopes.
+func (s ScopeSet) Strings() []string {
+	strs := make([]string, 0, len(s))
+	for sc := range s {
+		strs = append(strs, string(sc))
+	}
+
+	sort.Strings(strs)
+
+	return strs
+}
+
+// String returns the space-separated list of scopes, sorted.
+func (s ScopeSet) String() string {
+	return strings.Join(s.Strings(), " ")
+}
+
+// The functions below return the scope families required by groups of calls of
+// the pagerduty package. They return a new set on every call, which can be
+// combined using ScopeSet.Union to build the scopes an application requests,
+// and checked ahead of time using Token.CheckScopes.
+
+// IncidentsReadScopes returns the scopes required for reading incidents.
+func IncidentsReadScopes() ScopeSet {
+	return NewScopeSet(ScopeIncidentsRead)
+}
+
+// IncidentsManageScopes returns the scopes required for reading and managing
+// incidents.
+func IncidentsManageScopes() ScopeSet {
+	return NewScopeSet(ScopeIncidentsRead, ScopeIncidentsWrite)
+}
+
+// ServicesReadScopes returns the scopes required for reading services.
+func ServicesReadScopes() ScopeSet {
+	return NewScopeSet(ScopeServicesRead)
+}
+
+// ServicesManageScopes returns the scopes required for reading and managing
+// services.
+func ServicesManageScopes() ScopeSet {
+	return NewScopeSet(ScopeServicesRead, ScopeServicesWrite)
+}
+
+// SchedulesReadScopes returns the scopes required for reading schedules and
+// their users.
+func SchedulesReadScopes() ScopeSet {
+	return NewScopeSet(ScopeSchedulesRead, ScopeUsersRead)
+}
+
+// SchedulesManageScopes returns the scopes required for reading and managing
+// schedules.
+func SchedulesManageScopes() ScopeSet {
+	return NewScopeSet(ScopeSchedulesRead, ScopeSchedulesWrite, ScopeUsersRead)
+}
+
+// OncallsReadScopes returns the scopes required for reading on-calls with their
+// users, schedules and escalation policies.
+func OncallsReadScopes() ScopeSet {
+	return NewScopeSet(ScopeOncallsRead, ScopeUsersRead, ScopeSchedulesRead, ScopeEscalationPoliciesRead)
+}
+
+// UsersReadScopes returns the scopes required for reading users.
+func UsersReadScopes() ScopeSet {
+	return NewScopeSet(ScopeUsersRead)
+}
+
+// UsersManageScopes returns the scopes required for reading and managing users
+// and their contact methods.
+func UsersManageScopes() ScopeSet {
+	return NewScopeSet(ScopeUsersRead, ScopeUsersWrite, ScopeUserContactMethodsRead, ScopeUserContactMethodsWrite)
+}
+
+// TeamsReadScopes returns the scopes required for reading teams.
+func TeamsReadScopes() ScopeSet {
+	return NewScopeSet(ScopeTeamsRead)
+}
+
+// TeamsManageScopes returns the scopes required for reading and managing teams.
+func TeamsManageScopes() ScopeSet {
+	return NewScopeSet(ScopeTeamsRead, ScopeTeamsWrite)
+}
+
+// AnalyticsReadScopes returns the scopes required for reading analytics.
+func AnalyticsReadScopes() ScopeSet {
+	return NewScopeSet(ScopeAnalyticsRead)
+}
+
+// ScopeError is returned when a token lacks scopes required for a call.
+type ScopeError struct {
+	// Missing are the required scopes the token wasn't granted.
+	Missing []Scope
+}
+
+// Error satisfies the error interface.
+func (e *ScopeError) Error() string {
+	strs := make([]string, len(e.Missing))
+	for i, sc := range e.Missing {
+		strs[i] = string(sc)
+	}
+
+	return fmt.Sprintf("token is missing required scopes: %s", strings.Join(strs, ", "))
+}
+
+// CheckScopes returns a *ScopeError if the token wasn't granted all of the
+// scopes in required. Tokens not reporting their scopes can't be checked, and
+// are assumed to be allowed.
+func (t *Token) CheckScopes(required ScopeSet) error {
+	if len(strings.TrimSpace(t.Scope)) == 0 {
+		return nil
+	}
+
+	req := make([]Scope, 0, len(required))
+	for sc := range required {
+		req = append(req, sc)
+	}
+
+	if missing := ParseScopes(t.Scope).Missing(req...); len(missing) > 0 {
+		return &ScopeError{Missing: missing}
+	}
+
+	return nil
+}
+
+// CheckScopes checks the current token of the TokenSource using
+// Token.CheckScopes, obtaining a new token first if needed. It allows
+// applications to fail early when they were granted insufficient scopes.
+func (s *TokenSource) CheckScopes(ctx context.Context, required ScopeSet) error {
+	if _, err := s.Token(ctx); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.token.CheckScopes(required)
+}
@@ -0,0 +1,94 @@
+package oauth
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestScope(t *testing.T) {
+	tests := []struct {
+		scope    Scope
+		resource string
+		access   string
+	}{
+		{scope: ScopeIncidentsRead, resource: "incidents", access: "read"},
+		{scope: ScopeUserContactMethodsWrite, resource: "users:contact_methods", access: "write"},
+		{scope: ScopeWrite, resource: "", access: "write"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.scope), func(t *testing.T) {
+			if got := tt.scope.Resource(); got != tt.resource {
+				t.Errorf("Resource() = %q, want %q", got, tt.resource)
+			}
+
+			if got := tt.scope.Access(); got != tt.access {
+				t.Errorf("Access() = %q, want %q", got, tt.access)
+			}
+		})
+	}
+
+	if got := WriteScope("services"); got != ScopeServicesWrite {
+		t.Errorf("WriteScope() = %q, want %q", got, ScopeServicesWrite)
+	}
+}
+
+func TestScopeSet(t *testing.T) {
+	s := ServicesReadScopes().Union(NewScopeSet(ScopeIncidentsWrite))
+
+	if got, want := s.String(), "incidents.write services.read"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	if s.Allows(ScopeIncidentsRead) {
+		t.Error("Allows(incidents.read) = true, want false")
+	}
+
+	if got := s.Missing(ScopeServicesRead, ScopeTeamsRead, ScopeIncidentsRead); len(got) != 2 || got[0] != ScopeIncidentsRead || got[1] != ScopeTeamsRead {
+		t.Errorf("Missing() = %v, want [incidents.read teams.read]", got)
+	}
+
+	// the scope families can't be modified by their callers
+	delete(ServicesReadScopes(), ScopeServicesRead)
+
+	if !ServicesReadScopes().Allows(ScopeServicesRead) {
+		t.Error("ServicesReadScopes() was modified by a caller")
+	}
+
+	legacy := ParseScopes("read")
+
+	if !legacy.Allows(ScopeTeamsRead) {
+		t.Error("legacy read Allows(teams.read) = false, want true")
+	}
+
+	if legacy.Allows(ScopeTeamsWrite) {
+		t.Error("legacy read Allows(teams.write) = true, want false")
+	}
+
+	if !ParseScopes("write").Allows(ScopeTeamsWrite) {
+		t.Error("legacy write Allows(teams.write) = false, want true")
+	}
+}
+
+func TestToken_CheckScopes(t *testing.T) {
+	tok := &Token{AccessToken: "token", Scope: "incidents.read incidents.write services.read"}
+
+	if err := tok.CheckScopes(IncidentsManageScopes()); err != nil {
+		t.Errorf("CheckScopes(IncidentsManageScopes()) unexpected error: %s", err)
+	}
+
+	err := tok.CheckScopes(ServicesManageScopes())
+
+	var serr *ScopeError
+	if !errors.As(err, &serr) {
+		t.Fatalf("CheckScopes(ServicesManageScopes()) error = %v, want *ScopeError", err)
+	}
+
+	if len(serr.Missing) != 1 || serr.Missing[0] != ScopeServicesWrite {
+		t.Errorf("Missing = %v, want [services.write]", serr.Missing)
+	}
+
+	if err := (&Token{AccessToken: "token"}).CheckScopes(ServicesManageScopes()); err != nil {
+		t.Errorf("CheckScopes() without scopes unexpected error: %s", err)
+	}
+}
@@ -2,6 +2,10 @@ package pagerduty
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -16,6 +20,19 @@ type OnCall struct {
 	End              string           `json:"end,omitempty"`
 }
 
+// Permanent returns whether the user is always on call, such as when they're a
+// direct target of the escalation policy, in which case the on-call entry has
+// no start or end.
+func (oc OnCall) Permanent() bool {
+	return len(oc.Start) == 0 && len(oc.End) == 0
+}
+
+// Times returns the start and end of the on-call entry, parsed as RFC 3339
+// times. It returns an error for permanent entries.
+func (oc OnCall) Times() (start, end time.Time, err error) {
+	return parseTimeRange(oc.Start, oc.End)
+}
+
 // ListOnCallsResponse is the data structure returned from calling the ListOnCalls API endpoint.
 type ListOnCallsResponse struct {
 	APIListObject
@@ -51,8 +68,23 @@ type ListOnCallOptions struct {
 	Since               string   `url:"since,omitempty"`
 	Until               string   `url:"until,omitempty"`
 	Earliest            bool     `url:"earliest,omitempty"`
+
+	// Overflow returns the full on-call entries, instead of truncating them to
+	// the Since and Until time range.
+	Overflow bool `url:"overflow,omitempty"`
 }
 
+// ListOnCallsOptions is an alias of ListOnCallOptions, matching the name of
+// ListOnCallsResponse.
+type ListOnCallsOptions = ListOnCallOptions
+
+// Values of the Includes field of ListOnCallOptions.
+const (
+	OnCallIncludeEscalationPolicies = "escalation_policies"
+	OnCallIncludeSchedules          = "schedules"
+	OnCallIncludeUsers              = "users"
+)
+
 // ListOnCalls list the on-call entries during a given time range.
 //
 // Deprecated: Use ListOnCallsWithContext instead.
@@ -79,3 +111,298 @@ func (c *Client) ListOnCallsWithContext(ctx context.Context, o ListOnCallOptions
 
 	return &result, nil
 }
+
+// ListOnCallsPaginated lists the on-call entries during a given time range,
+// handling pagination of the results.
+func (c *Client) ListOnCallsPaginated(ctx context.Context, o ListOnCallOptions) ([]OnCall, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var onCalls []OnCall
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListOnCallsResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		onCalls = append(onCalls, result.OnCalls...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+			Total:  result.Total,
+		}, nil
+	}
+
+	if err := c.pagedGet(ctx, "/oncalls?"+v.Encode(), responseHandler); err != nil {
+		return nil, err
+	}
+
+	return onCalls, nil
+}
+
+// EscalationLevelOnCallUsers are the users on call at a level of an
+// escalation policy.
+type EscalationLevelOnCallUsers struct {
+	// Level is the escalation level, starting at 1 for the first escalation
+	// rule of the policy.
+	Level uint
+
+	// Users are the users on call at the level, either directly or through a
+	// schedule, in the order returned by the API.
+	Users []User
+}
+
+// ListEscalationPolicyOnCallUsersWithContext returns the users currently on
+// call for each level of an escalation policy, sorted by level. A user on call
+// through more than one schedule at the same level is only returned once.
+// Levels nobody is on call for are omitted.
+func (c *Client) ListEscalationPolicyOnCallUsersWithContext(ctx context.Context, escalationPolicyID string) ([]EscalationLevelOnCallUsers, error) {
+	onCalls, err := c.ListOnCallsPaginated(ctx, ListOnCallOptions{
+		EscalationPolicyIDs: []string{escalationPolicyID},
+		Includes:            []string{OnCallIncludeUsers},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byLevel := make(map[uint]*EscalationLevelOnCallUsers)
+	seen := make(map[uint]map[string]struct{})
+
+	for _, oc := range onCalls {
+		l, ok := byLevel[oc.EscalationLevel]
+		if !ok {
+			l = &EscalationLevelOnCallUsers{Level: oc.EscalationLevel}
+			byLevel[oc.EscalationLevel] = l
+			seen[oc.EscalationLevel] = make(map[string]struct{})
+		}
+
+		if _, ok := seen[oc.EscalationLevel][oc.User.ID]; ok {
+			continue
+		}
+
+		seen[oc.EscalationLevel][oc.User.ID] = struct{}{}
+		l.Users = append(l.Users, oc.User)
+	}
+
+	levels := make([]EscalationLevelOnCallUsers, 0, len(byLevel))
+	for _, l := range byLevel {
+		levels = append(levels, *l)
+	}
+
+	sort.Slice(levels, func(i, j int) bool { return levels[i].Level < levels[j].Level })
+
+	return levels, nil
+}
+
+// WhoIsOnCallOptions are the options of WhoIsOnCallWithContext. Exactly one
+// of ServiceID and TeamID must be set.
+type WhoIsOnCallOptions struct {
+	// ServiceID selects the escalation policy of a service.
+	ServiceID string
+
+	// TeamID selects the escalation policies of a team.
+	TeamID string
+
+	// TimeZone is the time zone of the returned users and schedules, such as
+	// the time zone of the caller. It defaults to the time zone of the account.
+	TimeZone string
+}
+
+// OnCallResponder is a user currently on call at an escalation level.
+type OnCallResponder struct {
+	// User is the user on call, including its email address and contact
+	// methods references.
+	User User
+
+	// Schedule is the schedule through which the user is on call, or nil if
+	// the user is a direct target of the escalation policy.
+	Schedule *Schedule
+
+	// Start and End are when the on-call shift starts and ends. They're zero
+	// for users permanently on call.
+	Start time.Time
+	End   time.Time
+}
+
+// EscalationLevelResponders are the users currently on call at a level of an
+// escalation policy.
+type EscalationLevelResponders struct {
+	Level      uint
+	Responders []OnCallResponder
+}
+
+// EscalationPolicyOnCall is who's currently on call for an escalation policy,
+// as returned by WhoIsOnCallWithContext.
+type EscalationPolicyOnCall struct {
+	EscalationPolicy EscalationPolicy
+
+	// Levels are sorted by level. Levels nobody is on call for are omitted.
+	Levels []EscalationLevelResponders
+}
+
+// WhoIsOnCallWithContext returns who's currently on call for a service or a
+// team, grouped by escalation policy and escalation level, with the names of
+// the escalation policies and schedules resolved. Escalation policies are
+// sorted by name.
+func (c *Client) WhoIsOnCallWithContext(ctx context.Context, o WhoIsOnCallOptions) ([]EscalationPolicyOnCall, error) {
+	var epIDs []string
+
+	switch {
+	case len(o.ServiceID) > 0 && len(o.TeamID) > 0:
+		return nil, errors.New("only one of ServiceID and TeamID can be set")
+
+	case len(o.ServiceID) > 0:
+		s, err := c.GetServiceWithContext(ctx, o.ServiceID, &GetServiceOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		epIDs = []string{s.EscalationPolicy.ID}
+
+	case len(o.TeamID) > 0:
+		eps, err := c.ListEscalationPoliciesPaginated(ctx, ListEscalationPoliciesOptions{TeamIDs: []string{o.TeamID}})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ep := range eps {
+			epIDs = append(epIDs, ep.ID)
+		}
+
+	default:
+		return nil, errors.New("one of ServiceID and TeamID must be set")
+	}
+
+	if len(epIDs) == 0 {
+		return nil, nil
+	}
+
+	onCalls, err := c.ListOnCallsPaginated(ctx, ListOnCallOptions{
+		TimeZone:            o.TimeZone,
+		EscalationPolicyIDs: epIDs,
+		Includes:            []string{OnCallIncludeEscalationPolicies, OnCallIncludeSchedules, OnCallIncludeUsers},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return groupOnCalls(onCalls)
+}
+
+// groupOnCalls groups on-call entries by escalation policy and level.
+func groupOnCalls(onCalls []OnCall) ([]EscalationPolicyOnCall, error) {
+	type levelKey struct {
+		epID  string
+		level uint
+	}
+
+	byEP := make(map[string]*EscalationPolicyOnCall)
+	byLevel := make(map[levelKey]*EscalationLevelResponders)
+	seen := make(map[levelKey]map[string]struct{})
+
+	for _, oc := range onCalls {
+		ep, ok := byEP[oc.EscalationPolicy.ID]
+		if !ok {
+			ep = &EscalationPolicyOnCall{EscalationPolicy: oc.EscalationPolicy}
+			byEP[oc.EscalationPolicy.ID] = ep
+		}
+
+		k := levelKey{epID: oc.EscalationPolicy.ID, level: oc.EscalationLevel}
+
+		l, ok := byLevel[k]
+		if !ok {
+			l = &EscalationLevelResponders{Level: oc.EscalationLevel}
+			byLevel[k] = l
+			seen[k] = make(map[string]struct{})
+		}
+
+		// a user is only returned once for each schedule of a level
+		responderKey := oc.User.ID + "/" + oc.Schedule.ID
+		if _, ok := seen[k][responderKey]; ok {
+			continue
+		}
+
+		seen[k][responderKey] = struct{}{}
+
+		r := OnCallResponder{User: oc.User}
+
+		if len(oc.Schedule.ID) > 0 {
+			s := oc.Schedule
+			r.Schedule = &s
+		}
+
+		if !oc.Permanent() {
+			var err error
+			if r.Start, r.End, err = oc.Times(); err != nil {
+				return nil, err
+			}
+		}
+
+		l.Responders = append(l.Responders, r)
+	}
+
+	for k, l := range byLevel {
+		byEP[k.epID].Levels = append(byEP[k.epID].Levels, *l)
+	}
+
+	eps := make([]EscalationPolicyOnCall, 0, len(byEP))
+
+	for _, ep := range byEP {
+		sort.Slice(ep.Levels, func(i, j int) bool { return ep.Levels[i].Level < ep.Levels[j].Level })
+		eps = append(eps, *ep)
+	}
+
+	sort.Slice(eps, func(i, j int) bool {
+		if eps[i].EscalationPolicy.Name != eps[j].EscalationPolicy.Name {
+			return eps[i].EscalationPolicy.Name < eps[j].EscalationPolicy.Name
+		}
+
+		return eps[i].EscalationPolicy.ID < eps[j].EscalationPolicy.ID
+	})
+
+	return eps, nil
+}
+
+// CurrentOnCallForServiceWithContext returns the users currently on call at
+// the first level of the escalation policy of a service, i.e. the users
+// paged first for its incidents, with their contact methods. If nobody is on
+// call at the first level, the users of the lowest level anyone is on call
+// for are returned. A user on call through more than one schedule is returned
+// once, with the first of them.
+func (c *Client) CurrentOnCallForServiceWithContext(ctx context.Context, serviceID string) ([]OnCallResponder, error) {
+	eps, err := c.WhoIsOnCallWithContext(ctx, WhoIsOnCallOptions{ServiceID: serviceID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(eps) == 0 || len(eps[0].Levels) == 0 {
+		return nil, nil
+	}
+
+	var responders []OnCallResponder
+
+	seen := make(map[string]struct{})
+
+	for _, r := range eps[0].Levels[0].Responders {
+		if _, ok := seen[r.User.ID]; ok {
+			continue
+		}
+
+		seen[r.User.ID] = struct{}{}
+
+		u, err := c.GetUserWithContext(ctx, r.User.ID, GetUserOptions{Includes: []string{UserIncludeContactMethods}})
+		if err != nil {
+			return nil, err
+		}
+
+		r.User = *u
+		responders = append(responders, r)
+	}
+
+	return responders, nil
+}
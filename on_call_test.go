@@ -1,8 +1,10 @@
 package pagerduty
 
 import (
+	"context"
 	"net/http"
 	"testing"
+	"time"
 )
 
 // ListOnCalls
@@ -45,3 +47,176 @@ func TestOnCall_List(t *testing.T) {
 	}
 	testEqual(t, want, res)
 }
+
+func TestOnCall_ListEscalationPolicyOnCallUsers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/oncalls", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "PEP1", r.URL.Query().Get("escalation_policy_ids[]"))
+
+		if r.URL.Query().Get("offset") == "" || r.URL.Query().Get("offset") == "0" {
+			_, _ = w.Write([]byte(`{"oncalls": [
+				{"escalation_level": 2, "user": {"id": "PU2"}, "schedule": {"id": "PS1"}},
+				{"escalation_level": 1, "user": {"id": "PU1"}, "schedule": {"id": "PS1"}}
+			], "more": true, "offset": 0, "limit": 2}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"oncalls": [
+			{"escalation_level": 1, "user": {"id": "PU1"}, "schedule": {"id": "PS2"}},
+			{"escalation_level": 2, "user": {"id": "PU3"}}
+		], "more": false, "offset": 2, "limit": 2}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListEscalationPolicyOnCallUsersWithContext(context.Background(), "PEP1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []EscalationLevelOnCallUsers{
+		{
+			Level: 1,
+			Users: []User{{APIObject: APIObject{ID: "PU1"}}},
+		},
+		{
+			Level: 2,
+			Users: []User{{APIObject: APIObject{ID: "PU2"}}, {APIObject: APIObject{ID: "PU3"}}},
+		},
+	}
+
+	testEqual(t, want, res)
+}
+
+func TestOnCall_ListOverflow(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/oncalls", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "true", r.URL.Query().Get("overflow"))
+		testEqual(t, "true", r.URL.Query().Get("earliest"))
+		testEqual(t, []string{OnCallIncludeUsers}, r.URL.Query()["include[]"])
+		_, _ = w.Write([]byte(`{"oncalls": [{"user": {"id": "PU1"}, "start": "2021-01-01T00:00:00Z", "end": "2021-01-08T00:00:00Z"}, {"user": {"id": "PU2"}}]}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListOnCallsWithContext(context.Background(), ListOnCallsOptions{
+		Includes: []string{OnCallIncludeUsers},
+		Since:    "2021-01-02T00:00:00Z",
+		Until:    "2021-01-03T00:00:00Z",
+		Earliest: true,
+		Overflow: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 2, len(res.OnCalls))
+	testEqual(t, false, res.OnCalls[0].Permanent())
+	testEqual(t, true, res.OnCalls[1].Permanent())
+
+	start, end, err := res.OnCalls[0].Times()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), start)
+	testEqual(t, 7*24*time.Hour, end.Sub(start))
+}
+
+func TestOnCall_WhoIsOnCall(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/escalation_policies", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, []string{"PT1"}, r.URL.Query()["team_ids[]"])
+		_, _ = w.Write([]byte(`{"escalation_policies": [{"id": "PEP1"}, {"id": "PEP2"}]}`))
+	})
+
+	mux.HandleFunc("/oncalls", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, []string{"PEP1", "PEP2"}, r.URL.Query()["escalation_policy_ids[]"])
+		testEqual(t, "Europe/Paris", r.URL.Query().Get("time_zone"))
+		_, _ = w.Write([]byte(`{"oncalls": [
+			{"escalation_policy": {"id": "PEP2", "name": "Web"}, "escalation_level": 2, "user": {"id": "PU2", "email": "bob@example.com"}},
+			{"escalation_policy": {"id": "PEP2", "name": "Web"}, "escalation_level": 1, "schedule": {"id": "PS1", "name": "Primary"}, "user": {"id": "PU1", "email": "alice@example.com"}, "start": "2021-01-01T00:00:00Z", "end": "2021-01-02T00:00:00Z"},
+			{"escalation_policy": {"id": "PEP2", "name": "Web"}, "escalation_level": 1, "schedule": {"id": "PS1", "name": "Primary"}, "user": {"id": "PU1", "email": "alice@example.com"}, "start": "2021-01-01T00:00:00Z", "end": "2021-01-02T00:00:00Z"},
+			{"escalation_policy": {"id": "PEP1", "name": "Database"}, "escalation_level": 1, "user": {"id": "PU2", "email": "bob@example.com"}}
+		]}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.WhoIsOnCallWithContext(context.Background(), WhoIsOnCallOptions{TeamID: "PT1", TimeZone: "Europe/Paris"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 2, len(res))
+	testEqual(t, "Database", res[0].EscalationPolicy.Name)
+	testEqual(t, "Web", res[1].EscalationPolicy.Name)
+
+	web := res[1].Levels
+	testEqual(t, 2, len(web))
+	testEqual(t, uint(1), web[0].Level)
+	testEqual(t, 1, len(web[0].Responders))
+	testEqual(t, "alice@example.com", web[0].Responders[0].User.Email)
+	testEqual(t, "Primary", web[0].Responders[0].Schedule.Name)
+	testEqual(t, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), web[0].Responders[0].Start)
+	testEqual(t, uint(2), web[1].Level)
+	testEqual(t, (*Schedule)(nil), web[1].Responders[0].Schedule)
+	testEqual(t, true, web[1].Responders[0].Start.IsZero())
+}
+
+func TestOnCall_WhoIsOnCallOptions(t *testing.T) {
+	client := defaultTestClient("http://localhost", "foo")
+
+	_, err := client.WhoIsOnCallWithContext(context.Background(), WhoIsOnCallOptions{})
+	testErrCheck(t, "WhoIsOnCallWithContext()", "one of ServiceID and TeamID must be set", err)
+
+	_, err = client.WhoIsOnCallWithContext(context.Background(), WhoIsOnCallOptions{ServiceID: "PS1", TeamID: "PT1"})
+	testErrCheck(t, "WhoIsOnCallWithContext()", "only one of ServiceID and TeamID can be set", err)
+}
+
+func TestOnCall_CurrentOnCallForService(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services/PS1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"service": {"id": "PS1", "escalation_policy": {"id": "PEP1"}}}`))
+	})
+
+	mux.HandleFunc("/oncalls", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, []string{"PEP1"}, r.URL.Query()["escalation_policy_ids[]"])
+		_, _ = w.Write([]byte(`{"oncalls": [
+			{"escalation_policy": {"id": "PEP1"}, "escalation_level": 2, "user": {"id": "PU2"}},
+			{"escalation_policy": {"id": "PEP1"}, "escalation_level": 1, "schedule": {"id": "PSC1"}, "user": {"id": "PU1"}},
+			{"escalation_policy": {"id": "PEP1"}, "escalation_level": 1, "schedule": {"id": "PSC2"}, "user": {"id": "PU1"}}
+		]}`))
+	})
+
+	mux.HandleFunc("/users/PU1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, []string{"contact_methods"}, r.URL.Query()["include[]"])
+		_, _ = w.Write([]byte(`{"user": {"id": "PU1", "contact_methods": [{"id": "PC1", "type": "phone_contact_method", "address": "5555555555"}]}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.CurrentOnCallForServiceWithContext(context.Background(), "PS1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 1, len(res))
+	testEqual(t, "PSC1", res[0].Schedule.ID)
+	testEqual(t, "5555555555", res[0].User.ContactMethods[0].Address)
+}
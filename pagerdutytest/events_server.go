@@ -0,0 +1,262 @@
+package pagerdutytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// EventsServer is a fake of the Events API V2, which records the events it
+// receives, for testing code sending events end to end. It can simulate
+// failures and latency to test retries and asynchronous senders.
+type EventsServer struct {
+	// URL is the endpoint of the server, to be used with
+	// pagerduty.WithEventsAPIV2ClientEndpoint.
+	URL string
+
+	server *httptest.Server
+
+	mu           sync.Mutex
+	received     *sync.Cond
+	events       []pagerduty.V2Event
+	changeEvents []pagerduty.ChangeEvent
+	requests     int
+	failures     []int
+	latency      time.Duration
+	dedupKeys    int
+}
+
+// NewEventsServer starts an EventsServer. It must be closed with Close.
+func NewEventsServer() *EventsServer {
+	s := &EventsServer{}
+	s.received = sync.NewCond(&s.mu)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/enqueue", s.handleEvent)
+	mux.HandleFunc("/v2/change/enqueue", s.handleChangeEvent)
+
+	s.server = httptest.NewServer(mux)
+	s.URL = s.server.URL
+
+	return s
+}
+
+// Close shuts down the server.
+func (s *EventsServer) Close() {
+	s.server.Close()
+}
+
+// Client returns an Events API V2 client sending events to the server, with
+// the given additional options.
+func (s *EventsServer) Client(options ...pagerduty.EventsAPIV2ClientOptions) *pagerduty.EventsAPIV2Client {
+	return pagerduty.NewEventsAPIV2Client(append([]pagerduty.EventsAPIV2ClientOptions{pagerduty.WithEventsAPIV2ClientEndpoint(s.URL)}, options...)...)
+}
+
+// FailNext makes the next n requests fail with the given status code, such as
+// http.StatusTooManyRequests or http.StatusInternalServerError. The events of
+// failed requests aren't recorded.
+func (s *EventsServer) FailNext(n int, statusCode int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := 0; i < n; i++ {
+		s.failures = append(s.failures, statusCode)
+	}
+}
+
+// SetLatency delays every response by d.
+func (s *EventsServer) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency = d
+}
+
+// Events returns the events received, in order.
+func (s *EventsServer) Events() []pagerduty.V2Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]pagerduty.V2Event(nil), s.events...)
+}
+
+// ChangeEvents returns the change events received, in order.
+func (s *EventsServer) ChangeEvents() []pagerduty.ChangeEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]pagerduty.ChangeEvent(nil), s.changeEvents...)
+}
+
+// Requests returns the number of requests received, including failed ones.
+func (s *EventsServer) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+// WaitForEvents waits until at least n events were received, or the timeout
+// expires. It returns whether n events were received.
+func (s *EventsServer) WaitForEvents(n int, timeout time.Duration) bool {
+	timer := time.AfterFunc(timeout, func() {
+		s.mu.Lock()
+		s.received.Broadcast()
+		s.mu.Unlock()
+	})
+	defer timer.Stop()
+
+	deadline := time.Now().Add(timeout)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for len(s.events) < n && time.Now().Before(deadline) {
+		s.received.Wait()
+	}
+
+	return len(s.events) >= n
+}
+
+// AssertEventCount fails the test if the number of events received isn't n.
+func (s *EventsServer) AssertEventCount(t testing.TB, n int) {
+	t.Helper()
+
+	if got := len(s.Events()); got != n {
+		t.Errorf("received %d events, want %d", got, n)
+	}
+}
+
+// AssertEventReceived fails the test if no event with the given action and
+// dedup key was received.
+func (s *EventsServer) AssertEventReceived(t testing.TB, action, dedupKey string) {
+	t.Helper()
+
+	for _, e := range s.Events() {
+		if e.Action == action && e.DedupKey == dedupKey {
+			return
+		}
+	}
+
+	t.Errorf("no %s event received with dedup key %q", action, dedupKey)
+}
+
+// begin waits for the latency of the server, and returns the status code of
+// the failure to simulate, if any.
+func (s *EventsServer) begin(r *http.Request) int {
+	s.mu.Lock()
+	s.requests++
+	latency := s.latency
+
+	var failure int
+	if len(s.failures) > 0 {
+		failure, s.failures = s.failures[0], s.failures[1:]
+	}
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+		}
+	}
+
+	return failure
+}
+
+func (s *EventsServer) handleEvent(w http.ResponseWriter, r *http.Request) {
+	if !s.accept(w, r) {
+		return
+	}
+
+	var e pagerduty.V2Event
+	if err := json.NewDecoder(r.Body).Decode(&e); err != nil || len(e.RoutingKey) == 0 || len(e.Action) == 0 {
+		writeEventsResponse(w, http.StatusBadRequest, map[string]interface{}{
+			"status":  "invalid event",
+			"message": "Event object is invalid",
+			"errors":  []string{"Event object is invalid"},
+		})
+		return
+	}
+
+	s.mu.Lock()
+	if len(e.DedupKey) == 0 {
+		s.dedupKeys++
+		e.DedupKey = fmt.Sprintf("dedup-key-%d", s.dedupKeys)
+	}
+
+	s.events = append(s.events, e)
+	s.received.Broadcast()
+	s.mu.Unlock()
+
+	writeEventsResponse(w, http.StatusAccepted, pagerduty.V2EventResponse{
+		Status:   "success",
+		Message:  "Event processed",
+		DedupKey: e.DedupKey,
+	})
+}
+
+func (s *EventsServer) handleChangeEvent(w http.ResponseWriter, r *http.Request) {
+	if !s.accept(w, r) {
+		return
+	}
+
+	var e pagerduty.ChangeEvent
+	if err := json.NewDecoder(r.Body).Decode(&e); err != nil || len(e.RoutingKey) == 0 || len(e.Payload.Summary) == 0 {
+		writeEventsResponse(w, http.StatusBadRequest, map[string]interface{}{
+			"status":  "invalid event",
+			"message": "Event object is invalid",
+			"errors":  []string{"Event object is invalid"},
+		})
+		return
+	}
+
+	s.mu.Lock()
+	s.changeEvents = append(s.changeEvents, e)
+	s.mu.Unlock()
+
+	writeEventsResponse(w, http.StatusAccepted, pagerduty.ChangeEventResponse{
+		Status:  "success",
+		Message: "Change event processed",
+	})
+}
+
+// accept checks the method of the request and simulates failures. It returns
+// false if it wrote the response.
+func (s *EventsServer) accept(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return false
+	}
+
+	switch failure := s.begin(r); failure {
+	case 0:
+		return true
+
+	case http.StatusTooManyRequests:
+		writeEventsResponse(w, failure, map[string]interface{}{
+			"status":  "throttle event",
+			"message": "Requests for this service are arriving too quickly. Please retry later.",
+		})
+
+	default:
+		writeEventsResponse(w, failure, map[string]interface{}{
+			"status":  "error",
+			"message": http.StatusText(failure),
+		})
+	}
+
+	return false
+}
+
+func writeEventsResponse(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
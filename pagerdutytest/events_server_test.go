@@ -0,0 +1,83 @@
+package pagerdutytest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+func TestEventsServer(t *testing.T) {
+	s := NewEventsServer()
+	defer s.Close()
+
+	client := s.Client(pagerduty.WithEventsAPIV2ClientRetryPolicy(pagerduty.EventsAPIV2RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}))
+
+	s.FailNext(2, http.StatusTooManyRequests)
+
+	res, err := client.TriggerWithContext(context.Background(), "key", "", pagerduty.V2Payload{Summary: "disk full", Source: "db1", Severity: "critical"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.DedupKey != "dedup-key-1" {
+		t.Errorf("res.DedupKey = %q, want dedup-key-1", res.DedupKey)
+	}
+
+	if s.Requests() != 3 {
+		t.Errorf("s.Requests() = %d, want 3", s.Requests())
+	}
+
+	if _, err := client.ResolveWithContext(context.Background(), "key", res.DedupKey); err != nil {
+		t.Fatal(err)
+	}
+
+	s.AssertEventCount(t, 2)
+	s.AssertEventReceived(t, pagerduty.V2EventActionResolve, "dedup-key-1")
+
+	s.FailNext(1, http.StatusBadRequest)
+
+	_, err = client.AcknowledgeWithContext(context.Background(), "key", res.DedupKey)
+
+	var eae pagerduty.EventsAPIV2Error
+	if !errors.As(err, &eae) || eae.StatusCode != http.StatusBadRequest {
+		t.Errorf("err = %v, want an EventsAPIV2Error with status code 400", err)
+	}
+
+	if _, err := client.CreateChangeEventWithContext(context.Background(), "key", pagerduty.ChangeEvent{Payload: pagerduty.ChangeEventPayload{Summary: "deploy"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(s.ChangeEvents()) != 1 {
+		t.Errorf("received %d change events, want 1", len(s.ChangeEvents()))
+	}
+}
+
+func TestEventsServer_Latency(t *testing.T) {
+	s := NewEventsServer()
+	defer s.Close()
+
+	s.SetLatency(200 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := s.Client().ResolveWithContext(ctx, "key", "dedup"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+
+	s.SetLatency(0)
+
+	go func() { _, _ = s.Client().ResolveWithContext(context.Background(), "key", "dedup") }()
+
+	if !s.WaitForEvents(1, 5*time.Second) {
+		t.Error("event not received")
+	}
+
+	if s.WaitForEvents(2, 10*time.Millisecond) {
+		t.Error("WaitForEvents(2) succeeded with 1 event")
+	}
+}
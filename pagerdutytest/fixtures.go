@@ -0,0 +1,319 @@
+package pagerdutytest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// The fixture builders below return realistic objects, as returned by the
+// API, for use in tests. Objects referencing each other are consistent, e.g.
+// an incident of a service references the escalation policy of the service,
+// and timestamps are derived from FixtureTime so that tests are
+// deterministic. Options customize the objects after their defaults are set.
+
+// FixtureTime is the time at which fixture incidents are created by default.
+var FixtureTime = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+const (
+	fixtureAPIURL = "https://api.pagerduty.com"
+	fixtureWebURL = "https://example.pagerduty.com"
+)
+
+var fixtureIDs uint64
+
+// NewTestID returns a new unique ID with the format of PagerDuty IDs, such
+// as "P000001".
+func NewTestID() string {
+	id := strings.ToUpper(strconv.FormatUint(atomic.AddUint64(&fixtureIDs, 1), 36))
+	if len(id) < 6 {
+		id = strings.Repeat("0", 6-len(id)) + id
+	}
+
+	return "P" + id
+}
+
+func fixtureObject(objectType, summary, apiPath, webPath string) pagerduty.APIObject {
+	id := NewTestID()
+
+	return pagerduty.APIObject{
+		ID:      id,
+		Type:    objectType,
+		Summary: summary,
+		Self:    fixtureAPIURL + "/" + apiPath + "/" + id,
+		HTMLURL: fixtureWebURL + "/" + webPath + "/" + id,
+	}
+}
+
+// reference returns a reference to o, as embedded by the API in other
+// objects.
+func reference(o pagerduty.APIObject) pagerduty.APIObject {
+	o.Type += "_reference"
+	return o
+}
+
+func fixtureTimestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// UserOption customizes a user returned by NewTestUser.
+type UserOption func(*pagerduty.User)
+
+// WithUserName sets the name of the user, and derives its email from it.
+func WithUserName(name string) UserOption {
+	return func(u *pagerduty.User) {
+		u.Name = name
+		u.Summary = name
+		u.Email = strings.ToLower(strings.Join(strings.Fields(name), ".")) + "@example.com"
+	}
+}
+
+// WithUserRole sets the role of the user.
+func WithUserRole(role pagerduty.UserRole) UserOption {
+	return func(u *pagerduty.User) {
+		u.Role = role
+	}
+}
+
+// NewTestUser returns a user, with the user role and an email contact method.
+func NewTestUser(opts ...UserOption) *pagerduty.User {
+	u := &pagerduty.User{
+		APIObject: fixtureObject("user", "", "users", "users"),
+		Timezone:  "Etc/UTC",
+		Color:     "purple",
+		Role:      pagerduty.UserRoleUser,
+	}
+
+	WithUserName("User " + u.ID)(u)
+
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	u.ContactMethods = []pagerduty.ContactMethod{{
+		ID:      NewTestID(),
+		Type:    "email_contact_method_reference",
+		Summary: "Default",
+		Self:    fmt.Sprintf("%s/users/%s/contact_methods/%s", fixtureAPIURL, u.ID, u.ID),
+		Label:   "Default",
+		Address: u.Email,
+	}}
+
+	return u
+}
+
+// EscalationPolicyOption customizes an escalation policy returned by
+// NewTestEscalationPolicy.
+type EscalationPolicyOption func(*pagerduty.EscalationPolicy)
+
+// WithEscalationPolicyName sets the name of the escalation policy.
+func WithEscalationPolicyName(name string) EscalationPolicyOption {
+	return func(e *pagerduty.EscalationPolicy) {
+		e.Name = name
+		e.Summary = name
+	}
+}
+
+// WithEscalationPolicyUsers replaces the rules of the escalation policy by
+// one rule per user, escalating after 30 minutes.
+func WithEscalationPolicyUsers(users ...*pagerduty.User) EscalationPolicyOption {
+	return func(e *pagerduty.EscalationPolicy) {
+		e.EscalationRules = make([]pagerduty.EscalationRule, len(users))
+
+		for i, u := range users {
+			e.EscalationRules[i] = pagerduty.EscalationRule{
+				ID:      NewTestID(),
+				Delay:   30,
+				Targets: []pagerduty.APIObject{reference(u.APIObject)},
+			}
+		}
+	}
+}
+
+// NewTestEscalationPolicy returns an escalation policy notifying a new user
+// created with NewTestUser.
+func NewTestEscalationPolicy(opts ...EscalationPolicyOption) *pagerduty.EscalationPolicy {
+	e := &pagerduty.EscalationPolicy{
+		APIObject: fixtureObject("escalation_policy", "", "escalation_policies", "escalation_policies"),
+		Teams:     []pagerduty.APIReference{},
+	}
+
+	WithEscalationPolicyName("Escalation Policy " + e.ID)(e)
+	WithEscalationPolicyUsers(NewTestUser())(e)
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// ServiceOption customizes a service returned by NewTestService.
+type ServiceOption func(*pagerduty.Service)
+
+// WithServiceName sets the name of the service.
+func WithServiceName(name string) ServiceOption {
+	return func(s *pagerduty.Service) {
+		s.Name = name
+		s.Summary = name
+	}
+}
+
+// WithServiceStatus sets the status of the service.
+func WithServiceStatus(status string) ServiceOption {
+	return func(s *pagerduty.Service) {
+		s.Status = status
+	}
+}
+
+// WithServiceEscalationPolicy sets the escalation policy of the service.
+func WithServiceEscalationPolicy(e *pagerduty.EscalationPolicy) ServiceOption {
+	return func(s *pagerduty.Service) {
+		s.EscalationPolicy = pagerduty.EscalationPolicy{APIObject: reference(e.APIObject)}
+		e.Services = append(e.Services, reference(s.APIObject))
+	}
+}
+
+// NewTestService returns an active service, using a new escalation policy
+// created with NewTestEscalationPolicy unless WithServiceEscalationPolicy is
+// given, in which case the service is added to the services of the escalation
+// policy.
+func NewTestService(opts ...ServiceOption) *pagerduty.Service {
+	s := &pagerduty.Service{
+		APIObject:     fixtureObject("service", "", "services", "service-directory"),
+		CreateAt:      fixtureTimestamp(FixtureTime.AddDate(0, -1, 0)),
+		Status:        pagerduty.ServiceStatusActive,
+		AlertCreation: "create_alerts_and_incidents",
+	}
+
+	WithServiceName("Service " + s.ID)(s)
+	WithServiceEscalationPolicy(NewTestEscalationPolicy())(s)
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// IncidentOption customizes an incident returned by NewTestIncident.
+type IncidentOption func(*pagerduty.Incident)
+
+// WithIncidentTitle sets the title of the incident.
+func WithIncidentTitle(title string) IncidentOption {
+	return func(i *pagerduty.Incident) {
+		i.Title = title
+		i.Summary = fmt.Sprintf("[#%d] %s", i.IncidentNumber, title)
+	}
+}
+
+// WithIncidentService sets the service of the incident, and its escalation
+// policy to the one of the service.
+func WithIncidentService(s *pagerduty.Service) IncidentOption {
+	return func(i *pagerduty.Incident) {
+		i.Service = reference(s.APIObject)
+		i.EscalationPolicy = s.EscalationPolicy.APIObject
+	}
+}
+
+// WithIncidentAssignees sets the users the incident is assigned to.
+func WithIncidentAssignees(users ...*pagerduty.User) IncidentOption {
+	return func(i *pagerduty.Incident) {
+		i.Assignments = make([]pagerduty.Assignment, len(users))
+
+		for n, u := range users {
+			i.Assignments[n] = pagerduty.Assignment{
+				At:       i.CreatedAt,
+				Assignee: reference(u.APIObject),
+			}
+		}
+	}
+}
+
+// WithIncidentUrgency sets the urgency of the incident.
+func WithIncidentUrgency(urgency pagerduty.Urgency) IncidentOption {
+	return func(i *pagerduty.Incident) {
+		i.Urgency = urgency
+	}
+}
+
+// WithIncidentCreatedAt sets when the incident was created.
+func WithIncidentCreatedAt(t time.Time) IncidentOption {
+	return func(i *pagerduty.Incident) {
+		i.CreatedAt = pagerduty.NewAPITime(t.UTC())
+	}
+}
+
+// WithIncidentStatus sets the status of the incident. Acknowledged incidents
+// are acknowledged by their first assignee 5 minutes after their creation,
+// and resolved incidents are resolved 30 minutes after their creation. The
+// timestamps are set by NewTestIncident, after all the options are applied.
+func WithIncidentStatus(status pagerduty.IncidentStatus) IncidentOption {
+	return func(i *pagerduty.Incident) {
+		i.Status = status
+	}
+}
+
+// NewTestIncident returns a triggered, high urgency incident of a new service
+// created with NewTestService, assigned to the user of the escalation policy
+// of the service. Incidents of another service should be assigned with
+// WithIncidentAssignees to a user of its escalation policy.
+func NewTestIncident(opts ...IncidentOption) *pagerduty.Incident {
+	n := atomic.AddUint64(&fixtureIDs, 1)
+
+	i := &pagerduty.Incident{
+		APIObject:      fixtureObject("incident", "", "incidents", "incidents"),
+		IncidentNumber: uint(n),
+		CreatedAt:      pagerduty.NewAPITime(FixtureTime.UTC()),
+		Urgency:        pagerduty.UrgencyHigh,
+		Status:         pagerduty.IncidentStatusTriggered,
+	}
+
+	i.IncidentKey = strings.ToLower(i.ID)
+
+	WithIncidentTitle("Incident " + i.ID)(i)
+
+	u := NewTestUser()
+	s := NewTestService(WithServiceEscalationPolicy(NewTestEscalationPolicy(WithEscalationPolicyUsers(u))))
+
+	WithIncidentService(s)(i)
+	WithIncidentAssignees(u)(i)
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	createdAt := i.CreatedAt.Time
+
+	i.LastStatusChangeAt = i.CreatedAt
+
+	switch i.Status {
+	case pagerduty.IncidentStatusAcknowledged:
+		i.LastStatusChangeAt = pagerduty.NewAPITime(createdAt.Add(5 * time.Minute))
+
+		if len(i.Assignments) > 0 {
+			i.Acknowledgements = []pagerduty.Acknowledgement{{
+				At:           i.LastStatusChangeAt,
+				Acknowledger: i.Assignments[0].Assignee,
+			}}
+			i.LastStatusChangeBy = i.Assignments[0].Assignee
+		}
+
+	case pagerduty.IncidentStatusResolved:
+		i.LastStatusChangeAt = pagerduty.NewAPITime(createdAt.Add(30 * time.Minute))
+		i.ResolvedAt = i.LastStatusChangeAt
+		i.Assignments = nil
+	}
+
+	if i.Status != pagerduty.IncidentStatusAcknowledged {
+		i.LastStatusChangeBy = i.Service
+	}
+
+	i.UpdatedAt = i.LastStatusChangeAt
+
+	return i
+}
@@ -0,0 +1,59 @@
+package pagerdutytest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+func TestNewTestIncident(t *testing.T) {
+	i := NewTestIncident()
+
+	if i.Status != pagerduty.IncidentStatusTriggered {
+		t.Errorf("i.Status = %q, want triggered", i.Status)
+	}
+
+	if i.Service.Type != "service_reference" || i.EscalationPolicy.Type != "escalation_policy_reference" {
+		t.Errorf("unexpected references %+v %+v", i.Service, i.EscalationPolicy)
+	}
+
+	if len(i.Assignments) != 1 || i.Assignments[0].Assignee.Type != "user_reference" {
+		t.Errorf("unexpected assignments %+v", i.Assignments)
+	}
+
+	u := NewTestUser(WithUserName("Jane Doe"))
+	if u.Email != "jane.doe@example.com" {
+		t.Errorf("u.Email = %q, want jane.doe@example.com", u.Email)
+	}
+
+	e := NewTestEscalationPolicy(WithEscalationPolicyUsers(u))
+	s := NewTestService(WithServiceName("API"), WithServiceEscalationPolicy(e))
+
+	if len(e.Services) != 1 || e.Services[0].ID != s.ID {
+		t.Errorf("e.Services = %+v, want service %s", e.Services, s.ID)
+	}
+
+	i = NewTestIncident(
+		WithIncidentService(s),
+		WithIncidentAssignees(u),
+		WithIncidentCreatedAt(time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)),
+		WithIncidentStatus(pagerduty.IncidentStatusAcknowledged),
+	)
+
+	if i.Service.ID != s.ID || i.EscalationPolicy.ID != e.ID {
+		t.Errorf("incident doesn't reference service %s and escalation policy %s", s.ID, e.ID)
+	}
+
+	if len(i.Acknowledgements) != 1 || i.Acknowledgements[0].Acknowledger.ID != u.ID {
+		t.Errorf("unexpected acknowledgements %+v", i.Acknowledgements)
+	}
+
+	if got := i.LastStatusChangeAt.String(); got != "2021-06-01T12:05:00Z" {
+		t.Errorf("i.LastStatusChangeAt = %q, want 2021-06-01T12:05:00Z", got)
+	}
+
+	if NewTestID() == NewTestID() {
+		t.Error("NewTestID() returned the same ID twice")
+	}
+}
@@ -0,0 +1,312 @@
+// Package pagerdutytest provides helpers for testing code using the
+// pagerduty package, without access to a PagerDuty account.
+//
+// Recorder is an http.RoundTripper that records real API interactions to a
+// fixture file, with secrets removed, and replays them deterministically in
+// CI:
+//
+//	mode := pagerdutytest.ModeReplay
+//	if os.Getenv("PAGERDUTY_RECORD") != "" {
+//		mode = pagerdutytest.ModeRecord
+//	}
+//
+//	rec, err := pagerdutytest.NewRecorder("testdata/incidents.json", mode)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer rec.Stop()
+//
+//	client := pagerduty.NewClient(os.Getenv("PAGERDUTY_TOKEN"))
+//	client.HTTPClient = rec.HTTPClient()
+package pagerdutytest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sync"
+)
+
+// Mode is the mode of a Recorder.
+type Mode int
+
+const (
+	// ModeReplay replays the interactions of the fixture file, without
+	// sending any request.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends the requests, and records the interactions to the
+	// fixture file when the Recorder is stopped.
+	ModeRecord
+)
+
+// RecordedRequest is a request of an Interaction. Its headers aren't
+// recorded, as they contain credentials.
+type RecordedRequest struct {
+	Method string `json:"method"`
+
+	// URL is the path and query of the request, so that interactions can be
+	// replayed against any API endpoint.
+	URL  string `json:"url"`
+	Body string `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response of an Interaction.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Interaction is a request sent to the API, and the response it returned.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// recordedResponseHeaders are the response headers kept when recording.
+var recordedResponseHeaders = []string{"Content-Type", "Retry-After"}
+
+// secretFields are the names of the fields with secret values, which are
+// redacted when recording.
+const secretFields = `token|access_token|refresh_token|client_secret|routing_key|integration_key|secret`
+
+// secretFieldRE matches the JSON fields with secret values.
+var secretFieldRE = regexp.MustCompile(`"(` + secretFields + `)"(\s*):(\s*)"[^"]*"`)
+
+// secretParamRE matches the query parameters and form-encoded fields with
+// secret values, such as the ones of OAuth token requests.
+var secretParamRE = regexp.MustCompile(`(^|[?&])(` + secretFields + `|code|code_verifier)=[^&#]*`)
+
+// Redacted replaces secret values in recorded interactions.
+const Redacted = "REDACTED"
+
+// Sanitize removes the secrets from a recorded interaction. It redacts the
+// values of JSON fields such as "token" and "routing_key" in the request and
+// response bodies, and of the secret query parameters and form-encoded fields
+// of the request, such as "client_secret". It also drops all but a few
+// response headers.
+func Sanitize(i *Interaction) {
+	i.Request.URL = redactParams(i.Request.URL)
+	i.Request.Body = redactParams(redactFields(i.Request.Body))
+	i.Response.Body = redactFields(i.Response.Body)
+
+	header := make(http.Header)
+	for _, k := range recordedResponseHeaders {
+		if v := i.Response.Header.Values(k); len(v) > 0 {
+			header[k] = v
+		}
+	}
+
+	i.Response.Header = header
+}
+
+func redactFields(s string) string {
+	return secretFieldRE.ReplaceAllString(s, `"$1"$2:$3"`+Redacted+`"`)
+}
+
+func redactParams(s string) string {
+	return secretParamRE.ReplaceAllString(s, "${1}${2}="+Redacted)
+}
+
+// Recorder records and replays API interactions. It must be created with
+// NewRecorder.
+type Recorder struct {
+	// Transport is the RoundTripper sending the requests in ModeRecord. It
+	// defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+
+	// Sanitize is called on every recorded interaction, after the default
+	// sanitization, to remove other secrets or personal data.
+	Sanitize func(*Interaction)
+
+	mode Mode
+	path string
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     []bool
+}
+
+// NewRecorder returns a Recorder using the fixture file at path. In
+// ModeReplay, the file is read immediately and must exist.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{mode: mode, path: path}
+
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &r.interactions); err != nil {
+		return nil, fmt.Errorf("failed to decode fixture file %s: %w", path, err)
+	}
+
+	r.replayed = make([]bool, len(r.interactions))
+
+	return r, nil
+}
+
+// HTTPClient returns an HTTP client using the Recorder, to be used as the
+// HTTPClient of a pagerduty.Client.
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper. In ModeReplay, it returns the
+// response of the first interaction not replayed yet with the same method,
+// URL and body as req once sanitized, or an error if there's none.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+
+	if req.Body != nil {
+		var err error
+
+		body, err = ioutil.ReadAll(req.Body)
+		_ = req.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rr := RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL.RequestURI(),
+		Body:   string(body),
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, r.sanitizeRequest(rr))
+	}
+
+	return r.record(req, rr, body)
+}
+
+// sanitizeRequest returns rr sanitized like the requests of the recorded
+// interactions, so that it can be matched against them.
+func (r *Recorder) sanitizeRequest(rr RecordedRequest) RecordedRequest {
+	i := Interaction{Request: rr}
+
+	Sanitize(&i)
+
+	if r.Sanitize != nil {
+		r.Sanitize(&i)
+	}
+
+	return i.Request
+}
+
+func (r *Recorder) replay(req *http.Request, rr RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for n, i := range r.interactions {
+		if r.replayed[n] || i.Request.Method != rr.Method || i.Request.URL != rr.URL || i.Request.Body != rr.Body {
+			continue
+		}
+
+		r.replayed[n] = true
+
+		return newResponse(req, i.Response), nil
+	}
+
+	return nil, fmt.Errorf("no recorded interaction for %s %s", rr.Method, rr.URL)
+}
+
+func (r *Recorder) record(req *http.Request, rr RecordedRequest, body []byte) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	i := Interaction{
+		Request: rr,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			Body:       string(respBody),
+		},
+	}
+
+	Sanitize(&i)
+
+	if r.Sanitize != nil {
+		r.Sanitize(&i)
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, i)
+	r.mu.Unlock()
+
+	// the caller gets the real response, not the sanitized one
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	return resp, nil
+}
+
+func newResponse(req *http.Request, rr RecordedResponse) *http.Response {
+	header := rr.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rr.StatusCode, http.StatusText(rr.StatusCode)),
+		StatusCode:    rr.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader([]byte(rr.Body))),
+		ContentLength: int64(len(rr.Body)),
+		Request:       req,
+	}
+}
+
+// Stop ends the recording. In ModeRecord, it writes the recorded interactions
+// to the fixture file. In ModeReplay, it returns an error if some
+// interactions weren't replayed.
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode == ModeRecord {
+		data, err := json.MarshalIndent(r.interactions, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		return ioutil.WriteFile(r.path, append(data, '\n'), 0o644)
+	}
+
+	for n, replayed := range r.replayed {
+		if !replayed {
+			return fmt.Errorf("interaction not replayed: %s %s", r.interactions[n].Request.Method, r.interactions[n].Request.URL)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package pagerdutytest
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+func TestRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/PU1" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "abc")
+		_, _ = w.Write([]byte(`{"user": {"id": "PU1", "name": "Foo", "token": "secret"}}`))
+	}))
+
+	path := filepath.Join(t.TempDir(), "fixture.json")
+
+	rec, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := pagerduty.NewClient("real-token", pagerduty.WithAPIEndpoint(server.URL))
+	client.HTTPClient = rec.HTTPClient()
+
+	user, err := client.GetUserWithContext(context.Background(), "PU1", pagerduty.GetUserOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user.Name != "Foo" {
+		t.Errorf("user.Name = %q, want Foo", user.Name)
+	}
+
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	server.Close()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, secret := range []string{"real-token", `"secret"`, "X-Request-Id"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("fixture contains %s:\n%s", secret, data)
+		}
+	}
+
+	rec, err = NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client = pagerduty.NewClient("", pagerduty.WithAPIEndpoint("https://api.example.com"))
+	client.HTTPClient = rec.HTTPClient()
+
+	if err := rec.Stop(); err == nil {
+		t.Error("rec.Stop() succeeded before the interaction was replayed")
+	}
+
+	user, err = client.GetUserWithContext(context.Background(), "PU1", pagerduty.GetUserOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user.Name != "Foo" {
+		t.Errorf("user.Name = %q, want Foo", user.Name)
+	}
+
+	if _, err := client.GetUserWithContext(context.Background(), "PU1", pagerduty.GetUserOptions{}); err == nil {
+		t.Error("interaction replayed twice")
+	}
+
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecorder_secretRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "success"}`))
+	}))
+	defer server.Close()
+
+	requests := []struct {
+		url  string
+		body string
+	}{
+		{url: "/oauth/token", body: "client_id=app&client_secret=s3cr3t&grant_type=refresh_token&refresh_token=r3fr3sh"},
+		{url: "/v2/enqueue", body: `{"routing_key": "r0ut1ng", "event_action": "trigger"}`},
+		{url: "/search?token=t0k3n&query=foo", body: ""},
+	}
+
+	send := func(rec *Recorder, base string) {
+		t.Helper()
+
+		for _, r := range requests {
+			resp, err := rec.HTTPClient().Post(base+r.url, "application/x-www-form-urlencoded", strings.NewReader(r.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_ = resp.Body.Close()
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "fixture.json")
+
+	rec, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	send(rec, server.URL)
+
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, secret := range []string{"s3cr3t", "r3fr3sh", "r0ut1ng", "t0k3n"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("fixture contains %s:\n%s", secret, data)
+		}
+	}
+
+	rec, err = NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	send(rec, "https://api.example.com")
+
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,108 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallelPagination(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const total = 9
+
+	var inFlight, maxInFlight int64
+
+	mux.HandleFunc("/escalation_policies", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "true", r.URL.Query().Get("total"))
+
+		n := atomic.AddInt64(&inFlight, 1)
+		defer atomic.AddInt64(&inFlight, -1)
+
+		for {
+			m := atomic.LoadInt64(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt64(&maxInFlight, m, n) {
+				break
+			}
+		}
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		// later pages are faster, so that they're fetched out of order
+		if offset > 0 {
+			time.Sleep(time.Duration(total-offset) * 5 * time.Millisecond)
+		}
+
+		var policies string
+		for i := offset; i < offset+2 && i < total; i++ {
+			if len(policies) > 0 {
+				policies += ","
+			}
+
+			policies += fmt.Sprintf(`{"id": "P%d"}`, i)
+		}
+
+		_, _ = fmt.Fprintf(w, `{"escalation_policies": [%s], "offset": %d, "limit": 2, "total": %d, "more": %t}`, policies, offset, total, offset+2 < total)
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+	WithParallelPagination(2)(client)
+
+	res, err := client.ListEscalationPoliciesPaginated(context.Background(), ListEscalationPoliciesOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, p := range res {
+		ids = append(ids, p.ID)
+	}
+
+	testEqual(t, []string{"P0", "P1", "P2", "P3", "P4", "P5", "P6", "P7", "P8"}, ids)
+	testEqual(t, int64(2), atomic.LoadInt64(&maxInFlight))
+}
+
+func TestParallelPagination_Error(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	var offsets []string
+
+	mux.HandleFunc("/escalation_policies", func(w http.ResponseWriter, r *http.Request) {
+		offset := r.URL.Query().Get("offset")
+
+		mu.Lock()
+		offsets = append(offsets, offset)
+		mu.Unlock()
+
+		if offset == "2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, `{"escalation_policies": [{"id": "P%s"}], "offset": %s, "limit": 2, "total": 100, "more": true}`, offset, offset)
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+	WithParallelPagination(4)(client)
+
+	_, err := client.ListEscalationPoliciesPaginated(context.Background(), ListEscalationPoliciesOptions{})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(offsets) == 50 {
+		t.Error("all pages were fetched after the error")
+	}
+}
@@ -0,0 +1,110 @@
+package pagerduty
+
+import (
+	"context"
+
+	"github.com/google/go-querystring/query"
+)
+
+// PausedIncidentReportOptions is the data structure used when calling the
+// GetPausedIncidentReportAlerts and GetPausedIncidentReportCounts API
+// endpoints. PagerDuty reports on up to the last 6 months.
+type PausedIncidentReportOptions struct {
+	Since     APITime `url:"since,omitempty"`
+	Until     APITime `url:"until,omitempty"`
+	ServiceID string  `url:"service_id,omitempty"`
+
+	// SuspendedBy only reports the alerts paused by either Auto-Pause or
+	// event rules, such as PausedIncidentSuspendedByAutoPause.
+	SuspendedBy string `url:"suspended_by,omitempty"`
+}
+
+// Values of the SuspendedBy field of PausedIncidentReportOptions.
+const (
+	PausedIncidentSuspendedByAutoPause  = "auto_pause"
+	PausedIncidentSuspendedByEventRules = "event_rules"
+)
+
+// PausedIncidentReportAlert is an alert whose incident creation was paused.
+type PausedIncidentReportAlert struct {
+	ID        string        `json:"id"`
+	CreatedAt APITime       `json:"created_at"`
+	Service   *APIReference `json:"service,omitempty"`
+}
+
+// PausedIncidentReportAlerts are the most recent alerts whose incident
+// creation was paused, and which were later triggered or resolved.
+type PausedIncidentReportAlerts struct {
+	Since       APITime `json:"since"`
+	Until       APITime `json:"until"`
+	ServiceID   string  `json:"service_id,omitempty"`
+	SuspendedBy string  `json:"suspended_by,omitempty"`
+
+	// MostRecentTriggered and MostRecentResolved are the alerts most recently
+	// triggered, respectively resolved, after being paused.
+	MostRecentTriggered []PausedIncidentReportAlert `json:"most_recent_triggered"`
+	MostRecentResolved  []PausedIncidentReportAlert `json:"most_recent_resolved"`
+}
+
+// PausedIncidentReportCounts are the numbers of alerts whose incident creation
+// was paused.
+type PausedIncidentReportCounts struct {
+	Since       APITime `json:"since"`
+	Until       APITime `json:"until"`
+	ServiceID   string  `json:"service_id,omitempty"`
+	SuspendedBy string  `json:"suspended_by,omitempty"`
+
+	// PausedCount is the number of paused alerts, of which TriggeredCount
+	// were then triggered, and ResolvedCount resolved before triggering.
+	PausedCount    uint `json:"paused_count"`
+	TriggeredCount uint `json:"triggered_count"`
+	ResolvedCount  uint `json:"resolved_count"`
+}
+
+// GetPausedIncidentReportAlertsWithContext gets the most recent alerts whose
+// incident creation was paused by Event Intelligence.
+func (c *Client) GetPausedIncidentReportAlertsWithContext(ctx context.Context, o PausedIncidentReportOptions) (*PausedIncidentReportAlerts, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, "/paused_incident_reports/alerts?"+v.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Alerts PausedIncidentReportAlerts `json:"paused_incident_reporting_alerts"`
+	}
+
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Alerts, nil
+}
+
+// GetPausedIncidentReportCountsWithContext gets the numbers of alerts whose
+// incident creation was paused by Event Intelligence.
+func (c *Client) GetPausedIncidentReportCountsWithContext(ctx context.Context, o PausedIncidentReportOptions) (*PausedIncidentReportCounts, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, "/paused_incident_reports/counts?"+v.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Counts PausedIncidentReportCounts `json:"paused_incident_reporting_counts"`
+	}
+
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Counts, nil
+}
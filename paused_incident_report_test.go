@@ -0,0 +1,78 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPausedIncidentReport_Alerts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/paused_incident_reports/alerts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "PS1", r.URL.Query().Get("service_id"))
+		testEqual(t, PausedIncidentSuspendedByAutoPause, r.URL.Query().Get("suspended_by"))
+		_, _ = w.Write([]byte(`{"paused_incident_reporting_alerts": {
+			"since": "2021-01-01T00:00:00Z",
+			"until": "2021-02-01T00:00:00Z",
+			"service_id": "PS1",
+			"suspended_by": "auto_pause",
+			"most_recent_triggered": [{"id": "A1", "created_at": "2021-01-10T00:00:00Z", "service": {"id": "PS1"}}],
+			"most_recent_resolved": [{"id": "A2", "created_at": "2021-01-11T00:00:00Z"}]
+		}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.GetPausedIncidentReportAlertsWithContext(context.Background(), PausedIncidentReportOptions{
+		ServiceID:   "PS1",
+		SuspendedBy: PausedIncidentSuspendedByAutoPause,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &PausedIncidentReportAlerts{
+		Since:       NewAPITime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+		Until:       NewAPITime(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+		ServiceID:   "PS1",
+		SuspendedBy: PausedIncidentSuspendedByAutoPause,
+		MostRecentTriggered: []PausedIncidentReportAlert{
+			{ID: "A1", CreatedAt: NewAPITime(time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)), Service: &APIReference{ID: "PS1"}},
+		},
+		MostRecentResolved: []PausedIncidentReportAlert{
+			{ID: "A2", CreatedAt: NewAPITime(time.Date(2021, 1, 11, 0, 0, 0, 0, time.UTC))},
+		},
+	}
+	testEqual(t, want, res)
+}
+
+func TestPausedIncidentReport_Counts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/paused_incident_reports/counts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "2021-01-01T00:00:00Z", r.URL.Query().Get("since"))
+		_, _ = w.Write([]byte(`{"paused_incident_reporting_counts": {"since": "2021-01-01T00:00:00Z", "until": "2021-02-01T00:00:00Z", "paused_count": 10, "triggered_count": 2, "resolved_count": 8}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.GetPausedIncidentReportCountsWithContext(context.Background(), PausedIncidentReportOptions{Since: NewAPITime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &PausedIncidentReportCounts{
+		Since:          NewAPITime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+		Until:          NewAPITime(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+		PausedCount:    10,
+		TriggeredCount: 2,
+		ResolvedCount:  8,
+	}
+	testEqual(t, want, res)
+}
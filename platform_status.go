@@ -0,0 +1,247 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+const platformStatusEndpoint = "https://status.pagerduty.com"
+
+// Values of the Indicator field of PlatformStatus.
+const (
+	PlatformStatusIndicatorNone     = "none"
+	PlatformStatusIndicatorMinor    = "minor"
+	PlatformStatusIndicatorMajor    = "major"
+	PlatformStatusIndicatorCritical = "critical"
+)
+
+// Values of the Status field of PlatformComponent.
+const (
+	PlatformComponentOperational         = "operational"
+	PlatformComponentDegradedPerformance = "degraded_performance"
+	PlatformComponentPartialOutage       = "partial_outage"
+	PlatformComponentMajorOutage         = "major_outage"
+	PlatformComponentUnderMaintenance    = "under_maintenance"
+)
+
+// PlatformStatus is the overall status of the PagerDuty platform, as reported
+// by its status page.
+type PlatformStatus struct {
+	// Indicator is the severity of the ongoing incidents, such as
+	// PlatformStatusIndicatorMajor, or PlatformStatusIndicatorNone if there
+	// isn't any.
+	Indicator string `json:"indicator"`
+
+	// Description is a summary of the status, such as "All Systems
+	// Operational".
+	Description string `json:"description"`
+}
+
+// PlatformComponent is a part of the PagerDuty platform, such as the REST
+// API or the notifications of a region.
+type PlatformComponent struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Status      string  `json:"status"`
+	Group       bool    `json:"group"`
+	GroupID     string  `json:"group_id"`
+	UpdatedAt   APITime `json:"updated_at"`
+}
+
+// PlatformIncident is an incident of the PagerDuty platform.
+type PlatformIncident struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	Impact    string  `json:"impact"`
+	Shortlink string  `json:"shortlink"`
+	CreatedAt APITime `json:"created_at"`
+	UpdatedAt APITime `json:"updated_at"`
+}
+
+// PlatformSummary is the status of the PagerDuty platform with the status
+// of its components and its unresolved incidents.
+type PlatformSummary struct {
+	Status     PlatformStatus      `json:"status"`
+	Components []PlatformComponent `json:"components"`
+	Incidents  []PlatformIncident  `json:"incidents"`
+}
+
+// PlatformStatusClient is a client of the status page of the PagerDuty
+// platform, status.pagerduty.com. It doesn't need any credentials.
+type PlatformStatusClient struct {
+	endpoint string
+
+	// HTTPClient is the HTTP client used for making requests against the
+	// status page. You can use either *http.Client here, or your own
+	// implementation.
+	HTTPClient HTTPClient
+}
+
+// PlatformStatusClientOptions allows for options to be passed into the
+// PlatformStatusClient for customization.
+type PlatformStatusClientOptions func(*PlatformStatusClient)
+
+// WithPlatformStatusClientEndpoint allows for a custom status page endpoint to
+// be passed into the client, such as the URL of a proxy.
+func WithPlatformStatusClientEndpoint(endpoint string) PlatformStatusClientOptions {
+	return func(c *PlatformStatusClient) {
+		c.endpoint = endpoint
+	}
+}
+
+// NewPlatformStatusClient creates a status page client.
+func NewPlatformStatusClient(options ...PlatformStatusClientOptions) *PlatformStatusClient {
+	c := PlatformStatusClient{
+		endpoint:   platformStatusEndpoint,
+		HTTPClient: defaultHTTPClient,
+	}
+
+	for _, opt := range options {
+		opt(&c)
+	}
+
+	return &c
+}
+
+// GetStatusWithContext gets the overall status of the PagerDuty platform.
+func (c *PlatformStatusClient) GetStatusWithContext(ctx context.Context) (*PlatformStatus, error) {
+	var result struct {
+		Status PlatformStatus `json:"status"`
+	}
+	if err := c.get(ctx, "/api/v2/status.json", &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Status, nil
+}
+
+// GetSummaryWithContext gets the status of the PagerDuty platform and of its
+// components, and its unresolved incidents.
+func (c *PlatformStatusClient) GetSummaryWithContext(ctx context.Context) (*PlatformSummary, error) {
+	var result PlatformSummary
+	if err := c.get(ctx, "/api/v2/summary.json", &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *PlatformStatusClient) get(ctx context.Context, path string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", userAgentHeader)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error calling the status page: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }() // explicitly discard error
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		return fmt.Errorf("status page responded with HTTP status code %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode JSON response: %w", err)
+	}
+
+	return nil
+}
+
+// Values of the State field of Health.
+const (
+	// HealthStateHealthy is the state of a client whose authenticated
+	// request succeeded, even if the status page reports an incident.
+	HealthStateHealthy = "healthy"
+
+	// HealthStateUnauthorized is the state of a client whose credentials
+	// were rejected by the REST API.
+	HealthStateUnauthorized = "unauthorized"
+
+	// HealthStateOutage is the state of a client whose authenticated request
+	// failed while the status page reports an incident.
+	HealthStateOutage = "outage"
+
+	// HealthStateUnreachable is the state of a client whose authenticated
+	// request failed while the status page reports no incident or can't be
+	// reached either, which likely means PagerDuty can't be reached from the
+	// client's network.
+	HealthStateUnreachable = "unreachable"
+)
+
+// Health is the result of a HealthCheck.
+type Health struct {
+	// State is the conclusion of the check, such as HealthStateOutage.
+	State string
+
+	// Status is the status of the PagerDuty platform, or nil if the status
+	// page couldn't be reached, in which case StatusErr is why.
+	Status    *PlatformStatus
+	StatusErr error
+
+	// APIErr is the error of the authenticated REST API request, if it
+	// failed.
+	APIErr error
+}
+
+// WithPlatformStatusEndpoint allows for a custom status page endpoint, which
+// HealthCheck gets the status of the PagerDuty platform from, to be passed into
+// the client.
+func WithPlatformStatusEndpoint(endpoint string) ClientOptions {
+	return func(c *Client) {
+		c.platformStatusEndpoint = endpoint
+	}
+}
+
+// HealthCheck checks whether the client can use PagerDuty, making a cheap
+// authenticated request to the REST API while getting the status of the
+// platform from its status page, so that an outage of PagerDuty can be told
+// apart from credentials being rejected.
+func (c *Client) HealthCheck(ctx context.Context) *Health {
+	var h Health
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		s := &PlatformStatusClient{endpoint: c.platformStatusEndpoint, HTTPClient: c.HTTPClient}
+		h.Status, h.StatusErr = s.GetStatusWithContext(ctx)
+	}()
+
+	_, h.APIErr = c.ListAbilitiesWithContext(ctx)
+
+	wg.Wait()
+
+	var aerr APIError
+
+	switch {
+	case h.APIErr == nil:
+		h.State = HealthStateHealthy
+
+	case errors.As(h.APIErr, &aerr) && (aerr.StatusCode == http.StatusUnauthorized || aerr.StatusCode == http.StatusForbidden):
+		h.State = HealthStateUnauthorized
+
+	case h.Status != nil && h.Status.Indicator != PlatformStatusIndicatorNone:
+		h.State = HealthStateOutage
+
+	default:
+		h.State = HealthStateUnreachable
+	}
+
+	return &h
+}
@@ -0,0 +1,143 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPlatformStatusClient(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/status.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"page": {"id": "P1", "name": "PagerDuty"}, "status": {"indicator": "minor", "description": "Minor Service Outage"}}`))
+	})
+	mux.HandleFunc("/api/v2/summary.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"status": {"indicator": "none", "description": "All Systems Operational"}, "components": [{"id": "C1", "name": "REST API", "status": "operational", "group_id": "G1", "updated_at": "2021-01-01T00:00:00.000Z"}], "incidents": []}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewPlatformStatusClient(WithPlatformStatusClientEndpoint(server.URL))
+
+	status, err := client.GetStatusWithContext(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, &PlatformStatus{Indicator: PlatformStatusIndicatorMinor, Description: "Minor Service Outage"}, status)
+
+	summary, err := client.GetSummaryWithContext(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &PlatformSummary{
+		Status:     PlatformStatus{Indicator: PlatformStatusIndicatorNone, Description: "All Systems Operational"},
+		Components: []PlatformComponent{{ID: "C1", Name: "REST API", Status: PlatformComponentOperational, GroupID: "G1", UpdatedAt: NewAPITime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))}},
+		Incidents:  []PlatformIncident{},
+	}
+	testEqual(t, want, summary)
+}
+
+func TestPlatformStatusClient_error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewPlatformStatusClient(WithPlatformStatusClientEndpoint(server.URL))
+
+	_, err := client.GetStatusWithContext(context.Background())
+	testErrCheck(t, "GetStatusWithContext()", "HTTP status code 502", err)
+}
+
+func TestClient_HealthCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		apiStatus   int
+		indicator   string
+		statusPage  int
+		wantState   string
+		wantStatus  bool
+		wantAPIErr  bool
+		wantStatErr bool
+	}{
+		{
+			name:       "healthy",
+			apiStatus:  http.StatusOK,
+			indicator:  PlatformStatusIndicatorMinor,
+			statusPage: http.StatusOK,
+			wantState:  HealthStateHealthy,
+			wantStatus: true,
+		},
+		{
+			name:       "unauthorized",
+			apiStatus:  http.StatusUnauthorized,
+			indicator:  PlatformStatusIndicatorNone,
+			statusPage: http.StatusOK,
+			wantState:  HealthStateUnauthorized,
+			wantStatus: true,
+			wantAPIErr: true,
+		},
+		{
+			name:       "outage",
+			apiStatus:  http.StatusServiceUnavailable,
+			indicator:  PlatformStatusIndicatorMajor,
+			statusPage: http.StatusOK,
+			wantState:  HealthStateOutage,
+			wantStatus: true,
+			wantAPIErr: true,
+		},
+		{
+			name:       "unreachable",
+			apiStatus:  http.StatusServiceUnavailable,
+			indicator:  PlatformStatusIndicatorNone,
+			statusPage: http.StatusOK,
+			wantState:  HealthStateUnreachable,
+			wantStatus: true,
+			wantAPIErr: true,
+		},
+		{
+			name:        "status page down",
+			apiStatus:   http.StatusServiceUnavailable,
+			statusPage:  http.StatusServiceUnavailable,
+			wantState:   HealthStateUnreachable,
+			wantAPIErr:  true,
+			wantStatErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			mux.HandleFunc("/abilities", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "GET")
+				w.WriteHeader(tt.apiStatus)
+				_, _ = w.Write([]byte(`{"abilities": ["sso"]}`))
+			})
+			mux.HandleFunc("/api/v2/status.json", func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Authorization"); got != "" {
+					t.Errorf("status page request has Authorization header %q", got)
+				}
+				w.WriteHeader(tt.statusPage)
+				_, _ = w.Write([]byte(`{"status": {"indicator": "` + tt.indicator + `"}}`))
+			})
+
+			client := defaultTestClient(server.URL, "foo")
+
+			h := client.HealthCheck(context.Background())
+
+			testEqual(t, tt.wantState, h.State)
+			testEqual(t, tt.wantStatus, h.Status != nil)
+			testEqual(t, tt.wantAPIErr, h.APIErr != nil)
+			testEqual(t, tt.wantStatErr, h.StatusErr != nil)
+		})
+	}
+}
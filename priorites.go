@@ -2,6 +2,8 @@ package pagerduty
 
 import (
 	"context"
+	"net/http"
+	"strings"
 
 	"github.com/google/go-querystring/query"
 )
@@ -74,3 +76,57 @@ func (c *Client) ListPrioritiesWithContext(ctx context.Context, o ListPriorities
 
 	return &p, nil
 }
+
+// ListPrioritiesPaginated lists existing priorities, handling pagination of
+// the results. Priorities are sorted from the most to the least severe.
+func (c *Client) ListPrioritiesPaginated(ctx context.Context, o ListPrioritiesOptions) ([]Priority, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var priorities []Priority
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListPrioritiesResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		priorities = append(priorities, result.Priorities...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+			Total:  result.Total,
+		}, nil
+	}
+
+	if err := c.pagedGet(ctx, "/priorities?"+v.Encode(), responseHandler); err != nil {
+		return nil, err
+	}
+
+	return priorities, nil
+}
+
+// GetPriorityByName returns a reference to the priority with the given name,
+// such as "P1", which can be used in CreateIncidentOptions. Names are matched
+// case-insensitively. It returns a MatchError matching ErrNotFound if there's
+// no such priority. The priorities of the account are listed on every call,
+// unless the client caches them with WithCache.
+func (c *Client) GetPriorityByName(ctx context.Context, name string) (*APIReference, error) {
+	priorities, err := c.ListPrioritiesPaginated(ctx, ListPrioritiesOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range priorities {
+		if strings.EqualFold(p.Name, name) {
+			ref := PriorityRef(p.ID)
+			return &ref, nil
+		}
+	}
+
+	return nil, MatchError{Kind: "priority", Name: name}
+}
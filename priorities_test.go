@@ -2,8 +2,10 @@ package pagerduty
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"testing"
+	"time"
 )
 
 // ListMaintenanceWindows
@@ -38,3 +40,41 @@ func TestPriorities_List(t *testing.T) {
 	}
 	testEqual(t, want, res)
 }
+
+func TestPriorities_GetPriorityByName(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var calls int
+
+	mux.HandleFunc("/priorities", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		calls++
+		_, _ = w.Write([]byte(`{"priorities": [{"id": "PP1", "name": "P1"}, {"id": "PP2", "name": "P2"}]}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	got, err := client.GetPriorityByName(context.Background(), "p2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, &APIReference{ID: "PP2", Type: "priority_reference"}, got)
+
+	_, err = client.GetPriorityByName(context.Background(), "P5")
+	testErrCheck(t, "GetPriorityByName()", `priority "P5" not found`, err)
+	testEqual(t, true, errors.Is(err, ErrNotFound))
+	testEqual(t, 2, calls)
+
+	// the priorities are listed once while they're cached
+	WithCache(NewMemoryCache(), time.Minute)(client)
+
+	for _, name := range []string{"P1", "P2"} {
+		if _, err = client.GetPriorityByName(context.Background(), name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testEqual(t, 3, calls)
+}
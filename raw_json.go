@@ -0,0 +1,32 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// RawJSONHook is called with the path of a request and the raw JSON body of
+// its response, before it's decoded, such as to read fields which aren't
+// decoded by the structs of the package yet. It's called once per page of
+// paginated requests. body must not be modified nor retained after the hook
+// returns, copy it if needed.
+type RawJSONHook func(path string, body json.RawMessage)
+
+type rawJSONHookKey struct{}
+
+// ContextWithRawJSONHook returns a context which makes the requests made with
+// it call hook with the raw JSON bodies of their responses, e.g.:
+//
+//	var raw json.RawMessage
+//	ctx := ContextWithRawJSONHook(ctx, func(_ string, body json.RawMessage) {
+//		raw = append(raw[:0], body...)
+//	})
+//	incident, err := client.GetIncidentWithContext(ctx, id)
+func ContextWithRawJSONHook(ctx context.Context, hook RawJSONHook) context.Context {
+	return context.WithValue(ctx, rawJSONHookKey{}, hook)
+}
+
+func rawJSONHookFromContext(ctx context.Context) RawJSONHook {
+	hook, _ := ctx.Value(rawJSONHookKey{}).(RawJSONHook)
+	return hook
+}
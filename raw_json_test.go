@@ -0,0 +1,47 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestContextWithRawJSONHook(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents/PI1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"incident": {"id": "PI1", "new_field": "bar"}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	var paths []string
+	var raw json.RawMessage
+
+	ctx := ContextWithRawJSONHook(context.Background(), func(path string, body json.RawMessage) {
+		paths = append(paths, path)
+		raw = append(raw[:0], body...)
+	})
+
+	res, err := client.GetIncidentWithContext(ctx, "PI1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "PI1", res.ID)
+	testEqual(t, []string{"/incidents/PI1"}, paths)
+
+	var extra struct {
+		Incident struct {
+			NewField string `json:"new_field"`
+		} `json:"incident"`
+	}
+
+	if err := json.Unmarshal(raw, &extra); err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "bar", extra.Incident.NewField)
+}
@@ -0,0 +1,34 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+)
+
+// ResponseHook is called with each response of the API, such as to read its
+// status code or headers like X-Request-Id, before the response is checked
+// and decoded. It's called once per page of paginated requests, and with the
+// responses served from the cache of a client created with WithCache. It must
+// not read nor close the body of the response.
+type ResponseHook func(resp *http.Response)
+
+type responseHookKey struct{}
+
+// ContextWithResponseHook returns a context which makes the requests made with
+// it call hook with their responses, including the responses with an error
+// status code. It's the way to access the metadata of the responses of any
+// method, e.g.:
+//
+//	var requestID string
+//	ctx := ContextWithResponseHook(ctx, func(resp *http.Response) {
+//		requestID = resp.Header.Get("X-Request-Id")
+//	})
+//	incident, err := client.GetIncidentWithContext(ctx, id)
+func ContextWithResponseHook(ctx context.Context, hook ResponseHook) context.Context {
+	return context.WithValue(ctx, responseHookKey{}, hook)
+}
+
+func responseHookFromContext(ctx context.Context) ResponseHook {
+	hook, _ := ctx.Value(responseHookKey{}).(ResponseHook)
+	return hook
+}
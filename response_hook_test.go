@@ -0,0 +1,63 @@
+package pagerduty
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestContextWithResponseHook(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/incidents/PI1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req1")
+		_, _ = w.Write([]byte(`{"incident": {"id": "PI1"}}`))
+	})
+
+	mux.HandleFunc("/incidents/PI2", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req2")
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	mux.HandleFunc("/priorities", func(w http.ResponseWriter, r *http.Request) {
+		offset := r.URL.Query().Get("offset")
+		if offset == "" {
+			offset = "0"
+		}
+
+		fmt.Fprintf(w, `{"priorities": [{"id": "P%s"}], "more": %t, "offset": %s, "limit": 1}`, offset, offset == "0", offset)
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	var (
+		requestIDs []string
+		statuses   []int
+	)
+
+	ctx := ContextWithResponseHook(context.Background(), func(resp *http.Response) {
+		requestIDs = append(requestIDs, resp.Header.Get("X-Request-Id"))
+		statuses = append(statuses, resp.StatusCode)
+	})
+
+	if _, err := client.GetIncidentWithContext(ctx, "PI1"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := client.GetIncidentWithContext(ctx, "PI2")
+	testEqual(t, true, errors.Is(err, ErrNotFound))
+
+	testEqual(t, []string{"req1", "req2"}, requestIDs)
+	testEqual(t, []int{http.StatusOK, http.StatusNotFound}, statuses)
+
+	statuses = nil
+
+	if _, err := client.ListPrioritiesPaginated(ctx, ListPrioritiesOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 2, len(statuses))
+}
@@ -30,6 +30,51 @@ type ResponsePlay struct {
 	ConferenceType     *string         `json:"conference_type"`
 }
 
+// Values of the Type field of the references in the Responders field of
+// ResponsePlay.
+const (
+	ResponsePlayResponderTypeUser             = "user_reference"
+	ResponsePlayResponderTypeEscalationPolicy = "escalation_policy_reference"
+)
+
+// Values of the Type field of the references in the Subscribers field of
+// ResponsePlay.
+const (
+	ResponsePlaySubscriberTypeUser = "user_reference"
+	ResponsePlaySubscriberTypeTeam = "team_reference"
+)
+
+// Values of the Runnability field of ResponsePlay.
+const (
+	ResponsePlayRunnabilityServices   = "services"
+	ResponsePlayRunnabilityTeams      = "teams"
+	ResponsePlayRunnabilityResponders = "responders"
+)
+
+// Values of the ConferenceType field of ResponsePlay.
+const (
+	ResponsePlayConferenceTypeNone   = "none"
+	ResponsePlayConferenceTypeManual = "manual"
+)
+
+// Validate returns an error if a responder or subscriber of the response play
+// isn't of a type it can be.
+func (rp ResponsePlay) Validate() error {
+	for i, r := range rp.Responders {
+		if r == nil || (r.Type != ResponsePlayResponderTypeUser && r.Type != ResponsePlayResponderTypeEscalationPolicy) {
+			return fmt.Errorf("response play responder %d must be a user or an escalation policy reference", i)
+		}
+	}
+
+	for i, s := range rp.Subscribers {
+		if s == nil || (s.Type != ResponsePlaySubscriberTypeUser && s.Type != ResponsePlaySubscriberTypeTeam) {
+			return fmt.Errorf("response play subscriber %d must be a user or a team reference", i)
+		}
+	}
+
+	return nil
+}
+
 // ListResponsePlaysResponse represents the list of response plays.
 type ListResponsePlaysResponse struct {
 	ResponsePlays []ResponsePlay `json:"response_plays"`
@@ -99,10 +144,7 @@ func (c *Client) DeleteResponsePlay(ctx context.Context, id string) error {
 // RunResponsePlay runs a response play on a given incident.
 func (c *Client) RunResponsePlay(ctx context.Context, from string, responsePlayID string, incidentID string) error {
 	d := map[string]APIReference{
-		"incident": {
-			ID:   incidentID,
-			Type: "incident_reference",
-		},
+		"incident": IncidentRef(incidentID),
 	}
 
 	h := map[string]string{
@@ -122,21 +164,10 @@ func (c *Client) RunResponsePlay(ctx context.Context, from string, responsePlayI
 }
 
 func getResponsePlayFromResponse(c *Client, resp *http.Response, err error) (ResponsePlay, error) {
-	if err != nil {
+	var t ResponsePlay
+	if err := decodeEnvelope(c, resp, err, "response_play", &t); err != nil {
 		return ResponsePlay{}, err
 	}
 
-	var target map[string]ResponsePlay
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return ResponsePlay{}, fmt.Errorf("Could not decode JSON response: %v", dErr)
-	}
-
-	const rootNode = "response_play"
-
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return ResponsePlay{}, fmt.Errorf("JSON response does not have %s field", rootNode)
-	}
-
 	return t, nil
 }
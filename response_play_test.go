@@ -146,3 +146,24 @@ func TestResponsePlay_Run(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestResponsePlay_Validate(t *testing.T) {
+	rp := ResponsePlay{
+		Responders: []*APIReference{
+			{ID: "PU1", Type: ResponsePlayResponderTypeUser},
+			{ID: "PEP1", Type: ResponsePlayResponderTypeEscalationPolicy},
+		},
+		Subscribers: []*APIReference{
+			{ID: "PT1", Type: ResponsePlaySubscriberTypeTeam},
+		},
+	}
+
+	testErrCheck(t, "rp.Validate()", "", rp.Validate())
+
+	rp.Responders = append(rp.Responders, &APIReference{ID: "PT1", Type: ResponsePlaySubscriberTypeTeam})
+	testErrCheck(t, "rp.Validate()", "responder 2 must be a user or an escalation policy reference", rp.Validate())
+
+	rp.Responders = nil
+	rp.Subscribers = append(rp.Subscribers, &APIReference{ID: "PEP1", Type: ResponsePlayResponderTypeEscalationPolicy})
+	testErrCheck(t, "rp.Validate()", "subscriber 1 must be a user or a team reference", rp.Validate())
+}
@@ -0,0 +1,100 @@
+package pagerduty
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy configures how requests to the REST API that failed
+// temporarily, because they were rate limited or the API returned a 5xx
+// status code, are retried by Do.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is made, including
+	// the first attempt. Values below 2 disable retries.
+	MaxAttempts int
+
+	// MinBackoff is the time waited before the first retry. It doubles after
+	// every attempt.
+	MinBackoff time.Duration
+
+	// MaxBackoff is the maximum time waited between two attempts.
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction of the backoff that's randomized, between 0 and
+	// 1, so that many clients failing at once don't retry in lockstep.
+	Jitter float64
+}
+
+// DefaultRetryPolicy is a retry policy suited to walking large collections,
+// waiting up to a minute between attempts to recover from rate limiting.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 6,
+	MinBackoff:  time.Second,
+	MaxBackoff:  time.Minute,
+	Jitter:      0.2,
+}
+
+// Do calls f, which makes requests to the REST API, until it succeeds,
+// returns an error that isn't a temporary APIError, or the attempts are
+// exhausted, waiting with an exponential backoff between attempts. It returns
+// the error of the last attempt, including when ctx is done while waiting.
+func (p RetryPolicy) Do(ctx context.Context, f func() error) error {
+	err := f()
+
+	for n := 2; n <= p.MaxAttempts && err != nil; n++ {
+		var aerr APIError
+		if !errors.As(err, &aerr) || !aerr.Temporary() {
+			return err
+		}
+
+		t := time.NewTimer(retryBackoff(n, p.MinBackoff, p.MaxBackoff, p.Jitter))
+
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return err
+
+		case <-t.C:
+		}
+
+		err = f()
+	}
+
+	return err
+}
+
+type retryPolicyKey struct{}
+
+// contextWithRetryPolicy returns a context which makes the GET requests of
+// the client made with it retried according to p, such as the pages of
+// paginated lists.
+func contextWithRetryPolicy(ctx context.Context, p RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, p)
+}
+
+func retryPolicyFromContext(ctx context.Context) (RetryPolicy, bool) {
+	p, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy)
+	return p, ok
+}
+
+// retryBackoff returns the time to wait before attempt n, starting at 2 for
+// the first retry, doubling from min up to max, and with a fraction jitter of
+// it randomized.
+func retryBackoff(n int, min, max time.Duration, jitter float64) time.Duration {
+	d := min
+	for i := 2; i < n && (max <= 0 || d < max); i++ {
+		d *= 2
+	}
+
+	if max > 0 && d > max {
+		d = max
+	}
+
+	if jitter > 0 {
+		d -= time.Duration(jitter * rand.Float64() * float64(d))
+	}
+
+	return d
+}
@@ -0,0 +1,44 @@
+package pagerduty
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_Do(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}
+
+	var attempts int
+
+	err := p.Do(context.Background(), func() error {
+		attempts++
+		return APIError{StatusCode: http.StatusServiceUnavailable}
+	})
+	testErrCheck(t, "Do()", "503", err)
+	testEqual(t, 3, attempts)
+
+	attempts = 0
+
+	err = p.Do(context.Background(), func() error {
+		attempts++
+		if attempts == 1 {
+			return APIError{StatusCode: http.StatusTooManyRequests}
+		}
+
+		return nil
+	})
+	testErrCheck(t, "Do()", "", err)
+	testEqual(t, 2, attempts)
+
+	attempts = 0
+
+	err = p.Do(context.Background(), func() error {
+		attempts++
+		return errors.New("connection reset")
+	})
+	testErrCheck(t, "Do()", "connection reset", err)
+	testEqual(t, 1, attempts)
+}
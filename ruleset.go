@@ -2,8 +2,8 @@ package pagerduty
 
 import (
 	"context"
-	"fmt"
 	"net/http"
+	"time"
 )
 
 // Ruleset represents a ruleset.
@@ -52,6 +52,7 @@ type RulesetRule struct {
 	Ruleset    *APIObject      `json:"ruleset,omitempty"`
 	CatchAll   bool            `json:"catch_all,omitempty"`
 	TimeFrame  *RuleTimeFrame  `json:"time_frame,omitempty"`
+	Variables  []*RuleVariable `json:"variables,omitempty"`
 }
 
 // RulesetRulePayload represents a payload for ruleset rules
@@ -65,6 +66,47 @@ type RuleConditions struct {
 	RuleSubconditions []*RuleSubcondition `json:"subconditions,omitempty"`
 }
 
+// Values of the Operator field of RuleConditions.
+const (
+	RuleConditionsOperatorAnd = "and"
+	RuleConditionsOperatorOr  = "or"
+)
+
+// Values of the Operator field of RuleSubcondition.
+const (
+	RuleSubconditionOperatorExists      = "exists"
+	RuleSubconditionOperatorNotExists   = "nexists"
+	RuleSubconditionOperatorEquals      = "equals"
+	RuleSubconditionOperatorNotEquals   = "nequals"
+	RuleSubconditionOperatorContains    = "contains"
+	RuleSubconditionOperatorNotContains = "ncontains"
+	RuleSubconditionOperatorMatches     = "matches"
+	RuleSubconditionOperatorNotMatches  = "nmatches"
+)
+
+// NewRuleConditions returns the conditions matching events for which all
+// (with RuleConditionsOperatorAnd) or any (with RuleConditionsOperatorOr) of
+// the subconditions match.
+func NewRuleConditions(operator string, subconditions ...*RuleSubcondition) *RuleConditions {
+	return &RuleConditions{
+		Operator:          operator,
+		RuleSubconditions: subconditions,
+	}
+}
+
+// NewRuleSubcondition returns a subcondition matching events whose field at
+// path matches value with operator, such as
+// NewRuleSubcondition("payload.source", RuleSubconditionOperatorEquals, "db1").
+func NewRuleSubcondition(path, operator, value string) *RuleSubcondition {
+	return &RuleSubcondition{
+		Operator: operator,
+		Parameters: &ConditionParameter{
+			Path:  path,
+			Value: value,
+		},
+	}
+}
+
 // RuleSubcondition represents a subcondition of a ruleset condition
 type RuleSubcondition struct {
 	Operator   string              `json:"operator,omitempty"`
@@ -77,6 +119,23 @@ type ConditionParameter struct {
 	Value string `json:"value,omitempty"`
 }
 
+// RuleVariable is a variable extracted from an event when evaluating a rule,
+// which can be referenced by the actions of the rule.
+type RuleVariable struct {
+	// Type is the type of the variable, which is "regex" to extract the
+	// first capture group of a regular expression.
+	Type       string                  `json:"type,omitempty"`
+	Name       string                  `json:"name,omitempty"`
+	Parameters *RuleVariableParameters `json:"parameters,omitempty"`
+}
+
+// RuleVariableParameters are the parameters of a RuleVariable.
+type RuleVariableParameters struct {
+	// Value is the regular expression matched against the field at Path.
+	Value string `json:"value,omitempty"`
+	Path  string `json:"path,omitempty"`
+}
+
 // RuleTimeFrame represents a time_frame object on the rule object
 type RuleTimeFrame struct {
 	ScheduledWeekly *ScheduledWeekly `json:"scheduled_weekly,omitempty"`
@@ -99,17 +158,42 @@ type ScheduledWeekly struct {
 	Duration int `json:"duration,omitempty"`
 }
 
+// NewScheduledWeekly returns a weekly time frame starting at start into the
+// day, in the IANA time zone tz, and lasting for duration, on the given days.
+func NewScheduledWeekly(tz string, start, duration time.Duration, days ...time.Weekday) *ScheduledWeekly {
+	weekdays := make([]int, len(days))
+	for i, d := range days {
+		weekdays[i] = int(d)
+	}
+
+	return &ScheduledWeekly{
+		Weekdays:  weekdays,
+		Timezone:  tz,
+		StartTime: int(start / time.Millisecond),
+		Duration:  int(duration / time.Millisecond),
+	}
+}
+
 // ActiveBetween represents an active_between object for setting a timeline for rules
 type ActiveBetween struct {
-	// StartTime is in the number of milliseconds into the day at which the
-	// window starts.
+	// StartTime is the time at which the window starts, as a number of
+	// milliseconds since the Unix epoch.
 	StartTime int `json:"start_time,omitempty"`
 
-	// EndTime is the number of milliseconds into the day at which the window
-	// ends.
+	// EndTime is the time at which the window ends, as a number of
+	// milliseconds since the Unix epoch.
 	EndTime int `json:"end_time,omitempty"`
 }
 
+// NewActiveBetween returns a time frame during which a rule is active, from
+// start to end.
+func NewActiveBetween(start, end time.Time) *ActiveBetween {
+	return &ActiveBetween{
+		StartTime: int(start.UnixNano() / int64(time.Millisecond)),
+		EndTime:   int(end.UnixNano() / int64(time.Millisecond)),
+	}
+}
+
 // ListRulesetRulesResponse represents a list of rules in a ruleset
 type ListRulesetRulesResponse struct {
 	Total  uint           `json:"total,omitempty"`
@@ -131,6 +215,27 @@ type RuleActions struct {
 	Route       *RuleActionParameter    `json:"route"`
 }
 
+// Values of the Severity action of RuleActions.
+const (
+	RuleActionSeverityInfo     = "info"
+	RuleActionSeverityWarning  = "warning"
+	RuleActionSeverityError    = "error"
+	RuleActionSeverityCritical = "critical"
+)
+
+// Values of the EventAction action of RuleActions.
+const (
+	RuleActionEventActionTrigger = "trigger"
+	RuleActionEventActionResolve = "resolve"
+)
+
+// Values of the ThresholdTimeUnit field of RuleActionSuppress.
+const (
+	RuleActionSuppressTimeUnitSeconds = "seconds"
+	RuleActionSuppressTimeUnitMinutes = "minutes"
+	RuleActionSuppressTimeUnitHours   = "hours"
+)
+
 // RuleActionParameter represents a generic parameter object on a rule action
 type RuleActionParameter struct {
 	Value string `json:"value,omitempty"`
@@ -155,6 +260,10 @@ type RuleActionExtraction struct {
 	Target string `json:"target,omitempty"`
 	Source string `json:"source,omitempty"`
 	Regex  string `json:"regex,omitempty"`
+
+	// Template is used instead of Source and Regex to set the target to a
+	// template, which may reference rule variables like {{variable_name}}.
+	Template string `json:"template,omitempty"`
 }
 
 // ListRulesets gets all rulesets. This method currently handles pagination of
@@ -191,6 +300,7 @@ func (c *Client) ListRulesetsPaginated(ctx context.Context) ([]*Ruleset, error)
 			More:   result.More,
 			Offset: result.Offset,
 			Limit:  result.Limit,
+			Total:  result.Total,
 		}, nil
 	}
 
@@ -263,20 +373,11 @@ func (c *Client) UpdateRulesetWithContext(ctx context.Context, r *Ruleset) (*Rul
 }
 
 func getRulesetFromResponse(c *Client, resp *http.Response, err error) (*Ruleset, error) {
-	if err != nil {
+	var t Ruleset
+	if err := decodeEnvelope(c, resp, err, "ruleset", &t); err != nil {
 		return nil, err
 	}
 
-	var target map[string]Ruleset
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
-	}
-
-	t, nodeOK := target["ruleset"]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have ruleset field")
-	}
-
 	return &t, nil
 }
 
@@ -315,6 +416,7 @@ func (c *Client) ListRulesetRulesPaginated(ctx context.Context, rulesetID string
 			More:   result.More,
 			Offset: result.Offset,
 			Limit:  result.Limit,
+			Total:  result.Total,
 		}, nil
 	}
 
@@ -387,21 +489,10 @@ func (c *Client) UpdateRulesetRuleWithContext(ctx context.Context, rulesetID, ru
 }
 
 func getRuleFromResponse(c *Client, resp *http.Response, err error) (*RulesetRule, error) {
-	if err != nil {
+	var t RulesetRule
+	if err := decodeEnvelope(c, resp, err, "rule", &t); err != nil {
 		return nil, err
 	}
 
-	var target map[string]RulesetRule
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
-	}
-
-	const rootNode = "rule"
-
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
-	}
-
 	return &t, nil
 }
@@ -1,8 +1,10 @@
 package pagerduty
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 )
 
 // List Rulesets
@@ -254,3 +256,41 @@ func TestRuleset_DeleteRule(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestRulesetRule_MarshalBuilders(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	rule := RulesetRule{
+		Conditions: NewRuleConditions(RuleConditionsOperatorAnd,
+			NewRuleSubcondition("payload.source", RuleSubconditionOperatorEquals, "db1"),
+			NewRuleSubcondition("payload.summary", RuleSubconditionOperatorContains, "disk"),
+		),
+		TimeFrame: &RuleTimeFrame{
+			ScheduledWeekly: NewScheduledWeekly("Europe/Paris", 9*time.Hour, 8*time.Hour, time.Monday, time.Friday),
+			ActiveBetween:   NewActiveBetween(start, start.Add(time.Hour)),
+		},
+		Actions: &RuleActions{
+			Severity: &RuleActionParameter{Value: RuleActionSeverityCritical},
+			Suppress: &RuleActionSuppress{
+				Value:               true,
+				ThresholdValue:      3,
+				ThresholdTimeUnit:   RuleActionSuppressTimeUnitMinutes,
+				ThresholdTimeAmount: 10,
+			},
+		},
+	}
+
+	b, err := json.Marshal(rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"conditions":{"operator":"and","subconditions":[` +
+		`{"operator":"equals","parameters":{"path":"payload.source","value":"db1"}},` +
+		`{"operator":"contains","parameters":{"path":"payload.summary","value":"disk"}}]},` +
+		`"actions":{"severity":{"value":"critical"},"suppress":{"value":true,"threshold_value":3,"threshold_time_unit":"minutes","threshold_time_amount":10},"route":null},` +
+		`"time_frame":{"scheduled_weekly":{"weekdays":[1,5],"timezone":"Europe/Paris","start_time":32400000,"duration":28800000},` +
+		`"active_between":{"start_time":1609459200000,"end_time":1609462800000}}}`
+
+	testEqual(t, want, string(b))
+}
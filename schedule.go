@@ -1,19 +1,67 @@
 package pagerduty
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
 
+// Values of the Restriction.Type field.
+const (
+	// RestrictionTypeDaily restricts a layer to the same window of time every
+	// day.
+	RestrictionTypeDaily = "daily_restriction"
+
+	// RestrictionTypeWeekly restricts a layer to a window of time starting on
+	// a given day of the week.
+	RestrictionTypeWeekly = "weekly_restriction"
+)
+
 // Restriction limits on-call responsibility for a layer to certain times of the day or week.
 type Restriction struct {
-	Type            string `json:"type,omitempty"`
-	StartTimeOfDay  string `json:"start_time_of_day,omitempty"`
-	StartDayOfWeek  uint   `json:"start_day_of_week,omitempty"`
-	DurationSeconds uint   `json:"duration_seconds,omitempty"`
+	Type string `json:"type,omitempty"`
+
+	// StartTimeOfDay is when the restriction starts, formatted as HH:MM:SS in
+	// the time zone of the schedule.
+	StartTimeOfDay string `json:"start_time_of_day,omitempty"`
+
+	// StartDayOfWeek is the day weekly restrictions start on, where 1 is
+	// Monday and 7 is Sunday.
+	StartDayOfWeek uint `json:"start_day_of_week,omitempty"`
+
+	DurationSeconds uint `json:"duration_seconds,omitempty"`
+}
+
+// DailyRestriction returns a Restriction limiting a layer to d, starting at
+// startTimeOfDay (HH:MM:SS) every day.
+func DailyRestriction(startTimeOfDay string, d time.Duration) Restriction {
+	return Restriction{
+		Type:            RestrictionTypeDaily,
+		StartTimeOfDay:  startTimeOfDay,
+		DurationSeconds: uint(d / time.Second),
+	}
+}
+
+// WeeklyRestriction returns a Restriction limiting a layer to d, starting at
+// startTimeOfDay (HH:MM:SS) on day every week.
+func WeeklyRestriction(day time.Weekday, startTimeOfDay string, d time.Duration) Restriction {
+	dow := uint(day)
+	if day == time.Sunday {
+		dow = 7
+	}
+
+	return Restriction{
+		Type:            RestrictionTypeWeekly,
+		StartTimeOfDay:  startTimeOfDay,
+		StartDayOfWeek:  dow,
+		DurationSeconds: uint(d / time.Second),
+	}
 }
 
 // RenderedScheduleEntry represents the computed set of schedule layer entries that put users on call for a schedule, and cannot be modified directly.
@@ -23,6 +71,34 @@ type RenderedScheduleEntry struct {
 	User  APIObject `json:"user,omitempty"`
 }
 
+// ScheduleEntry is a RenderedScheduleEntry with its start and end parsed.
+type ScheduleEntry struct {
+	Start time.Time
+	End   time.Time
+	User  APIObject
+}
+
+// Duration returns how long the entry lasts.
+func (e ScheduleEntry) Duration() time.Duration {
+	return e.End.Sub(e.Start)
+}
+
+// Contains returns whether t is within the entry, which includes its start
+// but not its end.
+func (e ScheduleEntry) Contains(t time.Time) bool {
+	return !t.Before(e.Start) && t.Before(e.End)
+}
+
+// Parse returns the entry with its start and end parsed as RFC 3339 times.
+func (e RenderedScheduleEntry) Parse() (ScheduleEntry, error) {
+	start, end, err := parseTimeRange(e.Start, e.End)
+	if err != nil {
+		return ScheduleEntry{}, err
+	}
+
+	return ScheduleEntry{Start: start, End: end, User: e.User}, nil
+}
+
 // ScheduleLayer is an entry that puts users on call for a schedule.
 type ScheduleLayer struct {
 	APIObject
@@ -37,6 +113,29 @@ type ScheduleLayer struct {
 	RenderedCoveragePercentage float64                 `json:"rendered_coverage_percentage,omitempty"`
 }
 
+// Entries returns the rendered schedule entries of the layer, with their
+// start and end parsed.
+func (l ScheduleLayer) Entries() ([]ScheduleEntry, error) {
+	entries := make([]ScheduleEntry, len(l.RenderedScheduleEntries))
+
+	for i, re := range l.RenderedScheduleEntries {
+		e, err := re.Parse()
+		if err != nil {
+			return nil, fmt.Errorf("rendered schedule entry %d: %w", i, err)
+		}
+
+		entries[i] = e
+	}
+
+	return entries, nil
+}
+
+// CoverageRatio returns the rendered coverage percentage of the layer as a
+// ratio between 0 and 1.
+func (l ScheduleLayer) CoverageRatio() float64 {
+	return l.RenderedCoveragePercentage / 100
+}
+
 // Schedule determines the time periods that users are on call.
 type Schedule struct {
 	APIObject
@@ -74,6 +173,7 @@ type ListSchedulesOptions struct {
 
 	Query    string   `url:"query,omitempty"`
 	Includes []string `url:"include,omitempty,brackets"`
+	TimeZone string   `url:"time_zone,omitempty"`
 }
 
 // ListSchedulesResponse is the data structure returned from calling the ListSchedules API endpoint.
@@ -114,6 +214,39 @@ func (c *Client) ListSchedulesWithContext(ctx context.Context, o ListSchedulesOp
 	return &result, nil
 }
 
+// ListSchedulesPaginated lists the on-call schedules, handling pagination of
+// the results.
+func (c *Client) ListSchedulesPaginated(ctx context.Context, o ListSchedulesOptions) ([]Schedule, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var schedules []Schedule
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListSchedulesResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		schedules = append(schedules, result.Schedules...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+			Total:  result.Total,
+		}, nil
+	}
+
+	if err := c.pagedGet(ctx, "/schedules?"+v.Encode(), responseHandler); err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
 // CreateSchedule creates a new on-call schedule.
 //
 // Deprecated: Use CreateScheduleWithContext instead.
@@ -133,9 +266,14 @@ func (c *Client) CreateScheduleWithContext(ctx context.Context, s Schedule) (*Sc
 
 // PreviewScheduleOptions is the data structure used when calling the PreviewSchedule API endpoint.
 type PreviewScheduleOptions struct {
-	Since    string `url:"since,omitempty"`
-	Until    string `url:"until,omitempty"`
-	Overflow bool   `url:"overflow,omitempty"`
+	// Since and Until are the time range the preview is rendered for, in
+	// ISO 8601 format.
+	Since string `url:"since,omitempty"`
+	Until string `url:"until,omitempty"`
+
+	// Overflow controls whether entries are rendered past the bounds of the
+	// time range, rather than being truncated to it.
+	Overflow bool `url:"overflow,omitempty"`
 }
 
 // PreviewSchedule previews what an on-call schedule would look like without
@@ -147,21 +285,31 @@ func (c *Client) PreviewSchedule(s Schedule, o PreviewScheduleOptions) error {
 }
 
 // PreviewScheduleWithContext previews what an on-call schedule would look like
-// without saving it. Nothing is returned from this method, because the API
-// should return the Schedule as we posted it. If this method call returns no
-// error, the schedule should be valid and can be updated.
+// without saving it. If this method call returns no error, the schedule should
+// be valid and can be created or updated. Use RenderSchedulePreviewWithContext
+// to get the rendered schedule entries of the preview.
 func (c *Client) PreviewScheduleWithContext(ctx context.Context, s Schedule, o PreviewScheduleOptions) error {
+	_, err := c.RenderSchedulePreviewWithContext(ctx, s, o)
+	return err
+}
+
+// RenderSchedulePreviewWithContext previews what an on-call schedule would look
+// like without saving it, and returns it with its layers, override
+// sub-schedule, and final schedule rendered for the time range of the options.
+// It allows configuration tools to show the resulting rotation before creating
+// or updating a schedule.
+func (c *Client) RenderSchedulePreviewWithContext(ctx context.Context, s Schedule, o PreviewScheduleOptions) (*Schedule, error) {
 	v, err := query.Values(o)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	d := map[string]Schedule{
 		"schedule": s,
 	}
 
-	_, err = c.post(ctx, "/schedules/preview?"+v.Encode(), d, nil)
-	return err
+	resp, err := c.post(ctx, "/schedules/preview?"+v.Encode(), d, nil)
+	return getScheduleFromResponse(c, resp, err)
 }
 
 // DeleteSchedule deletes an on-call schedule.
@@ -197,7 +345,7 @@ func (c *Client) GetSchedule(id string, o GetScheduleOptions) (*Schedule, error)
 func (c *Client) GetScheduleWithContext(ctx context.Context, id string, o GetScheduleOptions) (*Schedule, error) {
 	v, err := query.Values(o)
 	if err != nil {
-		return nil, fmt.Errorf("Could not parse values for query: %v", err)
+		return nil, fmt.Errorf("Could not parse values for query: %w", err)
 	}
 
 	resp, err := c.get(ctx, "/schedules/"+id+"?"+v.Encode())
@@ -218,11 +366,28 @@ func (c *Client) UpdateSchedule(id string, s Schedule) (*Schedule, error) {
 
 // UpdateScheduleWithContext updates an existing on-call schedule.
 func (c *Client) UpdateScheduleWithContext(ctx context.Context, id string, s Schedule) (*Schedule, error) {
+	return c.UpdateScheduleWithOptionsContext(ctx, id, s, UpdateScheduleOptions{})
+}
+
+// UpdateScheduleWithOptionsContext updates an existing on-call schedule,
+// using the options to control how the returned schedule entries are
+// rendered.
+func (c *Client) UpdateScheduleWithOptionsContext(ctx context.Context, id string, s Schedule, o UpdateScheduleOptions) (*Schedule, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
 	d := map[string]Schedule{
 		"schedule": s,
 	}
 
-	resp, err := c.put(ctx, "/schedules/"+id, d, nil)
+	path := "/schedules/" + id
+	if q := v.Encode(); len(q) > 0 {
+		path += "?" + q
+	}
+
+	resp, err := c.put(ctx, path, d, nil)
 	return getScheduleFromResponse(c, resp, err)
 }
 
@@ -251,6 +416,11 @@ type Override struct {
 	User    APIObject `json:"user,omitempty"`
 }
 
+// Times returns the start and end of the override, parsed as RFC 3339 times.
+func (o Override) Times() (start, end time.Time, err error) {
+	return parseTimeRange(o.Start, o.End)
+}
+
 // ListOverrides lists overrides for a given time range.
 //
 // Deprecated: Use ListOverridesWithContext instead.
@@ -301,6 +471,11 @@ func (c *Client) CreateOverrideWithContext(ctx context.Context, id string, o Ove
 	return getOverrideFromResponse(c, resp)
 }
 
+// CreateOverridesWithContext creates overrides in bulk, for specific users
+// covering the specified time ranges. The API creates each override
+// independently, so some of them may be created while others are rejected. In
+// that case, the created overrides are returned along with a
+// *CreateOverridesError describing the result for each override.
 func (c *Client) CreateOverridesWithContext(ctx context.Context, id string, overrides []Override) ([]Override, error) {
 	d := map[string][]Override{
 		"overrides": overrides,
@@ -314,10 +489,53 @@ func (c *Client) CreateOverridesWithContext(ctx context.Context, id string, over
 	return getOverridesFromResponse(c, resp)
 }
 
+// CreateOverrides creates overrides in bulk, for specific users covering the
+// specified time ranges.
+//
+// Deprecated: Use CreateOverridesWithContext instead.
 func (c *Client) CreateOverrides(id string, o []Override) ([]Override, error) {
 	return c.CreateOverridesWithContext(context.Background(), id, o)
 }
 
+// CreateOverrideResult is the result of creating one of the overrides passed
+// to CreateOverridesWithContext.
+type CreateOverrideResult struct {
+	// Status is the HTTP status code for this override, such as 201 when it
+	// was created, or 400 when it was rejected.
+	Status int `json:"status"`
+
+	// Errors describe why the override was rejected.
+	Errors []string `json:"errors,omitempty"`
+
+	Override Override `json:"override"`
+}
+
+// Created returns whether the override was created.
+func (r CreateOverrideResult) Created() bool {
+	return r.Status >= 200 && r.Status < 300
+}
+
+// CreateOverridesError is returned by CreateOverridesWithContext when some of
+// the overrides couldn't be created.
+type CreateOverridesError struct {
+	// Results are the results for each of the overrides, in the order they
+	// were passed in.
+	Results []CreateOverrideResult
+}
+
+// Error satisfies the error interface.
+func (e *CreateOverridesError) Error() string {
+	var failed []string
+
+	for i, r := range e.Results {
+		if !r.Created() {
+			failed = append(failed, fmt.Sprintf("override %d: status %d: %s", i, r.Status, strings.Join(r.Errors, ", ")))
+		}
+	}
+
+	return fmt.Sprintf("failed to create %d of %d overrides: %s", len(failed), len(e.Results), strings.Join(failed, "; "))
+}
+
 // DeleteOverride removes an override.
 //
 // Deprecated: Use DeleteOverrideWithContext instead.
@@ -331,6 +549,19 @@ func (c *Client) DeleteOverrideWithContext(ctx context.Context, scheduleID, over
 	return err
 }
 
+// ListScheduleAuditRecords lists the audit records of changes made to a
+// schedule, such as changes to its layers and overrides.
+func (c *Client) ListScheduleAuditRecords(ctx context.Context, id string, o ListResourceAuditRecordsOptions) (ListAuditRecordsResponse, error) {
+	return c.listResourceAuditRecords(ctx, "/schedules/"+id+"/audit/records", o)
+}
+
+// ListScheduleAuditRecordsPaginated lists all of the audit records of changes
+// made to a schedule, processing paginated responses. The Cursor of the
+// options is ignored.
+func (c *Client) ListScheduleAuditRecordsPaginated(ctx context.Context, id string, o ListResourceAuditRecordsOptions) ([]AuditRecord, error) {
+	return c.listResourceAuditRecordsPaginated(ctx, "/schedules/"+id+"/audit/records", o)
+}
+
 // ListOnCallUsersOptions is the data structure used when calling the ListOnCallUsers API endpoint.
 type ListOnCallUsersOptions struct {
 	Since string `url:"since,omitempty"`
@@ -354,68 +585,75 @@ func (c *Client) ListOnCallUsersWithContext(ctx context.Context, id string, o Li
 	}
 
 	resp, err := c.get(ctx, "/schedules/"+id+"/users?"+v.Encode())
-	if err != nil {
-		return nil, err
-	}
 
-	var result map[string][]User
-	if err := c.decodeJSON(resp, &result); err != nil {
+	var u []User
+	if err := decodeEnvelope(c, resp, err, "users", &u); err != nil {
 		return nil, err
 	}
 
-	u, ok := result["users"]
-	if !ok {
-		return nil, fmt.Errorf("JSON response does not have users field")
-	}
-
 	return u, nil
 }
 
 func getScheduleFromResponse(c *Client, resp *http.Response, err error) (*Schedule, error) {
-	if err != nil {
+	var t Schedule
+	if err := decodeEnvelope(c, resp, err, "schedule", &t); err != nil {
 		return nil, err
 	}
 
-	var target map[string]Schedule
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
-	}
-
-	const rootNode = "schedule"
-
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
-	}
-
 	return &t, nil
 }
 
 func getOverrideFromResponse(c *Client, resp *http.Response) (*Override, error) {
-	var target map[string]Override
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
-	}
-
-	const rootNode = "override"
-	o, nodeOK := target[rootNode]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
+	var o Override
+	if err := decodeEnvelope(c, resp, nil, "override", &o); err != nil {
+		return nil, err
 	}
 
 	return &o, nil
 }
 
 func getOverridesFromResponse(c *Client, resp *http.Response) ([]Override, error) {
+	var raw json.RawMessage
+	if dErr := c.decodeJSON(resp, &raw); dErr != nil {
+		return nil, fmt.Errorf("Could not decode JSON response: %w", dErr)
+	}
+
+	// the API responds with the result of each override as an array, but an
+	// object with an overrides field is also supported
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+		var results []CreateOverrideResult
+		if err := json.Unmarshal(trimmed, &results); err != nil {
+			return nil, fmt.Errorf("Could not decode JSON response: %w", err)
+		}
+
+		created := make([]Override, 0, len(results))
+		failed := false
+
+		for _, r := range results {
+			if !r.Created() {
+				failed = true
+				continue
+			}
+
+			created = append(created, r.Override)
+		}
+
+		if failed {
+			return created, &CreateOverridesError{Results: results}
+		}
+
+		return created, nil
+	}
+
 	var target map[string][]Override
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
+	if dErr := json.Unmarshal(raw, &target); dErr != nil {
+		return nil, fmt.Errorf("Could not decode JSON response: %w", dErr)
 	}
 
 	const rootNode = "overrides"
 	o, nodeOK := target[rootNode]
 	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
+		return nil, MissingFieldError{Field: rootNode}
 	}
 
 	return o, nil
@@ -1,8 +1,14 @@
 package pagerduty
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"testing"
+	"time"
 )
 
 // ListSchedules
@@ -74,7 +80,47 @@ func TestSchedule_Create(t *testing.T) {
 	testEqual(t, want, res)
 }
 
-// TODO: Preview a schedule -- should this function be changed to actually return a preview?
+// Preview a schedule
+func TestSchedule_RenderPreview(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/schedules/preview", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testEqual(t, "2021-01-01T00:00:00Z", r.URL.Query().Get("since"))
+		testEqual(t, "2021-01-08T00:00:00Z", r.URL.Query().Get("until"))
+
+		var body map[string]Schedule
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		testEqual(t, "foo", body["schedule"].Name)
+
+		_, _ = w.Write([]byte(`{"schedule": {"name": "foo", "final_schedule": {"name": "Final Schedule", "rendered_coverage_percentage": 100, "rendered_schedule_entries": [{"start": "2021-01-01T00:00:00Z", "end": "2021-01-08T00:00:00Z", "user": {"id": "PU1"}}]}}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+	opts := PreviewScheduleOptions{
+		Since: "2021-01-01T00:00:00Z",
+		Until: "2021-01-08T00:00:00Z",
+	}
+
+	res, err := client.RenderSchedulePreviewWithContext(context.Background(), Schedule{Name: "foo"}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []RenderedScheduleEntry{
+		{Start: "2021-01-01T00:00:00Z", End: "2021-01-08T00:00:00Z", User: APIObject{ID: "PU1"}},
+	}
+
+	testEqual(t, want, res.FinalSchedule.RenderedScheduleEntries)
+	testEqual(t, 100.0, res.FinalSchedule.RenderedCoveragePercentage)
+
+	err = client.PreviewScheduleWithContext(context.Background(), Schedule{Name: "foo"}, opts)
+	testErrCheck(t, "client.PreviewScheduleWithContext()", "", err)
+}
 
 // Delete a schedule
 func TestSchedule_Delete(t *testing.T) {
@@ -160,6 +206,80 @@ func TestSchedule_Update(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+// ListSchedulesPaginated
+func TestSchedule_ListPaginated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/schedules", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		resp := fmt.Sprintf(`{"schedules": [{"id": "%d"}], "more": %t, "offset": %d, "limit": 1}`, offset, offset == 0, offset)
+		_, _ = w.Write([]byte(resp))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListSchedulesPaginated(context.Background(), ListSchedulesOptions{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Schedule{
+		{APIObject: APIObject{ID: "0"}},
+		{APIObject: APIObject{ID: "1"}},
+	}
+
+	testEqual(t, want, res)
+}
+
+// UpdateScheduleWithOptions
+func TestSchedule_UpdateWithOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/schedules/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testEqual(t, "true", r.URL.Query().Get("overflow"))
+
+		var body map[string]Schedule
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		want := []Restriction{
+			{Type: RestrictionTypeDaily, StartTimeOfDay: "09:00:00", DurationSeconds: 28800},
+			{Type: RestrictionTypeWeekly, StartTimeOfDay: "18:00:00", StartDayOfWeek: 7, DurationSeconds: 3600},
+		}
+
+		testEqual(t, want, body["schedule"].ScheduleLayers[0].Restrictions)
+
+		_, _ = w.Write([]byte(`{"schedule": {"id": "1"}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	input := Schedule{
+		ScheduleLayers: []ScheduleLayer{
+			{
+				Restrictions: []Restriction{
+					DailyRestriction("09:00:00", 8*time.Hour),
+					WeeklyRestriction(time.Sunday, "18:00:00", time.Hour),
+				},
+			},
+		},
+	}
+
+	res, err := client.UpdateScheduleWithOptionsContext(context.Background(), "1", input, UpdateScheduleOptions{Overflow: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, &Schedule{APIObject: APIObject{ID: "1"}}, res)
+}
+
 // List overrides
 func TestSchedule_ListOverrides(t *testing.T) {
 	setup()
@@ -261,6 +381,50 @@ func TestSchedule_CreateOverrides(t *testing.T) {
 }
 
 // Delete an override
+func TestSchedule_CreateOverridesPartialFailure(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/schedules/1/overrides", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string][]Override
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		testEqual(t, 2, len(body["overrides"]))
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`[
+			{"status": 201, "override": {"id": "1", "start": "foo", "end": "bar", "user": {"id": "PU1"}}},
+			{"status": 400, "errors": ["Override must end after its start"], "override": {"start": "bar", "end": "foo", "user": {"id": "PU2"}}}
+		]`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+	input := []Override{
+		{Start: "foo", End: "bar", User: APIObject{ID: "PU1", Type: "user_reference"}},
+		{Start: "bar", End: "foo", User: APIObject{ID: "PU2", Type: "user_reference"}},
+	}
+
+	res, err := client.CreateOverridesWithContext(context.Background(), "1", input)
+
+	var cerr *CreateOverridesError
+	if !errors.As(err, &cerr) {
+		t.Fatalf("err = %v, want *CreateOverridesError", err)
+	}
+
+	testEqual(t, 2, len(cerr.Results))
+	testEqual(t, []string{"Override must end after its start"}, cerr.Results[1].Errors)
+
+	want := []Override{
+		{ID: "1", Start: "foo", End: "bar", User: APIObject{ID: "PU1"}},
+	}
+
+	testEqual(t, want, res)
+}
+
 func TestSchedule_DeleteOverride(t *testing.T) {
 	setup()
 	defer teardown()
@@ -310,3 +474,83 @@ func TestSchedule_ListOnCallUsers(t *testing.T) {
 	}
 	testEqual(t, want, res)
 }
+
+func TestScheduleLayer_Entries(t *testing.T) {
+	l := ScheduleLayer{
+		RenderedCoveragePercentage: 50,
+		RenderedScheduleEntries: []RenderedScheduleEntry{
+			{Start: "2021-01-01T00:00:00-05:00", End: "2021-01-01T12:00:00-05:00", User: APIObject{ID: "PU1"}},
+		},
+	}
+
+	entries, err := l.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2021, 1, 1, 5, 0, 0, 0, time.UTC)
+
+	testEqual(t, 1, len(entries))
+	testEqual(t, true, entries[0].Start.Equal(start))
+	testEqual(t, 12*time.Hour, entries[0].Duration())
+	testEqual(t, true, entries[0].Contains(start))
+	testEqual(t, false, entries[0].Contains(start.Add(12*time.Hour)))
+	testEqual(t, "PU1", entries[0].User.ID)
+	testEqual(t, 0.5, l.CoverageRatio())
+
+	l.RenderedScheduleEntries[0].End = "tomorrow"
+
+	_, err = l.Entries()
+	testErrCheck(t, "l.Entries()", "failed to parse end time", err)
+}
+
+func TestOverride_Times(t *testing.T) {
+	o := Override{Start: "2021-01-01T00:00:00Z", End: "2021-01-02T00:00:00Z"}
+
+	start, end, err := o.Times()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, 24*time.Hour, end.Sub(start))
+}
+
+func TestSchedule_ListAuditRecordsPaginated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/schedules/1/audit/records", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "2021-01-01T00:00:00Z", r.URL.Query().Get("since"))
+
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"records": [{"id": "R1", "action": "update"}], "limit": 1, "next_cursor": "c2"}`))
+			return
+		}
+
+		testEqual(t, "c2", r.URL.Query().Get("cursor"))
+		_, _ = w.Write([]byte(`{"records": [{"id": "R2", "action": "create"}], "limit": 1, "next_cursor": null}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+	opts := ListResourceAuditRecordsOptions{Since: "2021-01-01T00:00:00Z", Limit: 1}
+
+	res, err := client.ListScheduleAuditRecordsPaginated(context.Background(), "1", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []AuditRecord{
+		{ID: "R1", Action: "update"},
+		{ID: "R2", Action: "create"},
+	}
+
+	testEqual(t, want, res)
+
+	page, err := client.ListScheduleAuditRecords(context.Background(), "1", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "c2", *page.NextCursor)
+}
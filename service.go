@@ -2,12 +2,102 @@ package pagerduty
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
 
+// Values of the Service.Status field. The status of a service is set by
+// PagerDuty, except for ServiceStatusActive and ServiceStatusDisabled which
+// can be used to enable or disable a service.
+const (
+	ServiceStatusActive      = "active"
+	ServiceStatusWarning     = "warning"
+	ServiceStatusCritical    = "critical"
+	ServiceStatusMaintenance = "maintenance"
+	ServiceStatusDisabled    = "disabled"
+)
+
+// Values of the Service.AlertCreation field.
+const (
+	// AlertCreationCreateIncidents creates an incident for each event, without
+	// alerts. It's deprecated by PagerDuty.
+	AlertCreationCreateIncidents = "create_incidents"
+
+	// AlertCreationCreateAlertsAndIncidents creates alerts, which may be
+	// grouped into incidents.
+	AlertCreationCreateAlertsAndIncidents = "create_alerts_and_incidents"
+)
+
+// Values of the IncidentUrgencyRule.Type and IncidentUrgencyType.Type fields.
+const (
+	// IncidentUrgencyRuleTypeConstant uses the same urgency for all incidents.
+	IncidentUrgencyRuleTypeConstant = "constant"
+
+	// IncidentUrgencyRuleTypeUseSupportHours uses a different urgency during
+	// and outside of the support hours of the service.
+	IncidentUrgencyRuleTypeUseSupportHours = "use_support_hours"
+
+	// IncidentUrgencyRuleTypeSeverityBased sets the urgency based on the
+	// severity of the alert.
+	IncidentUrgencyRuleTypeSeverityBased = "severity_based"
+)
+
+// Values of the IncidentUrgencyRule.Urgency and IncidentUrgencyType.Urgency
+// fields.
+const (
+	UrgencyHigh = "high"
+	UrgencyLow  = "low"
+
+	// UrgencySeverityBased sets the urgency based on the severity of the
+	// alert.
+	UrgencySeverityBased = "severity_based"
+)
+
+// Urgency is the urgency of an incident, such as UrgencyHigh.
+type Urgency string
+
+// IsValid returns whether u is the urgency of an incident, UrgencyHigh or
+// UrgencyLow.
+func (u Urgency) IsValid() bool {
+	switch u {
+	case UrgencyHigh, UrgencyLow:
+		return true
+	default:
+		return false
+	}
+}
+
+// Values of the SupportHours.Type field.
+const (
+	// SupportHoursTypeFixedTimePerDay is the only supported type of support
+	// hours, which are the same time window on each of the days of the week.
+	SupportHoursTypeFixedTimePerDay = "fixed_time_per_day"
+)
+
+// Values of the ScheduledAction.Type and InlineModel fields.
+const (
+	// ScheduledActionTypeUrgencyChange changes the urgency of open incidents.
+	ScheduledActionTypeUrgencyChange = "urgency_change"
+
+	// InlineModelTypeNamedTime is the type of InlineModel for named times.
+	InlineModelTypeNamedTime = "named_time"
+
+	// InlineModelNameSupportHoursStart is when support hours start.
+	InlineModelNameSupportHoursStart = "support_hours_start"
+
+	// InlineModelNameSupportHoursEnd is when support hours end.
+	InlineModelNameSupportHoursEnd = "support_hours_end"
+)
+
+// AutoPauseNotificationsTimeouts are the values accepted by PagerDuty for
+// AutoPauseNotificationsParameters.Timeout, in seconds.
+var AutoPauseNotificationsTimeouts = []uint{120, 180, 300, 600, 900}
+
 // InlineModel represents when a scheduled action will occur.
 type InlineModel struct {
 	Type string `json:"type,omitempty"`
@@ -21,29 +111,161 @@ type ScheduledAction struct {
 	ToUrgency string      `json:"to_urgency"`
 }
 
+// Validate returns an error if the scheduled action isn't a valid urgency
+// change at the start or end of support hours.
+func (a ScheduledAction) Validate() error {
+	if a.Type != ScheduledActionTypeUrgencyChange {
+		return fmt.Errorf("scheduled action type %q is not supported, should be %q", a.Type, ScheduledActionTypeUrgencyChange)
+	}
+
+	if a.At.Type != InlineModelTypeNamedTime {
+		return fmt.Errorf("scheduled action at.type %q is not supported, should be %q", a.At.Type, InlineModelTypeNamedTime)
+	}
+
+	if a.At.Name != InlineModelNameSupportHoursStart && a.At.Name != InlineModelNameSupportHoursEnd {
+		return fmt.Errorf("scheduled action at.name %q should be %q or %q", a.At.Name, InlineModelNameSupportHoursStart, InlineModelNameSupportHoursEnd)
+	}
+
+	if a.ToUrgency != UrgencyHigh {
+		return fmt.Errorf("scheduled action to_urgency %q is not supported, should be %q", a.ToUrgency, UrgencyHigh)
+	}
+
+	return nil
+}
+
 // IncidentUrgencyType are the incidents urgency during or outside support hours.
 type IncidentUrgencyType struct {
-	Type    string `json:"type,omitempty"`
-	Urgency string `json:"urgency,omitempty"`
+	Type    string  `json:"type,omitempty"`
+	Urgency Urgency `json:"urgency,omitempty"`
 }
 
 // SupportHours are the support hours for the service.
 type SupportHours struct {
-	Type       string `json:"type,omitempty"`
-	Timezone   string `json:"time_zone,omitempty"`
-	StartTime  string `json:"start_time,omitempty"`
-	EndTime    string `json:"end_time,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Timezone  string `json:"time_zone,omitempty"`
+	StartTime string `json:"start_time,omitempty"`
+	EndTime   string `json:"end_time,omitempty"`
+
+	// DaysOfWeek are the days support hours apply to, where 1 is Monday and
+	// 7 is Sunday. Use SupportHoursDays to convert from time.Weekday values.
 	DaysOfWeek []uint `json:"days_of_week,omitempty"`
 }
 
+// SupportHoursDays returns the SupportHours.DaysOfWeek values of days.
+func SupportHoursDays(days ...time.Weekday) []uint {
+	d := make([]uint, len(days))
+
+	for i, day := range days {
+		if day == time.Sunday {
+			d[i] = 7
+			continue
+		}
+
+		d[i] = uint(day)
+	}
+
+	return d
+}
+
+// supportHoursTimeLayout is the layout of the SupportHours time fields.
+const supportHoursTimeLayout = "15:04:05"
+
+// Validate returns an error if the support hours are invalid, such as when
+// the time zone is unknown or the end time isn't after the start time.
+func (h SupportHours) Validate() error {
+	if h.Type != SupportHoursTypeFixedTimePerDay {
+		return fmt.Errorf("support hours type %q is not supported, should be %q", h.Type, SupportHoursTypeFixedTimePerDay)
+	}
+
+	if len(h.Timezone) == 0 {
+		return errors.New("support hours time_zone must be set")
+	}
+
+	if _, err := time.LoadLocation(h.Timezone); err != nil {
+		return fmt.Errorf("support hours time_zone %q is invalid: %w", h.Timezone, err)
+	}
+
+	start, err := time.Parse(supportHoursTimeLayout, h.StartTime)
+	if err != nil {
+		return fmt.Errorf("support hours start_time %q should be formatted as HH:MM:SS", h.StartTime)
+	}
+
+	end, err := time.Parse(supportHoursTimeLayout, h.EndTime)
+	if err != nil {
+		return fmt.Errorf("support hours end_time %q should be formatted as HH:MM:SS", h.EndTime)
+	}
+
+	if !end.After(start) {
+		return fmt.Errorf("support hours end_time %s should be after start_time %s", h.EndTime, h.StartTime)
+	}
+
+	if len(h.DaysOfWeek) == 0 {
+		return errors.New("support hours days_of_week must not be empty")
+	}
+
+	for _, d := range h.DaysOfWeek {
+		if d < 1 || d > 7 {
+			return fmt.Errorf("support hours day of week %d should be between 1 (Monday) and 7 (Sunday)", d)
+		}
+	}
+
+	return nil
+}
+
 // IncidentUrgencyRule is the default urgency for new incidents.
 type IncidentUrgencyRule struct {
 	Type                string               `json:"type,omitempty"`
-	Urgency             string               `json:"urgency,omitempty"`
+	Urgency             Urgency              `json:"urgency,omitempty"`
 	DuringSupportHours  *IncidentUrgencyType `json:"during_support_hours,omitempty"`
 	OutsideSupportHours *IncidentUrgencyType `json:"outside_support_hours,omitempty"`
 }
 
+// Validate returns an error if the urgency rule is inconsistent, such as when
+// a rule using support hours doesn't set the urgency during and outside of
+// them.
+func (r IncidentUrgencyRule) Validate() error {
+	switch r.Type {
+	case IncidentUrgencyRuleTypeConstant, IncidentUrgencyRuleTypeSeverityBased:
+		if r.DuringSupportHours != nil || r.OutsideSupportHours != nil {
+			return fmt.Errorf("incident urgency rule of type %q must not set support hours urgencies", r.Type)
+		}
+
+		if r.Type == IncidentUrgencyRuleTypeConstant {
+			return validateUrgency(r.Urgency)
+		}
+
+	case IncidentUrgencyRuleTypeUseSupportHours:
+		if r.DuringSupportHours == nil || r.OutsideSupportHours == nil {
+			return fmt.Errorf("incident urgency rule of type %q must set during_support_hours and outside_support_hours", r.Type)
+		}
+
+		for _, t := range []*IncidentUrgencyType{r.DuringSupportHours, r.OutsideSupportHours} {
+			if t.Type != IncidentUrgencyRuleTypeConstant && t.Type != IncidentUrgencyRuleTypeSeverityBased {
+				return fmt.Errorf("support hours urgency type %q is not supported", t.Type)
+			}
+
+			if t.Type == IncidentUrgencyRuleTypeConstant {
+				if err := validateUrgency(t.Urgency); err != nil {
+					return err
+				}
+			}
+		}
+
+	default:
+		return fmt.Errorf("incident urgency rule type %q is not supported", r.Type)
+	}
+
+	return nil
+}
+
+func validateUrgency(u Urgency) error {
+	if !u.IsValid() {
+		return fmt.Errorf("urgency %q should be %q or %q", u, UrgencyHigh, UrgencyLow)
+	}
+
+	return nil
+}
+
 // ListServiceRulesResponse represents a list of rules in a service
 type ListServiceRulesResponse struct {
 	Offset uint          `json:"offset,omitempty"`
@@ -59,6 +281,7 @@ type ServiceRule struct {
 	Self       string              `json:"self,omitempty"`
 	Disabled   *bool               `json:"disabled,omitempty"`
 	Conditions *RuleConditions     `json:"conditions,omitempty"`
+	Variables  []*RuleVariable     `json:"variables,omitempty"`
 	TimeFrame  *RuleTimeFrame      `json:"time_frame,omitempty"`
 	Position   *int                `json:"position,omitempty"`
 	Actions    *ServiceRuleActions `json:"actions,omitempty"`
@@ -103,9 +326,77 @@ type Service struct {
 // AutoPauseNotificationsParameters defines how alerts on the service will be automatically paused
 type AutoPauseNotificationsParameters struct {
 	Enabled bool `json:"enabled"`
+
+	// Timeout is how long, in seconds, notifications are paused for. It must
+	// be one of AutoPauseNotificationsTimeouts when Enabled is true.
 	Timeout uint `json:"timeout,omitempty"`
 }
 
+// Validate returns an error if auto-pause is enabled with a timeout PagerDuty
+// doesn't accept.
+func (p AutoPauseNotificationsParameters) Validate() error {
+	if !p.Enabled {
+		return nil
+	}
+
+	for _, t := range AutoPauseNotificationsTimeouts {
+		if p.Timeout == t {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("auto-pause notifications timeout %d should be one of %v", p.Timeout, AutoPauseNotificationsTimeouts)
+}
+
+// ValidateConfiguration validates the support hours, scheduled actions,
+// incident urgency rule, and auto-pause parameters of the service, as well as
+// the consistency between them, such as scheduled actions requiring support
+// hours. It's not called by CreateServiceWithContext or
+// UpdateServiceWithContext, to leave the final say to the API.
+func (s Service) ValidateConfiguration() error {
+	if s.SupportHours != nil {
+		if err := s.SupportHours.Validate(); err != nil {
+			return err
+		}
+	}
+
+	usesSupportHours := s.IncidentUrgencyRule != nil && s.IncidentUrgencyRule.Type == IncidentUrgencyRuleTypeUseSupportHours
+
+	if s.IncidentUrgencyRule != nil {
+		if err := s.IncidentUrgencyRule.Validate(); err != nil {
+			return err
+		}
+
+		if usesSupportHours && s.SupportHours == nil {
+			return errors.New("incident urgency rule uses support hours, but support_hours is not set")
+		}
+	}
+
+	for _, a := range s.ScheduledActions {
+		if !usesSupportHours {
+			return errors.New("scheduled actions require an incident urgency rule using support hours")
+		}
+
+		if err := a.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if s.AutoPauseNotificationsParameters != nil {
+		if err := s.AutoPauseNotificationsParameters.Validate(); err != nil {
+			return err
+		}
+	}
+
+	switch s.AlertCreation {
+	case "", AlertCreationCreateIncidents, AlertCreationCreateAlertsAndIncidents:
+	default:
+		return fmt.Errorf("alert_creation %q is not supported", s.AlertCreation)
+	}
+
+	return nil
+}
+
 // AlertGroupingParameters defines how alerts on the service will be automatically grouped into incidents
 type AlertGroupingParameters struct {
 	Type   string                  `json:"type,omitempty"`
@@ -150,7 +441,7 @@ type ListServiceOptions struct {
 // ListServiceResponse is the data structure returned from calling the ListServices API endpoint.
 type ListServiceResponse struct {
 	APIListObject
-	Services []Service
+	Services []Service `json:"services"`
 }
 
 // ListServices lists existing services.
@@ -201,6 +492,7 @@ func (c *Client) ListServicesPaginated(ctx context.Context, o ListServiceOptions
 			More:   result.More,
 			Offset: result.Offset,
 			Limit:  result.Limit,
+			Total:  result.Total,
 		}, nil
 	}
 
@@ -211,6 +503,64 @@ func (c *Client) ListServicesPaginated(ctx context.Context, o ListServiceOptions
 	return services, nil
 }
 
+// FindServiceByNameOptions is the data structure used when calling the
+// FindServiceByName API helper.
+type FindServiceByNameOptions struct {
+	// CaseInsensitive makes the name match services regardless of case, if
+	// no service has exactly the name.
+	CaseInsensitive bool
+
+	// TeamIDs restricts the lookup to the services of the teams.
+	TeamIDs []string
+}
+
+// FindServiceByName returns the service with the given name. It returns a
+// MatchError matching ErrNotFound, with the names of services with similar
+// names, if there's no such service, and a MatchError matching
+// ErrAmbiguousMatch if several services match case-insensitively.
+func (c *Client) FindServiceByName(ctx context.Context, name string, o FindServiceByNameOptions) (*Service, error) {
+	services, err := c.ListServicesPaginated(ctx, ListServiceOptions{
+		Query:   name,
+		TeamIDs: o.TeamIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var folded []Service
+
+	for i, s := range services {
+		if s.Name == name {
+			return &services[i], nil
+		}
+
+		if o.CaseInsensitive && strings.EqualFold(s.Name, name) {
+			folded = append(folded, s)
+		}
+	}
+
+	if len(folded) == 1 {
+		return &folded[0], nil
+	}
+
+	e := MatchError{Kind: "service", Name: name, Ambiguous: len(folded) > 1}
+
+	matches := services
+	if e.Ambiguous {
+		matches = folded
+	}
+
+	for _, s := range matches {
+		if len(e.Matches) == maxNearMatches && !e.Ambiguous {
+			break
+		}
+
+		e.Matches = append(e.Matches, s.Name)
+	}
+
+	return nil, e
+}
+
 // GetServiceOptions is the data structure used when calling the GetService API endpoint.
 type GetServiceOptions struct {
 	Includes []string `url:"include,brackets,omitempty"`
@@ -302,6 +652,7 @@ func (c *Client) ListServiceRulesPaginated(ctx context.Context, serviceID string
 			More:   result.More,
 			Offset: result.Offset,
 			Limit:  result.Limit,
+			Total:  result.Total,
 		}, nil
 	}
 
@@ -330,7 +681,7 @@ func (c *Client) CreateServiceRule(ctx context.Context, serviceID string, rule S
 	d := map[string]ServiceRule{
 		"rule": rule,
 	}
-	resp, err := c.post(ctx, "/services/"+serviceID+"/rules/", d, nil)
+	resp, err := c.post(ctx, "/services/"+serviceID+"/rules", d, nil)
 	return getServiceRuleFromResponse(c, resp, err)
 }
 
@@ -344,61 +695,28 @@ func (c *Client) UpdateServiceRule(ctx context.Context, serviceID, ruleID string
 }
 
 func getServiceRuleFromResponse(c *Client, resp *http.Response, err error) (ServiceRule, error) {
-	if err != nil {
+	var t ServiceRule
+	if err := decodeEnvelope(c, resp, err, "rule", &t); err != nil {
 		return ServiceRule{}, err
 	}
 
-	var target map[string]ServiceRule
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return ServiceRule{}, fmt.Errorf("Could not decode JSON response: %v", dErr)
-	}
-
-	const rootNode = "rule"
-
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return ServiceRule{}, fmt.Errorf("JSON response does not have %s field", rootNode)
-	}
-
 	return t, nil
 }
 
 func getServiceFromResponse(c *Client, resp *http.Response, err error) (*Service, error) {
-	if err != nil {
+	var t Service
+	if err := decodeEnvelope(c, resp, err, "service", &t); err != nil {
 		return nil, err
 	}
 
-	var target map[string]Service
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
-	}
-
-	const rootNode = "service"
-
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
-	}
-
 	return &t, nil
 }
 
 func getIntegrationFromResponse(c *Client, resp *http.Response, err error) (*Integration, error) {
-	if err != nil {
+	var t Integration
+	if err := decodeEnvelope(c, resp, err, "integration", &t); err != nil {
 		return nil, err
 	}
 
-	var target map[string]Integration
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("Could not decode JSON response: %v", err)
-	}
-
-	const rootNode = "integration"
-
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
-	}
-
 	return &t, nil
 }
@@ -2,10 +2,13 @@ package pagerduty
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"testing"
+	"time"
 )
 
 // ListServices
@@ -234,6 +237,65 @@ func TestService_CreateWithAlertGroupParamsContentBased(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+// Create Service with an urgency rule based on support hours
+func TestService_CreateWithSupportHoursUrgencyRule(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]Service
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		s := body["service"]
+		testEqual(t, "PEP1", s.EscalationPolicy.ID)
+		testEqual(t, IncidentUrgencyRuleTypeUseSupportHours, s.IncidentUrgencyRule.Type)
+		testEqual(t, Urgency(UrgencyHigh), s.IncidentUrgencyRule.DuringSupportHours.Urgency)
+		testEqual(t, Urgency(UrgencyLow), s.IncidentUrgencyRule.OutsideSupportHours.Urgency)
+		testEqual(t, []uint{1, 2, 3, 4, 5}, s.SupportHours.DaysOfWeek)
+
+		_, _ = w.Write([]byte(`{"service": {"id": "1","name":"foo","status":"active","alert_creation":"create_alerts_and_incidents"}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+	input := Service{
+		Name:             "foo",
+		EscalationPolicy: EscalationPolicy{APIObject: APIObject{ID: "PEP1", Type: "escalation_policy_reference"}},
+		AlertCreation:    AlertCreationCreateAlertsAndIncidents,
+		IncidentUrgencyRule: &IncidentUrgencyRule{
+			Type:                IncidentUrgencyRuleTypeUseSupportHours,
+			DuringSupportHours:  &IncidentUrgencyType{Type: IncidentUrgencyRuleTypeConstant, Urgency: UrgencyHigh},
+			OutsideSupportHours: &IncidentUrgencyType{Type: IncidentUrgencyRuleTypeConstant, Urgency: UrgencyLow},
+		},
+		SupportHours: &SupportHours{
+			Type:       "fixed_time_per_day",
+			Timezone:   "America/Lima",
+			StartTime:  "09:00:00",
+			EndTime:    "17:00:00",
+			DaysOfWeek: []uint{1, 2, 3, 4, 5},
+		},
+	}
+
+	res, err := client.CreateServiceWithContext(context.Background(), input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Service{
+		APIObject: APIObject{
+			ID: "1",
+		},
+		Name:          "foo",
+		Status:        ServiceStatusActive,
+		AlertCreation: AlertCreationCreateAlertsAndIncidents,
+	}
+
+	testEqual(t, want, res)
+}
+
 // Create Service with AlertGroupingParameters of type intelligent
 func TestService_CreateWithAlertGroupParamsIntelligent(t *testing.T) {
 	setup()
@@ -376,15 +438,49 @@ func TestService_CreateServiceRule(t *testing.T) {
 	setup()
 	defer teardown()
 
-	mux.HandleFunc("/services/1/rules/", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc("/services/1/rules", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "POST")
+
+		var body map[string]ServiceRule
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		rule := body["rule"]
+		testEqual(t, "host", rule.Variables[0].Name)
+		testEqual(t, "Host: {{host}}", rule.Actions.Extractions[0].Template)
+		testEqual(t, "contains", rule.Conditions.RuleSubconditions[0].Operator)
+
 		_, _ = w.Write([]byte(`{"rule": {"id": "1"}}`))
 	})
 
 	client := defaultTestClient(server.URL, "foo")
 
 	serviceID := "1"
-	rule := ServiceRule{}
+	rule := ServiceRule{
+		Conditions: &RuleConditions{
+			Operator: "and",
+			RuleSubconditions: []*RuleSubcondition{
+				{
+					Operator:   "contains",
+					Parameters: &ConditionParameter{Path: "summary", Value: "disk"},
+				},
+			},
+		},
+		Variables: []*RuleVariable{
+			{
+				Type:       "regex",
+				Name:       "host",
+				Parameters: &RuleVariableParameters{Path: "source", Value: "(.*)"},
+			},
+		},
+		Actions: &ServiceRuleActions{
+			Severity: &RuleActionParameter{Value: "warning"},
+			Extractions: []RuleActionExtraction{
+				{Target: "summary", Template: "Host: {{host}}"},
+			},
+		},
+	}
 
 	res, err := client.CreateServiceRule(context.Background(), serviceID, rule)
 	if err != nil {
@@ -467,3 +563,174 @@ func TestService_DeleteServiceRule(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestService_ValidateConfiguration(t *testing.T) {
+	supportHours := &SupportHours{
+		Type:       SupportHoursTypeFixedTimePerDay,
+		Timezone:   "America/Lima",
+		StartTime:  "09:00:00",
+		EndTime:    "17:00:00",
+		DaysOfWeek: SupportHoursDays(time.Monday, time.Friday),
+	}
+
+	urgencyRule := &IncidentUrgencyRule{
+		Type:                IncidentUrgencyRuleTypeUseSupportHours,
+		DuringSupportHours:  &IncidentUrgencyType{Type: IncidentUrgencyRuleTypeConstant, Urgency: UrgencyHigh},
+		OutsideSupportHours: &IncidentUrgencyType{Type: IncidentUrgencyRuleTypeConstant, Urgency: UrgencyLow},
+	}
+
+	scheduledAction := ScheduledAction{
+		Type:      ScheduledActionTypeUrgencyChange,
+		At:        InlineModel{Type: InlineModelTypeNamedTime, Name: InlineModelNameSupportHoursStart},
+		ToUrgency: UrgencyHigh,
+	}
+
+	tests := []struct {
+		name    string
+		s       Service
+		wantErr string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "support_hours",
+			s: Service{
+				SupportHours:        supportHours,
+				IncidentUrgencyRule: urgencyRule,
+				ScheduledActions:    []ScheduledAction{scheduledAction},
+				AlertCreation:       AlertCreationCreateAlertsAndIncidents,
+				AutoPauseNotificationsParameters: &AutoPauseNotificationsParameters{
+					Enabled: true,
+					Timeout: 300,
+				},
+			},
+		},
+		{
+			name: "missing_support_hours",
+			s: Service{
+				IncidentUrgencyRule: urgencyRule,
+			},
+			wantErr: "support_hours is not set",
+		},
+		{
+			name: "scheduled_actions_without_support_hours_rule",
+			s: Service{
+				IncidentUrgencyRule: &IncidentUrgencyRule{Type: IncidentUrgencyRuleTypeConstant, Urgency: UrgencyHigh},
+				ScheduledActions:    []ScheduledAction{scheduledAction},
+			},
+			wantErr: "scheduled actions require",
+		},
+		{
+			name: "bad_support_hours_window",
+			s: Service{
+				SupportHours: &SupportHours{
+					Type:       SupportHoursTypeFixedTimePerDay,
+					Timezone:   "UTC",
+					StartTime:  "17:00:00",
+					EndTime:    "09:00:00",
+					DaysOfWeek: []uint{1},
+				},
+			},
+			wantErr: "should be after start_time",
+		},
+		{
+			name: "bad_timezone",
+			s: Service{
+				SupportHours: &SupportHours{
+					Type:       SupportHoursTypeFixedTimePerDay,
+					Timezone:   "Mars/Olympus_Mons",
+					StartTime:  "09:00:00",
+					EndTime:    "17:00:00",
+					DaysOfWeek: []uint{1},
+				},
+			},
+			wantErr: "time_zone",
+		},
+		{
+			name: "bad_urgency",
+			s: Service{
+				IncidentUrgencyRule: &IncidentUrgencyRule{Type: IncidentUrgencyRuleTypeConstant, Urgency: "medium"},
+			},
+			wantErr: `urgency "medium"`,
+		},
+		{
+			name: "bad_auto_pause_timeout",
+			s: Service{
+				AutoPauseNotificationsParameters: &AutoPauseNotificationsParameters{Enabled: true, Timeout: 60},
+			},
+			wantErr: "auto-pause notifications timeout 60",
+		},
+		{
+			name: "bad_alert_creation",
+			s: Service{
+				AlertCreation: "create_everything",
+			},
+			wantErr: "alert_creation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testErrCheck(t, "s.ValidateConfiguration()", tt.wantErr, tt.s.ValidateConfiguration())
+		})
+	}
+}
+
+func TestSupportHoursDays(t *testing.T) {
+	testEqual(t, []uint{1, 6, 7}, SupportHoursDays(time.Monday, time.Saturday, time.Sunday))
+}
+
+func TestUrgency_IsValid(t *testing.T) {
+	testEqual(t, true, Urgency(UrgencyHigh).IsValid())
+	testEqual(t, true, Urgency("low").IsValid())
+	testEqual(t, false, Urgency("hgih").IsValid())
+}
+
+func TestService_FindServiceByName(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		switch r.URL.Query().Get("query") {
+		case "api":
+			_, _ = w.Write([]byte(`{"services": [{"id": "PS1", "name": "api-gateway"}, {"id": "PS2", "name": "API"}, {"id": "PS3", "name": "api"}]}`))
+		case "Api":
+			_, _ = w.Write([]byte(`{"services": [{"id": "PS2", "name": "API"}, {"id": "PS3", "name": "api"}]}`))
+		case "web":
+			_, _ = w.Write([]byte(`{"services": [{"id": "PS4", "name": "WEB"}, {"id": "PS5", "name": "web-frontend"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"services": []}`))
+		}
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+	ctx := context.Background()
+
+	s, err := client.FindServiceByName(ctx, "api", FindServiceByNameOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "PS3", s.ID)
+
+	s, err = client.FindServiceByName(ctx, "web", FindServiceByNameOptions{CaseInsensitive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "PS4", s.ID)
+
+	_, err = client.FindServiceByName(ctx, "web", FindServiceByNameOptions{})
+	testErrCheck(t, "FindServiceByName()", `service "web" not found, near matches: "WEB", "web-frontend"`, err)
+	testEqual(t, true, errors.Is(err, ErrNotFound))
+
+	_, err = client.FindServiceByName(ctx, "Api", FindServiceByNameOptions{CaseInsensitive: true})
+	testErrCheck(t, "FindServiceByName()", `service "Api" is ambiguous, matches: "API", "api"`, err)
+	testEqual(t, true, errors.Is(err, ErrAmbiguousMatch))
+
+	_, err = client.FindServiceByName(ctx, "db", FindServiceByNameOptions{})
+	testErrCheck(t, "FindServiceByName()", `service "db" not found`, err)
+}
@@ -0,0 +1,181 @@
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/go-querystring/query"
+)
+
+// SlackConnection connects a PagerDuty service or team to a Slack channel, to
+// which notifications of its incidents are sent.
+type SlackConnection struct {
+	ID string `json:"id,omitempty"`
+
+	// SourceID is the ID of the service or team, and SourceType its type.
+	SourceID   string `json:"source_id"`
+	SourceName string `json:"source_name,omitempty"`
+	SourceType string `json:"source_type"`
+
+	ChannelID   string `json:"channel_id"`
+	ChannelName string `json:"channel_name,omitempty"`
+
+	NotificationType string                `json:"notification_type"`
+	Config           SlackConnectionConfig `json:"config"`
+}
+
+// SlackConnectionConfig selects the incidents notified by a SlackConnection.
+type SlackConnectionConfig struct {
+	// Events are the types of the incident events notified, such as
+	// "incident.triggered".
+	Events []string `json:"events"`
+
+	// Priorities are the IDs of the priorities of the incidents notified, or
+	// "*" for all of them. Incidents without a priority are always notified.
+	Priorities []string `json:"priorities,omitempty"`
+
+	// Urgency is the urgency of the incidents notified, "high" or "low", or
+	// nil for both.
+	Urgency *string `json:"urgency"`
+}
+
+// Values of the SourceType field of SlackConnection.
+const (
+	SlackConnectionSourceTypeService = "service_reference"
+	SlackConnectionSourceTypeTeam    = "team_reference"
+)
+
+// Values of the NotificationType field of SlackConnection.
+const (
+	SlackConnectionNotificationTypeResponder   = "responder"
+	SlackConnectionNotificationTypeStakeholder = "stakeholder"
+)
+
+// ListSlackConnectionsOptions is the data structure used when calling the
+// ListSlackConnections API endpoint.
+type ListSlackConnectionsOptions struct {
+	// Limit is the pagination parameter that limits the number of results per
+	// page. PagerDuty defaults this value to 25 if omitted, and sets an upper
+	// bound of 100.
+	Limit uint `url:"limit,omitempty"`
+
+	// Offset is the pagination parameter that specifies the offset at which to
+	// start pagination results. When trying to request the next page of
+	// results, the new Offset value should be currentOffset + Limit.
+	Offset uint `url:"offset,omitempty"`
+}
+
+// ListSlackConnectionsResponse is the data structure returned from calling
+// the ListSlackConnections API endpoint.
+type ListSlackConnectionsResponse struct {
+	APIListObject
+	SlackConnections []SlackConnection `json:"slack_connections"`
+}
+
+// slackConnectionsPath returns the path of the Slack connections of a Slack
+// workspace, whose ID is the Slack team ID, such as "T0123ABCD".
+func slackConnectionsPath(workspaceID string) string {
+	return "/workspaces/" + workspaceID + "/connections"
+}
+
+// ListSlackConnectionsWithContext lists the connections between PagerDuty
+// services or teams and the channels of a Slack workspace.
+func (c *Client) ListSlackConnectionsWithContext(ctx context.Context, workspaceID string, o ListSlackConnectionsOptions) (*ListSlackConnectionsResponse, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.slackDo(ctx, http.MethodGet, slackConnectionsPath(workspaceID)+"?"+v.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListSlackConnectionsResponse
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListSlackConnectionsPaginated lists the connections between PagerDuty
+// services or teams and the channels of a Slack workspace, handling
+// pagination of the results.
+func (c *Client) ListSlackConnectionsPaginated(ctx context.Context, workspaceID string) ([]SlackConnection, error) {
+	var connections []SlackConnection
+
+	o := ListSlackConnectionsOptions{}
+
+	for {
+		res, err := c.ListSlackConnectionsWithContext(ctx, workspaceID, o)
+		if err != nil {
+			return nil, err
+		}
+
+		connections = append(connections, res.SlackConnections...)
+
+		if !res.More || len(res.SlackConnections) == 0 {
+			return connections, nil
+		}
+
+		o.Offset = res.Offset + uint(len(res.SlackConnections))
+	}
+}
+
+// CreateSlackConnectionWithContext connects a PagerDuty service or team to a
+// channel of a Slack workspace.
+func (c *Client) CreateSlackConnectionWithContext(ctx context.Context, workspaceID string, s SlackConnection) (*SlackConnection, error) {
+	d := map[string]SlackConnection{
+		"slack_connection": s,
+	}
+
+	resp, err := c.slackDo(ctx, http.MethodPost, slackConnectionsPath(workspaceID), d)
+	return getSlackConnectionFromResponse(c, resp, err)
+}
+
+// GetSlackConnectionWithContext gets a connection of a Slack workspace.
+func (c *Client) GetSlackConnectionWithContext(ctx context.Context, workspaceID, id string) (*SlackConnection, error) {
+	resp, err := c.slackDo(ctx, http.MethodGet, slackConnectionsPath(workspaceID)+"/"+id, nil)
+	return getSlackConnectionFromResponse(c, resp, err)
+}
+
+// UpdateSlackConnectionWithContext updates a connection of a Slack workspace.
+func (c *Client) UpdateSlackConnectionWithContext(ctx context.Context, workspaceID, id string, s SlackConnection) (*SlackConnection, error) {
+	d := map[string]SlackConnection{
+		"slack_connection": s,
+	}
+
+	resp, err := c.slackDo(ctx, http.MethodPut, slackConnectionsPath(workspaceID)+"/"+id, d)
+	return getSlackConnectionFromResponse(c, resp, err)
+}
+
+// DeleteSlackConnectionWithContext deletes a connection of a Slack workspace.
+func (c *Client) DeleteSlackConnectionWithContext(ctx context.Context, workspaceID, id string) error {
+	_, err := c.slackDo(ctx, http.MethodDelete, slackConnectionsPath(workspaceID)+"/"+id, nil)
+	return err
+}
+
+// slackDo makes a request to the Slack integration API, which is served from
+// another endpoint than the REST API, with payload encoded in JSON if not nil.
+func (c *Client) slackDo(ctx context.Context, method, path string, payload interface{}) (*http.Response, error) {
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		return c.doWithEndpoint(ctx, c.slackAPIEndpoint, method, path, true, bytes.NewBuffer(data), nil)
+	}
+	return c.doWithEndpoint(ctx, c.slackAPIEndpoint, method, path, true, nil, nil)
+}
+
+func getSlackConnectionFromResponse(c *Client, resp *http.Response, err error) (*SlackConnection, error) {
+	var s SlackConnection
+	if err := decodeEnvelope(c, resp, err, "slack_connection", &s); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
@@ -0,0 +1,144 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestSlackConnection_ListPaginated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/workspaces/T1/connections", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("offset") == "1" {
+			_, _ = w.Write([]byte(`{"slack_connections": [{"id": "C2", "source_id": "PT1", "source_type": "team_reference", "channel_id": "CH2", "notification_type": "stakeholder", "config": {"events": ["incident.resolved"], "urgency": null}}], "offset": 1, "more": false}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"slack_connections": [{"id": "C1", "source_id": "PS1", "source_type": "service_reference", "channel_id": "CH1", "notification_type": "responder", "config": {"events": ["incident.triggered"], "urgency": "high"}}], "offset": 0, "more": true}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListSlackConnectionsPaginated(context.Background(), "T1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	high := "high"
+	want := []SlackConnection{
+		{
+			ID:               "C1",
+			SourceID:         "PS1",
+			SourceType:       SlackConnectionSourceTypeService,
+			ChannelID:        "CH1",
+			NotificationType: SlackConnectionNotificationTypeResponder,
+			Config:           SlackConnectionConfig{Events: []string{"incident.triggered"}, Urgency: &high},
+		},
+		{
+			ID:               "C2",
+			SourceID:         "PT1",
+			SourceType:       SlackConnectionSourceTypeTeam,
+			ChannelID:        "CH2",
+			NotificationType: SlackConnectionNotificationTypeStakeholder,
+			Config:           SlackConnectionConfig{Events: []string{"incident.resolved"}},
+		},
+	}
+	testEqual(t, want, res)
+}
+
+func TestSlackConnection_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/workspaces/T1/connections", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]SlackConnection
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+		testEqual(t, "CH1", body["slack_connection"].ChannelID)
+
+		_, _ = w.Write([]byte(`{"slack_connection": {"id": "C1", "source_id": "PS1", "channel_id": "CH1"}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.CreateSlackConnectionWithContext(context.Background(), "T1", SlackConnection{
+		SourceID:         "PS1",
+		SourceType:       SlackConnectionSourceTypeService,
+		ChannelID:        "CH1",
+		NotificationType: SlackConnectionNotificationTypeResponder,
+		Config:           SlackConnectionConfig{Events: []string{"incident.triggered"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "C1", res.ID)
+}
+
+func TestSlackConnection_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/workspaces/T1/connections/C1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"slack_connection": {"id": "C1", "channel_name": "ops"}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.GetSlackConnectionWithContext(context.Background(), "T1", "C1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "ops", res.ChannelName)
+}
+
+func TestSlackConnection_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/workspaces/T1/connections/C1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var body map[string]SlackConnection
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+		testEqual(t, []string{"P1"}, body["slack_connection"].Config.Priorities)
+
+		_, _ = w.Write([]byte(`{"slack_connection": {"id": "C1", "config": {"events": [], "priorities": ["P1"]}}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.UpdateSlackConnectionWithContext(context.Background(), "T1", "C1", SlackConnection{
+		Config: SlackConnectionConfig{Priorities: []string{"P1"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, []string{"P1"}, res.Config.Priorities)
+}
+
+func TestSlackConnection_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/workspaces/T1/connections/C1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	err := client.DeleteSlackConnectionWithContext(context.Background(), "T1", "C1")
+	testErrCheck(t, "DeleteSlackConnectionWithContext()", "", err)
+}
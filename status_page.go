@@ -0,0 +1,537 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/go-querystring/query"
+)
+
+// StatusPage is a public or private status page of the account.
+type StatusPage struct {
+	ID             string  `json:"id,omitempty"`
+	Type           string  `json:"type,omitempty"`
+	Name           string  `json:"name,omitempty"`
+	PublishedAt    APITime `json:"published_at,omitempty"`
+	StatusPageType string  `json:"status_page_type,omitempty"`
+	URL            string  `json:"url,omitempty"`
+}
+
+// Values of the StatusPageType field of StatusPage.
+const (
+	StatusPageTypePublic  = "public"
+	StatusPageTypePrivate = "private"
+)
+
+// StatusPageImpact is an impact of posts on the services of a status page,
+// such as "Major outage".
+type StatusPageImpact struct {
+	ID          string        `json:"id,omitempty"`
+	Type        string        `json:"type,omitempty"`
+	Self        string        `json:"self,omitempty"`
+	Description string        `json:"description,omitempty"`
+	PostType    string        `json:"post_type,omitempty"`
+	StatusPage  *APIReference `json:"status_page,omitempty"`
+}
+
+// StatusPageSeverity is a severity of the posts of a status page.
+type StatusPageSeverity struct {
+	ID          string        `json:"id,omitempty"`
+	Type        string        `json:"type,omitempty"`
+	Self        string        `json:"self,omitempty"`
+	Description string        `json:"description,omitempty"`
+	PostType    string        `json:"post_type,omitempty"`
+	StatusPage  *APIReference `json:"status_page,omitempty"`
+}
+
+// StatusPageStatus is a status of the posts of a status page, such as
+// "Investigating" or "Resolved".
+type StatusPageStatus struct {
+	ID          string        `json:"id,omitempty"`
+	Type        string        `json:"type,omitempty"`
+	Self        string        `json:"self,omitempty"`
+	Description string        `json:"description,omitempty"`
+	PostType    string        `json:"post_type,omitempty"`
+	StatusPage  *APIReference `json:"status_page,omitempty"`
+}
+
+// StatusPageService is a service shown on a status page.
+type StatusPageService struct {
+	ID              string        `json:"id,omitempty"`
+	Type            string        `json:"type,omitempty"`
+	Self            string        `json:"self,omitempty"`
+	Name            string        `json:"name,omitempty"`
+	StatusPage      *APIReference `json:"status_page,omitempty"`
+	BusinessService *APIReference `json:"business_service,omitempty"`
+}
+
+// StatusPagePost is an incident or a maintenance posted on a status page.
+type StatusPagePost struct {
+	ID         string        `json:"id,omitempty"`
+	Type       string        `json:"type,omitempty"`
+	Self       string        `json:"self,omitempty"`
+	PostType   string        `json:"post_type,omitempty"`
+	StatusPage *APIReference `json:"status_page,omitempty"`
+	Title      string        `json:"title,omitempty"`
+
+	// StartsAt and EndsAt are the window of maintenance posts.
+	StartsAt APITime `json:"starts_at,omitempty"`
+	EndsAt   APITime `json:"ends_at,omitempty"`
+
+	// Updates are the first updates of the post, when creating it.
+	Updates []StatusPagePostUpdate `json:"updates,omitempty"`
+}
+
+// Values of the PostType field of StatusPagePost, and of the impacts,
+// severities and statuses of a status page.
+const (
+	StatusPagePostTypeIncident    = "incident"
+	StatusPagePostTypeMaintenance = "maintenance"
+)
+
+// StatusPagePostUpdate is an update of a status page post.
+type StatusPagePostUpdate struct {
+	ID                string                          `json:"id,omitempty"`
+	Type              string                          `json:"type,omitempty"`
+	Self              string                          `json:"self,omitempty"`
+	Post              *APIReference                   `json:"post,omitempty"`
+	Message           string                          `json:"message,omitempty"`
+	ReviewedStatus    string                          `json:"reviewed_status,omitempty"`
+	Status            *APIReference                   `json:"status,omitempty"`
+	Severity          *APIReference                   `json:"severity,omitempty"`
+	ImpactedServices  []StatusPagePostImpactedService `json:"impacted_services,omitempty"`
+	UpdateFrequencyMS *uint                           `json:"update_frequency_ms,omitempty"`
+	NotifySubscribers bool                            `json:"notify_subscribers,omitempty"`
+	ReportedAt        APITime                         `json:"reported_at,omitempty"`
+}
+
+// Values of the ReviewedStatus field of StatusPagePostUpdate.
+const (
+	StatusPageReviewedStatusApproved    = "approved"
+	StatusPageReviewedStatusNotReviewed = "not_reviewed"
+)
+
+// StatusPagePostImpactedService is the impact of a post update on a service of
+// the status page.
+type StatusPagePostImpactedService struct {
+	Service *APIReference `json:"service"`
+	Impact  *APIReference `json:"impact"`
+}
+
+// StatusPageSubscription is a subscription to the posts of a status page.
+type StatusPageSubscription struct {
+	ID                 string        `json:"id,omitempty"`
+	Type               string        `json:"type,omitempty"`
+	Self               string        `json:"self,omitempty"`
+	Channel            string        `json:"channel,omitempty"`
+	Contact            string        `json:"contact,omitempty"`
+	Status             string        `json:"status,omitempty"`
+	StatusPage         *APIReference `json:"status_page,omitempty"`
+	SubscribableObject *APIReference `json:"subscribable_object,omitempty"`
+}
+
+// Values of the Channel field of StatusPageSubscription.
+const (
+	StatusPageSubscriptionChannelEmail   = "email"
+	StatusPageSubscriptionChannelWebhook = "webhook"
+	StatusPageSubscriptionChannelSlack   = "slack"
+)
+
+// ListStatusPagesOptions is the data structure used when calling the
+// ListStatusPages API endpoint.
+type ListStatusPagesOptions struct {
+	StatusPageType string `url:"status_page_type,omitempty"`
+}
+
+// ListStatusPagesResponse is the data structure returned from calling the
+// ListStatusPages API endpoint.
+type ListStatusPagesResponse struct {
+	APIListObject
+	StatusPages []StatusPage `json:"status_pages"`
+}
+
+// ListStatusPagesWithContext lists the status pages of the account.
+func (c *Client) ListStatusPagesWithContext(ctx context.Context, o ListStatusPagesOptions) (*ListStatusPagesResponse, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListStatusPagesResponse
+	resp, err := c.get(ctx, "/status_pages?"+v.Encode())
+	if err := decodeStatusPageList(c, resp, err, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListStatusPageResourcesOptions is the data structure used when listing the
+// impacts, severities or statuses of a status page.
+type ListStatusPageResourcesOptions struct {
+	PostType string `url:"post_type,omitempty"`
+}
+
+// ListStatusPageImpactsResponse is the data structure returned from calling
+// the ListStatusPageImpacts API endpoint.
+type ListStatusPageImpactsResponse struct {
+	APIListObject
+	Impacts []StatusPageImpact `json:"impacts"`
+}
+
+// ListStatusPageImpactsWithContext lists the impacts of a status page.
+func (c *Client) ListStatusPageImpactsWithContext(ctx context.Context, statusPageID string, o ListStatusPageResourcesOptions) (*ListStatusPageImpactsResponse, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListStatusPageImpactsResponse
+	resp, err := c.get(ctx, "/status_pages/"+statusPageID+"/impacts?"+v.Encode())
+	if err := decodeStatusPageList(c, resp, err, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetStatusPageImpactWithContext gets an impact of a status page.
+func (c *Client) GetStatusPageImpactWithContext(ctx context.Context, statusPageID, id string) (*StatusPageImpact, error) {
+	var result StatusPageImpact
+	resp, err := c.get(ctx, "/status_pages/"+statusPageID+"/impacts/"+id)
+	if err := decodeEnvelope(c, resp, err, "impact", &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListStatusPageSeveritiesResponse is the data structure returned from calling
+// the ListStatusPageSeverities API endpoint.
+type ListStatusPageSeveritiesResponse struct {
+	APIListObject
+	Severities []StatusPageSeverity `json:"severities"`
+}
+
+// ListStatusPageSeveritiesWithContext lists the severities of a status page.
+func (c *Client) ListStatusPageSeveritiesWithContext(ctx context.Context, statusPageID string, o ListStatusPageResourcesOptions) (*ListStatusPageSeveritiesResponse, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListStatusPageSeveritiesResponse
+	resp, err := c.get(ctx, "/status_pages/"+statusPageID+"/severities?"+v.Encode())
+	if err := decodeStatusPageList(c, resp, err, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetStatusPageSeverityWithContext gets a severity of a status page.
+func (c *Client) GetStatusPageSeverityWithContext(ctx context.Context, statusPageID, id string) (*StatusPageSeverity, error) {
+	var result StatusPageSeverity
+	resp, err := c.get(ctx, "/status_pages/"+statusPageID+"/severities/"+id)
+	if err := decodeEnvelope(c, resp, err, "severity", &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListStatusPageStatusesResponse is the data structure returned from calling
+// the ListStatusPageStatuses API endpoint.
+type ListStatusPageStatusesResponse struct {
+	APIListObject
+	Statuses []StatusPageStatus `json:"statuses"`
+}
+
+// ListStatusPageStatusesWithContext lists the statuses of a status page.
+func (c *Client) ListStatusPageStatusesWithContext(ctx context.Context, statusPageID string, o ListStatusPageResourcesOptions) (*ListStatusPageStatusesResponse, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListStatusPageStatusesResponse
+	resp, err := c.get(ctx, "/status_pages/"+statusPageID+"/statuses?"+v.Encode())
+	if err := decodeStatusPageList(c, resp, err, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetStatusPageStatusWithContext gets a status of a status page.
+func (c *Client) GetStatusPageStatusWithContext(ctx context.Context, statusPageID, id string) (*StatusPageStatus, error) {
+	var result StatusPageStatus
+	resp, err := c.get(ctx, "/status_pages/"+statusPageID+"/statuses/"+id)
+	if err := decodeEnvelope(c, resp, err, "status", &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListStatusPageServicesResponse is the data structure returned from calling
+// the ListStatusPageServices API endpoint.
+type ListStatusPageServicesResponse struct {
+	APIListObject
+	Services []StatusPageService `json:"services"`
+}
+
+// ListStatusPageServicesWithContext lists the services of a status page.
+func (c *Client) ListStatusPageServicesWithContext(ctx context.Context, statusPageID string) (*ListStatusPageServicesResponse, error) {
+	var result ListStatusPageServicesResponse
+	resp, err := c.get(ctx, "/status_pages/"+statusPageID+"/services")
+	if err := decodeStatusPageList(c, resp, err, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetStatusPageServiceWithContext gets a service of a status page.
+func (c *Client) GetStatusPageServiceWithContext(ctx context.Context, statusPageID, id string) (*StatusPageService, error) {
+	var result StatusPageService
+	resp, err := c.get(ctx, "/status_pages/"+statusPageID+"/services/"+id)
+	if err := decodeEnvelope(c, resp, err, "service", &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListStatusPagePostsOptions is the data structure used when calling the
+// ListStatusPagePosts API endpoint.
+type ListStatusPagePostsOptions struct {
+	PostType       string   `url:"post_type,omitempty"`
+	ReviewedStatus string   `url:"reviewed_status,omitempty"`
+	Statuses       []string `url:"status,omitempty,brackets"`
+}
+
+// ListStatusPagePostsResponse is the data structure returned from calling the
+// ListStatusPagePosts API endpoint.
+type ListStatusPagePostsResponse struct {
+	APIListObject
+	Posts []StatusPagePost `json:"posts"`
+}
+
+// ListStatusPagePostsWithContext lists the posts of a status page.
+func (c *Client) ListStatusPagePostsWithContext(ctx context.Context, statusPageID string, o ListStatusPagePostsOptions) (*ListStatusPagePostsResponse, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListStatusPagePostsResponse
+	resp, err := c.get(ctx, "/status_pages/"+statusPageID+"/posts?"+v.Encode())
+	if err := decodeStatusPageList(c, resp, err, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateStatusPagePostWithContext creates a post on a status page, along with
+// its first Updates.
+func (c *Client) CreateStatusPagePostWithContext(ctx context.Context, statusPageID string, p StatusPagePost) (*StatusPagePost, error) {
+	d := map[string]StatusPagePost{
+		"post": p,
+	}
+
+	var result StatusPagePost
+	resp, err := c.post(ctx, "/status_pages/"+statusPageID+"/posts", d, nil)
+	if err := decodeEnvelope(c, resp, err, "post", &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetStatusPagePostWithContext gets a post of a status page.
+func (c *Client) GetStatusPagePostWithContext(ctx context.Context, statusPageID, id string) (*StatusPagePost, error) {
+	var result StatusPagePost
+	resp, err := c.get(ctx, "/status_pages/"+statusPageID+"/posts/"+id)
+	if err := decodeEnvelope(c, resp, err, "post", &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// UpdateStatusPagePostWithContext updates a post of a status page.
+func (c *Client) UpdateStatusPagePostWithContext(ctx context.Context, statusPageID, id string, p StatusPagePost) (*StatusPagePost, error) {
+	d := map[string]StatusPagePost{
+		"post": p,
+	}
+
+	var result StatusPagePost
+	resp, err := c.put(ctx, "/status_pages/"+statusPageID+"/posts/"+id, d, nil)
+	if err := decodeEnvelope(c, resp, err, "post", &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DeleteStatusPagePostWithContext deletes a post of a status page.
+func (c *Client) DeleteStatusPagePostWithContext(ctx context.Context, statusPageID, id string) error {
+	_, err := c.delete(ctx, "/status_pages/"+statusPageID+"/posts/"+id)
+	return err
+}
+
+// ListStatusPagePostUpdatesOptions is the data structure used when calling the
+// ListStatusPagePostUpdates API endpoint.
+type ListStatusPagePostUpdatesOptions struct {
+	ReviewedStatus string `url:"reviewed_status,omitempty"`
+}
+
+// ListStatusPagePostUpdatesResponse is the data structure returned from
+// calling the ListStatusPagePostUpdates API endpoint.
+type ListStatusPagePostUpdatesResponse struct {
+	APIListObject
+	PostUpdates []StatusPagePostUpdate `json:"post_updates"`
+}
+
+// ListStatusPagePostUpdatesWithContext lists the updates of a post of a status
+// page.
+func (c *Client) ListStatusPagePostUpdatesWithContext(ctx context.Context, statusPageID, postID string, o ListStatusPagePostUpdatesOptions) (*ListStatusPagePostUpdatesResponse, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListStatusPagePostUpdatesResponse
+	resp, err := c.get(ctx, "/status_pages/"+statusPageID+"/posts/"+postID+"/post_updates?"+v.Encode())
+	if err := decodeStatusPageList(c, resp, err, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateStatusPagePostUpdateWithContext publishes an update of a post of a
+// status page.
+func (c *Client) CreateStatusPagePostUpdateWithContext(ctx context.Context, statusPageID, postID string, u StatusPagePostUpdate) (*StatusPagePostUpdate, error) {
+	d := map[string]StatusPagePostUpdate{
+		"post_update": u,
+	}
+
+	var result StatusPagePostUpdate
+	resp, err := c.post(ctx, "/status_pages/"+statusPageID+"/posts/"+postID+"/post_updates", d, nil)
+	if err := decodeEnvelope(c, resp, err, "post_update", &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetStatusPagePostUpdateWithContext gets an update of a post of a status
+// page.
+func (c *Client) GetStatusPagePostUpdateWithContext(ctx context.Context, statusPageID, postID, id string) (*StatusPagePostUpdate, error) {
+	var result StatusPagePostUpdate
+	resp, err := c.get(ctx, "/status_pages/"+statusPageID+"/posts/"+postID+"/post_updates/"+id)
+	if err := decodeEnvelope(c, resp, err, "post_update", &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// UpdateStatusPagePostUpdateWithContext updates an update of a post of a
+// status page.
+func (c *Client) UpdateStatusPagePostUpdateWithContext(ctx context.Context, statusPageID, postID, id string, u StatusPagePostUpdate) (*StatusPagePostUpdate, error) {
+	d := map[string]StatusPagePostUpdate{
+		"post_update": u,
+	}
+
+	var result StatusPagePostUpdate
+	resp, err := c.put(ctx, "/status_pages/"+statusPageID+"/posts/"+postID+"/post_updates/"+id, d, nil)
+	if err := decodeEnvelope(c, resp, err, "post_update", &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DeleteStatusPagePostUpdateWithContext deletes an update of a post of a
+// status page.
+func (c *Client) DeleteStatusPagePostUpdateWithContext(ctx context.Context, statusPageID, postID, id string) error {
+	_, err := c.delete(ctx, "/status_pages/"+statusPageID+"/posts/"+postID+"/post_updates/"+id)
+	return err
+}
+
+// ListStatusPageSubscriptionsOptions is the data structure used when calling
+// the ListStatusPageSubscriptions API endpoint.
+type ListStatusPageSubscriptionsOptions struct {
+	Channel string `url:"channel,omitempty"`
+	Status  string `url:"status,omitempty"`
+}
+
+// ListStatusPageSubscriptionsResponse is the data structure returned from
+// calling the ListStatusPageSubscriptions API endpoint.
+type ListStatusPageSubscriptionsResponse struct {
+	APIListObject
+	Subscriptions []StatusPageSubscription `json:"subscriptions"`
+}
+
+// ListStatusPageSubscriptionsWithContext lists the subscriptions of a status
+// page.
+func (c *Client) ListStatusPageSubscriptionsWithContext(ctx context.Context, statusPageID string, o ListStatusPageSubscriptionsOptions) (*ListStatusPageSubscriptionsResponse, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListStatusPageSubscriptionsResponse
+	resp, err := c.get(ctx, "/status_pages/"+statusPageID+"/subscriptions?"+v.Encode())
+	if err := decodeStatusPageList(c, resp, err, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateStatusPageSubscriptionWithContext subscribes a contact to a status
+// page.
+func (c *Client) CreateStatusPageSubscriptionWithContext(ctx context.Context, statusPageID string, s StatusPageSubscription) (*StatusPageSubscription, error) {
+	d := map[string]StatusPageSubscription{
+		"subscription": s,
+	}
+
+	var result StatusPageSubscription
+	resp, err := c.post(ctx, "/status_pages/"+statusPageID+"/subscriptions", d, nil)
+	if err := decodeEnvelope(c, resp, err, "subscription", &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetStatusPageSubscriptionWithContext gets a subscription of a status page.
+func (c *Client) GetStatusPageSubscriptionWithContext(ctx context.Context, statusPageID, id string) (*StatusPageSubscription, error) {
+	var result StatusPageSubscription
+	resp, err := c.get(ctx, "/status_pages/"+statusPageID+"/subscriptions/"+id)
+	if err := decodeEnvelope(c, resp, err, "subscription", &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DeleteStatusPageSubscriptionWithContext deletes a subscription of a status
+// page.
+func (c *Client) DeleteStatusPageSubscriptionWithContext(ctx context.Context, statusPageID, id string) error {
+	_, err := c.delete(ctx, "/status_pages/"+statusPageID+"/subscriptions/"+id)
+	return err
+}
+
+func decodeStatusPageList(c *Client, resp *http.Response, err error, v interface{}) error {
+	if err != nil {
+		return err
+	}
+
+	return c.decodeJSON(resp, v)
+}
@@ -0,0 +1,299 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestStatusPage_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/status_pages", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, StatusPageTypePublic, r.URL.Query().Get("status_page_type"))
+		_, _ = w.Write([]byte(`{"status_pages": [{"id": "SP1", "name": "Acme", "published_at": "2021-01-01T00:00:00Z", "status_page_type": "public"}], "more": false}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListStatusPagesWithContext(context.Background(), ListStatusPagesOptions{StatusPageType: StatusPageTypePublic})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []StatusPage{{
+		ID:             "SP1",
+		Name:           "Acme",
+		PublishedAt:    NewAPITime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+		StatusPageType: StatusPageTypePublic,
+	}}
+	testEqual(t, want, res.StatusPages)
+}
+
+func TestStatusPage_ImpactsSeveritiesStatuses(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/status_pages/SP1/impacts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, StatusPagePostTypeIncident, r.URL.Query().Get("post_type"))
+		_, _ = w.Write([]byte(`{"impacts": [{"id": "I1", "description": "Major outage", "post_type": "incident"}]}`))
+	})
+
+	mux.HandleFunc("/status_pages/SP1/severities/S1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"severity": {"id": "S1", "description": "Critical"}}`))
+	})
+
+	mux.HandleFunc("/status_pages/SP1/statuses", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"statuses": [{"id": "ST1", "description": "Investigating"}]}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+	ctx := context.Background()
+
+	impacts, err := client.ListStatusPageImpactsWithContext(ctx, "SP1", ListStatusPageResourcesOptions{PostType: StatusPagePostTypeIncident})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, []StatusPageImpact{{ID: "I1", Description: "Major outage", PostType: StatusPagePostTypeIncident}}, impacts.Impacts)
+
+	severity, err := client.GetStatusPageSeverityWithContext(ctx, "SP1", "S1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, &StatusPageSeverity{ID: "S1", Description: "Critical"}, severity)
+
+	statuses, err := client.ListStatusPageStatusesWithContext(ctx, "SP1", ListStatusPageResourcesOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "Investigating", statuses.Statuses[0].Description)
+}
+
+func TestStatusPage_Services(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/status_pages/SP1/services", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"services": [{"id": "SV1", "name": "API"}]}`))
+	})
+
+	mux.HandleFunc("/status_pages/SP1/services/SV1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"wrong": {"id": "SV1"}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListStatusPageServicesWithContext(context.Background(), "SP1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, []StatusPageService{{ID: "SV1", Name: "API"}}, res.Services)
+
+	_, err = client.GetStatusPageServiceWithContext(context.Background(), "SP1", "SV1")
+	testErrCheck(t, "GetStatusPageServiceWithContext()", "JSON response does not have service field", err)
+}
+
+func TestStatusPage_Posts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/status_pages/SP1/posts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]StatusPagePost
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		p := body["post"]
+		testEqual(t, "Degraded API", p.Title)
+		testEqual(t, "We're investigating", p.Updates[0].Message)
+
+		_, _ = w.Write([]byte(`{"post": {"id": "P1", "title": "Degraded API", "post_type": "incident"}}`))
+	})
+
+	mux.HandleFunc("/status_pages/SP1/posts/P1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"post": {"id": "P1", "title": "Degraded API"}}`))
+		case http.MethodPut:
+			_, _ = w.Write([]byte(`{"post": {"id": "P1", "title": "Degraded API in EU"}}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+	ctx := context.Background()
+
+	post := StatusPagePost{
+		PostType: StatusPagePostTypeIncident,
+		Title:    "Degraded API",
+		Updates: []StatusPagePostUpdate{
+			{
+				Message:  "We're investigating",
+				Status:   &APIReference{ID: "ST1", Type: "status_page_status"},
+				Severity: &APIReference{ID: "S1", Type: "status_page_severity"},
+				ImpactedServices: []StatusPagePostImpactedService{
+					{Service: &APIReference{ID: "SV1"}, Impact: &APIReference{ID: "I1"}},
+				},
+			},
+		},
+	}
+
+	created, err := client.CreateStatusPagePostWithContext(ctx, "SP1", post)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "P1", created.ID)
+
+	got, err := client.GetStatusPagePostWithContext(ctx, "SP1", "P1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "Degraded API", got.Title)
+
+	updated, err := client.UpdateStatusPagePostWithContext(ctx, "SP1", "P1", StatusPagePost{Title: "Degraded API in EU"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "Degraded API in EU", updated.Title)
+
+	if err := client.DeleteStatusPagePostWithContext(ctx, "SP1", "P1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStatusPage_PostUpdates(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/status_pages/SP1/posts/P1/post_updates", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			testEqual(t, StatusPageReviewedStatusApproved, r.URL.Query().Get("reviewed_status"))
+			_, _ = w.Write([]byte(`{"post_updates": [{"id": "U1", "message": "Investigating"}]}`))
+		case http.MethodPost:
+			var body map[string]StatusPagePostUpdate
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode request body: %s", err)
+			}
+			testEqual(t, true, body["post_update"].NotifySubscribers)
+
+			_, _ = w.Write([]byte(`{"post_update": {"id": "U2", "message": "Resolved"}}`))
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	mux.HandleFunc("/status_pages/SP1/posts/P1/post_updates/U2", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"post_update": {"id": "U2", "message": "Resolved"}}`))
+		case http.MethodPut:
+			_, _ = w.Write([]byte(`{"post_update": {"id": "U2", "message": "Fully resolved"}}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+	ctx := context.Background()
+
+	list, err := client.ListStatusPagePostUpdatesWithContext(ctx, "SP1", "P1", ListStatusPagePostUpdatesOptions{ReviewedStatus: StatusPageReviewedStatusApproved})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, []StatusPagePostUpdate{{ID: "U1", Message: "Investigating"}}, list.PostUpdates)
+
+	created, err := client.CreateStatusPagePostUpdateWithContext(ctx, "SP1", "P1", StatusPagePostUpdate{Message: "Resolved", NotifySubscribers: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "U2", created.ID)
+
+	got, err := client.GetStatusPagePostUpdateWithContext(ctx, "SP1", "P1", "U2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "Resolved", got.Message)
+
+	updated, err := client.UpdateStatusPagePostUpdateWithContext(ctx, "SP1", "P1", "U2", StatusPagePostUpdate{Message: "Fully resolved"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "Fully resolved", updated.Message)
+
+	if err := client.DeleteStatusPagePostUpdateWithContext(ctx, "SP1", "P1", "U2"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStatusPage_Subscriptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/status_pages/SP1/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			testEqual(t, StatusPageSubscriptionChannelEmail, r.URL.Query().Get("channel"))
+			_, _ = w.Write([]byte(`{"subscriptions": [{"id": "SB1", "channel": "email", "contact": "ops@example.com"}]}`))
+		case http.MethodPost:
+			_, _ = w.Write([]byte(`{"subscription": {"id": "SB2", "channel": "webhook"}}`))
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	mux.HandleFunc("/status_pages/SP1/subscriptions/SB2", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"subscription": {"id": "SB2", "channel": "webhook"}}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+	ctx := context.Background()
+
+	list, err := client.ListStatusPageSubscriptionsWithContext(ctx, "SP1", ListStatusPageSubscriptionsOptions{Channel: StatusPageSubscriptionChannelEmail})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "ops@example.com", list.Subscriptions[0].Contact)
+
+	created, err := client.CreateStatusPageSubscriptionWithContext(ctx, "SP1", StatusPageSubscription{
+		Channel: StatusPageSubscriptionChannelWebhook,
+		Contact: "https://example.com/status",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, "SB2", created.ID)
+
+	got, err := client.GetStatusPageSubscriptionWithContext(ctx, "SP1", "SB2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEqual(t, StatusPageSubscriptionChannelWebhook, got.Channel)
+
+	if err := client.DeleteStatusPageSubscriptionWithContext(ctx, "SP1", "SB2"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -2,7 +2,6 @@ package pagerduty
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 
 	"github.com/google/go-querystring/query"
@@ -14,6 +13,14 @@ type Tag struct {
 	Label string `json:"label,omitempty"`
 }
 
+// Values of the entityType argument of AssignTagsWithContext and
+// GetTagsForEntityPaginated, and of their variants.
+const (
+	TagEntityTypeUsers              = "users"
+	TagEntityTypeTeams              = "teams"
+	TagEntityTypeEscalationPolicies = "escalation_policies"
+)
+
 // ListTagResponse is the structure used when calling the ListTags API endpoint.
 type ListTagResponse struct {
 	APIListObject
@@ -198,6 +205,7 @@ func (c *Client) GetUsersByTagPaginated(ctx context.Context, tagID string) ([]*A
 			More:   result.More,
 			Offset: result.Offset,
 			Limit:  result.Limit,
+			Total:  result.Total,
 		}, nil
 	}
 
@@ -246,6 +254,7 @@ func (c *Client) GetTeamsByTagPaginated(ctx context.Context, tagID string) ([]*A
 			More:   result.More,
 			Offset: result.Offset,
 			Limit:  result.Limit,
+			Total:  result.Total,
 		}, nil
 	}
 
@@ -295,6 +304,7 @@ func (c *Client) GetEscalationPoliciesByTagPaginated(ctx context.Context, tagID
 			More:   result.More,
 			Offset: result.Offset,
 			Limit:  result.Limit,
+			Total:  result.Total,
 		}, nil
 	}
 
@@ -328,22 +338,11 @@ func (c *Client) GetTagsForEntityPaginated(ctx context.Context, entityType, enti
 }
 
 func getTagFromResponse(c *Client, resp *http.Response, err error) (*Tag, error) {
-	if err != nil {
+	var t Tag
+	if err := decodeEnvelope(c, resp, err, "tag", &t); err != nil {
 		return nil, err
 	}
 
-	var target map[string]Tag
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
-	}
-
-	const rootNode = "tag"
-
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
-	}
-
 	return &t, nil
 }
 
@@ -372,6 +371,7 @@ func getTagList(ctx context.Context, c *Client, entityType, entityID string, o L
 			More:   result.More,
 			Offset: result.Offset,
 			Limit:  result.Limit,
+			Total:  result.Total,
 		}, nil
 	}
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
 
 	"github.com/google/go-querystring/query"
 )
@@ -19,7 +20,7 @@ type Team struct {
 // ListTeamResponse is the structure used when calling the ListTeams API endpoint.
 type ListTeamResponse struct {
 	APIListObject
-	Teams []Team
+	Teams []Team `json:"teams"`
 }
 
 // ListTeamOptions are the input parameters used when calling the ListTeams API endpoint.
@@ -75,6 +76,39 @@ func (c *Client) ListTeamsWithContext(ctx context.Context, o ListTeamOptions) (*
 	return &result, nil
 }
 
+// ListTeamsPaginated lists teams of your PagerDuty account, optionally
+// filtered by a search query, handling pagination of the results.
+func (c *Client) ListTeamsPaginated(ctx context.Context, o ListTeamOptions) ([]Team, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var teams []Team
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListTeamResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		teams = append(teams, result.Teams...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+			Total:  result.Total,
+		}, nil
+	}
+
+	if err := c.pagedGet(ctx, "/teams?"+v.Encode(), responseHandler); err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
 // CreateTeam creates a new team.
 //
 // Deprecated: Use CreateTeamWithContext instead.
@@ -82,9 +116,14 @@ func (c *Client) CreateTeam(t *Team) (*Team, error) {
 	return c.CreateTeamWithContext(context.Background(), t)
 }
 
-// CreateTeamWithContext creates a new team.
+// CreateTeamWithContext creates a new team. Set Parent to create it as a
+// subteam of an existing team.
 func (c *Client) CreateTeamWithContext(ctx context.Context, t *Team) (*Team, error) {
-	resp, err := c.post(ctx, "/teams", t, nil)
+	d := map[string]*Team{
+		"team": t,
+	}
+
+	resp, err := c.post(ctx, "/teams", d, nil)
 	return getTeamFromResponse(c, resp, err)
 }
 
@@ -123,7 +162,11 @@ func (c *Client) UpdateTeam(id string, t *Team) (*Team, error) {
 
 // UpdateTeamWithContext updates an existing team.
 func (c *Client) UpdateTeamWithContext(ctx context.Context, id string, t *Team) (*Team, error) {
-	resp, err := c.put(ctx, "/teams/"+id, t, nil)
+	d := map[string]*Team{
+		"team": t,
+	}
+
+	resp, err := c.put(ctx, "/teams/"+id, d, nil)
 	return getTeamFromResponse(c, resp, err)
 }
 
@@ -211,22 +254,11 @@ func (c *Client) AddUserToTeamWithContext(ctx context.Context, o AddUserToTeamOp
 }
 
 func getTeamFromResponse(c *Client, resp *http.Response, err error) (*Team, error) {
-	if err != nil {
+	var t Team
+	if err := decodeEnvelope(c, resp, err, "team", &t); err != nil {
 		return nil, err
 	}
 
-	var target map[string]Team
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
-	}
-
-	const rootNode = "team"
-
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
-	}
-
 	return &t, nil
 }
 
@@ -344,6 +376,7 @@ func (c *Client) ListTeamMembersPaginated(ctx context.Context, teamID string) ([
 			More:   result.More,
 			Offset: result.Offset,
 			Limit:  result.Limit,
+			Total:  result.Total,
 		}, nil
 	}
 
@@ -354,3 +387,84 @@ func (c *Client) ListTeamMembersPaginated(ctx context.Context, teamID string) ([
 
 	return members, nil
 }
+
+// TeamMembershipChanges are the changes needed to make the members of a team
+// match a desired set of members, as returned by DiffTeamMembers.
+type TeamMembershipChanges struct {
+	// Add are the users to add to the team, or whose role must change.
+	Add []AddUserToTeamOptions
+
+	// Remove are the IDs of the users to remove from the team.
+	Remove []string
+}
+
+// Empty returns whether there are no changes.
+func (tc TeamMembershipChanges) Empty() bool {
+	return len(tc.Add) == 0 && len(tc.Remove) == 0
+}
+
+// DiffTeamMembers returns the changes needed to turn the current members of
+// team teamID into the desired ones, keyed by user ID. A desired role that's
+// empty keeps the role of existing members, and uses the default role of the
+// team for new ones. Changes are sorted by user ID.
+func DiffTeamMembers(teamID string, current []Member, desired map[string]TeamUserRole) TeamMembershipChanges {
+	var changes TeamMembershipChanges
+
+	existing := make(map[string]string, len(current))
+	for _, m := range current {
+		existing[m.User.ID] = m.Role
+	}
+
+	for userID, role := range desired {
+		currentRole, ok := existing[userID]
+		if ok && (len(role) == 0 || string(role) == currentRole) {
+			continue
+		}
+
+		changes.Add = append(changes.Add, AddUserToTeamOptions{TeamID: teamID, UserID: userID, Role: role})
+	}
+
+	for userID := range existing {
+		if _, ok := desired[userID]; !ok {
+			changes.Remove = append(changes.Remove, userID)
+		}
+	}
+
+	sort.Slice(changes.Add, func(i, j int) bool { return changes.Add[i].UserID < changes.Add[j].UserID })
+	sort.Strings(changes.Remove)
+
+	return changes
+}
+
+// SyncTeamMembersWithContext makes the members of a team match the desired
+// ones, keyed by user ID, such as to mirror a group of an identity provider.
+// Users are added or have their role changed before others are removed. It
+// returns the changes it applied, which are partial if an error occurs.
+func (c *Client) SyncTeamMembersWithContext(ctx context.Context, teamID string, desired map[string]TeamUserRole) (TeamMembershipChanges, error) {
+	var applied TeamMembershipChanges
+
+	current, err := c.ListTeamMembersPaginated(ctx, teamID)
+	if err != nil {
+		return applied, err
+	}
+
+	changes := DiffTeamMembers(teamID, current, desired)
+
+	for _, o := range changes.Add {
+		if err := c.AddUserToTeamWithContext(ctx, o); err != nil {
+			return applied, fmt.Errorf("failed to add user %s to team %s: %w", o.UserID, teamID, err)
+		}
+
+		applied.Add = append(applied.Add, o)
+	}
+
+	for _, userID := range changes.Remove {
+		if err := c.RemoveUserFromTeamWithContext(ctx, teamID, userID); err != nil {
+			return applied, fmt.Errorf("failed to remove user %s from team %s: %w", userID, teamID, err)
+		}
+
+		applied.Remove = append(applied.Remove, userID)
+	}
+
+	return applied, nil
+}
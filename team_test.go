@@ -2,8 +2,11 @@ package pagerduty
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"testing"
 	"text/template"
 )
@@ -44,6 +47,36 @@ func TestTeam_List(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+// ListTeamsPaginated
+func TestTeam_ListPaginated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/teams", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "foo", r.URL.Query().Get("query"))
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		resp := fmt.Sprintf(`{"teams": [{"id": "%d"}], "more": %t, "offset": %d, "limit": 1}`, offset, offset == 0, offset)
+		_, _ = w.Write([]byte(resp))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListTeamsPaginated(context.Background(), ListTeamOptions{Limit: 1, Query: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Team{
+		{APIObject: APIObject{ID: "0"}},
+		{APIObject: APIObject{ID: "1"}},
+	}
+
+	testEqual(t, want, res)
+}
+
 // Create Team
 func TestTeam_Create(t *testing.T) {
 	setup()
@@ -51,12 +84,21 @@ func TestTeam_Create(t *testing.T) {
 
 	mux.HandleFunc("/teams", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "POST")
+
+		var body map[string]Team
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		testEqual(t, Team{Name: "foo", Parent: &APIObject{ID: "2", Type: "team_reference"}}, body["team"])
+
 		_, _ = w.Write([]byte(`{"team": {"id": "1","name":"foo"}}`))
 	})
 
 	client := defaultTestClient(server.URL, "foo")
 	input := &Team{
-		Name: "foo",
+		Name:   "foo",
+		Parent: &APIObject{ID: "2", Type: "team_reference"},
 	}
 	res, err := client.CreateTeam(input)
 
@@ -124,6 +166,14 @@ func TestTeam_Update(t *testing.T) {
 
 	mux.HandleFunc("/teams/1", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "PUT")
+
+		var body map[string]Team
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		testEqual(t, "foo", body["team"].Name)
+
 		_, _ = w.Write([]byte(`{"team": {"id": "1","name":"foo"}}`))
 	})
 
@@ -417,3 +467,73 @@ func TestListAllMembersError(t *testing.T) {
 		t.Fatalf("Expected 0 members, got: %v", members)
 	}
 }
+
+func TestDiffTeamMembers(t *testing.T) {
+	current := []Member{
+		{User: APIObject{ID: "PU1"}, Role: "manager"},
+		{User: APIObject{ID: "PU2"}, Role: "responder"},
+		{User: APIObject{ID: "PU3"}, Role: "observer"},
+	}
+
+	desired := map[string]TeamUserRole{
+		"PU1": "",
+		"PU2": TeamUserRoleManager,
+		"PU4": TeamUserRoleResponder,
+	}
+
+	want := TeamMembershipChanges{
+		Add: []AddUserToTeamOptions{
+			{TeamID: "PT1", UserID: "PU2", Role: TeamUserRoleManager},
+			{TeamID: "PT1", UserID: "PU4", Role: TeamUserRoleResponder},
+		},
+		Remove: []string{"PU3"},
+	}
+
+	got := DiffTeamMembers("PT1", current, desired)
+	testEqual(t, want, got)
+	testEqual(t, true, DiffTeamMembers("PT1", current[:1], map[string]TeamUserRole{"PU1": "manager"}).Empty())
+}
+
+func TestTeam_SyncTeamMembers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/teams/PT1/members", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"members": [{"user": {"id": "PU1"}, "role": "responder"}, {"user": {"id": "PU2"}, "role": "responder"}]}`))
+	})
+
+	mux.HandleFunc("/teams/PT1/users/PU1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("/teams/PT1/users/PU3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		testEqual(t, "observer", body["role"])
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	got, err := client.SyncTeamMembersWithContext(context.Background(), "PT1", map[string]TeamUserRole{
+		"PU2": TeamUserRoleResponder,
+		"PU3": TeamUserRoleObserver,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := TeamMembershipChanges{
+		Add:    []AddUserToTeamOptions{{TeamID: "PT1", UserID: "PU3", Role: TeamUserRoleObserver}},
+		Remove: []string{"PU1"},
+	}
+
+	testEqual(t, want, got)
+}
@@ -2,8 +2,9 @@ package pagerduty
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/google/go-querystring/query"
 )
@@ -21,6 +22,42 @@ type NotificationRule struct {
 	Urgency             string        `json:"urgency"`
 }
 
+// Values of the User.Role field. The roles available depend on the pricing
+// plan of the account.
+const (
+	UserRoleAdmin               = "admin"
+	UserRoleLimitedUser         = "limited_user"
+	UserRoleObserver            = "observer"
+	UserRoleOwner               = "owner"
+	UserRoleReadOnlyUser        = "read_only_user"
+	UserRoleReadOnlyLimitedUser = "read_only_limited_user"
+	UserRoleRestrictedAccess    = "restricted_access"
+	UserRoleUser                = "user"
+)
+
+// UserRole is the role of a user in the account, such as UserRoleObserver.
+type UserRole string
+
+// IsValid returns whether r is a known user role.
+func (r UserRole) IsValid() bool {
+	switch r {
+	case UserRoleAdmin, UserRoleLimitedUser, UserRoleObserver, UserRoleOwner,
+		UserRoleReadOnlyUser, UserRoleReadOnlyLimitedUser, UserRoleRestrictedAccess, UserRoleUser:
+		return true
+	default:
+		return false
+	}
+}
+
+// Values of the Includes field of the options used to list and get users,
+// which return the related objects instead of references to them.
+const (
+	UserIncludeContactMethods    = "contact_methods"
+	UserIncludeNotificationRules = "notification_rules"
+	UserIncludeTeams             = "teams"
+	UserIncludeSubdomains        = "subdomains"
+)
+
 // User is a member of a PagerDuty account that has the ability to interact with incidents and other data on the account.
 type User struct {
 	APIObject
@@ -28,7 +65,7 @@ type User struct {
 	Email             string             `json:"email"`
 	Timezone          string             `json:"time_zone,omitempty"`
 	Color             string             `json:"color,omitempty"`
-	Role              string             `json:"role,omitempty"`
+	Role              UserRole           `json:"role,omitempty"`
 	AvatarURL         string             `json:"avatar_url,omitempty"`
 	Description       string             `json:"description,omitempty"`
 	InvitationSent    bool               `json:"invitation_sent,omitempty"`
@@ -136,6 +173,82 @@ func (c *Client) ListUsersWithContext(ctx context.Context, o ListUsersOptions) (
 	return &result, nil
 }
 
+// ListUsersPaginated lists users of your PagerDuty account, optionally
+// filtered by a search query, handling pagination of the results.
+func (c *Client) ListUsersPaginated(ctx context.Context, o ListUsersOptions) ([]User, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []User
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListUsersResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		users = append(users, result.Users...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+			Total:  result.Total,
+		}, nil
+	}
+
+	if err := c.pagedGet(ctx, "/users?"+v.Encode(), responseHandler); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// FindUserByEmail returns the user with the given email, which is matched
+// case-insensitively. It returns a MatchError matching ErrNotFound, with the
+// emails of users matching the query, if there's no such user.
+func (c *Client) FindUserByEmail(ctx context.Context, email string) (*User, error) {
+	users, err := c.ListUsersPaginated(ctx, ListUsersOptions{Query: email})
+	if err != nil {
+		return nil, err
+	}
+
+	e := MatchError{Kind: "user", Name: email}
+
+	var found *User
+
+	for i, u := range users {
+		if !strings.EqualFold(u.Email, email) {
+			continue
+		}
+
+		if found != nil {
+			e.Ambiguous = true
+			e.Matches = []string{found.Email, u.Email}
+
+			return nil, e
+		}
+
+		found = &users[i]
+	}
+
+	if found != nil {
+		return found, nil
+	}
+
+	for _, u := range users {
+		if len(e.Matches) == maxNearMatches {
+			break
+		}
+
+		e.Matches = append(e.Matches, u.Email)
+	}
+
+	return nil, e
+}
+
 // CreateUser creates a new user.
 //
 // Deprecated: Use CreateUserWithContext instead.
@@ -221,21 +334,29 @@ func (c *Client) GetCurrentUserWithContext(ctx context.Context, o GetCurrentUser
 	return getUserFromResponse(c, resp, err)
 }
 
-func getUserFromResponse(c *Client, resp *http.Response, err error) (*User, error) {
+// UseCurrentUserAsFrom gets the user associated with the token, and uses its
+// email address in the From header of later REST API requests that don't set
+// one. This lets OAuth apps acting on behalf of a user omit the From header of
+// endpoints that require it.
+func (c *Client) UseCurrentUserAsFrom(ctx context.Context) (*User, error) {
+	u, err := c.GetCurrentUserWithContext(ctx, GetCurrentUserOptions{})
 	if err != nil {
 		return nil, err
 	}
 
-	var target map[string]User
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
+	if len(u.Email) == 0 {
+		return nil, errors.New("current user has no email address")
 	}
 
-	const rootNode = "user"
+	c.defaultFrom.Store(u.Email)
 
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
+	return u, nil
+}
+
+func getUserFromResponse(c *Client, resp *http.Response, err error) (*User, error) {
+	var t User
+	if err := decodeEnvelope(c, resp, err, "user", &t); err != nil {
+		return nil, err
 	}
 
 	return &t, nil
@@ -309,11 +430,19 @@ func (c *Client) CreateUserContactMethodWithContext(ctx context.Context, userID
 // UpdateUserContactMethod updates an existing user. It's recommended to use
 // UpdateUserContactMethodWithContext instead.
 func (c *Client) UpdateUserContactMethod(userID string, cm ContactMethod) (*ContactMethod, error) {
-	return c.UpdateUserContactMethodWthContext(context.Background(), userID, cm)
+	return c.UpdateUserContactMethodWithContext(context.Background(), userID, cm)
 }
 
 // UpdateUserContactMethodWthContext updates an existing user.
+//
+// Deprecated: Use UpdateUserContactMethodWithContext instead.
 func (c *Client) UpdateUserContactMethodWthContext(ctx context.Context, userID string, cm ContactMethod) (*ContactMethod, error) {
+	return c.UpdateUserContactMethodWithContext(ctx, userID, cm)
+}
+
+// UpdateUserContactMethodWithContext updates an existing contact method of a
+// user.
+func (c *Client) UpdateUserContactMethodWithContext(ctx context.Context, userID string, cm ContactMethod) (*ContactMethod, error) {
 	d := map[string]ContactMethod{
 		"contact_method": cm,
 	}
@@ -323,22 +452,11 @@ func (c *Client) UpdateUserContactMethodWthContext(ctx context.Context, userID s
 }
 
 func getContactMethodFromResponse(c *Client, resp *http.Response, err error) (*ContactMethod, error) {
-	if err != nil {
+	var t ContactMethod
+	if err := decodeEnvelope(c, resp, err, "contact_method", &t); err != nil {
 		return nil, err
 	}
 
-	var target map[string]ContactMethod
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
-	}
-
-	const rootNode = "contact_method"
-
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
-	}
-
 	return &t, nil
 }
 
@@ -425,21 +543,10 @@ func (c *Client) ListUserNotificationRulesWithContext(ctx context.Context, userI
 }
 
 func getUserNotificationRuleFromResponse(c *Client, resp *http.Response, err error) (*NotificationRule, error) {
-	if err != nil {
+	var t NotificationRule
+	if err := decodeEnvelope(c, resp, err, "notification_rule", &t); err != nil {
 		return nil, err
 	}
 
-	var target map[string]NotificationRule
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
-	}
-
-	const rootNode = "notification_rule"
-
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
-	}
-
 	return &t, nil
 }
@@ -1,7 +1,11 @@
 package pagerduty
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"testing"
 )
 
@@ -43,6 +47,40 @@ func TestUser_List(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+// ListUsersPaginated
+func TestUser_ListPaginated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, []string{UserIncludeContactMethods, UserIncludeTeams}, r.URL.Query()["include[]"])
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		resp := fmt.Sprintf(`{"users": [{"id": "%d", "role": "user"}], "more": %t, "offset": %d, "limit": 1}`, offset, offset == 0, offset)
+		_, _ = w.Write([]byte(resp))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+	opts := ListUsersOptions{
+		Limit:    1,
+		Includes: []string{UserIncludeContactMethods, UserIncludeTeams},
+	}
+
+	res, err := client.ListUsersPaginated(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []User{
+		{APIObject: APIObject{ID: "0"}, Role: UserRoleUser},
+		{APIObject: APIObject{ID: "1"}, Role: UserRoleUser},
+	}
+
+	testEqual(t, want, res)
+}
+
 // Create User
 func TestUser_Create(t *testing.T) {
 	setup()
@@ -180,6 +218,37 @@ func TestUser_GetCurrent(t *testing.T) {
 	testEqual(t, want, res)
 }
 
+func TestUser_UseCurrentUserAsFrom(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/me", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "", r.Header.Get("From"))
+		_, _ = w.Write([]byte(`{"user": {"id": "1", "email":"foo@bar.com"}}`))
+	})
+
+	mux.HandleFunc("/incidents", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testEqual(t, "foo@bar.com", r.Header.Get("From"))
+		_, _ = w.Write([]byte(`{"incident": {"id": "P1"}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	u, err := client.UseCurrentUserAsFrom(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "1", u.ID)
+	testEqual(t, "foo@bar.com", client.DefaultFrom())
+
+	if _, err := client.CreateIncidentWithContext(context.Background(), "", &CreateIncidentOptions{Title: "foo", Service: &APIReference{ID: "PS1"}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // List User Contactmethods
 func TestUser_ListContactMethods(t *testing.T) {
 	setup()
@@ -461,3 +530,40 @@ func TestUser_DeleteUserNotificationRule(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestUserRole_IsValid(t *testing.T) {
+	testEqual(t, true, UserRole(UserRoleObserver).IsValid())
+	testEqual(t, false, UserRole("manager").IsValid())
+}
+
+func TestUser_FindUserByEmail(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		switch r.URL.Query().Get("query") {
+		case "jane@example.com":
+			_, _ = w.Write([]byte(`{"users": [{"id": "PU1", "email": "mary.jane@example.com"}, {"id": "PU2", "email": "Jane@Example.com"}]}`))
+		case "john@example.com":
+			_, _ = w.Write([]byte(`{"users": [{"id": "PU3", "email": "big.john@example.com"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"users": []}`))
+		}
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+	ctx := context.Background()
+
+	u, err := client.FindUserByEmail(ctx, "jane@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "PU2", u.ID)
+
+	_, err = client.FindUserByEmail(ctx, "john@example.com")
+	testErrCheck(t, "FindUserByEmail()", `user "john@example.com" not found, near matches: "big.john@example.com"`, err)
+	testEqual(t, true, errors.Is(err, ErrNotFound))
+}
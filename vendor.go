@@ -2,7 +2,6 @@ package pagerduty
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 
 	"github.com/google/go-querystring/query"
@@ -94,21 +93,10 @@ func (c *Client) GetVendorWithContext(ctx context.Context, id string) (*Vendor,
 }
 
 func getVendorFromResponse(c *Client, resp *http.Response, err error) (*Vendor, error) {
-	if err != nil {
+	var t Vendor
+	if err := decodeEnvelope(c, resp, err, "vendor", &t); err != nil {
 		return nil, err
 	}
 
-	var target map[string]Vendor
-	if dErr := c.decodeJSON(resp, &target); dErr != nil {
-		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
-	}
-
-	const rootNode = "vendor"
-
-	t, nodeOK := target[rootNode]
-	if !nodeOK {
-		return nil, fmt.Errorf("JSON response does not have %s field", rootNode)
-	}
-
 	return &t, nil
 }
@@ -0,0 +1,224 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/go-querystring/query"
+)
+
+// WebhookSubscription is a subscription of a V3 webhook to the events of an
+// account, a service or a team.
+type WebhookSubscription struct {
+	ID   string `json:"id,omitempty"`
+	Type string `json:"type,omitempty"`
+
+	// Active is whether webhooks are delivered. It's always sent, so it must
+	// be set when creating or updating a subscription.
+	Active bool `json:"active"`
+
+	DeliveryMethod WebhookSubscriptionDeliveryMethod `json:"delivery_method"`
+	Description    string                            `json:"description,omitempty"`
+
+	// Events are the types of the events delivered, such as
+	// "incident.triggered".
+	Events []string `json:"events"`
+
+	Filter WebhookSubscriptionFilter `json:"filter"`
+}
+
+// WebhookSubscriptionDeliveryMethod is how the webhooks of a subscription are
+// delivered.
+type WebhookSubscriptionDeliveryMethod struct {
+	ID   string `json:"id,omitempty"`
+	Type string `json:"type,omitempty"`
+	URL  string `json:"url,omitempty"`
+
+	// Secret is the secret the webhooks are signed with. It's only returned
+	// when the subscription is created.
+	Secret string `json:"secret,omitempty"`
+
+	// TemporarilyDisabled is set by PagerDuty when it disables the
+	// subscription after repeated delivery failures. Use
+	// EnableWebhookSubscriptionWithContext to clear it.
+	TemporarilyDisabled bool `json:"temporarily_disabled,omitempty"`
+
+	CustomHeaders []WebhookSubscriptionHeader `json:"custom_headers,omitempty"`
+}
+
+// WebhookSubscriptionHeader is a custom HTTP header sent with the webhooks of
+// a subscription.
+type WebhookSubscriptionHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// WebhookSubscriptionFilter is the resource whose events are delivered.
+type WebhookSubscriptionFilter struct {
+	ID   string `json:"id,omitempty"`
+	Type string `json:"type"`
+}
+
+// Values of the Type field of WebhookSubscriptionDeliveryMethod.
+const (
+	WebhookSubscriptionDeliveryMethodHTTP = "http_delivery_method"
+)
+
+// Values of the Type field of WebhookSubscriptionFilter.
+const (
+	WebhookSubscriptionFilterAccount = "account_reference"
+	WebhookSubscriptionFilterService = "service_reference"
+	WebhookSubscriptionFilterTeam    = "team_reference"
+)
+
+// ListWebhookSubscriptionsOptions is the data structure used when calling the
+// ListWebhookSubscriptions API endpoint.
+type ListWebhookSubscriptionsOptions struct {
+	// Limit is the pagination parameter that limits the number of results per
+	// page. PagerDuty defaults this value to 25 if omitted, and sets an upper
+	// bound of 100.
+	Limit uint `url:"limit,omitempty"`
+
+	// Offset is the pagination parameter that specifies the offset at which to
+	// start pagination results. When trying to request the next page of
+	// results, the new Offset value should be currentOffset + Limit.
+	Offset uint `url:"offset,omitempty"`
+
+	// Total is the pagination parameter to request that the API return the
+	// total count of items in the response. If this field is omitted or set to
+	// false, the total number of results will not be sent back from the PagerDuty API.
+	Total bool `url:"total,omitempty"`
+
+	// FilterType only lists the subscriptions of a type of resource, such as
+	// "service", and FilterID of a specific resource.
+	FilterType string `url:"filter_type,omitempty"`
+	FilterID   string `url:"filter_id,omitempty"`
+}
+
+// ListWebhookSubscriptionsResponse is the data structure returned from calling
+// the ListWebhookSubscriptions API endpoint.
+type ListWebhookSubscriptionsResponse struct {
+	APIListObject
+	WebhookSubscriptions []WebhookSubscription `json:"webhook_subscriptions"`
+}
+
+// ListWebhookSubscriptionsWithContext lists webhook subscriptions.
+func (c *Client) ListWebhookSubscriptionsWithContext(ctx context.Context, o ListWebhookSubscriptionsOptions) (*ListWebhookSubscriptionsResponse, error) {
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, "/webhook_subscriptions?"+v.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListWebhookSubscriptionsResponse
+	if err = c.decodeJSON(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListWebhookSubscriptionsPaginated lists all the webhook subscriptions,
+// handling pagination.
+func (c *Client) ListWebhookSubscriptionsPaginated(ctx context.Context, o ListWebhookSubscriptionsOptions) ([]WebhookSubscription, error) {
+	var subscriptions []WebhookSubscription
+
+	responseHandler := func(response *http.Response) (APIListObject, error) {
+		var result ListWebhookSubscriptionsResponse
+		if err := c.decodeJSON(response, &result); err != nil {
+			return APIListObject{}, err
+		}
+
+		subscriptions = append(subscriptions, result.WebhookSubscriptions...)
+
+		return APIListObject{
+			More:   result.More,
+			Offset: result.Offset,
+			Limit:  result.Limit,
+			Total:  result.Total,
+		}, nil
+	}
+
+	v, err := query.Values(o)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.pagedGet(ctx, "/webhook_subscriptions?"+v.Encode(), responseHandler); err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// CreateWebhookSubscriptionWithContext creates a webhook subscription. The
+// returned subscription is the only one whose delivery method has its Secret.
+func (c *Client) CreateWebhookSubscriptionWithContext(ctx context.Context, s WebhookSubscription) (*WebhookSubscription, error) {
+	d := map[string]WebhookSubscription{
+		"webhook_subscription": s,
+	}
+
+	resp, err := c.post(ctx, "/webhook_subscriptions", d, nil)
+	return getWebhookSubscriptionFromResponse(c, resp, err)
+}
+
+// GetWebhookSubscriptionWithContext gets a webhook subscription.
+func (c *Client) GetWebhookSubscriptionWithContext(ctx context.Context, id string) (*WebhookSubscription, error) {
+	resp, err := c.get(ctx, "/webhook_subscriptions/"+id)
+	return getWebhookSubscriptionFromResponse(c, resp, err)
+}
+
+// UpdateWebhookSubscriptionWithContext updates a webhook subscription.
+func (c *Client) UpdateWebhookSubscriptionWithContext(ctx context.Context, id string, s WebhookSubscription) (*WebhookSubscription, error) {
+	d := map[string]WebhookSubscription{
+		"webhook_subscription": s,
+	}
+
+	resp, err := c.put(ctx, "/webhook_subscriptions/"+id, d, nil)
+	return getWebhookSubscriptionFromResponse(c, resp, err)
+}
+
+// SetWebhookSubscriptionActiveWithContext activates or deactivates a webhook
+// subscription, without changing anything else.
+func (c *Client) SetWebhookSubscriptionActiveWithContext(ctx context.Context, id string, active bool) (*WebhookSubscription, error) {
+	d := map[string]interface{}{
+		"webhook_subscription": map[string]bool{"active": active},
+	}
+
+	resp, err := c.put(ctx, "/webhook_subscriptions/"+id, d, nil)
+	return getWebhookSubscriptionFromResponse(c, resp, err)
+}
+
+// DeleteWebhookSubscriptionWithContext deletes a webhook subscription.
+func (c *Client) DeleteWebhookSubscriptionWithContext(ctx context.Context, id string) error {
+	_, err := c.delete(ctx, "/webhook_subscriptions/"+id)
+	return err
+}
+
+// EnableWebhookSubscriptionWithContext re-enables a webhook subscription that
+// PagerDuty temporarily disabled after repeated delivery failures, clearing
+// the TemporarilyDisabled field of its delivery method.
+func (c *Client) EnableWebhookSubscriptionWithContext(ctx context.Context, id string) (*WebhookSubscription, error) {
+	resp, err := c.post(ctx, "/webhook_subscriptions/"+id+"/enable", nil, nil)
+	return getWebhookSubscriptionFromResponse(c, resp, err)
+}
+
+// TestWebhookSubscriptionWithContext asks PagerDuty to send a test
+// pagey.ping event to a webhook subscription.
+func (c *Client) TestWebhookSubscriptionWithContext(ctx context.Context, id string) error {
+	_, err := c.post(ctx, "/webhook_subscriptions/"+id+"/ping", nil, nil)
+	return err
+}
+
+func getWebhookSubscriptionFromResponse(c *Client, resp *http.Response, err error) (*WebhookSubscription, error) {
+	var t WebhookSubscription
+	if err := decodeEnvelope(c, resp, err, "webhook_subscription", &t); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
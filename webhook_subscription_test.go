@@ -0,0 +1,171 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestWebhookSubscription_ListPaginated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/webhook_subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testEqual(t, "service", r.URL.Query().Get("filter_type"))
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		_, _ = fmt.Fprintf(w, `{"webhook_subscriptions": [{"id": "%d", "active": true}], "more": %t, "offset": %d, "limit": 1}`, offset, offset == 0, offset)
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.ListWebhookSubscriptionsPaginated(context.Background(), ListWebhookSubscriptionsOptions{FilterType: "service"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []WebhookSubscription{
+		{ID: "0", Active: true},
+		{ID: "1", Active: true},
+	}
+	testEqual(t, want, res)
+}
+
+func TestWebhookSubscription_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/webhook_subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]WebhookSubscription
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+
+		s := body["webhook_subscription"]
+		testEqual(t, "https://example.com/webhooks", s.DeliveryMethod.URL)
+		testEqual(t, WebhookSubscriptionFilterService, s.Filter.Type)
+
+		_, _ = w.Write([]byte(`{"webhook_subscription": {"id": "PWS1", "active": true, "delivery_method": {"secret": "s3cr3t"}}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	input := WebhookSubscription{
+		Active: true,
+		DeliveryMethod: WebhookSubscriptionDeliveryMethod{
+			Type: WebhookSubscriptionDeliveryMethodHTTP,
+			URL:  "https://example.com/webhooks",
+		},
+		Events: []string{"incident.triggered"},
+		Filter: WebhookSubscriptionFilter{ID: "PS1", Type: WebhookSubscriptionFilterService},
+	}
+
+	res, err := client.CreateWebhookSubscriptionWithContext(context.Background(), input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, "s3cr3t", res.DeliveryMethod.Secret)
+}
+
+func TestWebhookSubscription_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/webhook_subscriptions/PWS1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{"webhook_subscription": {"id": "PWS1", "active": true, "delivery_method": {"temporarily_disabled": true}}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.GetWebhookSubscriptionWithContext(context.Background(), "PWS1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, true, res.DeliveryMethod.TemporarilyDisabled)
+}
+
+func TestWebhookSubscription_SetActive(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/webhook_subscriptions/PWS1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var body map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %s", err)
+		}
+		testEqual(t, map[string]interface{}{"active": false}, body["webhook_subscription"])
+
+		_, _ = w.Write([]byte(`{"webhook_subscription": {"id": "PWS1", "active": false}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.SetWebhookSubscriptionActiveWithContext(context.Background(), "PWS1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, false, res.Active)
+}
+
+func TestWebhookSubscription_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/webhook_subscriptions/PWS1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	if err := client.DeleteWebhookSubscriptionWithContext(context.Background(), "PWS1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWebhookSubscription_Enable(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/webhook_subscriptions/PWS1/enable", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		_, _ = w.Write([]byte(`{"webhook_subscription": {"id": "PWS1", "active": true, "delivery_method": {"temporarily_disabled": false}}}`))
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	res, err := client.EnableWebhookSubscriptionWithContext(context.Background(), "PWS1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testEqual(t, false, res.DeliveryMethod.TemporarilyDisabled)
+}
+
+func TestWebhookSubscription_Test(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/webhook_subscriptions/PWS1/ping", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	client := defaultTestClient(server.URL, "foo")
+
+	if err := client.TestWebhookSubscriptionWithContext(context.Background(), "PWS1"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,250 @@
+package webhookv3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// ErrBridgeClosed is returned by a Bridge receiving a webhook after being
+// closed. It responds to it with HTTP 503, so PagerDuty redelivers it later.
+var ErrBridgeClosed = errors.New("webhook bridge closed")
+
+// EventHandler handles the events received by a Bridge.
+type EventHandler interface {
+	// HandleEvent is called with the typed event returned by UnmarshalEvent:
+	// an *IncidentEvent, an *IncidentAnnotatedEvent, a *ServiceEvent or an
+	// *Event. If it fails, the webhook is redelivered.
+	HandleEvent(ctx context.Context, e interface{}) error
+}
+
+// EventHandlerFunc is an EventHandler calling itself.
+type EventHandlerFunc func(ctx context.Context, e interface{}) error
+
+// HandleEvent satisfies the EventHandler interface.
+func (f EventHandlerFunc) HandleEvent(ctx context.Context, e interface{}) error {
+	return f(ctx, e)
+}
+
+// defaultDedupSize is the default of BridgeOptions.DedupSize.
+const defaultDedupSize = 1000
+
+// BridgeOptions are the options of NewBridge.
+type BridgeOptions struct {
+	// Handler handles the events, if set. Otherwise they are published on
+	// the channel returned by Bridge.Events.
+	Handler EventHandler
+
+	// Buffer is the capacity of the channel returned by Bridge.Events.
+	Buffer int
+
+	// EventTypes are the types of the events handled, such as
+	// EventTypeIncidentTriggered. Webhooks with other events are acknowledged
+	// and dropped. All events are handled if empty.
+	EventTypes []string
+
+	// DedupSize is the number of the most recent event IDs remembered to
+	// drop redeliveries of events already handled. It defaults to 1000.
+	DedupSize int
+}
+
+// Bridge is an http.Handler receiving V3 PagerDuty Webhooks, which pushes
+// their events to the application. It verifies their signature and responds
+// to errors like Handler, drops redeliveries of events already handled, and
+// then passes the typed events to an EventHandler, or publishes them on a
+// channel:
+//
+//	b := webhookv3.NewBridge(secret, webhookv3.BridgeOptions{})
+//	http.Handle("/webhooks", b)
+//
+//	for e := range b.Events() {
+//		if ie, ok := e.(*webhookv3.IncidentEvent); ok {
+//			log.Printf("incident %s is %s", ie.Incident.ID, ie.Incident.Status)
+//		}
+//	}
+//
+// Publishing blocks until the event is received or the request is canceled,
+// in which case the webhook is redelivered, so the channel must be drained.
+type Bridge struct {
+	secret     string
+	handler    EventHandler
+	eventTypes map[string]bool
+	dedup      *dedupSet
+
+	events    chan interface{}
+	done      chan struct{}
+	closeOnce sync.Once
+
+	// mu is held for reading while publishing, and for writing to close
+	// events once done is closed, which unblocks the publishers.
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewBridge returns a Bridge verifying the signatures of webhooks against
+// secret.
+func NewBridge(secret string, o BridgeOptions) *Bridge {
+	b := &Bridge{
+		secret:  secret,
+		handler: o.Handler,
+		dedup:   newDedupSet(o.DedupSize),
+		events:  make(chan interface{}, o.Buffer),
+		done:    make(chan struct{}),
+	}
+
+	if len(o.EventTypes) > 0 {
+		b.eventTypes = make(map[string]bool, len(o.EventTypes))
+		for _, t := range o.EventTypes {
+			b.eventTypes[t] = true
+		}
+	}
+
+	return b
+}
+
+// Events returns the channel the events are published on when the Bridge has
+// no EventHandler. It's closed by Close.
+func (b *Bridge) Events() <-chan interface{} {
+	return b.events
+}
+
+// Close stops the Bridge from handling events, and closes the channel returned
+// by Events once the events being published are done.
+func (b *Bridge) Close() {
+	b.closeOnce.Do(func() {
+		close(b.done)
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		b.closed = true
+		close(b.events)
+	})
+}
+
+// ServeHTTP implements http.Handler.
+func (b *Bridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p, err := VerifyAndDecode(r, b.secret)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	e := p.Event
+
+	if b.eventTypes != nil && !b.eventTypes[e.EventType] {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	// the ID is claimed while the event is handled, so that concurrent
+	// redeliveries are dropped too, and released if it fails
+	if e.ID != "" && !b.dedup.add(e.ID) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if err := b.handle(r.Context(), e); err != nil {
+		b.dedup.remove(e.ID)
+
+		if errors.Is(err, ErrBridgeClosed) {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (b *Bridge) handle(ctx context.Context, e Event) error {
+	typed, err := UnmarshalEvent(e)
+	if err != nil {
+		return err
+	}
+
+	if b.handler != nil {
+		return b.handler.HandleEvent(ctx, typed)
+	}
+
+	// Close can't close events while it's held
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return ErrBridgeClosed
+	}
+
+	select {
+	case b.events <- typed:
+		return nil
+
+	case <-b.done:
+		return ErrBridgeClosed
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// dedupSet is a set of IDs remembering the most recent ones only.
+type dedupSet struct {
+	mu   sync.Mutex
+	ids  map[string]struct{}
+	ring []string
+	next int
+}
+
+func newDedupSet(size int) *dedupSet {
+	if size <= 0 {
+		size = defaultDedupSize
+	}
+
+	return &dedupSet{
+		ids:  make(map[string]struct{}, size),
+		ring: make([]string, size),
+	}
+}
+
+// add adds id to the set, evicting the oldest one if it's full, and returns
+// whether it wasn't in the set already.
+func (s *dedupSet) add(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.ids[id]; ok {
+		return false
+	}
+
+	if old := s.ring[s.next]; old != "" {
+		delete(s.ids, old)
+	}
+
+	s.ring[s.next] = id
+	s.next = (s.next + 1) % len(s.ring)
+	s.ids[id] = struct{}{}
+
+	return true
+}
+
+// remove removes id from the set.
+func (s *dedupSet) remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.ids[id]; !ok {
+		return
+	}
+
+	delete(s.ids, id)
+
+	for i, r := range s.ring {
+		if r == id {
+			s.ring[i] = ""
+			break
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package webhookv3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func serveWebhook(h http.Handler, body string) int {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	req.Header.Set("X-PagerDuty-Signature", Signature([]byte(body), secret))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec.Code
+}
+
+func TestBridge_Events(t *testing.T) {
+	b := NewBridge(secret, BridgeOptions{Buffer: 2})
+
+	if status := serveWebhook(b, defaultBody); status != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", status, http.StatusNoContent)
+	}
+
+	// redeliveries are dropped
+	if status := serveWebhook(b, defaultBody); status != http.StatusNoContent {
+		t.Fatalf("redelivery status = %d, want %d", status, http.StatusNoContent)
+	}
+
+	b.Close()
+
+	var events []interface{}
+	for e := range b.Events() {
+		events = append(events, e)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+
+	ie, ok := events[0].(*IncidentEvent)
+	if !ok || ie.Incident.ID != "PGR0VU2" {
+		t.Fatalf("event = %#v, want an incident event", events[0])
+	}
+
+	otherBody := `{"event":{"id":"E2","event_type":"incident.responder.added","resource_type":"incident","data":{}}}`
+	if status := serveWebhook(b, otherBody); status != http.StatusServiceUnavailable {
+		t.Fatalf("status after close = %d, want %d", status, http.StatusServiceUnavailable)
+	}
+}
+
+func TestBridge_Handler(t *testing.T) {
+	var (
+		got   []string
+		fail  = true
+		calls int
+	)
+
+	b := NewBridge(secret, BridgeOptions{
+		EventTypes: []string{EventTypeIncidentPriorityUpdated, EventTypeServiceUpdated},
+		Handler: EventHandlerFunc(func(ctx context.Context, e interface{}) error {
+			calls++
+			if fail {
+				return errors.New("unavailable")
+			}
+
+			switch e := e.(type) {
+			case *IncidentEvent:
+				got = append(got, e.Incident.ID)
+			case *ServiceEvent:
+				got = append(got, e.Service.ID)
+			}
+
+			return nil
+		}),
+	})
+
+	// failed deliveries are retried by PagerDuty, so they aren't dropped
+	if status := serveWebhook(b, defaultBody); status != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", status, http.StatusInternalServerError)
+	}
+
+	fail = false
+
+	serviceBody := `{"event":{"id":"E2","event_type":"service.updated","resource_type":"service","data":{"id":"PS1"}}}`
+	ignoredBody := `{"event":{"id":"E3","event_type":"incident.triggered","resource_type":"incident","data":{"id":"P2"}}}`
+
+	for _, body := range []string{defaultBody, serviceBody, ignoredBody, defaultBody} {
+		if status := serveWebhook(b, body); status != http.StatusNoContent {
+			t.Fatalf("status = %d, want %d", status, http.StatusNoContent)
+		}
+	}
+
+	if len(got) != 2 || got[0] != "PGR0VU2" || got[1] != "PS1" || calls != 3 {
+		t.Fatalf("handled %v in %d calls", got, calls)
+	}
+
+	if status := serveWebhook(b, `{"event":{"id":"E4","event_type":"service.updated","resource_type":"service","data":[]}}`); status != http.StatusBadRequest {
+		t.Fatalf("malformed data status = %d, want %d", status, http.StatusBadRequest)
+	}
+}
+
+func TestBridge_canceled(t *testing.T) {
+	b := NewBridge(secret, BridgeOptions{})
+	defer b.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(defaultBody)).WithContext(ctx)
+	req.Header.Set("X-PagerDuty-Signature", Signature([]byte(defaultBody), secret))
+
+	rec := httptest.NewRecorder()
+	b.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+
+	// the event can be redelivered
+	if !b.dedup.add("01BWDWL3NYY7LUFPZCC28QUCMK") {
+		t.Fatal("canceled event wasn't released")
+	}
+}
+
+func TestDedupSet(t *testing.T) {
+	s := newDedupSet(2)
+
+	for _, id := range []string{"a", "b"} {
+		if !s.add(id) {
+			t.Fatalf("add(%q) = false, want true", id)
+		}
+	}
+
+	if s.add("a") {
+		t.Fatal("add(a) = true for a duplicate")
+	}
+
+	// evicts a
+	s.add("c")
+
+	if !s.add("a") {
+		t.Fatal("add(a) = false after eviction")
+	}
+
+	s.remove("a")
+
+	if !s.add("a") {
+		t.Fatal("add(a) = false after removal")
+	}
+}
@@ -0,0 +1,164 @@
+package webhookv3
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Values of the EventType field of Event.
+const (
+	EventTypeIncidentAcknowledged          = "incident.acknowledged"
+	EventTypeIncidentAnnotated             = "incident.annotated"
+	EventTypeIncidentDelegated             = "incident.delegated"
+	EventTypeIncidentEscalated             = "incident.escalated"
+	EventTypeIncidentPriorityUpdated       = "incident.priority_updated"
+	EventTypeIncidentReassigned            = "incident.reassigned"
+	EventTypeIncidentReopened              = "incident.reopened"
+	EventTypeIncidentResolved              = "incident.resolved"
+	EventTypeIncidentResponderAdded        = "incident.responder.added"
+	EventTypeIncidentResponderReplied      = "incident.responder.replied"
+	EventTypeIncidentStatusUpdatePublished = "incident.status_update_published"
+	EventTypeIncidentTriggered             = "incident.triggered"
+	EventTypeIncidentUnacknowledged        = "incident.unacknowledged"
+	EventTypeServiceCreated                = "service.created"
+	EventTypeServiceDeleted                = "service.deleted"
+	EventTypeServiceUpdated                = "service.updated"
+)
+
+// Values of the ResourceType field of Event.
+const (
+	ResourceTypeIncident = "incident"
+	ResourceTypeService  = "service"
+)
+
+// Incident is the data of the incident events, other than
+// incident.annotated, incident.responder.* and
+// incident.status_update_published.
+type Incident struct {
+	ID               string            `json:"id"`
+	Type             string            `json:"type"`
+	Self             string            `json:"self"`
+	HTMLURL          string            `json:"html_url"`
+	Number           uint              `json:"number"`
+	Status           string            `json:"status"`
+	IncidentKey      string            `json:"incident_key,omitempty"`
+	CreatedAt        string            `json:"created_at,omitempty"`
+	Title            string            `json:"title"`
+	Service          *Reference        `json:"service"`
+	Assignees        []Reference       `json:"assignees"`
+	EscalationPolicy *Reference        `json:"escalation_policy"`
+	Teams            []Reference       `json:"teams"`
+	Priority         *Reference        `json:"priority"`
+	Urgency          string            `json:"urgency"`
+	ConferenceBridge *ConferenceBridge `json:"conference_bridge"`
+	ResolveReason    *ResolveReason    `json:"resolve_reason"`
+}
+
+// ConferenceBridge is the conference bridge of an incident.
+type ConferenceBridge struct {
+	ConferenceNumber string `json:"conference_number"`
+	ConferenceURL    string `json:"conference_url"`
+}
+
+// UnmarshalJSON accepts conference numbers encoded as JSON numbers, as sent in
+// some webhooks, as well as strings.
+func (c *ConferenceBridge) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		ConferenceNumber json.RawMessage `json:"conference_number"`
+		ConferenceURL    string          `json:"conference_url"`
+	}
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	c.ConferenceURL = raw.ConferenceURL
+	if err := json.Unmarshal(raw.ConferenceNumber, &c.ConferenceNumber); err != nil {
+		c.ConferenceNumber = string(raw.ConferenceNumber)
+	}
+
+	return nil
+}
+
+// ResolveReason is the reason an incident was resolved, such as being merged
+// into another incident.
+type ResolveReason struct {
+	Type     string     `json:"type"`
+	Incident *Reference `json:"incident"`
+}
+
+// IncidentNote is the data of incident.annotated events.
+type IncidentNote struct {
+	ID       string     `json:"id"`
+	Type     string     `json:"type"`
+	Content  string     `json:"content"`
+	Incident *Reference `json:"incident"`
+}
+
+// Service is the data of service events.
+type Service struct {
+	ID               string      `json:"id"`
+	Type             string      `json:"type"`
+	Self             string      `json:"self"`
+	HTMLURL          string      `json:"html_url"`
+	Summary          string      `json:"summary"`
+	Name             string      `json:"name,omitempty"`
+	Description      string      `json:"description,omitempty"`
+	Status           string      `json:"status,omitempty"`
+	EscalationPolicy *Reference  `json:"escalation_policy,omitempty"`
+	Teams            []Reference `json:"teams,omitempty"`
+}
+
+// IncidentEvent is an incident event whose data is an Incident.
+type IncidentEvent struct {
+	Event
+	Incident Incident
+}
+
+// IncidentAnnotatedEvent is an incident.annotated event.
+type IncidentAnnotatedEvent struct {
+	Event
+	Note IncidentNote
+}
+
+// ServiceEvent is a service event.
+type ServiceEvent struct {
+	Event
+	Service Service
+}
+
+// UnmarshalEvent decodes the data of e according to its type. It returns an
+// *IncidentEvent, an *IncidentAnnotatedEvent or a *ServiceEvent, or e itself
+// for the other event types, whose data remains raw. It returns an error
+// wrapping ErrMalformedBody if the data can't be decoded.
+func UnmarshalEvent(e Event) (interface{}, error) {
+	var (
+		typed  interface{}
+		target interface{}
+	)
+
+	switch {
+	case e.EventType == EventTypeIncidentAnnotated:
+		t := &IncidentAnnotatedEvent{Event: e}
+		typed, target = t, &t.Note
+
+	case e.ResourceType == ResourceTypeIncident && e.EventType != EventTypeIncidentStatusUpdatePublished &&
+		!strings.HasPrefix(e.EventType, "incident.responder."):
+		t := &IncidentEvent{Event: e}
+		typed, target = t, &t.Incident
+
+	case e.ResourceType == ResourceTypeService:
+		t := &ServiceEvent{Event: e}
+		typed, target = t, &t.Service
+
+	default:
+		return &e, nil
+	}
+
+	if err := json.Unmarshal(e.Data, target); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode the data of %s event %s: %v", ErrMalformedBody, e.EventType, e.ID, err)
+	}
+
+	return typed, nil
+}
@@ -0,0 +1,96 @@
+package webhookv3
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestUnmarshalEvent(t *testing.T) {
+	var p Payload
+	if err := json.Unmarshal([]byte(defaultBody), &p); err != nil {
+		t.Fatalf("failed to decode payload: %s", err)
+	}
+
+	e, err := UnmarshalEvent(p.Event)
+	if err != nil {
+		t.Fatalf("UnmarshalEvent unexpected error: %v", err)
+	}
+
+	ie, ok := e.(*IncidentEvent)
+	if !ok {
+		t.Fatalf("UnmarshalEvent returned %T, want *IncidentEvent", e)
+	}
+
+	if ie.Incident.Number != 2 || ie.Incident.Priority.Summary != "P1" || ie.Incident.ConferenceBridge.ConferenceNumber != "1000" {
+		t.Fatalf("unexpected incident %+v", ie.Incident)
+	}
+
+	tests := []struct {
+		name  string
+		event Event
+		want  interface{}
+	}{
+		{
+			name: "annotated",
+			event: Event{
+				EventType:    EventTypeIncidentAnnotated,
+				ResourceType: ResourceTypeIncident,
+				Data:         json.RawMessage(`{"id": "PN1", "content": "Looking into it", "incident": {"id": "PI1"}}`),
+			},
+			want: IncidentNote{ID: "PN1", Content: "Looking into it", Incident: &Reference{ID: "PI1"}},
+		},
+		{
+			name: "service",
+			event: Event{
+				EventType:    EventTypeServiceUpdated,
+				ResourceType: ResourceTypeService,
+				Data:         json.RawMessage(`{"id": "PS1", "name": "API"}`),
+			},
+			want: Service{ID: "PS1", Name: "API"},
+		},
+		{
+			name: "raw",
+			event: Event{
+				EventType:    EventTypeIncidentResponderAdded,
+				ResourceType: ResourceTypeIncident,
+				Data:         json.RawMessage(`{"incident": {"id": "PI1"}}`),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := UnmarshalEvent(tt.event)
+			if err != nil {
+				t.Fatalf("UnmarshalEvent unexpected error: %v", err)
+			}
+
+			var got interface{}
+			switch e := e.(type) {
+			case *IncidentAnnotatedEvent:
+				got = e.Note
+			case *ServiceEvent:
+				got = e.Service
+			case *Event:
+				got = nil
+			default:
+				t.Fatalf("UnmarshalEvent returned unexpected %T", e)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Fatalf("UnmarshalEvent data = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalEvent_Malformed(t *testing.T) {
+	_, err := UnmarshalEvent(Event{
+		EventType:    EventTypeIncidentTriggered,
+		ResourceType: ResourceTypeIncident,
+		Data:         json.RawMessage(`{"number": "one"}`),
+	})
+
+	testErrIs(t, "UnmarshalEvent", ErrMalformedBody, err)
+}
@@ -0,0 +1,142 @@
+package webhookv3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Handler is an http.Handler receiving V3 PagerDuty Webhooks. It verifies
+// their signature, decodes their event with UnmarshalEvent, and calls the
+// callback registered for the event type. It responds with HTTP 403 to invalid
+// signatures and HTTP 400 to malformed webhooks, so PagerDuty doesn't redeliver
+// them, and with HTTP 500 when a callback fails, so PagerDuty retries.
+//
+// Callbacks must be registered before the Handler serves requests.
+type Handler struct {
+	secret    string
+	callbacks map[string]func(context.Context, interface{}) error
+
+	// Fallback is called with the events without a registered callback, if
+	// set.
+	Fallback func(ctx context.Context, e *Event) error
+}
+
+// NewHandler returns a Handler verifying the signatures of webhooks against
+// secret.
+func NewHandler(secret string) *Handler {
+	return &Handler{
+		secret:    secret,
+		callbacks: make(map[string]func(context.Context, interface{}) error),
+	}
+}
+
+// OnIncident registers the callback of incident events of the given types,
+// such as EventTypeIncidentTriggered, whose data is an Incident.
+func (h *Handler) OnIncident(f func(ctx context.Context, e *IncidentEvent) error, eventTypes ...string) {
+	for _, t := range eventTypes {
+		h.callbacks[t] = func(ctx context.Context, e interface{}) error {
+			ie, ok := e.(*IncidentEvent)
+			if !ok {
+				return errUnexpectedEvent
+			}
+
+			return f(ctx, ie)
+		}
+	}
+}
+
+// OnIncidentAnnotated registers the callback of incident.annotated events.
+func (h *Handler) OnIncidentAnnotated(f func(ctx context.Context, e *IncidentAnnotatedEvent) error) {
+	h.callbacks[EventTypeIncidentAnnotated] = func(ctx context.Context, e interface{}) error {
+		ae, ok := e.(*IncidentAnnotatedEvent)
+		if !ok {
+			return errUnexpectedEvent
+		}
+
+		return f(ctx, ae)
+	}
+}
+
+// OnService registers the callback of service events of the given types, such
+// as EventTypeServiceUpdated.
+func (h *Handler) OnService(f func(ctx context.Context, e *ServiceEvent) error, eventTypes ...string) {
+	for _, t := range eventTypes {
+		h.callbacks[t] = func(ctx context.Context, e interface{}) error {
+			se, ok := e.(*ServiceEvent)
+			if !ok {
+				return errUnexpectedEvent
+			}
+
+			return f(ctx, se)
+		}
+	}
+}
+
+// OnEvent registers the callback of events of the given types without typed
+// data, such as EventTypeIncidentResponderAdded.
+func (h *Handler) OnEvent(f func(ctx context.Context, e *Event) error, eventTypes ...string) {
+	for _, t := range eventTypes {
+		h.callbacks[t] = func(ctx context.Context, e interface{}) error {
+			re, ok := e.(*Event)
+			if !ok {
+				return errUnexpectedEvent
+			}
+
+			return f(ctx, re)
+		}
+	}
+}
+
+var errUnexpectedEvent = errors.New("event type registered with the wrong callback")
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p, err := VerifyAndDecode(r, h.secret)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := h.dispatch(r.Context(), p.Event); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeError responds to a webhook with the status matching err: HTTP 403 to
+// invalid signatures and HTTP 400 to malformed webhooks, so PagerDuty doesn't
+// redeliver them, and HTTP 500 otherwise, so PagerDuty retries.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, ErrNoValidSignatures):
+		status = http.StatusForbidden
+
+	case errors.Is(err, ErrMalformedHeader), errors.Is(err, ErrMalformedBody):
+		status = http.StatusBadRequest
+	}
+
+	http.Error(w, err.Error(), status)
+}
+
+func (h *Handler) dispatch(ctx context.Context, e Event) error {
+	f, ok := h.callbacks[e.EventType]
+	if !ok {
+		if h.Fallback != nil {
+			return h.Fallback(ctx, &e)
+		}
+
+		return nil
+	}
+
+	typed, err := UnmarshalEvent(e)
+	if err != nil {
+		return err
+	}
+
+	return f(ctx, typed)
+}
@@ -0,0 +1,94 @@
+package webhookv3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	var (
+		got      *IncidentEvent
+		fallback []string
+	)
+
+	h := NewHandler(secret)
+	h.OnIncident(func(ctx context.Context, e *IncidentEvent) error {
+		got = e
+		return nil
+	}, EventTypeIncidentPriorityUpdated, EventTypeIncidentTriggered)
+	h.OnService(func(ctx context.Context, e *ServiceEvent) error {
+		return errors.New("service unavailable")
+	}, EventTypeServiceUpdated)
+	h.Fallback = func(ctx context.Context, e *Event) error {
+		fallback = append(fallback, e.EventType)
+		return nil
+	}
+
+	serviceBody := `{"event":{"id":"E2","event_type":"service.updated","resource_type":"service","data":{"id":"PS1"}}}`
+	otherBody := `{"event":{"id":"E3","event_type":"incident.responder.added","resource_type":"incident","data":{}}}`
+
+	tests := []struct {
+		name   string
+		body   string
+		sig    string
+		status int
+	}{
+		{
+			name:   "dispatched",
+			body:   defaultBody,
+			sig:    Signature([]byte(defaultBody), secret),
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "fallback",
+			body:   otherBody,
+			sig:    Signature([]byte(otherBody), secret),
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "callback_error",
+			body:   serviceBody,
+			sig:    Signature([]byte(serviceBody), secret),
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:   "invalid_signature",
+			body:   defaultBody,
+			sig:    Signature([]byte(defaultBody), "other"),
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "missing_signature",
+			body:   defaultBody,
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(tt.body))
+			if len(tt.sig) > 0 {
+				req.Header.Set("X-PagerDuty-Signature", tt.sig)
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("ServeHTTP status = %d, want %d: %s", rec.Code, tt.status, rec.Body.String())
+			}
+		})
+	}
+
+	if got == nil || got.Incident.ID != "PGR0VU2" {
+		t.Fatalf("incident callback got %+v", got)
+	}
+
+	if len(fallback) != 1 || fallback[0] != EventTypeIncidentResponderAdded {
+		t.Fatalf("fallback got %v", fallback)
+	}
+}
@@ -0,0 +1,64 @@
+package webhookv3
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Payload is the body of a V3 PagerDuty Webhook.
+type Payload struct {
+	Event Event `json:"event"`
+}
+
+// Event is an event delivered by a V3 PagerDuty Webhook, such as an incident
+// being triggered. Data holds the resource the event is about, whose type
+// depends on ResourceType.
+type Event struct {
+	ID           string          `json:"id"`
+	EventType    string          `json:"event_type"`
+	ResourceType string          `json:"resource_type"`
+	OccurredAt   string          `json:"occurred_at"`
+	Agent        *Reference      `json:"agent"`
+	Client       *EventClient    `json:"client"`
+	Data         json.RawMessage `json:"data"`
+}
+
+// Reference is a reference to a PagerDuty resource, such as the user who
+// caused an event.
+type Reference struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Summary string `json:"summary,omitempty"`
+	Self    string `json:"self,omitempty"`
+	HTMLURL string `json:"html_url,omitempty"`
+}
+
+// EventClient is the integration that caused an event, if any.
+type EventClient struct {
+	Name string `json:"name"`
+}
+
+// VerifyAndDecode verifies the signature of a PagerDuty v3 Webhook like
+// VerifySignature, and only then decodes its body. It returns the same errors
+// as VerifySignature, and ErrMalformedBody if the body can't be decoded.
+func VerifyAndDecode(r *http.Request, secret string) (*Payload, error) {
+	if err := VerifySignature(r, secret); err != nil {
+		return nil, err
+	}
+
+	var p Payload
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedBody, err)
+	}
+
+	return &p, nil
+}
+
+// Signature returns the value of the X-PagerDuty-Signature header PagerDuty
+// sends with a webhook body signed with secret. It's meant to test webhook
+// receivers.
+func Signature(body []byte, secret string) string {
+	return webhookSignaturePrefix + hex.EncodeToString(calculateSignature(body, secret))
+}
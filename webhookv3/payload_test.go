@@ -0,0 +1,74 @@
+package webhookv3
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestVerifyAndDecode(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "http://127.0.0.1:80/test", strings.NewReader(defaultBody))
+	if err != nil {
+		t.Fatalf("failed to generate new request: %s", err.Error())
+	}
+
+	// the first signature is for another secret, as during a secret rotation
+	req.Header.Set("X-PagerDuty-Signature", Signature([]byte(defaultBody), "old")+","+Signature([]byte(defaultBody), secret))
+
+	p, err := VerifyAndDecode(req, secret)
+	if err != nil {
+		t.Fatalf("VerifyAndDecode unexpected error: %v", err)
+	}
+
+	if p.Event.EventType != "incident.priority_updated" || p.Event.Agent.ID != "PLH1HKV" || len(p.Event.Data) == 0 {
+		t.Fatalf("unexpected event %+v", p.Event)
+	}
+}
+
+func TestVerifyAndDecode_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		sig  string
+		body string
+		err  error
+	}{
+		{
+			name: "mismatch",
+			sig:  Signature([]byte(defaultBody), "other"),
+			body: defaultBody,
+			err:  ErrNoValidSignatures,
+		},
+		{
+			name: "malformed_json",
+			sig:  Signature([]byte(`{"event":`), secret),
+			body: `{"event":`,
+			err:  ErrMalformedBody,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "http://127.0.0.1:80/test", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("failed to generate new request: %s", err.Error())
+			}
+
+			req.Header.Set("X-PagerDuty-Signature", tt.sig)
+
+			p, err := VerifyAndDecode(req, secret)
+			testErrIs(t, "VerifyAndDecode", tt.err, err)
+
+			if p != nil {
+				t.Fatalf("VerifyAndDecode returned a payload with an error")
+			}
+		})
+	}
+}
+
+func TestSignature(t *testing.T) {
+	want := "v1=0c0b9495b893a39e70d1fea2fe11fbe0a825f88b9f67846f6cc07dd2bc5476cd"
+
+	if got := Signature([]byte(defaultBody), secret); got != want {
+		t.Fatalf("Signature() = %s, want %s", got, want)
+	}
+}